@@ -16,10 +16,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apiRuntime "k8s.io/apimachinery/pkg/runtime"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
 	clustersV1 "open-cluster-management.io/api/cluster/v1"
 	clustersv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
 	clustersV1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	policiesV1 "open-cluster-management.io/governance-policy-propagator/api/v1"
+	policiesV1beta1 "open-cluster-management.io/governance-policy-propagator/api/v1beta1"
 	placementRulesV1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/apps/placementrule/v1"
 	appsV1alpha1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/apps/v1alpha1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -31,6 +33,7 @@ import (
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/lease"
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/spec/bundle"
 	specController "github.com/stolostron/multicluster-global-hub/agent/pkg/spec/controller"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle/specapply"
 	statusController "github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller"
 	consumer "github.com/stolostron/multicluster-global-hub/agent/pkg/transport/consumer"
 	producer "github.com/stolostron/multicluster-global-hub/agent/pkg/transport/producer"
@@ -82,18 +85,29 @@ func doMain() int {
 	defer consumer.Stop()
 	defer producer.Stop()
 
-	mgr, err := createManager(consumer, producer, configManager)
+	// the manager is stopped to restart the agent with a new incarnation on resync requests
+	signalCtx := ctrl.SetupSignalHandler()
+	ctx, restart := context.WithCancel(signalCtx)
+	defer restart()
+
+	mgr, err := createManager(consumer, producer, configManager, restart)
 	if err != nil {
 		log.Error(err, "failed to create manager")
 		return 1
 	}
 
 	log.Info("starting the Cmd")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		log.Error(err, "manager exited non-zero")
 		return 1
 	}
 
+	// exit non-zero so the container is restarted, unless the agent is stopped by a signal
+	if signalCtx.Err() == nil {
+		log.Info("exiting to restart the agent")
+		return 1
+	}
+
 	return 0
 }
 
@@ -162,7 +176,7 @@ func getProducer(environmentManager *helper.ConfigManager) (producer.Producer, e
 }
 
 func createManager(consumer consumer.Consumer, producer producer.Producer,
-	environmentManager *helper.ConfigManager,
+	environmentManager *helper.ConfigManager, restartFunc context.CancelFunc,
 ) (ctrl.Manager, error) {
 	// leaseDuration := 137 * time.Second
 	// renewDeadline := 107 * time.Second
@@ -194,11 +208,19 @@ func createManager(consumer consumer.Consumer, producer producer.Producer,
 	}
 	fmt.Printf("Starting the Cmd incarnation: %d", incarnation)
 
-	if err := specController.AddSyncersToManager(mgr, consumer, *environmentManager); err != nil {
+	// the spec syncers report the results of applying the spec objects, the corrected drifts of the objects and the
+	// full state requests of the spec bundles, the status controllers send them
+	specApplyResults := specapply.NewBundle(environmentManager.LeafHubName, incarnation)
+
+	if err := specController.AddSyncersToManager(mgr, consumer, *environmentManager, client.ObjectKey{
+		Namespace: HOH_LOCAL_NAMESPACE,
+		Name:      INCARNATION_CONFIG_MAP_KEY,
+	}, restartFunc, specApplyResults, specApplyResults, specApplyResults); err != nil {
 		return nil, fmt.Errorf("failed to add spec syncer: %w", err)
 	}
 
-	if err := statusController.AddControllers(mgr, producer, *environmentManager, incarnation); err != nil {
+	if err := statusController.AddControllers(mgr, producer, *environmentManager, incarnation,
+		specApplyResults); err != nil {
 		return nil, fmt.Errorf("failed to add status syncer: %w", err)
 	}
 
@@ -228,12 +250,16 @@ func addToScheme(runtimeScheme *apiRuntime.Scheme) error {
 		return fmt.Errorf("failed to add clustersv1alpha1 scheme: %w", err)
 	}
 
+	if err := addonv1alpha1.Install(runtimeScheme); err != nil {
+		return fmt.Errorf("failed to add addonv1alpha1 scheme: %w", err)
+	}
+
 	if err := apiextensionsv1.AddToScheme(runtimeScheme); err != nil {
 		return fmt.Errorf("failed to add apiextensionsv1 scheme: %w", err)
 	}
 
 	schemeBuilders := []*scheme.Builder{
-		policiesV1.SchemeBuilder, placementRulesV1.SchemeBuilder, appsV1alpha1.SchemeBuilder,
+		policiesV1.SchemeBuilder, policiesV1beta1.SchemeBuilder, placementRulesV1.SchemeBuilder, appsV1alpha1.SchemeBuilder,
 		mchv1.SchemeBuilder,
 	} // add schemes
 
@@ -295,7 +321,9 @@ func getIncarnation(mgr ctrl.Manager) (uint64, error) {
 			INCARNATION_CONFIG_MAP_KEY, err)
 	}
 
-	newConfigMap := createIncarnationConfigMap(lastIncarnation + 1)
+	// keep the other keys of the configmap, e.g. the last handled resync request
+	newConfigMap := configMap.DeepCopy()
+	newConfigMap.Data[INCARNATION_CONFIG_MAP_KEY] = strconv.FormatUint(lastIncarnation+1, BASE10)
 	if err := k8sClient.Patch(ctx, newConfigMap, client.MergeFrom(configMap)); err != nil {
 		return 0, fmt.Errorf("failed to update incarnation version - %w", err)
 	}
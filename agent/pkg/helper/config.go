@@ -3,6 +3,7 @@ package helper
 import (
 	"flag"
 	"fmt"
+	"os"
 
 	"github.com/confluentinc/confluent-kafka-go/kafka"
 	"github.com/operator-framework/operator-sdk/pkg/log/zap"
@@ -19,6 +20,8 @@ const (
 type KafkaConfig struct {
 	BootstrapServers     string
 	SslCa                string
+	SaslUser             string
+	SaslPassword         string
 	ComsumerTopic        string
 	ProducerId           string
 	ProducerTopic        string
@@ -39,6 +42,7 @@ type ConfigManager struct {
 	PodNameSpace                 string
 	TransportType                string
 	TransportCompressionType     string
+	TransportEncryptionKeysPath  string
 	SpecWorkPoolSize             int
 	SpecEnforceHohRbac           bool
 	StatusDeltaCountSwitchFactor int
@@ -61,6 +65,9 @@ func NewConfigManager() (*ConfigManager, error) {
 		"The bootstrap server for kafka.")
 	pflag.StringVar(&configManager.Kafka.SslCa, "kafka-ssl-ca", "",
 		"The authentication to connect to the kafka.")
+	pflag.StringVar(&configManager.Kafka.SaslUser, "kafka-sasl-user", "",
+		"The SCRAM user to authenticate to the kafka with, empty disables the SASL authentication. the password "+
+			"is read from the "+kafkaclient.SASLPasswordEnvVar+" environment variable.")
 
 	pflag.StringVar(&configManager.Kafka.ProducerId, "kafka-producer-id", "",
 		"Producer Id for the kafka, default is the leaf hub name.")
@@ -91,6 +98,8 @@ func NewConfigManager() (*ConfigManager, error) {
 	pflag.StringVar(&configManager.TransportCompressionType,
 		"transport-message-compression-type", "gzip",
 		"The message compression type for transport layer, 'gzip' or 'no-op'.")
+	pflag.StringVar(&configManager.TransportEncryptionKeysPath, "transport-encryption-keys-path", "",
+		"The directory of the keys to decrypt the spec messages with, empty disables encryption.")
 	pflag.IntVar(&configManager.Kafka.ProducerMessageLimit, "kafka-message-size-limit", 100,
 		"The limit for kafka message size in KB.")
 	pflag.IntVar(&configManager.StatusDeltaCountSwitchFactor,
@@ -104,6 +113,7 @@ func NewConfigManager() (*ConfigManager, error) {
 	if configManager.Kafka.ProducerId == "" {
 		configManager.Kafka.ProducerId = configManager.LeafHubName
 	}
+	configManager.Kafka.SaslPassword = os.Getenv(kafkaclient.SASLPasswordEnvVar)
 	if configManager.SpecWorkPoolSize < 1 || configManager.SpecWorkPoolSize > 100 {
 		return nil, fmt.Errorf("flag consumer-worker-pool-size should be in the scope [1, 100]")
 	}
@@ -165,5 +175,6 @@ func (configManger *ConfigManager) loadSslToConfigMap(kafkaConfigMap *kafka.Conf
 			return fmt.Errorf("failed to SetKey ssl.ca.location - %w", err)
 		}
 	}
-	return nil
+	// the SASL credential is layered on top of the SSL configuration
+	return kafkaclient.SetSASLCredential(kafkaConfigMap, configManger.Kafka.SaslUser, configManger.Kafka.SaslPassword)
 }
@@ -52,3 +52,22 @@ func AddAnnotations(obj metav1.Object, annotations map[string]string) {
 
 	obj.SetAnnotations(mergedAnnotations)
 }
+
+// AddLabels adds the given labels to the given object. if obj is nil or labels are nil, it's a no-op.
+func AddLabels(obj metav1.Object, labels map[string]string) {
+	if obj == nil || labels == nil {
+		return
+	}
+	if obj.GetLabels() == nil {
+		obj.SetLabels(labels)
+		return
+	}
+
+	mergedLabels := obj.GetLabels()
+
+	for key, value := range labels {
+		mergedLabels[key] = value
+	}
+
+	obj.SetLabels(mergedLabels)
+}
@@ -0,0 +1,63 @@
+package helper
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clustersv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+const placementKind = "Placement"
+
+// PlacementDecisionOrigin is the origin of the placement of a placement decision.
+type PlacementDecisionOrigin string
+
+const (
+	// PlacementDecisionOriginUnknown is the origin of a placement decision whose placement isn't found.
+	PlacementDecisionOriginUnknown PlacementDecisionOrigin = "unknown"
+	// PlacementDecisionOriginLocal is the origin of a placement decision of a placement of the regional hub.
+	PlacementDecisionOriginLocal PlacementDecisionOrigin = "local"
+	// PlacementDecisionOriginGlobal is the origin of a placement decision of a placement created from the global hub.
+	PlacementDecisionOriginGlobal PlacementDecisionOrigin = "global"
+)
+
+// GetPlacementName returns the name of the placement of the placement decision by its placement label, or by its
+// owner reference if the label is missing. it returns an empty string if the object isn't a placement decision of a
+// placement.
+func GetPlacementName(decision metav1.Object) string {
+	if placementName := decision.GetLabels()[clustersv1beta1.PlacementLabel]; placementName != "" {
+		return placementName
+	}
+
+	if owner := metav1.GetControllerOf(decision); owner != nil && owner.Kind == placementKind {
+		return owner.Name
+	}
+
+	return ""
+}
+
+// GetPlacementDecisionOrigin returns the origin of the placement decision by the origin annotation of its placement,
+// which is read by the reader, e.g. from the cache of the manager. the origin is unknown if the placement isn't found.
+func GetPlacementDecisionOrigin(ctx context.Context, reader client.Reader,
+	decision metav1.Object,
+) PlacementDecisionOrigin {
+	placementName := GetPlacementName(decision)
+	if placementName == "" {
+		return PlacementDecisionOriginUnknown
+	}
+
+	placement := &clustersv1beta1.Placement{}
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: decision.GetNamespace(), Name: placementName},
+		placement); err != nil {
+		return PlacementDecisionOriginUnknown
+	}
+
+	if HasAnnotation(placement, constants.OriginOwnerReferenceAnnotation) {
+		return PlacementDecisionOriginGlobal
+	}
+
+	return PlacementDecisionOriginLocal
+}
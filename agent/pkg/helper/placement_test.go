@@ -0,0 +1,54 @@
+package helper
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clustersv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+func TestGetPlacementDecisionOrigin(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clustersv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&clustersv1beta1.Placement{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "local"}},
+		&clustersv1beta1.Placement{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "global",
+			Annotations: map[string]string{constants.OriginOwnerReferenceAnnotation: "uid1"},
+		}},
+	).Build()
+
+	tests := []struct {
+		name      string
+		placement string
+		expected  PlacementDecisionOrigin
+	}{
+		{"local placement", "local", PlacementDecisionOriginLocal},
+		{"global placement", "global", PlacementDecisionOriginGlobal},
+		{"missing placement", "missing", PlacementDecisionOriginUnknown},
+		{"no placement", "", PlacementDecisionOriginUnknown},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decision := &clustersv1beta1.PlacementDecision{ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      test.placement + "-decision-1",
+				Labels:    map[string]string{clustersv1beta1.PlacementLabel: test.placement},
+			}}
+
+			if origin := GetPlacementDecisionOrigin(context.Background(), reader, decision); origin != test.expected {
+				t.Errorf("expected the origin %s, got %s", test.expected, origin)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package bundle
 
 import (
+	"fmt"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -10,7 +12,58 @@ func NewGenericBundle() *GenericBundle {
 }
 
 // GenericBundle bundle received from transport containing Objects/DeletedObjects.
+// a delta bundle holds only the objects changed since the bundle of its BaseBundleVersion, a bundle without
+// BaseBundleVersion holds the full state.
 type GenericBundle struct {
-	Objects        []*unstructured.Unstructured `json:"objects"`
-	DeletedObjects []*unstructured.Unstructured `json:"deletedObjects"`
+	// ID is the transport message id of the bundle, it's set by the consumer.
+	ID                string                       `json:"-"`
+	BundleVersion     string                       `json:"bundleVersion,omitempty"`
+	BaseBundleVersion string                       `json:"baseBundleVersion,omitempty"`
+	Objects           []*unstructured.Unstructured `json:"objects"`
+	DeletedObjects    []*unstructured.Unstructured `json:"deletedObjects"`
+}
+
+// IsDelta returns true if the bundle holds only the objects changed since its base bundle version.
+func (bundle *GenericBundle) IsDelta() bool {
+	return bundle.BaseBundleVersion != ""
+}
+
+// Conflate returns a bundle with the state of the bundle followed by the next bundle, so a bundle waiting to be
+// applied is replaced by a single bundle once the next bundle is received. the next bundle is returned as is if it
+// holds the full state or if it isn't based on the bundle.
+func (bundle *GenericBundle) Conflate(next *GenericBundle) *GenericBundle {
+	if !next.IsDelta() || next.BaseBundleVersion != bundle.BundleVersion {
+		return next
+	}
+
+	changed := make(map[string]struct{}, len(next.Objects)+len(next.DeletedObjects))
+	for _, objects := range [][]*unstructured.Unstructured{next.Objects, next.DeletedObjects} {
+		for _, obj := range objects {
+			changed[objectKey(obj)] = struct{}{}
+		}
+	}
+
+	unchanged := func(objects []*unstructured.Unstructured) []*unstructured.Unstructured {
+		result := make([]*unstructured.Unstructured, 0, len(objects))
+
+		for _, obj := range objects {
+			if _, found := changed[objectKey(obj)]; !found {
+				result = append(result, obj)
+			}
+		}
+
+		return result
+	}
+
+	return &GenericBundle{
+		ID:                next.ID,
+		BundleVersion:     next.BundleVersion,
+		BaseBundleVersion: bundle.BaseBundleVersion,
+		Objects:           append(unchanged(bundle.Objects), next.Objects...),
+		DeletedObjects:    append(unchanged(bundle.DeletedObjects), next.DeletedObjects...),
+	}
+}
+
+func objectKey(obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s/%s/%s", obj.GroupVersionKind().GroupKind(), obj.GetNamespace(), obj.GetName())
 }
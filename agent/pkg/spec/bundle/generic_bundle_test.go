@@ -0,0 +1,61 @@
+package bundle
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestConflate(t *testing.T) {
+	newObject := func(name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion("v1")
+		obj.SetKind("ConfigMap")
+		obj.SetNamespace("default")
+		obj.SetName(name)
+
+		return obj
+	}
+
+	names := func(objects []*unstructured.Unstructured) []string {
+		result := make([]string, 0, len(objects))
+		for _, obj := range objects {
+			result = append(result, obj.GetName())
+		}
+
+		return result
+	}
+
+	fullState := &GenericBundle{
+		BundleVersion:  "1",
+		Objects:        []*unstructured.Unstructured{newObject("a"), newObject("b")},
+		DeletedObjects: []*unstructured.Unstructured{newObject("c")},
+	}
+	delta := &GenericBundle{
+		BundleVersion:     "2",
+		BaseBundleVersion: "1",
+		Objects:           []*unstructured.Unstructured{newObject("c")},
+		DeletedObjects:    []*unstructured.Unstructured{newObject("a")},
+	}
+
+	conflated := fullState.Conflate(delta)
+	if conflated.IsDelta() || conflated.BundleVersion != "2" {
+		t.Fatalf("expected the full state of version 2, got version %s based on %s", conflated.BundleVersion,
+			conflated.BaseBundleVersion)
+	}
+
+	if objects := names(conflated.Objects); !reflect.DeepEqual(objects, []string{"b", "c"}) {
+		t.Errorf("unexpected objects %v", objects)
+	}
+
+	if deletedObjects := names(conflated.DeletedObjects); !reflect.DeepEqual(deletedObjects, []string{"a"}) {
+		t.Errorf("unexpected deleted objects %v", deletedObjects)
+	}
+
+	// a delta bundle that isn't based on the waiting bundle replaces it, its gap is detected when it's applied
+	gap := &GenericBundle{BundleVersion: "4", BaseBundleVersion: "3"}
+	if conflated := fullState.Conflate(gap); conflated != gap {
+		t.Error("expected the bundle with a gap to replace the waiting bundle")
+	}
+}
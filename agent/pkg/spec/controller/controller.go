@@ -1,13 +1,16 @@
 package controller
 
 import (
+	"context"
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	clustersv1 "open-cluster-management.io/api/cluster/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/helper"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/spec/controller/drift"
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/spec/controller/syncers"
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/spec/controller/workers"
 	consumer "github.com/stolostron/multicluster-global-hub/agent/pkg/transport/consumer"
@@ -22,16 +25,30 @@ func AddToScheme(scheme *runtime.Scheme) error {
 	return nil
 }
 
-// AddSpecSyncers adds spec syncers to the Manager.
-func AddSyncersToManager(manager ctrl.Manager, consumer consumer.Consumer, configManager helper.ConfigManager) error {
+// AddSpecSyncers adds spec syncers to the Manager. the restartFunc is invoked to restart the agent on resync requests,
+// the handled resync request is recorded in the incarnation configmap. the results of applying the objects are
+// reported to the applyResultReporter, and the full state of the bundles is requested by the fullStateRequester. the
+// objects modified or deleted locally are restored and the drifts are reported to the driftReporter.
+func AddSyncersToManager(manager ctrl.Manager, consumer consumer.Consumer, configManager helper.ConfigManager,
+	incarnationConfigMap client.ObjectKey, restartFunc context.CancelFunc,
+	applyResultReporter syncers.ApplyResultReporter, fullStateRequester syncers.FullStateRequester,
+	driftReporter drift.Reporter,
+) error {
 	workerPool, err := workers.AddWorkerPool(ctrl.Log.WithName("workers-pool"),
 		configManager.SpecWorkPoolSize, manager)
 	if err != nil {
 		return fmt.Errorf("failed to add worker pool to runtime manager: %w", err)
 	}
 
+	desiredStateCache, err := drift.AddDesiredStateCache(ctrl.Log.WithName("desired-state-cache"), manager,
+		driftReporter)
+	if err != nil {
+		return fmt.Errorf("failed to add desired state cache to runtime manager: %w", err)
+	}
+
 	if err = syncers.AddGenericBundleSyncer(ctrl.Log.WithName("generic-bundle-syncer"), manager,
-		configManager.SpecEnforceHohRbac, consumer, workerPool); err != nil {
+		configManager.SpecEnforceHohRbac, consumer, workerPool, applyResultReporter,
+		fullStateRequester, desiredStateCache); err != nil {
 		return fmt.Errorf("failed to add bundles spec syncer to runtime manager: %w", err)
 	}
 
@@ -42,5 +59,11 @@ func AddSyncersToManager(manager ctrl.Manager, consumer consumer.Consumer, confi
 		return fmt.Errorf("failed to add managed cluster labels syncer to runtime manager: %w", err)
 	}
 
+	// add resync syncer to mgr
+	if err = syncers.AddResyncBundleSyncer(ctrl.Log.WithName("resync-syncer"), manager, consumer,
+		incarnationConfigMap, restartFunc); err != nil {
+		return fmt.Errorf("failed to add resync syncer to runtime manager: %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,279 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/helper"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+// Reporter reports the drifts corrected on the regional hub and the results of restoring the objects.
+type Reporter interface {
+	// ReportApplied reports the result of applying the object, the applied object is updated by the apply request.
+	ReportApplied(obj *unstructured.Unstructured, applyErr error)
+	// ReportDrift reports the object was modified or deleted locally and restored to the state of the global hub.
+	ReportDrift(obj *unstructured.Unstructured, reason string)
+}
+
+// desiredState is the last state of an object received from the global hub.
+type desiredState struct {
+	obj *unstructured.Unstructured
+	// client is the client that applied the object, the object is restored with the same identity so the rbac of
+	// the regional hub applies to the restore as well.
+	client client.Client
+	// correctionDisabled is set when the local object opts out of the drift correction, so the local deletion of the
+	// object is kept as well.
+	correctionDisabled bool
+}
+
+type objectKey struct {
+	gvk schema.GroupVersionKind
+	key types.NamespacedName
+}
+
+// DesiredStateCache holds the last state of the objects received from the global hub, the objects are restored to
+// the cached state when they are modified or deleted locally. a drift controller is started per kind of the objects
+// once an object of the kind is applied, it watches only the objects labeled when they're applied from the global hub,
+// so the other objects of the kind, e.g. the local secrets, aren't cached by the agent. the cache is kept in memory, after a restart of the agent the objects are
+// cached again as the spec bundles are received.
+type DesiredStateCache struct {
+	log                        logr.Logger
+	mgr                        ctrl.Manager
+	reporter                   Reporter
+	ctx                        context.Context
+	initializationWaitingGroup sync.WaitGroup
+	lock                       sync.RWMutex
+	objects                    map[schema.GroupVersionKind]map[types.NamespacedName]*desiredState
+	// applyingObjects is the set of objects being applied, their drifts are checked once the apply completes.
+	applyingObjects map[objectKey]struct{}
+	// watchedKinds is the set of kinds whose drift controllers are started.
+	watchedKinds map[schema.GroupVersionKind]struct{}
+}
+
+// AddDesiredStateCache adds the desired state cache to the manager and returns it.
+func AddDesiredStateCache(log logr.Logger, mgr ctrl.Manager, reporter Reporter) (*DesiredStateCache, error) {
+	cache := &DesiredStateCache{
+		log:                        log,
+		mgr:                        mgr,
+		reporter:                   reporter,
+		initializationWaitingGroup: sync.WaitGroup{},
+		lock:                       sync.RWMutex{},
+		objects:                    make(map[schema.GroupVersionKind]map[types.NamespacedName]*desiredState),
+		applyingObjects:            make(map[objectKey]struct{}),
+		watchedKinds:               make(map[schema.GroupVersionKind]struct{}),
+	}
+
+	cache.initializationWaitingGroup.Add(1)
+
+	if err := mgr.Add(cache); err != nil {
+		return nil, fmt.Errorf("failed to add desired state cache - %w", err)
+	}
+
+	return cache, nil
+}
+
+// Start function starts the desired state cache, the drift controllers are started with the context of the cache.
+func (cache *DesiredStateCache) Start(ctx context.Context) error {
+	cache.ctx = ctx
+	cache.initializationWaitingGroup.Done() // once context is saved, it's safe to start the drift controllers.
+
+	<-ctx.Done() // blocking wait for stop event
+
+	return nil
+}
+
+// ApplyDesiredState labels the object as a global resource, applies it with the client and caches its state once it's
+// applied, an object that fails to be applied isn't cached. the drift of the object isn't checked while it's applied, so a drift controller doesn't
+// restore the previous state of the object meanwhile.
+func (cache *DesiredStateCache) ApplyDesiredState(ctx context.Context, k8sClient client.Client,
+	obj *unstructured.Unstructured,
+) error {
+	helper.AddLabels(obj, map[string]string{constants.GlobalHubGlobalResource: ""})
+
+	key := objectKey{gvk: obj.GroupVersionKind(), key: client.ObjectKeyFromObject(obj)}
+	desiredObj := obj.DeepCopy() // the object is updated by the apply request
+
+	cache.setApplying(key, true)
+	defer cache.setApplying(key, false)
+
+	if err := helper.UpdateObject(ctx, k8sClient, obj); err != nil {
+		return err
+	}
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if _, found := cache.objects[key.gvk]; !found {
+		cache.objects[key.gvk] = make(map[types.NamespacedName]*desiredState)
+	}
+
+	cache.objects[key.gvk][key.key] = &desiredState{obj: desiredObj, client: k8sClient}
+
+	return nil
+}
+
+// DeleteDesiredState removes the object from the cache, it's called before the object is deleted so the deletion
+// isn't reverted.
+func (cache *DesiredStateCache) DeleteDesiredState(obj *unstructured.Unstructured) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	delete(cache.objects[obj.GroupVersionKind()], client.ObjectKeyFromObject(obj))
+}
+
+// WatchDrifts starts the drift controller of the kind of the object, it's called once the object is applied so the
+// kinds which aren't installed on the regional hub aren't watched.
+func (cache *DesiredStateCache) WatchDrifts(obj *unstructured.Unstructured) {
+	gvk := obj.GroupVersionKind()
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if _, found := cache.watchedKinds[gvk]; found {
+		return
+	}
+
+	if err := cache.startDriftController(gvk); err != nil {
+		cache.log.Error(err, "failed to start drift controller", "kind", gvk.String())
+		return
+	}
+
+	cache.watchedKinds[gvk] = struct{}{}
+}
+
+// getDesiredState returns a copy of the desired state of the object, nil is returned if the object isn't received
+// from the global hub.
+func (cache *DesiredStateCache) getDesiredState(gvk schema.GroupVersionKind, key types.NamespacedName) *desiredState {
+	cache.lock.RLock()
+	defer cache.lock.RUnlock()
+
+	state, found := cache.objects[gvk][key]
+	if !found {
+		return nil
+	}
+
+	return &desiredState{obj: state.obj.DeepCopy(), client: state.client, correctionDisabled: state.correctionDisabled}
+}
+
+func (cache *DesiredStateCache) isApplying(gvk schema.GroupVersionKind, key types.NamespacedName) bool {
+	cache.lock.RLock()
+	defer cache.lock.RUnlock()
+
+	_, found := cache.applyingObjects[objectKey{gvk: gvk, key: key}]
+
+	return found
+}
+
+func (cache *DesiredStateCache) setApplying(key objectKey, applying bool) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if applying {
+		cache.applyingObjects[key] = struct{}{}
+	} else {
+		delete(cache.applyingObjects, key)
+	}
+}
+
+func (cache *DesiredStateCache) isCached(gvk schema.GroupVersionKind, key types.NamespacedName) bool {
+	cache.lock.RLock()
+	defer cache.lock.RUnlock()
+
+	_, found := cache.objects[gvk][key]
+
+	return found
+}
+
+func (cache *DesiredStateCache) setCorrectionDisabled(gvk schema.GroupVersionKind, key types.NamespacedName,
+	correctionDisabled bool,
+) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if state, found := cache.objects[gvk][key]; found {
+		state.correctionDisabled = correctionDisabled
+	}
+}
+
+// startDriftController starts the drift controller of the kind, the controller isn't managed by the manager so a
+// kind that fails to be watched doesn't stop the agent. the controller watches the kind through a cache of its own
+// that lists only the objects with the global resource label, the cache is stopped with the controller.
+func (cache *DesiredStateCache) startDriftController(gvk schema.GroupVersionKind) error {
+	name := strings.ToLower(fmt.Sprintf("drift-controller-%s.%s.%s", gvk.Kind, gvk.Version, gvk.Group))
+	log := cache.log.WithValues("kind", gvk.String())
+
+	globalResourceSelector, err := labels.Parse(constants.GlobalHubGlobalResource)
+	if err != nil {
+		return fmt.Errorf("failed to parse global resource selector - %w", err)
+	}
+
+	kindCache, err := ctrlcache.New(cache.mgr.GetConfig(), ctrlcache.Options{
+		Scheme:          cache.mgr.GetScheme(),
+		Mapper:          cache.mgr.GetRESTMapper(),
+		DefaultSelector: ctrlcache.ObjectSelector{Label: globalResourceSelector},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create cache of %s - %w", gvk.String(), err)
+	}
+
+	driftController, err := controller.NewUnmanaged(name, cache.mgr, controller.Options{
+		Reconciler: &driftReconciler{
+			log:       log,
+			gvk:       gvk,
+			reader:    kindCache,
+			apiReader: cache.mgr.GetAPIReader(),
+			recorder:  cache.mgr.GetEventRecorderFor(name),
+			cache:     cache,
+			reporter:  cache.reporter,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create drift controller - %w", err)
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+
+	if err := driftController.Watch(source.NewKindWithCache(obj, kindCache), &handler.EnqueueRequestForObject{},
+		driftPredicate(gvk, cache)); err != nil {
+		return fmt.Errorf("failed to watch %s - %w", gvk.String(), err)
+	}
+
+	go func() {
+		cache.initializationWaitingGroup.Wait() // start the controller only after the context is saved.
+
+		ctx, cancel := context.WithCancel(cache.ctx)
+		defer cancel() // the cache of the kind is stopped with the controller
+
+		go func() {
+			if err := kindCache.Start(ctx); err != nil {
+				log.Error(err, "drift cache stopped")
+			}
+		}()
+
+		if err := driftController.Start(ctx); err != nil {
+			log.Error(err, "drift controller stopped")
+
+			cache.lock.Lock()
+			delete(cache.watchedKinds, gvk) // the controller is started again once the kind is applied
+			cache.lock.Unlock()
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package drift
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/helper"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+// patchClient returns the given error for the patch requests.
+type patchClient struct {
+	client.Client
+	patchErr error
+}
+
+func (c *patchClient) Patch(context.Context, client.Object, client.Patch, ...client.PatchOption) error {
+	return c.patchErr
+}
+
+func TestApplyDesiredState(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "policy.open-cluster-management.io", Version: "v1", Kind: "Policy"}
+	forbiddenErr := apierrors.NewForbidden(schema.GroupResource{Group: gvk.Group, Resource: "policies"}, "policy1",
+		nil)
+
+	cases := []struct {
+		name     string
+		patchErr error
+		cached   bool
+	}{
+		{"applied object is cached", nil, true},
+		{"refused object isn't cached", forbiddenErr, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cache := &DesiredStateCache{
+				objects:         make(map[schema.GroupVersionKind]map[types.NamespacedName]*desiredState),
+				applyingObjects: make(map[objectKey]struct{}),
+			}
+			k8sClient := &patchClient{Client: fake.NewClientBuilder().Build(), patchErr: c.patchErr}
+
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(gvk)
+			obj.SetName("policy1")
+			obj.SetNamespace("default")
+
+			if err := cache.ApplyDesiredState(context.Background(), k8sClient, obj); (err != nil) == c.cached {
+				t.Fatalf("unexpected apply error: %v", err)
+			}
+
+			key := client.ObjectKeyFromObject(obj)
+			if cache.isApplying(gvk, key) {
+				t.Error("expected the object not to be applying once the apply returned")
+			}
+
+			state := cache.getDesiredState(gvk, key)
+			if (state != nil) != c.cached {
+				t.Fatalf("expected cached to be %v, got %v", c.cached, state != nil)
+			}
+
+			if state != nil && state.client != k8sClient {
+				t.Error("expected the object to be restored with the client that applied it")
+			}
+
+			if !helper.HasLabel(obj, constants.GlobalHubGlobalResource) ||
+				state != nil && !helper.HasLabel(state.obj, constants.GlobalHubGlobalResource) {
+				t.Error("expected the object to be applied and cached with the global resource label")
+			}
+		})
+	}
+}
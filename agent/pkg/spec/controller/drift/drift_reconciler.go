@@ -0,0 +1,206 @@
+package drift
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/helper"
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+const (
+	driftCorrectedReason = "DriftCorrected"
+	// applyingRequeuePeriod is the delay of checking the drift of an object that is being applied.
+	applyingRequeuePeriod = time.Second
+)
+
+// driftReconciler restores the objects of a kind received from the global hub when they're modified or deleted
+// locally on the regional hub.
+type driftReconciler struct {
+	log       logr.Logger
+	gvk       schema.GroupVersionKind
+	reader    client.Reader
+	apiReader client.Reader
+	recorder  record.EventRecorder
+	cache     *DesiredStateCache
+	reporter  Reporter
+}
+
+func (r *driftReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	if r.cache.isApplying(r.gvk, request.NamespacedName) { // the drift is checked against the applied state
+		return ctrl.Result{RequeueAfter: applyingRequeuePeriod}, nil
+	}
+
+	state := r.cache.getDesiredState(r.gvk, request.NamespacedName)
+	if state == nil { // the object isn't received from the global hub or it's deleted from the global hub
+		return ctrl.Result{}, nil
+	}
+
+	desiredObj, correctionDisabled := state.obj, state.correctionDisabled
+
+	reason, err := r.detectDrift(ctx, r.reader, desiredObj, request.NamespacedName, correctionDisabled)
+	if err != nil || reason == "" {
+		return ctrl.Result{}, err
+	}
+
+	// the cached object might not be updated yet with the last apply of the object, or it's missing from the cache
+	// since its global resource label was removed locally, the drift is confirmed against the api server to avoid
+	// reapplying the objects needlessly and to report the removed label as a modification.
+	reason, err = r.detectDrift(ctx, r.apiReader, desiredObj, request.NamespacedName, correctionDisabled)
+	if err != nil || reason == "" {
+		return ctrl.Result{}, err
+	}
+
+	// the object is restored with the identity that applied it
+	appliedObj := desiredObj.DeepCopy()
+	err = helper.UpdateObject(ctx, state.client, appliedObj)
+	r.reporter.ReportApplied(appliedObj, err)
+
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to restore the drifted object - %w", err)
+	}
+
+	r.reporter.ReportDrift(desiredObj, reason)
+	r.recorder.Eventf(appliedObj, corev1.EventTypeWarning, driftCorrectedReason,
+		"%s %s was %s locally and restored to the state of the global hub, set the annotation %s=%s to keep "+
+			"the local changes", r.gvk.Kind, request.NamespacedName, strings.ToLower(reason),
+		constants.DriftCorrectionAnnotation, constants.DriftCorrectionDisabled)
+	r.log.Info("drifted object restored", "name", request.Name, "namespace", request.Namespace, "reason", reason)
+
+	return ctrl.Result{}, nil
+}
+
+// detectDrift returns the reason of the drift of the object, an empty reason is returned if the object isn't
+// drifted or the drift correction is disabled for it.
+func (r *driftReconciler) detectDrift(ctx context.Context, reader client.Reader, desiredObj *unstructured.Unstructured,
+	key types.NamespacedName, correctionDisabled bool,
+) (string, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(r.gvk)
+
+	if err := reader.Get(ctx, key, obj); err != nil {
+		if !errors.IsNotFound(err) {
+			return "", fmt.Errorf("failed to get %s %s - %w", r.gvk.Kind, key, err)
+		}
+
+		if correctionDisabled { // the object opted out of the drift correction before it was deleted
+			return "", nil
+		}
+
+		return status.SpecDriftDeleted, nil
+	}
+
+	if obj.GetAnnotations()[constants.DriftCorrectionAnnotation] == constants.DriftCorrectionDisabled {
+		r.cache.setCorrectionDisabled(r.gvk, key, true)
+		return "", nil
+	}
+
+	r.cache.setCorrectionDisabled(r.gvk, key, false)
+
+	if obj.GetDeletionTimestamp() != nil || !isDrifted(desiredObj, obj) {
+		return "", nil
+	}
+
+	return status.SpecDriftModified, nil
+}
+
+// isDrifted returns true if the object doesn't have the state received from the global hub. the fields which aren't
+// set by the global hub are ignored, so the fields defaulted by the api server and the status aren't drifts.
+func isDrifted(desiredObj, obj *unstructured.Unstructured) bool {
+	if !isSubset(desiredObj.GetLabels(), obj.GetLabels()) ||
+		!isSubset(desiredObj.GetAnnotations(), obj.GetAnnotations()) {
+		return true
+	}
+
+	for field, desiredValue := range desiredObj.Object {
+		switch field {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		}
+
+		if !isSubsetValue(desiredValue, obj.Object[field]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSubset(desired, actual map[string]string) bool {
+	for key, value := range desired {
+		if actualValue, found := actual[key]; !found || actualValue != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isSubsetValue returns true if the fields of the desired value are set to the same values in the actual value, the
+// lists are compared item by item.
+func isSubsetValue(desired, actual interface{}) bool {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		for key, value := range desiredValue {
+			if !isSubsetValue(value, actualValue[key]) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if !ok || len(actualValue) != len(desiredValue) {
+			return false
+		}
+
+		for i := range desiredValue {
+			if !isSubsetValue(desiredValue[i], actualValue[i]) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return equality.Semantic.DeepEqual(desired, actual)
+	}
+}
+
+// driftPredicate filters the events of the objects received from the global hub, the updates of the status of the
+// objects are ignored for the kinds that have the generation.
+func driftPredicate(gvk schema.GroupVersionKind, cache *DesiredStateCache) predicate.Predicate {
+	isCached := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return cache.isCached(gvk, client.ObjectKeyFromObject(obj))
+	})
+
+	return predicate.And(isCached, predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return false }, // the objects are created by the agent
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectNew.GetGeneration() == 0 || predicate.GenerationChangedPredicate{}.Update(e) ||
+				predicate.LabelChangedPredicate{}.Update(e) || predicate.AnnotationChangedPredicate{}.Update(e)
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return true },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	})
+}
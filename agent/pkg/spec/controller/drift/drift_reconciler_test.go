@@ -0,0 +1,56 @@
+package drift
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestIsDrifted(t *testing.T) {
+	desiredObj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "policy.open-cluster-management.io/v1",
+		"kind":       "Policy",
+		"metadata": map[string]interface{}{
+			"name":        "policy1",
+			"namespace":   "default",
+			"annotations": map[string]interface{}{"a": "b"},
+		},
+		"spec": map[string]interface{}{
+			"disabled": false,
+			"policy-templates": []interface{}{
+				map[string]interface{}{"objectDefinition": map[string]interface{}{"kind": "ConfigurationPolicy"}},
+			},
+		},
+	}}
+
+	cases := []struct {
+		name    string
+		mutate  func(obj *unstructured.Unstructured)
+		drifted bool
+	}{
+		{"same", func(obj *unstructured.Unstructured) {}, false},
+		{"defaulted and status fields", func(obj *unstructured.Unstructured) {
+			_ = unstructured.SetNestedField(obj.Object, "inform", "spec", "remediationAction")
+			_ = unstructured.SetNestedField(obj.Object, "Compliant", "status", "compliant")
+			obj.SetLabels(map[string]string{"local": "label"})
+		}, false},
+		{"modified field", func(obj *unstructured.Unstructured) {
+			_ = unstructured.SetNestedField(obj.Object, true, "spec", "disabled")
+		}, true},
+		{"removed list item", func(obj *unstructured.Unstructured) {
+			_ = unstructured.SetNestedSlice(obj.Object, []interface{}{}, "spec", "policy-templates")
+		}, true},
+		{"modified annotation", func(obj *unstructured.Unstructured) {
+			obj.SetAnnotations(map[string]string{"a": "c"})
+		}, true},
+	}
+
+	for _, c := range cases {
+		obj := desiredObj.DeepCopy()
+		c.mutate(obj)
+
+		if drifted := isDrifted(desiredObj, obj); drifted != c.drifted {
+			t.Errorf("%s: expected drifted to be %t, got %t", c.name, c.drifted, drifted)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -17,25 +18,109 @@ import (
 	consumer "github.com/stolostron/multicluster-global-hub/agent/pkg/transport/consumer"
 )
 
+// ApplyResultReporter reports the results of applying the objects received from the global hub, so the global hub
+// learns about the objects failed to land on the regional hub.
+type ApplyResultReporter interface {
+	// ReportApplied reports the result of applying the object, the applied object is updated by the apply request.
+	ReportApplied(obj *unstructured.Unstructured, applyErr error)
+	// ReportDeleted reports the object is deleted from the global hub.
+	ReportDeleted(obj *unstructured.Unstructured)
+}
+
+// FullStateRequester requests the global hub to resend the full state of the spec bundles.
+type FullStateRequester interface {
+	// RequestFullState requests the full state of the spec bundle.
+	RequestFullState(bundleID string)
+	// FullStateReceived clears the full state request of the spec bundle.
+	FullStateReceived(bundleID string)
+}
+
+// DesiredStateCache caches the state of the objects received from the global hub, so the objects are restored when
+// they're modified or deleted locally on the regional hub.
+type DesiredStateCache interface {
+	// ApplyDesiredState applies the object with the client and caches its state once it's applied, the cached object
+	// is restored with the same client.
+	ApplyDesiredState(ctx context.Context, k8sClient client.Client, obj *unstructured.Unstructured) error
+	// WatchDrifts watches the kind of the object for drifts, it's called once the object is applied.
+	WatchDrifts(obj *unstructured.Unstructured)
+	// DeleteDesiredState removes the object from the cache, it's called before the object is deleted.
+	DeleteDesiredState(obj *unstructured.Unstructured)
+}
+
+// bundleLanes is a map of bundle id -> the priority lane of the objects of the bundle, the objects of the bundles
+// that aren't listed are applied in the normal priority lane.
+var bundleLanes = map[string]workers.Lane{
+	"Policies":          workers.HighPriorityLane,
+	"PolicySets":        workers.HighPriorityLane,
+	"PlacementBindings": workers.HighPriorityLane,
+	"Applications":      workers.LowPriorityLane,
+	"Subscriptions":     workers.LowPriorityLane,
+	"Channels":          workers.LowPriorityLane,
+	"GenericResources":  workers.LowPriorityLane,
+}
+
 // genericBundleSyncer syncs objects spec from received bundles.
 type genericBundleSyncer struct {
-	log                          logr.Logger
-	genericBundleChan            chan *bundle.GenericBundle
-	workerPool                   *workers.WorkerPool
-	bundleProcessingWaitingGroup sync.WaitGroup
-	enforceHohRbac               bool
+	log                 logr.Logger
+	genericBundleChan   chan *bundle.GenericBundle
+	workerPool          *workers.WorkerPool
+	enforceHohRbac      bool
+	applyResultReporter ApplyResultReporter
+	fullStateRequester  FullStateRequester
+	desiredStateCache   DesiredStateCache
+	// bundleQueues is a map of bundle id -> the bundle waiting to be applied. the bundles of an id are applied in
+	// order, and the bundles of different ids are applied concurrently, so a large bundle doesn't delay the others.
+	bundleQueues map[string]*bundleQueue
+}
+
+// bundleQueue holds the bundle of an id waiting to be applied, a received bundle is conflated with the waiting
+// bundle, so receiving the bundles of an id never waits for applying the previous bundles.
+type bundleQueue struct {
+	lock          sync.Mutex
+	waitingBundle *bundle.GenericBundle
+	// ready is signaled once a bundle is waiting.
+	ready chan struct{}
+}
+
+func (queue *bundleQueue) push(receivedBundle *bundle.GenericBundle) {
+	queue.lock.Lock()
+	if queue.waitingBundle == nil {
+		queue.waitingBundle = receivedBundle
+	} else {
+		queue.waitingBundle = queue.waitingBundle.Conflate(receivedBundle)
+	}
+	queue.lock.Unlock()
+
+	select {
+	case queue.ready <- struct{}{}:
+	default: // the waiting bundle is signaled already
+	}
+}
+
+func (queue *bundleQueue) pop() *bundle.GenericBundle {
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	waitingBundle := queue.waitingBundle
+	queue.waitingBundle = nil
+
+	return waitingBundle
 }
 
 // AddGenericBundleSyncer adds genericBundleSyncer to the manager.
 func AddGenericBundleSyncer(log logr.Logger, mgr ctrl.Manager, enforceHohRbac bool,
-	consumer consumer.Consumer, workerPool *workers.WorkerPool,
+	consumer consumer.Consumer, workerPool *workers.WorkerPool, applyResultReporter ApplyResultReporter,
+	fullStateRequester FullStateRequester, desiredStateCache DesiredStateCache,
 ) error {
 	if err := mgr.Add(&genericBundleSyncer{
-		log:                          log,
-		genericBundleChan:            consumer.GetGenericBundleChan(),
-		workerPool:                   workerPool,
-		bundleProcessingWaitingGroup: sync.WaitGroup{},
-		enforceHohRbac:               enforceHohRbac,
+		log:                 log,
+		genericBundleChan:   consumer.GetGenericBundleChan(),
+		workerPool:          workerPool,
+		enforceHohRbac:      enforceHohRbac,
+		applyResultReporter: applyResultReporter,
+		fullStateRequester:  fullStateRequester,
+		desiredStateCache:   desiredStateCache,
+		bundleQueues:        make(map[string]*bundleQueue),
 	}); err != nil {
 		return fmt.Errorf("failed to add generic bundles spec syncer - %w", err)
 	}
@@ -62,71 +147,172 @@ func (syncer *genericBundleSyncer) sync(ctx context.Context) {
 			return
 
 		case receivedBundle := <-syncer.genericBundleChan: // handle the bundle
-			syncer.bundleProcessingWaitingGroup.Add(
-				len(receivedBundle.Objects) + len(receivedBundle.DeletedObjects))
-			syncer.syncObjects(receivedBundle.Objects)
-			syncer.syncDeletedObjects(receivedBundle.DeletedObjects)
-			syncer.bundleProcessingWaitingGroup.Wait()
+			syncer.getBundleQueue(ctx, receivedBundle.ID).push(receivedBundle)
+		}
+	}
+}
+
+// getBundleQueue returns the queue of the bundles of the id, the bundles of the queue are applied by a goroutine
+// started with the queue. the goroutine keeps the version of the last bundle applied without errors, a delta bundle
+// that isn't based on it isn't applied.
+func (syncer *genericBundleSyncer) getBundleQueue(ctx context.Context, bundleID string) *bundleQueue {
+	if queue, found := syncer.bundleQueues[bundleID]; found {
+		return queue
+	}
+
+	lane, found := bundleLanes[bundleID]
+	if !found {
+		lane = workers.NormalPriorityLane
+	}
+
+	queue := &bundleQueue{ready: make(chan struct{}, 1)}
+	syncer.bundleQueues[bundleID] = queue
+
+	go func() {
+		appliedVersion := ""
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-queue.ready:
+				receivedBundle := queue.pop()
+				if receivedBundle == nil {
+					continue
+				}
+
+				if !syncer.checkBundleVersion(receivedBundle, appliedVersion) {
+					continue
+				}
+
+				// the objects failed to apply are applied again with the full state, which is requested by the
+				// next delta bundle that isn't based on the applied version
+				if syncer.applyBundle(receivedBundle, lane) {
+					appliedVersion = receivedBundle.BundleVersion
+				} else {
+					syncer.log.Info("failed to apply some objects of the bundle", "bundle", receivedBundle.ID,
+						"version", receivedBundle.BundleVersion)
+				}
+			}
 		}
+	}()
+
+	return queue
+}
+
+// applyBundle applies the objects of the bundle in the given lane, it returns once all the objects are handled.
+// returns true if all the objects were applied without errors.
+func (syncer *genericBundleSyncer) applyBundle(receivedBundle *bundle.GenericBundle, lane workers.Lane) bool {
+	var failedObjects int32
+
+	bundleProcessingWaitingGroup := &sync.WaitGroup{}
+	bundleProcessingWaitingGroup.Add(len(receivedBundle.Objects) + len(receivedBundle.DeletedObjects))
+	syncer.syncObjects(receivedBundle.Objects, lane, bundleProcessingWaitingGroup, &failedObjects)
+	syncer.syncDeletedObjects(receivedBundle.DeletedObjects, lane, bundleProcessingWaitingGroup, &failedObjects)
+	bundleProcessingWaitingGroup.Wait()
+
+	return atomic.LoadInt32(&failedObjects) == 0
+}
+
+// checkBundleVersion returns false if the delta bundle isn't based on the last applied bundle, the full state of the
+// bundle is requested from the global hub in such case.
+func (syncer *genericBundleSyncer) checkBundleVersion(receivedBundle *bundle.GenericBundle,
+	appliedVersion string,
+) bool {
+	if !receivedBundle.IsDelta() {
+		syncer.fullStateRequester.FullStateReceived(receivedBundle.ID)
+
+		return true
 	}
+
+	if appliedVersion != receivedBundle.BaseBundleVersion {
+		syncer.log.Info("detected a gap in the delta bundles, requesting the full state", "bundle", receivedBundle.ID,
+			"appliedVersion", appliedVersion, "baseVersion", receivedBundle.BaseBundleVersion)
+		syncer.fullStateRequester.RequestFullState(receivedBundle.ID)
+
+		return false
+	}
+
+	return true
 }
 
-func (syncer *genericBundleSyncer) syncObjects(bundleObjects []*unstructured.Unstructured) {
+func (syncer *genericBundleSyncer) syncObjects(bundleObjects []*unstructured.Unstructured, lane workers.Lane,
+	bundleProcessingWaitingGroup *sync.WaitGroup, failedObjects *int32,
+) {
 	for _, bundleObject := range bundleObjects {
 		if !syncer.enforceHohRbac { // if rbac not enforced, use controller's identity.
 			bundleObject = syncer.anonymize(bundleObject) // anonymize removes the user identity from the obj if exists
 		}
 
-		syncer.workerPool.Submit(workers.NewJob(bundleObject, func(ctx context.Context,
+		syncer.workerPool.Submit(workers.NewJob(bundleObject, lane, func(ctx context.Context,
 			k8sClient client.Client, obj interface{},
-		) {
-			defer syncer.bundleProcessingWaitingGroup.Done()
-
+		) error {
 			unstructuredObject, _ := obj.(*unstructured.Unstructured)
 
 			if !syncer.enforceHohRbac { // if rbac not enforced, create missing namespaces.
 				if err := helper.CreateNamespaceIfNotExist(ctx, k8sClient,
 					unstructuredObject.GetNamespace()); err != nil {
-					syncer.log.Error(err, "failed to create namespace",
-						"namespace", unstructuredObject.GetNamespace())
-					return
+					return fmt.Errorf("failed to create namespace %s - %w", unstructuredObject.GetNamespace(), err)
 				}
 			}
 
-			err := helper.UpdateObject(ctx, k8sClient, unstructuredObject)
+			return syncer.desiredStateCache.ApplyDesiredState(ctx, k8sClient, unstructuredObject)
+		}, func(obj interface{}, err error) {
+			defer bundleProcessingWaitingGroup.Done()
+
+			unstructuredObject, _ := obj.(*unstructured.Unstructured)
+
+			syncer.applyResultReporter.ReportApplied(unstructuredObject, err)
 			if err != nil {
+				atomic.AddInt32(failedObjects, 1)
 				syncer.log.Error(err, "failed to update object", "name", unstructuredObject.GetName(),
 					"namespace", unstructuredObject.GetNamespace(), "kind", unstructuredObject.GetKind())
 				return
 			}
+			syncer.desiredStateCache.WatchDrifts(unstructuredObject)
 			syncer.log.Info("object updated", "name", unstructuredObject.GetName(), "namespace",
 				unstructuredObject.GetNamespace(), "kind", unstructuredObject.GetKind())
 		}))
 	}
 }
 
-func (syncer *genericBundleSyncer) syncDeletedObjects(deletedObjects []*unstructured.Unstructured) {
+func (syncer *genericBundleSyncer) syncDeletedObjects(deletedObjects []*unstructured.Unstructured, lane workers.Lane,
+	bundleProcessingWaitingGroup *sync.WaitGroup, failedObjects *int32,
+) {
 	for _, deletedBundleObj := range deletedObjects {
 		if !syncer.enforceHohRbac { // if rbac not enforced, use controller's identity.
 			deletedBundleObj = syncer.anonymize(deletedBundleObj) // anonymize removes the user identity from the obj if exists
 		}
 
-		syncer.workerPool.Submit(workers.NewJob(deletedBundleObj, func(ctx context.Context,
+		syncer.workerPool.Submit(workers.NewJob(deletedBundleObj, lane, func(ctx context.Context,
 			k8sClient client.Client, obj interface{},
-		) {
-			defer syncer.bundleProcessingWaitingGroup.Done()
+		) error {
+			unstructuredObject, _ := obj.(*unstructured.Unstructured)
+
+			syncer.desiredStateCache.DeleteDesiredState(unstructuredObject)
+			deleted, err := helper.DeleteObject(ctx, k8sClient, unstructuredObject)
+			if err != nil {
+				return err
+			}
+			if deleted {
+				syncer.log.Info("object deleted", "name", unstructuredObject.GetName(),
+					"namespace", unstructuredObject.GetNamespace(), "kind", unstructuredObject.GetKind())
+			}
+
+			return nil
+		}, func(obj interface{}, err error) {
+			defer bundleProcessingWaitingGroup.Done()
 
 			unstructuredObject, _ := obj.(*unstructured.Unstructured)
 
-			// syncer.deleteObject(ctx, k8sClient, obj.(*unstructured.Unstructured))
-			if deleted, err := helper.DeleteObject(ctx, k8sClient, unstructuredObject); err != nil {
+			if err != nil {
+				atomic.AddInt32(failedObjects, 1)
 				syncer.log.Error(err, "failed to delete object", "name",
 					unstructuredObject.GetName(), "namespace",
 					unstructuredObject.GetNamespace(), "kind", unstructuredObject.GetKind())
-			} else if deleted {
-				syncer.log.Info("object deleted", "name", unstructuredObject.GetName(),
-					"namespace", unstructuredObject.GetNamespace(), "kind", unstructuredObject.GetKind())
+				return
 			}
+			syncer.applyResultReporter.ReportDeleted(unstructuredObject)
 		}))
 	}
 }
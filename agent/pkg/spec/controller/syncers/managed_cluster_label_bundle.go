@@ -144,15 +144,13 @@ func (syncer *managedClusterLabelsBundleSyncer) handleBundle() {
 func (syncer *managedClusterLabelsBundleSyncer) updateManagedClusterAsync(
 	labelsSpec *specbundle.ManagedClusterLabelsSpec, lastProcessedTimestampPtr *time.Time,
 ) {
-	syncer.workerPool.Submit(workers.NewJob(labelsSpec, func(ctx context.Context,
+	syncer.workerPool.Submit(workers.NewJob(labelsSpec, workers.HighPriorityLane, func(ctx context.Context,
 		k8sClient client.Client, obj interface{},
-	) {
-		defer syncer.bundleProcessingWaitingGroup.Done()
-
+	) error {
 		syncer.log.Info("update the label bundle to ManagedCluster CR...")
 		labelsSpec, ok := obj.(*specbundle.ManagedClusterLabelsSpec)
 		if !ok {
-			syncer.log.Error(errors.New("job obj is not a ManagedClusterLabelsSpec type"), "invald obj type")
+			return errors.New("job obj is not a ManagedClusterLabelsSpec type")
 		}
 
 		managedCluster := &clusterv1.ManagedCluster{}
@@ -160,12 +158,9 @@ func (syncer *managedClusterLabelsBundleSyncer) updateManagedClusterAsync(
 			Name: labelsSpec.ClusterName,
 		}, managedCluster); k8serrors.IsNotFound(err) {
 			syncer.log.Info("managed cluster ignored - not found", "name", labelsSpec.ClusterName)
-			syncer.managedClusterMarkUpdated(labelsSpec, lastProcessedTimestampPtr) // if not found then irrelevant
-
-			return
+			return nil // if not found then irrelevant
 		} else if err != nil {
-			syncer.log.Error(err, "failed to get managed cluster", "name", labelsSpec.ClusterName)
-			return
+			return fmt.Errorf("failed to get managed cluster - %w", err)
 		}
 
 		// enforce received labels state (overwrite if exists)
@@ -179,18 +174,26 @@ func (syncer *managedClusterLabelsBundleSyncer) updateManagedClusterAsync(
 		}
 
 		if err := syncer.updateManagedFieldEntry(managedCluster, labelsSpec); err != nil {
-			syncer.log.Error(err, "failed to update managed cluster", "name", labelsSpec.ClusterName)
-			return
+			return err
 		}
 
-		// update CR with replace API: fails if CR was modified since client.get
+		// update CR with replace API: fails if CR was modified since client.get, the job is retried on conflicts
 		if err := k8sClient.Update(ctx, managedCluster,
 			&client.UpdateOptions{FieldManager: hohFieldManager}); err != nil {
-			syncer.log.Error(err, "failed to update managed cluster", "name", labelsSpec.ClusterName)
-			return
+			return fmt.Errorf("failed to update managed cluster - %w", err)
 		}
 
 		syncer.log.Info("managed cluster updated", "name", labelsSpec.ClusterName)
+
+		return nil
+	}, func(obj interface{}, err error) {
+		defer syncer.bundleProcessingWaitingGroup.Done()
+
+		if err != nil {
+			syncer.log.Error(err, "failed to update managed cluster labels", "name", labelsSpec.ClusterName)
+			return
+		}
+
 		syncer.managedClusterMarkUpdated(labelsSpec, lastProcessedTimestampPtr)
 	}))
 }
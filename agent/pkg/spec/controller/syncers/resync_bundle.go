@@ -0,0 +1,110 @@
+package syncers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/spec/bundle"
+	consumer "github.com/stolostron/multicluster-global-hub/agent/pkg/transport/consumer"
+	specbundle "github.com/stolostron/multicluster-global-hub/pkg/bundle/spec"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+// ResyncConfigMapKey is the key in the incarnation configmap of the last handled resync request.
+const ResyncConfigMapKey = "resync"
+
+// resyncBundleSyncer restarts the agent once a new resync request is received, so the status bundles are resent
+// with their complete state and a new incarnation.
+type resyncBundleSyncer struct {
+	log                  logr.Logger
+	bundleUpdatesChan    chan interface{}
+	k8sClient            client.Client
+	apiReader            client.Reader
+	incarnationConfigMap client.ObjectKey
+	restartFunc          context.CancelFunc
+}
+
+// AddResyncBundleSyncer adds resyncBundleSyncer to the manager.
+func AddResyncBundleSyncer(log logr.Logger, mgr ctrl.Manager, consumer consumer.Consumer,
+	incarnationConfigMap client.ObjectKey, restartFunc context.CancelFunc,
+) error {
+	customBundleUpdatesChan := make(chan interface{})
+
+	if err := mgr.Add(&resyncBundleSyncer{
+		log:                  log,
+		bundleUpdatesChan:    customBundleUpdatesChan,
+		k8sClient:            mgr.GetClient(),
+		apiReader:            mgr.GetAPIReader(), // avoid caching all the configmaps
+		incarnationConfigMap: incarnationConfigMap,
+		restartFunc:          restartFunc,
+	}); err != nil {
+		close(customBundleUpdatesChan)
+		return fmt.Errorf("failed to add resync bundle syncer - %w", err)
+	}
+
+	consumer.Register(constants.ResyncMsgKey, &bundle.CustomBundleRegistration{
+		InitBundlesResourceFunc: func() interface{} {
+			return &specbundle.ResyncSpecBundle{}
+		},
+		BundleUpdatesChan: customBundleUpdatesChan,
+	})
+
+	return nil
+}
+
+// Start function starts resync bundle syncer.
+func (syncer *resyncBundleSyncer) Start(ctx context.Context) error {
+	syncer.log.Info("started resync bundle syncer...")
+
+	for {
+		select {
+		case <-ctx.Done(): // we have received a signal to stop
+			syncer.log.Info("stopped resync bundle syncer")
+			return nil
+
+		case transportedBundle := <-syncer.bundleUpdatesChan: // handle the bundle
+			receivedBundle, ok := transportedBundle.(*specbundle.ResyncSpecBundle)
+			if !ok {
+				continue
+			}
+
+			if err := syncer.handleBundle(ctx, receivedBundle); err != nil {
+				syncer.log.Error(err, "failed to handle resync bundle", "id", receivedBundle.ID)
+			}
+		}
+	}
+}
+
+// handleBundle records the resync request in the incarnation configmap and restarts the agent if the request
+// wasn't handled before, the incarnation is incremented by the restarted agent.
+func (syncer *resyncBundleSyncer) handleBundle(ctx context.Context, resyncBundle *specbundle.ResyncSpecBundle) error {
+	configMap := &v1.ConfigMap{}
+	if err := syncer.apiReader.Get(ctx, syncer.incarnationConfigMap, configMap); err != nil {
+		return fmt.Errorf("failed to get incarnation config-map - %w", err)
+	}
+
+	if configMap.Data[ResyncConfigMapKey] == resyncBundle.ID {
+		return nil // already handled
+	}
+
+	updatedConfigMap := configMap.DeepCopy()
+	if updatedConfigMap.Data == nil {
+		updatedConfigMap.Data = map[string]string{}
+	}
+	updatedConfigMap.Data[ResyncConfigMapKey] = resyncBundle.ID
+
+	if err := syncer.k8sClient.Patch(ctx, updatedConfigMap, client.MergeFrom(configMap)); err != nil {
+		return fmt.Errorf("failed to update resync request - %w", err)
+	}
+
+	syncer.log.Info("restarting to resend the complete status with a new incarnation", "id", resyncBundle.ID,
+		"requestTimestamp", resyncBundle.RequestTimestamp)
+	syncer.restartFunc()
+
+	return nil
+}
@@ -2,22 +2,57 @@ package workers
 
 import (
 	"context"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// K8sJobHandlerFunc is a function for running a k8s job by a k8s worker.
-// type K8sJobHandlerFunc func(context.Context, client.Client, interface{})
-// K8sJob represents the job to be run by a k8sWorker from the pool.
+// Lane is the priority lane of a job, the workers pick the jobs of the lanes by the weights of the lanes.
+type Lane int
+
+const (
+	// HighPriorityLane is the lane of the jobs that should be applied first, e.g. policies and cluster labels.
+	HighPriorityLane Lane = iota
+	// NormalPriorityLane is the default lane of the jobs.
+	NormalPriorityLane
+	// LowPriorityLane is the lane of the jobs of large bundles that may wait, e.g. subscriptions.
+	LowPriorityLane
+)
+
+// String returns the name of the lane.
+func (lane Lane) String() string {
+	switch lane {
+	case HighPriorityLane:
+		return "high"
+	case LowPriorityLane:
+		return "low"
+	default:
+		return "normal"
+	}
+}
+
+// JobHandlerFunc is a function for running a job by a worker, the job is retried if it returns a transient error.
+type JobHandlerFunc func(context.Context, client.Client, interface{}) error
+
+// JobCompletionFunc is a function invoked once the job is done, with the error of its last run.
+type JobCompletionFunc func(obj interface{}, err error)
+
+// Job represents the job to be run by a worker from the pool.
 type Job struct {
-	obj         interface{}
-	handlerFunc func(context.Context, client.Client, interface{})
+	obj            interface{}
+	lane           Lane
+	handlerFunc    JobHandlerFunc
+	completionFunc JobCompletionFunc
+	attempts       int
+	submittedAt    time.Time
 }
 
-// NewJob creates a new instance of K8sJob.
-func NewJob(obj interface{}, handlerFunc func(context.Context, client.Client, interface{})) *Job {
+// NewJob creates a new instance of Job, the completionFunc is optional.
+func NewJob(obj interface{}, lane Lane, handlerFunc JobHandlerFunc, completionFunc JobCompletionFunc) *Job {
 	return &Job{
-		obj:         obj,
-		handlerFunc: handlerFunc,
+		obj:            obj,
+		lane:           lane,
+		handlerFunc:    handlerFunc,
+		completionFunc: completionFunc,
 	}
 }
@@ -0,0 +1,28 @@
+package workers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	jobQueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "multicluster_global_hub_agent_spec_job_queue_depth",
+		Help: "Number of spec jobs waiting for a worker, by priority lane.",
+	}, []string{"lane"})
+
+	jobApplyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "multicluster_global_hub_agent_spec_job_apply_duration_seconds",
+		Help:    "Time from submitting a spec job until it's done, including the waits and the retries, by priority lane.",
+		Buckets: prometheus.ExponentialBuckets(0.01, 2, 15),
+	}, []string{"lane"})
+
+	jobRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "multicluster_global_hub_agent_spec_job_retries_total",
+		Help: "Number of spec job retries after transient errors, by priority lane.",
+	}, []string{"lane"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(jobQueueDepth, jobApplyDuration, jobRetries)
+}
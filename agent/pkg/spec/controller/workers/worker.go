@@ -11,26 +11,32 @@ import (
 
 // worker within the WorkerPool. runs as a goroutine and invokes Jobs.
 type Worker struct {
-	log      logr.Logger
-	id       int
-	client   client.Client
-	jobQueue chan *Job
+	log        logr.Logger
+	id         int
+	client     client.Client
+	jobQueue   chan *Job
+	runJobFunc func(context.Context, client.Client, *Job)
 }
 
-func newWorker(log logr.Logger, id int, kubeConfig *rest.Config, jobsQueue chan *Job) (*Worker, error) {
+func newWorker(log logr.Logger, id int, kubeConfig *rest.Config, jobsQueue chan *Job,
+	runJobFunc func(context.Context, client.Client, *Job),
+) (*Worker, error) {
 	client, err := client.New(kubeConfig, client.Options{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize worker - %w", err)
 	}
-	return newWorkerWithClient(log, id, client, jobsQueue), nil
+	return newWorkerWithClient(log, id, client, jobsQueue, runJobFunc), nil
 }
 
-func newWorkerWithClient(log logr.Logger, id int, k8sClient client.Client, jobsQueue chan *Job) *Worker {
+func newWorkerWithClient(log logr.Logger, id int, k8sClient client.Client, jobsQueue chan *Job,
+	runJobFunc func(context.Context, client.Client, *Job),
+) *Worker {
 	return &Worker{
-		log:      log,
-		id:       id,
-		client:   k8sClient,
-		jobQueue: jobsQueue,
+		log:        log,
+		id:         id,
+		client:     k8sClient,
+		jobQueue:   jobsQueue,
+		runJobFunc: runJobFunc,
 	}
 }
 
@@ -42,7 +48,7 @@ func (worker *Worker) start(ctx context.Context) {
 			case <-ctx.Done(): // received a signal to stop
 				return
 			case job := <-worker.jobQueue: // Worker received a job request.
-				job.handlerFunc(ctx, worker.client, job.obj)
+				worker.runJobFunc(ctx, worker.client, job)
 			}
 		}
 	}()
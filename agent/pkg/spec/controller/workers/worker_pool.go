@@ -4,20 +4,42 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/spec/controller/rbac"
 )
 
+const (
+	// maxJobAttempts is the number of times a job is run before giving up on transient errors.
+	maxJobAttempts = 5
+	// jobRetryBaseDelay is the delay of the first retry of a job, it's doubled on each retry up to jobRetryMaxDelay.
+	jobRetryBaseDelay = time.Second
+	jobRetryMaxDelay  = 30 * time.Second
+)
+
+// laneSchedule is the order the lanes are picked in when jobs wait in several lanes, each lane appears by its weight:
+// high 4, normal 2, low 1. a lane with no waiting jobs is skipped, so the workers are never idle while jobs wait.
+var laneSchedule = []Lane{
+	HighPriorityLane, NormalPriorityLane, HighPriorityLane, LowPriorityLane,
+	HighPriorityLane, NormalPriorityLane, HighPriorityLane,
+}
+
 // Workpool pool that creates all k8s workers and the assigns k8s jobs to available workers.
+// the jobs of the controller's identity wait in priority lanes, and are handed to the workers by the weights of the
+// lanes. the jobs of an impersonated user are run in order by the worker of the user.
 type WorkerPool struct {
 	ctx                        context.Context
 	log                        logr.Logger
 	kubeConfig                 *rest.Config
 	jobsQueue                  chan *Job
+	laneQueues                 map[Lane]chan *Job
 	poolSize                   int
 	initializationWaitingGroup sync.WaitGroup
 	impersonationManager       *rbac.ImpersonationManager
@@ -31,9 +53,14 @@ func AddWorkerPool(log logr.Logger, workpoolSize int, manager ctrl.Manager) (*Wo
 
 	// for impersonation workers we have additional workers, one per impersonated user.
 	workerPool := &WorkerPool{
-		log:                        log,
-		kubeConfig:                 config,
-		jobsQueue:                  make(chan *Job, workpoolSize), // each worker can handle at most one job at a time
+		log:        log,
+		kubeConfig: config,
+		jobsQueue:  make(chan *Job), // a job is picked from the lanes only once a worker is available
+		laneQueues: map[Lane]chan *Job{
+			HighPriorityLane:   make(chan *Job, workpoolSize),
+			NormalPriorityLane: make(chan *Job, workpoolSize),
+			LowPriorityLane:    make(chan *Job, workpoolSize),
+		},
 		poolSize:                   workpoolSize,
 		initializationWaitingGroup: sync.WaitGroup{},
 		impersonationManager:       rbac.NewImpersonationManager(config),
@@ -56,7 +83,7 @@ func (pool *WorkerPool) Start(ctx context.Context) error {
 	pool.initializationWaitingGroup.Done() // once context is saved, it's safe to let RunAsync work with no concerns.
 
 	for i := 1; i <= pool.poolSize; i++ {
-		worker, err := newWorker(pool.log, i, pool.kubeConfig, pool.jobsQueue)
+		worker, err := newWorker(pool.log, i, pool.kubeConfig, pool.jobsQueue, pool.runJob)
 		if err != nil {
 			return fmt.Errorf("failed to start k8s workers pool - %w", err)
 		}
@@ -64,53 +91,149 @@ func (pool *WorkerPool) Start(ctx context.Context) error {
 		worker.start(ctx)
 	}
 
-	<-ctx.Done() // blocking wait for stop event
-
-	// context was cancelled, do cleanup
-	for _, workerQueue := range pool.impersonationWorkersQueues {
-		close(workerQueue)
-	}
+	go pool.dispatch(ctx)
 
-	close(pool.jobsQueue)
+	<-ctx.Done() // blocking wait for stop event
 
 	return nil
 }
 
+// Submit submits the job to the pool, it blocks while the queue of the job is full. the completion func of the job
+// is invoked once the job is done.
 func (pool *WorkerPool) Submit(job *Job) {
 	pool.initializationWaitingGroup.Wait() // start running jobs only after some initialization steps have finished.
 
-	userIdentity, err := pool.impersonationManager.GetUserIdentity(job.obj)
+	job.submittedAt = time.Now()
+	pool.enqueue(job)
+}
+
+func (pool *WorkerPool) enqueue(job *Job) {
+	jobQueue, err := pool.getJobQueue(job)
 	if err != nil {
-		pool.log.Error(err, "failed to get user identity from obj")
+		pool.log.Error(err, "failed to get the queue of the job")
+		pool.completeJob(job, err)
+
 		return
 	}
+
+	jobQueueDepth.WithLabelValues(job.lane.String()).Inc()
+
+	select {
+	case jobQueue <- job:
+	case <-pool.ctx.Done():
+		jobQueueDepth.WithLabelValues(job.lane.String()).Dec()
+		pool.completeJob(job, pool.ctx.Err())
+	}
+}
+
+// getJobQueue returns the lane of the job if it runs with the controller's identity, otherwise the queue of the
+// worker that impersonates the user of the job.
+func (pool *WorkerPool) getJobQueue(job *Job) (chan *Job, error) {
+	userIdentity, err := pool.impersonationManager.GetUserIdentity(job.obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user identity from obj - %w", err)
+	}
 	// if it doesn't contain impersonation info, let the controller worker pool handle it.
 	if userIdentity == rbac.NoIdentity {
-		pool.jobsQueue <- job
-		return
+		return pool.laneQueues[job.lane], nil
 	}
 	// otherwise, need to impersonate and use the specific worker to enforce permissions.
 	base64UserGroups, userGroups, err := pool.impersonationManager.GetUserGroups(job.obj)
 	if err != nil {
-		pool.log.Error(err, "failed to get user groups from obj")
-		return
+		return nil, fmt.Errorf("failed to get user groups from obj - %w", err)
 	}
 
 	pool.impersonationWorkersLock.Lock()
+	defer pool.impersonationWorkersLock.Unlock()
 
 	workerIdentifier := fmt.Sprintf("%s.%s", userIdentity, base64UserGroups)
 
 	if _, found := pool.impersonationWorkersQueues[workerIdentifier]; !found {
 		if err := pool.createUserWorker(userIdentity, userGroups, workerIdentifier); err != nil {
-			pool.log.Error(err, "failed to create user worker", "user", userIdentity)
+			return nil, fmt.Errorf("failed to create user worker for %s - %w", userIdentity, err)
+		}
+	}
+
+	return pool.impersonationWorkersQueues[workerIdentifier], nil
+}
+
+// dispatch hands the jobs of the lanes to the workers by the lane schedule.
+func (pool *WorkerPool) dispatch(ctx context.Context) {
+	nextLane := 0
+
+	for {
+		job, found := pool.nextJob(&nextLane)
+		if !found { // wait for a job in any of the lanes
+			select {
+			case <-ctx.Done():
+				return
+			case job = <-pool.laneQueues[HighPriorityLane]:
+			case job = <-pool.laneQueues[NormalPriorityLane]:
+			case job = <-pool.laneQueues[LowPriorityLane]:
+			}
+		}
+
+		select {
+		case <-ctx.Done():
 			return
+		case pool.jobsQueue <- job:
 		}
 	}
-	// push the job to the queue of the specific worker that uses the user identity
-	workerQueue := pool.impersonationWorkersQueues[workerIdentifier]
+}
+
+// nextJob returns a waiting job of the first lane with waiting jobs in the schedule, starting from nextLane.
+func (pool *WorkerPool) nextJob(nextLane *int) (*Job, bool) {
+	for i := range laneSchedule {
+		scheduleIndex := (*nextLane + i) % len(laneSchedule)
+
+		select {
+		case job := <-pool.laneQueues[laneSchedule[scheduleIndex]]:
+			*nextLane = (scheduleIndex + 1) % len(laneSchedule)
+			return job, true
+		default:
+		}
+	}
+
+	return nil, false
+}
+
+// runJob runs the job, it's retried with a backoff if it fails with a transient error.
+func (pool *WorkerPool) runJob(ctx context.Context, k8sClient client.Client, job *Job) {
+	jobQueueDepth.WithLabelValues(job.lane.String()).Dec()
+
+	job.attempts++
+	err := job.handlerFunc(ctx, k8sClient, job.obj)
+
+	if err == nil || !isTransientError(err) || job.attempts >= maxJobAttempts || ctx.Err() != nil {
+		pool.completeJob(job, err)
+		return
+	}
+
+	delay := jobRetryBaseDelay << (job.attempts - 1)
+	if delay > jobRetryMaxDelay {
+		delay = jobRetryMaxDelay
+	}
+
+	pool.log.Info("retrying job after a transient error", "attempt", job.attempts, "delay", delay,
+		"error", err.Error())
+	jobRetries.WithLabelValues(job.lane.String()).Inc()
+
+	time.AfterFunc(delay, func() { pool.enqueue(job) })
+}
+
+func (pool *WorkerPool) completeJob(job *Job, err error) {
+	jobApplyDuration.WithLabelValues(job.lane.String()).Observe(time.Since(job.submittedAt).Seconds())
+
+	if job.completionFunc != nil {
+		job.completionFunc(job.obj, err)
+	}
+}
 
-	pool.impersonationWorkersLock.Unlock()
-	workerQueue <- job // since this call might get blocking, first Unlock, then try to insert job into queue
+// isTransientError returns true if the request may succeed when it's retried.
+func isTransientError(err error) bool {
+	return apierrors.IsConflict(err) || apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) || apierrors.IsServiceUnavailable(err) || apierrors.IsInternalError(err) ||
+		utilnet.IsConnectionRefused(err) || utilnet.IsConnectionReset(err) || utilnet.IsProbableEOF(err)
 }
 
 func (pool *WorkerPool) createUserWorker(userIdentity string, userGroups []string, workerIdentifier string) error {
@@ -122,7 +245,7 @@ func (pool *WorkerPool) createUserWorker(userIdentity string, userGroups []strin
 	workerQueue := make(chan *Job, pool.poolSize)
 	worker := newWorkerWithClient(pool.log.WithName(
 		fmt.Sprintf("impersonation-%s", userIdentity)),
-		1, k8sClient, workerQueue)
+		1, k8sClient, workerQueue, pool.runJob)
 	worker.start(pool.ctx)
 	pool.impersonationWorkersQueues[workerIdentifier] = workerQueue
 
@@ -0,0 +1,54 @@
+package workers
+
+import (
+	"testing"
+)
+
+func TestNextJob(t *testing.T) {
+	pool := &WorkerPool{laneQueues: map[Lane]chan *Job{
+		HighPriorityLane:   make(chan *Job, 10),
+		NormalPriorityLane: make(chan *Job, 10),
+		LowPriorityLane:    make(chan *Job, 10),
+	}}
+
+	for lane := range pool.laneQueues {
+		for i := 0; i < 10; i++ {
+			pool.laneQueues[lane] <- NewJob(nil, lane, nil, nil)
+		}
+	}
+
+	// the lanes are picked by their weights while all of them have waiting jobs
+	picked := map[Lane]int{}
+	nextLane := 0
+
+	for i := 0; i < len(laneSchedule); i++ {
+		job, found := pool.nextJob(&nextLane)
+		if !found {
+			t.Fatalf("expected a job to be picked")
+		}
+		picked[job.lane]++
+	}
+
+	if picked[HighPriorityLane] != 4 || picked[NormalPriorityLane] != 2 || picked[LowPriorityLane] != 1 {
+		t.Errorf("unexpected picked jobs by lane: %v", picked)
+	}
+
+	// the lanes with no waiting jobs are skipped
+	for len(pool.laneQueues[HighPriorityLane]) > 0 {
+		<-pool.laneQueues[HighPriorityLane]
+	}
+	for len(pool.laneQueues[NormalPriorityLane]) > 0 {
+		<-pool.laneQueues[NormalPriorityLane]
+	}
+
+	waitingJobs := len(pool.laneQueues[LowPriorityLane])
+	for i := 0; i < waitingJobs; i++ {
+		if job, found := pool.nextJob(&nextLane); !found || job.lane != LowPriorityLane {
+			t.Fatalf("expected a job of the low priority lane to be picked")
+		}
+	}
+
+	if _, found := pool.nextJob(&nextLane); found {
+		t.Errorf("expected no job to be picked")
+	}
+}
@@ -0,0 +1,124 @@
+package clusterlifecycle
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	statusbundle "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+)
+
+// condition is a hive condition of a cluster deployment or a cluster claim.
+type condition struct {
+	status  string
+	reason  string
+	message string
+}
+
+// GetClusterDeploymentStatus returns the lifecycle status of a hive cluster deployment.
+func GetClusterDeploymentStatus(object *unstructured.Unstructured) interface{} {
+	status := &statusbundle.ClusterDeploymentStatus{
+		UID:       string(object.GetUID()),
+		Name:      object.GetName(),
+		Namespace: object.GetNamespace(),
+	}
+
+	// the platform is the single field of the platform spec, e.g. aws
+	platforms, _, _ := unstructured.NestedMap(object.Object, "spec", "platform")
+	for platform := range platforms {
+		status.Platform = platform
+		status.Region, _, _ = unstructured.NestedString(platforms, platform, "region")
+	}
+
+	status.ClusterPoolName, _, _ = unstructured.NestedString(object.Object, "spec", "clusterPoolRef", "poolName")
+	status.ClaimName, _, _ = unstructured.NestedString(object.Object, "spec", "clusterPoolRef", "claimName")
+	status.InstallRestarts, _, _ = unstructured.NestedInt64(object.Object, "status", "installRestarts")
+
+	status.PowerState, _, _ = unstructured.NestedString(object.Object, "status", "powerState")
+	if status.PowerState == "" { // older hive versions report only the desired power state
+		status.PowerState, _, _ = unstructured.NestedString(object.Object, "spec", "powerState")
+	}
+
+	if installedTimestamp, found, _ := unstructured.NestedString(object.Object, "status",
+		"installedTimestamp"); found {
+		if timestamp, err := time.Parse(time.RFC3339, installedTimestamp); err == nil {
+			status.InstalledTimestamp = &timestamp
+		}
+	}
+
+	installed, _, _ := unstructured.NestedBool(object.Object, "spec", "installed")
+	conditions := getConditions(object)
+
+	var reasonCondition *condition
+
+	switch {
+	case object.GetDeletionTimestamp() != nil:
+		status.ProvisionStatus = statusbundle.ClusterDestroying
+	case installed:
+		status.ProvisionStatus = statusbundle.ClusterInstalled
+		if hibernating, found := conditions["Hibernating"]; found && hibernating.status == "True" {
+			reasonCondition = hibernating
+		}
+	case isTrue(conditions, "ProvisionStopped"):
+		status.ProvisionStatus = statusbundle.ClusterProvisionStopped
+		reasonCondition = conditions["ProvisionStopped"]
+	case isTrue(conditions, "ProvisionFailed"):
+		status.ProvisionStatus = statusbundle.ClusterProvisionFailed
+		reasonCondition = conditions["ProvisionFailed"]
+	default:
+		status.ProvisionStatus = statusbundle.ClusterProvisioning
+	}
+
+	if reasonCondition != nil {
+		status.Reason, status.Message = reasonCondition.reason, reasonCondition.message
+	}
+
+	return status
+}
+
+// GetClusterPoolClaimStatus returns the status of a hive cluster claim.
+func GetClusterPoolClaimStatus(object *unstructured.Unstructured) interface{} {
+	status := &statusbundle.ClusterPoolClaimStatus{
+		UID:       string(object.GetUID()),
+		Name:      object.GetName(),
+		Namespace: object.GetNamespace(),
+	}
+
+	status.ClusterPoolName, _, _ = unstructured.NestedString(object.Object, "spec", "clusterPoolName")
+	// the namespace of the assigned cluster deployment is named after the cluster
+	status.ClusterName, _, _ = unstructured.NestedString(object.Object, "spec", "namespace")
+
+	// the claim is pending until the pending condition is false
+	status.Pending = true
+	if pending, found := getConditions(object)["Pending"]; found {
+		status.Pending = pending.status != "False"
+		status.Reason, status.Message = pending.reason, pending.message
+	}
+
+	return status
+}
+
+func getConditions(object *unstructured.Unstructured) map[string]*condition {
+	conditions := make(map[string]*condition)
+
+	items, _, _ := unstructured.NestedSlice(object.Object, "status", "conditions")
+	for _, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		conditionType, _, _ := unstructured.NestedString(fields, "type")
+		status, _, _ := unstructured.NestedString(fields, "status")
+		reason, _, _ := unstructured.NestedString(fields, "reason")
+		message, _, _ := unstructured.NestedString(fields, "message")
+		conditions[conditionType] = &condition{status: status, reason: reason, message: message}
+	}
+
+	return conditions
+}
+
+func isTrue(conditions map[string]*condition, conditionType string) bool {
+	condition, found := conditions[conditionType]
+	return found && condition.status == "True"
+}
@@ -0,0 +1,79 @@
+package clusterlifecycle
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	statusbundle "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+)
+
+func TestGetClusterDeploymentStatus(t *testing.T) {
+	for _, test := range []struct {
+		name            string
+		spec            map[string]interface{}
+		conditions      []interface{}
+		provisionStatus string
+		reason          string
+	}{
+		{
+			name:            "provisioning",
+			spec:            map[string]interface{}{"installed": false},
+			provisionStatus: statusbundle.ClusterProvisioning,
+		},
+		{
+			name: "failed",
+			spec: map[string]interface{}{"installed": false},
+			conditions: []interface{}{
+				map[string]interface{}{"type": "ProvisionFailed", "status": "True", "reason": "InstallFailed"},
+			},
+			provisionStatus: statusbundle.ClusterProvisionFailed,
+			reason:          "InstallFailed",
+		},
+		{
+			name: "stopped",
+			spec: map[string]interface{}{"installed": false},
+			conditions: []interface{}{
+				map[string]interface{}{"type": "ProvisionFailed", "status": "True", "reason": "InstallFailed"},
+				map[string]interface{}{"type": "ProvisionStopped", "status": "True", "reason": "InstallAttemptsLimit"},
+			},
+			provisionStatus: statusbundle.ClusterProvisionStopped,
+			reason:          "InstallAttemptsLimit",
+		},
+		{
+			name: "installed",
+			spec: map[string]interface{}{
+				"installed": true,
+				"platform":  map[string]interface{}{"aws": map[string]interface{}{"region": "us-east-1"}},
+			},
+			provisionStatus: statusbundle.ClusterInstalled,
+		},
+	} {
+		object := &unstructured.Unstructured{Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": test.name, "namespace": test.name, "uid": "uid"},
+			"spec":     test.spec,
+			"status":   map[string]interface{}{"conditions": test.conditions},
+		}}
+
+		status, ok := GetClusterDeploymentStatus(object).(*statusbundle.ClusterDeploymentStatus)
+		if !ok {
+			t.Fatalf("%s: unexpected status type", test.name)
+		}
+
+		if status.ProvisionStatus != test.provisionStatus || status.Reason != test.reason {
+			t.Errorf("%s: expected provision status %s with reason %q, got %s with reason %q", test.name,
+				test.provisionStatus, test.reason, status.ProvisionStatus, status.Reason)
+		}
+	}
+
+	object := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"platform": map[string]interface{}{"aws": map[string]interface{}{"region": "us-east-1"}},
+		},
+	}}
+
+	status, _ := GetClusterDeploymentStatus(object).(*statusbundle.ClusterDeploymentStatus)
+	if status.Platform != "aws" || status.Region != "us-east-1" {
+		t.Errorf("expected platform aws in region us-east-1, got %s in region %s", status.Platform, status.Region)
+	}
+}
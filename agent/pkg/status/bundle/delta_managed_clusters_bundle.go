@@ -0,0 +1,165 @@
+package bundle
+
+import (
+	"sync"
+
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+
+	statusbundle "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+)
+
+// NewDeltaManagedClustersBundle creates a new instance of DeltaManagedClustersBundle. the base bundle is the complete
+// state managed clusters bundle, the delta bundle carries only the clusters that changed since it was last synced.
+func NewDeltaManagedClustersBundle(leafHubName string, baseBundle *GenericStatusBundle, incarnation uint64,
+	manipulateObjFunc func(obj Object),
+) DeltaStateBundle {
+	if manipulateObjFunc == nil {
+		manipulateObjFunc = func(object Object) {
+			// do nothing
+		}
+	}
+
+	return &DeltaManagedClustersBundle{
+		BaseDeltaManagedClustersBundle: statusbundle.BaseDeltaManagedClustersBundle{
+			Objects:           make([]*clusterv1.ManagedCluster, 0),
+			DeletedClusters:   make([]string, 0),
+			LeafHubName:       leafHubName,
+			BaseBundleVersion: statusbundle.NewBundleVersion(incarnation, baseBundle.GetBundleVersion().Generation),
+			BundleVersion:     statusbundle.NewBundleVersion(incarnation, 0),
+		},
+		cyclicTransportationBundleID: 0,
+		baseBundle:                   baseBundle,
+		resourceVersionsCache:        make(map[string]string),
+		manipulateObjFunc:            manipulateObjFunc,
+		lock:                         sync.Mutex{},
+	}
+}
+
+// DeltaManagedClustersBundle abstracts management of delta state managed clusters bundle.
+type DeltaManagedClustersBundle struct {
+	statusbundle.BaseDeltaManagedClustersBundle
+	cyclicTransportationBundleID int
+	baseBundle                   *GenericStatusBundle
+	// resourceVersionsCache is a map of cluster name -> the last resource version of the cluster that was either in
+	// the base bundle or in a delta bundle, a cluster is added to the delta bundle only if its version changed.
+	resourceVersionsCache map[string]string
+	manipulateObjFunc     func(obj Object)
+	lock                  sync.Mutex
+}
+
+// GetTransportationID function to get bundle transportation ID to be attached to message-key during transportation.
+func (bundle *DeltaManagedClustersBundle) GetTransportationID() int {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	return bundle.cyclicTransportationBundleID
+}
+
+// UpdateObject function to update a single object inside a bundle.
+func (bundle *DeltaManagedClustersBundle) UpdateObject(object Object) {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	cluster, isManagedCluster := object.(*clusterv1.ManagedCluster)
+	if !isManagedCluster {
+		return // do not handle objects other than managed cluster
+	}
+
+	if resourceVersion, found := bundle.resourceVersionsCache[cluster.GetName()]; found &&
+		resourceVersion == cluster.GetResourceVersion() {
+		return // the cluster didn't change since it was last sent
+	}
+
+	bundle.manipulateObjFunc(cluster)
+	bundle.resourceVersionsCache[cluster.GetName()] = cluster.GetResourceVersion()
+	bundle.removeDeletedCluster(cluster.GetName()) // a cluster may be recreated with the same name
+
+	if index := bundle.getObjectIndexByName(cluster.GetName()); index >= 0 {
+		bundle.Objects[index] = cluster
+	} else {
+		bundle.Objects = append(bundle.Objects, cluster)
+	}
+
+	bundle.BundleVersion.Generation++
+}
+
+// DeleteObject function to delete a single object inside a bundle.
+func (bundle *DeltaManagedClustersBundle) DeleteObject(object Object) {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	if _, isManagedCluster := object.(*clusterv1.ManagedCluster); !isManagedCluster {
+		return // do not handle objects other than managed cluster
+	}
+
+	if _, found := bundle.resourceVersionsCache[object.GetName()]; !found {
+		return // trying to delete object which wasn't sent - return with no error
+	}
+
+	delete(bundle.resourceVersionsCache, object.GetName())
+
+	if index := bundle.getObjectIndexByName(object.GetName()); index >= 0 {
+		bundle.Objects = append(bundle.Objects[:index], bundle.Objects[index+1:]...) // remove from objects
+	}
+
+	bundle.DeletedClusters = append(bundle.DeletedClusters, object.GetName())
+	bundle.BundleVersion.Generation++
+}
+
+// GetBundleVersion function to get bundle version.
+func (bundle *DeltaManagedClustersBundle) GetBundleVersion() *statusbundle.BundleVersion {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	return bundle.BundleVersion
+}
+
+// SyncState syncs the state of the delta-bundle with the full-state.
+func (bundle *DeltaManagedClustersBundle) SyncState() {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	// update version
+	bundle.BaseBundleVersion.Generation = bundle.baseBundle.GetBundleVersion().Generation
+
+	// update the clusters versions from the managed clusters bundle's (full-state) objects
+	bundle.resourceVersionsCache = make(map[string]string)
+
+	bundle.baseBundle.lock.Lock()
+	for _, object := range bundle.baseBundle.Objects {
+		bundle.resourceVersionsCache[object.GetName()] = object.GetResourceVersion()
+	}
+	bundle.baseBundle.lock.Unlock()
+
+	// reset ID since state-sync means base has changed and a new line is starting
+	bundle.cyclicTransportationBundleID = 0
+}
+
+// Reset flushes the objects in the bundle (after delivery).
+func (bundle *DeltaManagedClustersBundle) Reset() {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	bundle.Objects = nil                  // safe since go1.0
+	bundle.DeletedClusters = nil          // safe since go1.0
+	bundle.cyclicTransportationBundleID++ // increment ID since a reset means a new bundle is starting
+}
+
+func (bundle *DeltaManagedClustersBundle) getObjectIndexByName(name string) int {
+	for i, object := range bundle.Objects {
+		if object.GetName() == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (bundle *DeltaManagedClustersBundle) removeDeletedCluster(name string) {
+	for i, deletedCluster := range bundle.DeletedClusters {
+		if deletedCluster == name {
+			bundle.DeletedClusters = append(bundle.DeletedClusters[:i], bundle.DeletedClusters[i+1:]...)
+			return
+		}
+	}
+}
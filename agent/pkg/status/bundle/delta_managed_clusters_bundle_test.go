@@ -0,0 +1,54 @@
+package bundle
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+)
+
+func newManagedCluster(name, resourceVersion string) *clusterv1.ManagedCluster {
+	return &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{
+		Name:            name,
+		UID:             types.UID("uid-" + name),
+		ResourceVersion: resourceVersion,
+	}}
+}
+
+func TestDeltaManagedClustersBundle(t *testing.T) {
+	baseBundle := NewGenericStatusBundle("hub1", 0, nil).(*GenericStatusBundle)
+	baseBundle.UpdateObject(newManagedCluster("cluster1", "1"))
+	baseBundle.UpdateObject(newManagedCluster("cluster2", "1"))
+
+	deltaBundle := NewDeltaManagedClustersBundle("hub1", baseBundle, 0, nil).(*DeltaManagedClustersBundle)
+	deltaBundle.SyncState()
+
+	// the clusters of the base bundle aren't sent again unless they change
+	deltaBundle.UpdateObject(newManagedCluster("cluster1", "1"))
+	deltaBundle.UpdateObject(newManagedCluster("cluster2", "2"))
+	deltaBundle.UpdateObject(newManagedCluster("cluster3", "1"))
+
+	if len(deltaBundle.Objects) != 2 || deltaBundle.Objects[0].Name != "cluster2" ||
+		deltaBundle.Objects[1].Name != "cluster3" {
+		t.Fatalf("expected the changed clusters cluster2 and cluster3, got %v", deltaBundle.Objects)
+	}
+
+	if deltaBundle.BaseBundleVersion.Generation != baseBundle.GetBundleVersion().Generation {
+		t.Errorf("expected the base bundle version %d, got %d", baseBundle.GetBundleVersion().Generation,
+			deltaBundle.BaseBundleVersion.Generation)
+	}
+
+	deltaBundle.Reset()
+	deltaBundle.DeleteObject(newManagedCluster("cluster1", "1"))
+	deltaBundle.DeleteObject(newManagedCluster("cluster4", "1")) // never sent
+
+	if len(deltaBundle.Objects) != 0 || len(deltaBundle.DeletedClusters) != 1 ||
+		deltaBundle.DeletedClusters[0] != "cluster1" {
+		t.Fatalf("expected only cluster1 to be deleted, got %v %v", deltaBundle.Objects, deltaBundle.DeletedClusters)
+	}
+
+	if deltaBundle.GetTransportationID() != 1 {
+		t.Errorf("expected transportation id 1 after reset, got %d", deltaBundle.GetTransportationID())
+	}
+}
@@ -0,0 +1,221 @@
+package events
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+
+	statusbundle "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+)
+
+// eventKey is the key the events are deduplicated by, the involved object and the reason.
+type eventKey struct {
+	kind      string
+	namespace string
+	name      string
+	reason    string
+}
+
+// eventEntry is a deduplicated event, with the counts of the kubernetes events it was deduplicated from.
+type eventEntry struct {
+	event       *statusbundle.Event
+	eventCounts map[types.UID]int32
+}
+
+// NewBundle creates a new instance of Bundle.
+func NewBundle(leafHubName string, incarnation uint64, maxEvents int, maxAge time.Duration,
+	rateLimiter flowcontrol.RateLimiter,
+) *Bundle {
+	return &Bundle{
+		leafHubName:   leafHubName,
+		bundleVersion: statusbundle.NewBundleVersion(incarnation, 0),
+		maxEvents:     maxEvents,
+		maxAge:        maxAge,
+		rateLimiter:   rateLimiter,
+		entries:       make(map[eventKey]*eventEntry),
+		lock:          sync.Mutex{},
+	}
+}
+
+// Bundle holds the recent events of the regional hub, deduplicated by their involved object and reason. an event is
+// held until it doesn't occur for the max age, at most max events are held. the new events are rate limited, the
+// occurrences of the held events aren't.
+type Bundle struct {
+	leafHubName   string
+	bundleVersion *statusbundle.BundleVersion
+	maxEvents     int
+	maxAge        time.Duration
+	rateLimiter   flowcontrol.RateLimiter
+	entries       map[eventKey]*eventEntry
+	droppedEvents int
+	lock          sync.Mutex
+}
+
+// UpdateEvent adds an occurrence of the event to the bundle.
+func (bundle *Bundle) UpdateEvent(event *corev1.Event, now time.Time) {
+	timestamp := getEventTime(event)
+	if timestamp.Before(now.Add(-bundle.maxAge)) {
+		return // the event is too old to be relayed
+	}
+
+	count := event.Count
+	if event.Series != nil {
+		count = event.Series.Count
+	}
+
+	if count < 1 {
+		count = 1
+	}
+
+	key := eventKey{
+		kind:      event.InvolvedObject.Kind,
+		namespace: event.InvolvedObject.Namespace,
+		name:      event.InvolvedObject.Name,
+		reason:    event.Reason,
+	}
+
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	entry, found := bundle.entries[key]
+	// an event that keeps occurring starts a new deduplicated event once in the max age
+	if !found || entry.event.FirstTimestamp.Before(now.Add(-bundle.maxAge)) {
+		if !bundle.rateLimiter.TryAccept() {
+			bundle.droppedEvents++
+			return
+		}
+
+		entry = &eventEntry{
+			event: &statusbundle.Event{
+				InvolvedObject: statusbundle.EventInvolvedObject{
+					APIVersion: event.InvolvedObject.APIVersion,
+					Kind:       event.InvolvedObject.Kind,
+					Namespace:  event.InvolvedObject.Namespace,
+					Name:       event.InvolvedObject.Name,
+					UID:        string(event.InvolvedObject.UID),
+				},
+				Reason:         event.Reason,
+				FirstTimestamp: timestamp,
+			},
+			eventCounts: make(map[types.UID]int32),
+		}
+		bundle.entries[key] = entry
+	} else if count <= entry.eventCounts[event.UID] {
+		return // the occurrence is held already
+	}
+
+	entry.event.Count += count - entry.eventCounts[event.UID]
+	entry.eventCounts[event.UID] = count
+
+	if !timestamp.Before(entry.event.LastTimestamp) {
+		entry.event.LastTimestamp = timestamp
+		entry.event.EventNamespace = event.Namespace
+		entry.event.EventName = event.Name
+		entry.event.Message = event.Message
+		entry.event.Type = event.Type
+		entry.event.Source = getEventSource(event)
+	}
+
+	bundle.evictOldestEvents()
+	bundle.bundleVersion.Generation++
+}
+
+// evictOldestEvents removes the events that occurred least recently if there are more than max events.
+func (bundle *Bundle) evictOldestEvents() {
+	for len(bundle.entries) > bundle.maxEvents {
+		var oldestKey *eventKey
+
+		for key, entry := range bundle.entries {
+			if oldestKey == nil ||
+				entry.event.LastTimestamp.Before(bundle.entries[*oldestKey].event.LastTimestamp) {
+				key := key
+				oldestKey = &key
+			}
+		}
+
+		delete(bundle.entries, *oldestKey)
+	}
+}
+
+// Prune removes the events that didn't occur for the max age.
+func (bundle *Bundle) Prune(now time.Time) {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	for key, entry := range bundle.entries {
+		if entry.event.LastTimestamp.Before(now.Add(-bundle.maxAge)) {
+			delete(bundle.entries, key)
+			bundle.bundleVersion.Generation++
+		}
+	}
+}
+
+// GetDroppedEvents returns the number of the events dropped by the rate limit since it was called last.
+func (bundle *Bundle) GetDroppedEvents() int {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	droppedEvents := bundle.droppedEvents
+	bundle.droppedEvents = 0
+
+	return droppedEvents
+}
+
+// GetBundleVersion function to get bundle version.
+func (bundle *Bundle) GetBundleVersion() *statusbundle.BundleVersion {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	return bundle.bundleVersion
+}
+
+// MarshalJSON marshals the events ordered by the time they occurred last.
+func (bundle *Bundle) MarshalJSON() ([]byte, error) {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	events := make([]*statusbundle.Event, 0, len(bundle.entries))
+	for _, entry := range bundle.entries {
+		events = append(events, entry.event)
+	}
+
+	sort.Slice(events, func(i, j int) bool { return events[i].LastTimestamp.Before(events[j].LastTimestamp) })
+
+	return json.Marshal(&struct {
+		Objects       []*statusbundle.Event       `json:"objects"`
+		LeafHubName   string                      `json:"leafHubName"`
+		BundleVersion *statusbundle.BundleVersion `json:"bundleVersion"`
+	}{
+		Objects:       events,
+		LeafHubName:   bundle.leafHubName,
+		BundleVersion: bundle.bundleVersion,
+	})
+}
+
+// getEventTime returns the time the event occurred last, the events of the events API set the event time instead of
+// the last timestamp.
+func getEventTime(event *corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+func getEventSource(event *corev1.Event) string {
+	if event.Source.Component != "" {
+		return event.Source.Component
+	}
+
+	return event.ReportingController
+}
@@ -0,0 +1,84 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+func newEvent(uid types.UID, policyName string, reason string, count int32, lastTimestamp time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "cluster1", Name: string(uid), UID: uid},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "policy.open-cluster-management.io/v1",
+			Kind:       "Policy",
+			Namespace:  "cluster1",
+			Name:       policyName,
+		},
+		Reason:        reason,
+		Message:       string(uid),
+		Type:          corev1.EventTypeWarning,
+		Count:         count,
+		LastTimestamp: metav1.NewTime(lastTimestamp),
+	}
+}
+
+func TestUpdateEvent(t *testing.T) {
+	now := time.Date(2022, time.October, 1, 12, 0, 0, 0, time.UTC)
+	bundle := NewBundle("hub1", 0, 2, time.Hour, flowcontrol.NewFakeAlwaysRateLimiter())
+
+	// the events of the same involved object and reason are deduplicated
+	bundle.UpdateEvent(newEvent("event1", "policy1", "PolicyStatusSync", 1, now.Add(-time.Minute)), now)
+	bundle.UpdateEvent(newEvent("event1", "policy1", "PolicyStatusSync", 3, now.Add(-time.Minute)), now)
+	bundle.UpdateEvent(newEvent("event2", "policy1", "PolicyStatusSync", 2, now), now)
+
+	entry := bundle.entries[eventKey{kind: "Policy", namespace: "cluster1", name: "policy1", reason: "PolicyStatusSync"}]
+	if len(bundle.entries) != 1 || entry.event.Count != 5 || entry.event.Message != "event2" ||
+		!entry.event.FirstTimestamp.Equal(now.Add(-time.Minute)) || !entry.event.LastTimestamp.Equal(now) {
+		t.Errorf("unexpected deduplicated event %+v", entry.event)
+	}
+
+	// an occurrence that is held already doesn't change the bundle
+	generation := bundle.GetBundleVersion().Generation
+	bundle.UpdateEvent(newEvent("event1", "policy1", "PolicyStatusSync", 3, now.Add(-time.Minute)), now)
+
+	if bundle.GetBundleVersion().Generation != generation {
+		t.Error("expected the bundle version not to change")
+	}
+
+	// the events that are too old aren't relayed, the least recent events are evicted
+	bundle.UpdateEvent(newEvent("event3", "policy2", "PolicyStatusSync", 1, now.Add(-2*time.Hour)), now)
+	bundle.UpdateEvent(newEvent("event4", "policy3", "PolicyStatusSync", 1, now.Add(-2*time.Minute)), now)
+	bundle.UpdateEvent(newEvent("event5", "policy4", "PolicyStatusSync", 1, now.Add(-time.Second)), now)
+
+	if len(bundle.entries) != 2 {
+		t.Errorf("expected 2 events, got %d", len(bundle.entries))
+	}
+
+	if _, found := bundle.entries[eventKey{kind: "Policy", namespace: "cluster1", name: "policy3",
+		reason: "PolicyStatusSync"}]; found {
+		t.Error("expected the least recent event to be evicted")
+	}
+
+	// the events that didn't occur for the max age are pruned
+	bundle.Prune(now.Add(time.Hour - time.Millisecond))
+
+	if len(bundle.entries) != 1 {
+		t.Errorf("expected 1 event after pruning, got %d", len(bundle.entries))
+	}
+}
+
+func TestUpdateEventRateLimit(t *testing.T) {
+	now := time.Now()
+	bundle := NewBundle("hub1", 0, 10, time.Hour, flowcontrol.NewFakeNeverRateLimiter())
+
+	bundle.UpdateEvent(newEvent("event1", "policy1", "PolicyStatusSync", 1, now), now)
+
+	if len(bundle.entries) != 0 || bundle.GetDroppedEvents() != 1 || bundle.GetDroppedEvents() != 0 {
+		t.Error("expected the event to be dropped by the rate limit")
+	}
+}
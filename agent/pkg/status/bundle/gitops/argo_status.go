@@ -0,0 +1,108 @@
+package gitops
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	statusbundle "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+)
+
+const applicationSetKind = "ApplicationSet"
+
+// GetApplicationStatus returns the sync and health status of an Argo CD application.
+func GetApplicationStatus(object *unstructured.Unstructured) interface{} {
+	status := &statusbundle.ArgoApplicationStatus{
+		UID:       string(object.GetUID()),
+		Name:      object.GetName(),
+		Namespace: object.GetNamespace(),
+	}
+
+	for _, ownerReference := range object.GetOwnerReferences() {
+		if ownerReference.Kind == applicationSetKind {
+			status.ApplicationSetName = ownerReference.Name
+		}
+	}
+
+	status.Project, _, _ = unstructured.NestedString(object.Object, "spec", "project")
+
+	source, found, _ := unstructured.NestedMap(object.Object, "spec", "source")
+	if !found { // the applications with multiple sources have no source
+		if sources, _, _ := unstructured.NestedSlice(object.Object, "spec", "sources"); len(sources) > 0 {
+			source, _ = sources[0].(map[string]interface{})
+		}
+	}
+
+	status.RepoURL, _, _ = unstructured.NestedString(source, "repoURL")
+	status.Path, _, _ = unstructured.NestedString(source, "path")
+	status.Chart, _, _ = unstructured.NestedString(source, "chart")
+	status.TargetRevision, _, _ = unstructured.NestedString(source, "targetRevision")
+
+	status.DestinationServer, _, _ = unstructured.NestedString(object.Object, "spec", "destination", "server")
+	status.DestinationName, _, _ = unstructured.NestedString(object.Object, "spec", "destination", "name")
+	status.DestinationNamespace, _, _ = unstructured.NestedString(object.Object, "spec", "destination", "namespace")
+
+	status.SyncStatus, _, _ = unstructured.NestedString(object.Object, "status", "sync", "status")
+	status.SyncRevision, _, _ = unstructured.NestedString(object.Object, "status", "sync", "revision")
+	status.HealthStatus, _, _ = unstructured.NestedString(object.Object, "status", "health", "status")
+	status.HealthMessage, _, _ = unstructured.NestedString(object.Object, "status", "health", "message")
+	status.OperationPhase, _, _ = unstructured.NestedString(object.Object, "status", "operationState", "phase")
+	status.OperationMessage, _, _ = unstructured.NestedString(object.Object, "status", "operationState", "message")
+
+	// the status is empty until the application is reconciled for the first time
+	if status.SyncStatus == "" {
+		status.SyncStatus = "Unknown"
+	}
+
+	if status.HealthStatus == "" {
+		status.HealthStatus = "Unknown"
+	}
+
+	if reconciledAt, found, _ := unstructured.NestedString(object.Object, "status", "reconciledAt"); found {
+		if timestamp, err := time.Parse(time.RFC3339, reconciledAt); err == nil {
+			status.ReconciledAt = &timestamp
+		}
+	}
+
+	return status
+}
+
+// GetApplicationSetStatus returns the generation status of an Argo CD application set.
+func GetApplicationSetStatus(object *unstructured.Unstructured) interface{} {
+	status := &statusbundle.ArgoApplicationSetStatus{
+		UID:       string(object.GetUID()),
+		Name:      object.GetName(),
+		Namespace: object.GetNamespace(),
+	}
+
+	resources, _, _ := unstructured.NestedSlice(object.Object, "status", "resources")
+	status.Applications = len(resources)
+
+	conditions, _, _ := unstructured.NestedSlice(object.Object, "status", "conditions")
+	for _, item := range conditions {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		conditionType, _, _ := unstructured.NestedString(fields, "type")
+		conditionStatus, _, _ := unstructured.NestedString(fields, "status")
+		reason, _, _ := unstructured.NestedString(fields, "reason")
+		message, _, _ := unstructured.NestedString(fields, "message")
+
+		switch conditionType {
+		case "ErrorOccurred":
+			status.ErrorOccurred = conditionStatus == "True"
+			if status.ErrorOccurred { // the error explains the status better than the other conditions
+				status.Reason, status.Message = reason, message
+			}
+		case "ResourcesUpToDate":
+			status.ResourcesUpToDate = conditionStatus == "True"
+			if !status.ErrorOccurred {
+				status.Reason, status.Message = reason, message
+			}
+		}
+	}
+
+	return status
+}
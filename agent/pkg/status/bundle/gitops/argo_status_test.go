@@ -0,0 +1,82 @@
+package gitops
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	statusbundle "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+)
+
+func TestGetApplicationStatus(t *testing.T) {
+	object := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name": "guestbook", "namespace": "openshift-gitops", "uid": "uid",
+			"ownerReferences": []interface{}{
+				map[string]interface{}{
+					"apiVersion": "argoproj.io/v1alpha1", "kind": "ApplicationSet", "name": "guestbook-set",
+					"uid": "set-uid",
+				},
+			},
+		},
+		"spec": map[string]interface{}{
+			"project": "default",
+			"sources": []interface{}{
+				map[string]interface{}{"repoURL": "https://github.com/argoproj/argocd-example-apps", "path": "guestbook"},
+			},
+			"destination": map[string]interface{}{"server": "https://kubernetes.default.svc", "namespace": "guestbook"},
+		},
+		"status": map[string]interface{}{
+			"sync":           map[string]interface{}{"status": "OutOfSync", "revision": "abc"},
+			"health":         map[string]interface{}{"status": "Degraded", "message": "back-off restarting"},
+			"operationState": map[string]interface{}{"phase": "Failed"},
+			"reconciledAt":   "2022-10-01T10:00:00Z",
+		},
+	}}
+
+	status, ok := GetApplicationStatus(object).(*statusbundle.ArgoApplicationStatus)
+	if !ok {
+		t.Fatal("unexpected status type")
+	}
+
+	if status.ApplicationSetName != "guestbook-set" || status.Path != "guestbook" || status.SyncStatus != "OutOfSync" ||
+		status.HealthStatus != "Degraded" || status.OperationPhase != "Failed" || status.ReconciledAt == nil {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	status, _ = GetApplicationStatus(&unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "new", "namespace": "openshift-gitops"},
+	}}).(*statusbundle.ArgoApplicationStatus)
+
+	if status.SyncStatus != "Unknown" || status.HealthStatus != "Unknown" {
+		t.Errorf("expected the status of a new application to be unknown, got %+v", status)
+	}
+}
+
+func TestGetApplicationSetStatus(t *testing.T) {
+	object := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "guestbook-set", "namespace": "openshift-gitops", "uid": "uid"},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "ResourcesUpToDate", "status": "False", "reason": "ApplicationSetUpToDate"},
+				map[string]interface{}{
+					"type": "ErrorOccurred", "status": "True", "reason": "ApplicationGenerationFromParamsError",
+					"message": "failed to generate",
+				},
+			},
+			"resources": []interface{}{
+				map[string]interface{}{"name": "guestbook-cluster1"}, map[string]interface{}{"name": "guestbook-cluster2"},
+			},
+		},
+	}}
+
+	status, ok := GetApplicationSetStatus(object).(*statusbundle.ArgoApplicationSetStatus)
+	if !ok {
+		t.Fatal("unexpected status type")
+	}
+
+	if !status.ErrorOccurred || status.ResourcesUpToDate || status.Applications != 2 ||
+		status.Reason != "ApplicationGenerationFromParamsError" {
+		t.Errorf("unexpected status %+v", status)
+	}
+}
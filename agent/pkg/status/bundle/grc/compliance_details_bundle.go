@@ -0,0 +1,196 @@
+package grc
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/types"
+	policiesv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
+
+	bundlepkg "github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle"
+	statusbundle "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+)
+
+const (
+	clusterNameLabel = "policy.open-cluster-management.io/cluster-name"
+	// maxViolationsPerCluster is the highest number of template violations sent per policy and cluster.
+	maxViolationsPerCluster = 10
+	// maxViolationMessageLength is the highest length in bytes of a violation message, longer messages are truncated.
+	maxViolationMessageLength = 512
+)
+
+// NewComplianceDetailsBundle creates a new instance of ComplianceDetailsBundle.
+func NewComplianceDetailsBundle(leafHubName string, incarnation uint64,
+	extractObjIDFunc bundlepkg.ExtractObjIDFunc,
+) *ComplianceDetailsBundle {
+	return &ComplianceDetailsBundle{
+		BaseComplianceDetailsBundle: statusbundle.BaseComplianceDetailsBundle{
+			Objects:       make([]*statusbundle.PolicyComplianceDetails, 0),
+			LeafHubName:   leafHubName,
+			BundleVersion: statusbundle.NewBundleVersion(incarnation, 0),
+		},
+		extractObjIDFunc:   extractObjIDFunc,
+		replicatedPolicies: make(map[types.NamespacedName]*statusbundle.PolicyComplianceDetails),
+		lock:               sync.Mutex{},
+	}
+}
+
+// ComplianceDetailsBundle abstracts management of the compliance details bundle. the objects of the bundle are built
+// from the replicated policies of the non compliant clusters, one object per replicated policy.
+type ComplianceDetailsBundle struct {
+	statusbundle.BaseComplianceDetailsBundle
+	extractObjIDFunc bundlepkg.ExtractObjIDFunc
+	// replicatedPolicies is a map of replicated policy -> the object of the bundle built from it.
+	replicatedPolicies map[types.NamespacedName]*statusbundle.PolicyComplianceDetails
+	lock               sync.Mutex
+}
+
+// UpdateObject function to update a single object inside a bundle.
+func (bundle *ComplianceDetailsBundle) UpdateObject(object bundlepkg.Object) {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	policy, isPolicy := object.(*policiesv1.Policy)
+	if !isPolicy {
+		return // do not handle objects other than policy
+	}
+
+	originPolicyID, ok := bundle.extractObjIDFunc(object)
+	if !ok {
+		return // cant update the object without finding its id.
+	}
+
+	key := types.NamespacedName{Namespace: policy.GetNamespace(), Name: policy.GetName()}
+	details := getComplianceDetails(originPolicyID, policy)
+	existingDetails, found := bundle.replicatedPolicies[key]
+
+	if details == nil { // the cluster isn't non compliant anymore
+		if found {
+			bundle.removeObject(key)
+		}
+
+		return
+	}
+
+	if !found {
+		bundle.replicatedPolicies[key] = details
+		bundle.Objects = append(bundle.Objects, details)
+		bundle.BundleVersion.Generation++
+
+		return
+	}
+
+	if reflect.DeepEqual(existingDetails, details) {
+		return
+	}
+
+	*existingDetails = *details
+	bundle.BundleVersion.Generation++
+}
+
+// DeleteObject function to delete a single object inside a bundle.
+func (bundle *ComplianceDetailsBundle) DeleteObject(object bundlepkg.Object) {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	key := types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetName()}
+	if _, found := bundle.replicatedPolicies[key]; found {
+		bundle.removeObject(key)
+	}
+}
+
+// GetBundleVersion function to get bundle version.
+func (bundle *ComplianceDetailsBundle) GetBundleVersion() *statusbundle.BundleVersion {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	return bundle.BundleVersion
+}
+
+// Refresh increments the generation of the bundle, so it's sent again even though its objects aren't changed.
+func (bundle *ComplianceDetailsBundle) Refresh() {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	bundle.BundleVersion.Generation++
+}
+
+// MarshalJSON marshals the bundle with the lock held, the objects are updated concurrently by the controller.
+func (bundle *ComplianceDetailsBundle) MarshalJSON() ([]byte, error) {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	return json.Marshal(&bundle.BaseComplianceDetailsBundle)
+}
+
+func (bundle *ComplianceDetailsBundle) removeObject(key types.NamespacedName) {
+	details := bundle.replicatedPolicies[key]
+	delete(bundle.replicatedPolicies, key)
+
+	for i, object := range bundle.Objects {
+		if object == details {
+			bundle.Objects = append(bundle.Objects[:i], bundle.Objects[i+1:]...)
+			break
+		}
+	}
+
+	bundle.BundleVersion.Generation++
+}
+
+// getComplianceDetails returns the violations of the non compliant templates of the replicated policy, or nil if the
+// cluster isn't non compliant.
+func getComplianceDetails(originPolicyID string, policy *policiesv1.Policy,
+) *statusbundle.PolicyComplianceDetails {
+	if policy.Status.ComplianceState != policiesv1.NonCompliant {
+		return nil
+	}
+
+	clusterName, found := policy.GetLabels()[clusterNameLabel]
+	if !found {
+		clusterName = policy.GetNamespace() // replicated policies are in the namespaces of their clusters
+	}
+
+	details := &statusbundle.PolicyComplianceDetails{
+		PolicyID:    originPolicyID,
+		ClusterName: clusterName,
+		Violations:  make([]*statusbundle.TemplateViolation, 0),
+	}
+
+	for _, templateDetails := range policy.Status.Details {
+		if templateDetails == nil || templateDetails.ComplianceState != policiesv1.NonCompliant {
+			continue
+		}
+
+		if len(details.Violations) == maxViolationsPerCluster {
+			details.OmittedViolations++
+			continue
+		}
+
+		violation := &statusbundle.TemplateViolation{TemplateName: templateDetails.TemplateMeta.GetName()}
+		if len(templateDetails.History) > 0 { // the last event is the first in the history
+			violation.Message, violation.Truncated = truncateMessage(templateDetails.History[0].Message)
+			violation.Timestamp = templateDetails.History[0].LastTimestamp.Time
+		}
+
+		details.Violations = append(details.Violations, violation)
+	}
+
+	return details
+}
+
+// truncateMessage returns the message cut to the max violation message length on a rune boundary, and whether it was
+// truncated.
+func truncateMessage(message string) (string, bool) {
+	if len(message) <= maxViolationMessageLength {
+		return message, false
+	}
+
+	end := maxViolationMessageLength
+	for end > 0 && !utf8.RuneStart(message[end]) {
+		end--
+	}
+
+	return message[:end], true
+}
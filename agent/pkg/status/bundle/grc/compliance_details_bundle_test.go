@@ -0,0 +1,90 @@
+package grc
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	policiesv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
+
+	bundlepkg "github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle"
+)
+
+func newReplicatedPolicy(complianceState policiesv1.ComplianceState, messages ...string) *policiesv1.Policy {
+	policy := &policiesv1.Policy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "cluster1",
+			Name:        "default.policy1",
+			Labels:      map[string]string{clusterNameLabel: "cluster1"},
+			Annotations: map[string]string{"policy-id": "1234"},
+		},
+		Status: policiesv1.PolicyStatus{ComplianceState: complianceState},
+	}
+
+	for i, message := range messages {
+		policy.Status.Details = append(policy.Status.Details, &policiesv1.DetailsPerTemplate{
+			TemplateMeta:    metav1.ObjectMeta{Name: fmt.Sprintf("template%d", i)},
+			ComplianceState: policiesv1.NonCompliant,
+			History:         []policiesv1.ComplianceHistory{{Message: message}},
+		})
+	}
+
+	return policy
+}
+
+func TestComplianceDetailsBundle(t *testing.T) {
+	bundle := NewComplianceDetailsBundle("hub1", 0, func(obj bundlepkg.Object) (string, bool) {
+		id, found := obj.GetAnnotations()["policy-id"]
+		return id, found
+	})
+
+	bundle.UpdateObject(newReplicatedPolicy(policiesv1.Compliant))
+
+	if len(bundle.Objects) != 0 || bundle.BundleVersion.Generation != 0 {
+		t.Fatalf("expected no details of a compliant cluster, got %d", len(bundle.Objects))
+	}
+
+	messages := make([]string, 0, maxViolationsPerCluster+2)
+	for i := 0; i < maxViolationsPerCluster+2; i++ {
+		messages = append(messages, "NonCompliant; violation")
+	}
+
+	messages[0] = strings.Repeat("é", maxViolationMessageLength) // 2 bytes per rune
+
+	bundle.UpdateObject(newReplicatedPolicy(policiesv1.NonCompliant, messages...))
+
+	if len(bundle.Objects) != 1 {
+		t.Fatalf("expected the details of the non compliant cluster, got %d", len(bundle.Objects))
+	}
+
+	details := bundle.Objects[0]
+	if details.PolicyID != "1234" || details.ClusterName != "cluster1" {
+		t.Errorf("unexpected policy %s and cluster %s", details.PolicyID, details.ClusterName)
+	}
+
+	if len(details.Violations) != maxViolationsPerCluster || details.OmittedViolations != 2 {
+		t.Errorf("expected %d violations and 2 omitted, got %d and %d", maxViolationsPerCluster,
+			len(details.Violations), details.OmittedViolations)
+	}
+
+	if message := details.Violations[0].Message; !details.Violations[0].Truncated ||
+		len(message) != maxViolationMessageLength || strings.Trim(message, "é") != "" {
+		t.Errorf("expected the message to be truncated on a rune boundary, got %d bytes", len(message))
+	}
+
+	generation := bundle.BundleVersion.Generation
+	bundle.UpdateObject(newReplicatedPolicy(policiesv1.NonCompliant, messages...))
+
+	if bundle.BundleVersion.Generation != generation {
+		t.Errorf("expected the generation not to change if the details aren't changed")
+	}
+
+	bundle.DeleteObject(&policiesv1.Policy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "cluster1", Name: "default.policy1"},
+	})
+
+	if len(bundle.Objects) != 0 || bundle.BundleVersion.Generation != generation+1 {
+		t.Errorf("expected the details to be removed with the replicated policy")
+	}
+}
@@ -0,0 +1,198 @@
+package specapply
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle"
+	statusbundle "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+// NewBundle creates a new instance of Bundle.
+func NewBundle(leafHubName string, incarnation uint64) *Bundle {
+	return &Bundle{
+		Objects:           make([]*statusbundle.SpecApplyResult, 0),
+		FullStateRequests: make([]string, 0),
+		LeafHubName:       leafHubName,
+		BundleVersion:     statusbundle.NewBundleVersion(incarnation, 0),
+		lock:              sync.Mutex{},
+	}
+}
+
+// Bundle holds the results of applying the objects received from the global hub, one result per global hub object.
+// the results are reported by the spec syncers, so the objects of the bundle aren't updated by a status controller.
+// the bundle also holds the ids of the spec bundles whose full state is requested from the global hub.
+type Bundle struct {
+	Objects           []*statusbundle.SpecApplyResult `json:"objects"`
+	FullStateRequests []string                        `json:"fullStateRequests"`
+	LeafHubName       string                          `json:"leafHubName"`
+	BundleVersion     *statusbundle.BundleVersion     `json:"bundleVersion"`
+	lock              sync.Mutex
+}
+
+// ReportApplied records the result of applying the object, the object is expected to be updated by the apply request
+// if it succeeded. objects without the origin owner reference annotation are ignored.
+func (bundle *Bundle) ReportApplied(obj *unstructured.Unstructured, applyErr error) {
+	originUID, found := obj.GetAnnotations()[constants.OriginOwnerReferenceAnnotation]
+	if !found {
+		return
+	}
+
+	result := &statusbundle.SpecApplyResult{
+		OriginOwnerReferenceUID: originUID,
+		APIVersion:              obj.GetAPIVersion(),
+		Kind:                    obj.GetKind(),
+		Name:                    obj.GetName(),
+		Namespace:               obj.GetNamespace(),
+		Applied:                 applyErr == nil,
+	}
+	if applyErr != nil {
+		result.Error = applyErr.Error()
+	} else {
+		result.UID = string(obj.GetUID())
+		result.Generation = obj.GetGeneration()
+	}
+
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	index := bundle.getResultIndex(originUID)
+	if index < 0 {
+		result.Timestamp = time.Now()
+		bundle.Objects = append(bundle.Objects, result)
+		bundle.BundleVersion.Generation++
+
+		return
+	}
+
+	// the result is resent only if it's changed, the spec bundles are received periodically
+	existing := bundle.Objects[index]
+	if existing.Applied == result.Applied && existing.Error == result.Error && existing.UID == result.UID &&
+		existing.Generation == result.Generation {
+		return
+	}
+
+	result.Timestamp = time.Now()
+	result.Drift = existing.Drift
+	bundle.Objects[index] = result
+	bundle.BundleVersion.Generation++
+}
+
+// ReportDrift records the drift of the object which was modified or deleted locally and restored to the state
+// received from the global hub.
+func (bundle *Bundle) ReportDrift(obj *unstructured.Unstructured, reason string) {
+	originUID, found := obj.GetAnnotations()[constants.OriginOwnerReferenceAnnotation]
+	if !found {
+		return
+	}
+
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	index := bundle.getResultIndex(originUID)
+	if index < 0 { // the object isn't applied by the agent yet
+		return
+	}
+
+	result := bundle.Objects[index]
+	drift := &statusbundle.SpecDrift{Reason: reason, Count: 1, Timestamp: time.Now()}
+
+	if result.Drift != nil {
+		drift.Count = result.Drift.Count + 1
+	}
+
+	result.Drift = drift
+	bundle.BundleVersion.Generation++
+}
+
+// ReportDeleted removes the result of the object which is deleted from the global hub.
+func (bundle *Bundle) ReportDeleted(obj *unstructured.Unstructured) {
+	originUID, found := obj.GetAnnotations()[constants.OriginOwnerReferenceAnnotation]
+	if !found {
+		return
+	}
+
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	index := bundle.getResultIndex(originUID)
+	if index < 0 {
+		return
+	}
+
+	bundle.Objects = append(bundle.Objects[:index], bundle.Objects[index+1:]...)
+	bundle.BundleVersion.Generation++
+}
+
+// RequestFullState requests the global hub to resend the full state of the spec bundle, e.g. when a gap is detected
+// in the delta bundles.
+func (bundle *Bundle) RequestFullState(bundleID string) {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	for _, requestedBundleID := range bundle.FullStateRequests {
+		if requestedBundleID == bundleID {
+			return
+		}
+	}
+
+	bundle.FullStateRequests = append(bundle.FullStateRequests, bundleID)
+	bundle.BundleVersion.Generation++
+}
+
+// FullStateReceived clears the full state request of the spec bundle.
+func (bundle *Bundle) FullStateReceived(bundleID string) {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	for i, requestedBundleID := range bundle.FullStateRequests {
+		if requestedBundleID == bundleID {
+			bundle.FullStateRequests = append(bundle.FullStateRequests[:i], bundle.FullStateRequests[i+1:]...)
+			bundle.BundleVersion.Generation++
+
+			return
+		}
+	}
+}
+
+// MarshalJSON marshals the bundle with the lock held, the results are reported concurrently by the spec workers.
+func (bundle *Bundle) MarshalJSON() ([]byte, error) {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	type bundleAlias Bundle // avoid the recursion of MarshalJSON
+
+	return json.Marshal((*bundleAlias)(bundle))
+}
+
+// UpdateObject function to update a single object inside a bundle.
+func (bundle *Bundle) UpdateObject(bundle.Object) {
+	// do nothing, the results are reported by ReportApplied
+}
+
+// DeleteObject function to delete a single object inside a bundle.
+func (bundle *Bundle) DeleteObject(bundle.Object) {
+	// do nothing, the results are removed by ReportDeleted
+}
+
+// GetBundleVersion function to get bundle version.
+func (bundle *Bundle) GetBundleVersion() *statusbundle.BundleVersion {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	return bundle.BundleVersion
+}
+
+func (bundle *Bundle) getResultIndex(originUID string) int {
+	for i, result := range bundle.Objects {
+		if result.OriginOwnerReferenceUID == originUID {
+			return i
+		}
+	}
+
+	return -1
+}
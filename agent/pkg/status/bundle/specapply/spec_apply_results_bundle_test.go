@@ -0,0 +1,76 @@
+package specapply
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	statusbundle "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+func TestReportApplied(t *testing.T) {
+	bundle := NewBundle("hub1", 0)
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("policy.open-cluster-management.io/v1")
+	obj.SetKind("Policy")
+	obj.SetName("policy1")
+
+	bundle.ReportApplied(obj, nil)
+	if len(bundle.Objects) != 0 {
+		t.Fatal("expected the object without the origin annotation to be ignored")
+	}
+
+	obj.SetAnnotations(map[string]string{constants.OriginOwnerReferenceAnnotation: "uid1"})
+
+	bundle.ReportApplied(obj, errors.New("forbidden"))
+	if len(bundle.Objects) != 1 || bundle.Objects[0].Applied || bundle.Objects[0].Error != "forbidden" {
+		t.Fatalf("expected the failed result, got %+v", bundle.Objects)
+	}
+
+	generation := bundle.GetBundleVersion().Generation
+
+	bundle.ReportApplied(obj, errors.New("forbidden"))
+	if bundle.GetBundleVersion().Generation != generation {
+		t.Error("expected the unchanged result not to be resent")
+	}
+
+	bundle.ReportDrift(obj, statusbundle.SpecDriftModified)
+	bundle.ReportDrift(obj, statusbundle.SpecDriftDeleted)
+
+	obj.SetUID("local-uid")
+	bundle.ReportApplied(obj, nil)
+
+	result := bundle.Objects[0]
+	if !result.Applied || result.UID != "local-uid" || result.Error != "" {
+		t.Errorf("expected the applied result, got %+v", result)
+	}
+
+	if result.Drift == nil || result.Drift.Count != 2 || result.Drift.Reason != statusbundle.SpecDriftDeleted {
+		t.Errorf("expected the drifts to be kept, got %+v", result.Drift)
+	}
+
+	bundle.ReportDeleted(obj)
+	if len(bundle.Objects) != 0 {
+		t.Errorf("expected the result of the deleted object to be removed, got %+v", bundle.Objects)
+	}
+}
+
+func TestFullStateRequests(t *testing.T) {
+	bundle := NewBundle("hub1", 0)
+
+	bundle.RequestFullState("Policies")
+	bundle.RequestFullState("Policies")
+	bundle.RequestFullState("Placements")
+
+	if len(bundle.FullStateRequests) != 2 {
+		t.Fatalf("expected the requests to be deduplicated, got %v", bundle.FullStateRequests)
+	}
+
+	bundle.FullStateReceived("Policies")
+	if len(bundle.FullStateRequests) != 1 || bundle.FullStateRequests[0] != "Placements" {
+		t.Errorf("expected the received request to be cleared, got %v", bundle.FullStateRequests)
+	}
+}
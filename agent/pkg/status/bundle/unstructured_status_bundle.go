@@ -0,0 +1,112 @@
+package bundle
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	statusbundle "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+)
+
+// GetStatusFunc is a function that returns the status of an unstructured object that is sent in the bundle.
+type GetStatusFunc func(object *unstructured.Unstructured) interface{}
+
+// NewUnstructuredStatusBundle creates a new instance of UnstructuredStatusBundle.
+func NewUnstructuredStatusBundle(leafHubName string, incarnation uint64,
+	getStatusFunc GetStatusFunc,
+) *UnstructuredStatusBundle {
+	return &UnstructuredStatusBundle{
+		leafHubName:   leafHubName,
+		bundleVersion: statusbundle.NewBundleVersion(incarnation, 0),
+		getStatusFunc: getStatusFunc,
+		statuses:      make(map[types.NamespacedName]interface{}),
+		lock:          sync.Mutex{},
+	}
+}
+
+// UnstructuredStatusBundle holds the statuses of the objects of a kind whose types the agent doesn't import, e.g. the
+// hive cluster deployments. the objects are tracked until they're removed, so the objects being deleted keep being
+// sent, e.g. the clusters being destroyed.
+type UnstructuredStatusBundle struct {
+	leafHubName   string
+	bundleVersion *statusbundle.BundleVersion
+	getStatusFunc GetStatusFunc
+	statuses      map[types.NamespacedName]interface{}
+	lock          sync.Mutex
+}
+
+// UpdateObject function to update a single object inside a bundle.
+func (bundle *UnstructuredStatusBundle) UpdateObject(object Object) {
+	unstructuredObj, ok := object.(*unstructured.Unstructured)
+	if !ok {
+		return // do not handle objects other than unstructured objects
+	}
+
+	status := bundle.getStatusFunc(unstructuredObj)
+	key := types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetName()}
+
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	if existingStatus, found := bundle.statuses[key]; found && reflect.DeepEqual(existingStatus, status) {
+		return
+	}
+
+	bundle.statuses[key] = status
+	bundle.bundleVersion.Generation++
+}
+
+// DeleteObject function to delete a single object inside a bundle, the object is removed by its namespace and name.
+func (bundle *UnstructuredStatusBundle) DeleteObject(object Object) {
+	key := types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetName()}
+
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	if _, found := bundle.statuses[key]; !found {
+		return
+	}
+
+	delete(bundle.statuses, key)
+	bundle.bundleVersion.Generation++
+}
+
+// GetBundleVersion function to get bundle version.
+func (bundle *UnstructuredStatusBundle) GetBundleVersion() *statusbundle.BundleVersion {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	return bundle.bundleVersion
+}
+
+// MarshalJSON marshals the statuses of the objects ordered by their namespace and name.
+func (bundle *UnstructuredStatusBundle) MarshalJSON() ([]byte, error) {
+	bundle.lock.Lock()
+	defer bundle.lock.Unlock()
+
+	keys := make([]types.NamespacedName, 0, len(bundle.statuses))
+	for key := range bundle.statuses {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	objects := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		objects = append(objects, bundle.statuses[key])
+	}
+
+	return json.Marshal(&struct {
+		Objects       []interface{}               `json:"objects"`
+		LeafHubName   string                      `json:"leafHubName"`
+		BundleVersion *statusbundle.BundleVersion `json:"bundleVersion"`
+	}{
+		Objects:       objects,
+		LeafHubName:   bundle.leafHubName,
+		BundleVersion: bundle.bundleVersion,
+	})
+}
@@ -31,7 +31,8 @@ func AddSubscriptionReportsController(mgr ctrl.Manager, transport producer.Produ
 	} // bundle predicate - always send subscription report.
 
 	if err := generic.NewGenericStatusSyncController(mgr, subscriptionReportsSyncLog, transport, bundleCollection,
-		createObjFunction, nil, syncIntervalsData.GetPolicies); err != nil {
+		createObjFunction, nil, nil, syncIntervalsData,
+		syncintervals.SubscriptionReports); err != nil {
 		return fmt.Errorf("failed to add subscription reports controller to the manager - %w", err)
 	}
 
@@ -31,7 +31,8 @@ func AddSubscriptionStatusesController(mgr ctrl.Manager, transport producer.Prod
 	} // bundle predicate - always send subscription status.
 
 	if err := generic.NewGenericStatusSyncController(mgr, subscriptionStatusSyncLog, transport, bundleCollection,
-		createObjFunction, nil, syncIntervalsData.GetPolicies); err != nil {
+		createObjFunction, nil, nil, syncIntervalsData,
+		syncintervals.SubscriptionStatuses); err != nil {
 		return fmt.Errorf("failed to add subscription statuses controller to the manager - %w", err)
 	}
 
@@ -0,0 +1,41 @@
+package clusterlifecycle
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle/clusterlifecycle"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/generic"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/syncintervals"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/transport/producer"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+const (
+	clusterDeploymentsSyncLog = "cluster-deployments-sync"
+	clusterPoolClaimsSyncLog  = "cluster-pool-claims-sync"
+)
+
+var (
+	clusterDeploymentGVK = schema.GroupVersionKind{Group: "hive.openshift.io", Version: "v1", Kind: "ClusterDeployment"}
+	clusterClaimGVK      = schema.GroupVersionKind{Group: "hive.openshift.io", Version: "v1", Kind: "ClusterClaim"}
+)
+
+// AddClusterDeploymentsController adds the hive cluster deployments status controller to the manager.
+func AddClusterDeploymentsController(mgr ctrl.Manager, producer producer.Producer, leafHubName string,
+	incarnation uint64, _ *corev1.ConfigMap, syncIntervalsData *syncintervals.SyncIntervals,
+) error {
+	return generic.AddUnstructuredStatusSyncController(mgr, producer, leafHubName, incarnation, syncIntervalsData,
+		clusterDeploymentsSyncLog, clusterDeploymentGVK, constants.ClusterDeploymentsMsgKey,
+		syncintervals.ClusterDeployments, clusterlifecycle.GetClusterDeploymentStatus)
+}
+
+// AddClusterPoolClaimsController adds the hive cluster claims status controller to the manager.
+func AddClusterPoolClaimsController(mgr ctrl.Manager, producer producer.Producer, leafHubName string,
+	incarnation uint64, _ *corev1.ConfigMap, syncIntervalsData *syncintervals.SyncIntervals,
+) error {
+	return generic.AddUnstructuredStatusSyncController(mgr, producer, leafHubName, incarnation, syncIntervalsData,
+		clusterPoolClaimsSyncLog, clusterClaimGVK, constants.ClusterPoolClaimsMsgKey,
+		syncintervals.ClusterPoolClaims, clusterlifecycle.GetClusterPoolClaimStatus)
+}
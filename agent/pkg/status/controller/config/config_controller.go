@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -13,13 +14,19 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/helper"
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/projection"
 	"github.com/stolostron/multicluster-global-hub/pkg/constants"
 )
 
 const (
 	RequeuePeriod = 5 * time.Second
+	// statusProjectionsKey is the key of the status bundles projections in the config.
+	statusProjectionsKey = "statusProjections"
 )
 
+// statusProjections holds the projections of the status bundles parsed from the config.
+var statusProjections atomic.Value
+
 type hubOfHubsConfigController struct {
 	client       client.Client
 	log          logr.Logger
@@ -63,7 +70,26 @@ func (c *hubOfHubsConfigController) Reconcile(ctx context.Context, request ctrl.
 			fmt.Errorf("reconciliation failed: %w", err)
 	}
 
+	projections, err := projection.Parse(c.configObject.Data[statusProjectionsKey])
+	if err != nil { // the bundles are sent as is until the config is fixed
+		reqLogger.Error(err, "invalid status projections, sending the status bundles without projections")
+		projections = projection.Projections{}
+	}
+
+	statusProjections.Store(projections)
+
 	reqLogger.Info("Reconciliation complete.")
 
 	return ctrl.Result{}, nil
 }
+
+// GetStatusProjections returns the projections of the status bundles, the bundles are sent as is until the config
+// is read.
+func GetStatusProjections() projection.Projections {
+	projections, ok := statusProjections.Load().(projection.Projections)
+	if !ok {
+		return projection.Projections{}
+	}
+
+	return projections
+}
@@ -103,11 +103,13 @@ func (c *LeafHubControlInfoController) syncBundle() {
 		transportMessageKey = fmt.Sprintf("%s@%d", c.transportBundleKey, deltaStateBundle.GetTransportationID())
 	}
 
-	c.transport.SendAsync(&producer.Message{
+	if err := c.transport.SendAsync(&producer.Message{
 		Key:     transportMessageKey,
 		ID:      c.transportBundleKey,
 		MsgType: constants.StatusBundle,
 		Version: c.bundle.GetBundleVersion().String(),
 		Payload: payloadBytes,
-	})
+	}); err != nil {
+		c.log.Error(err, "failed to send bundle", "key", c.transportBundleKey)
+	}
 }
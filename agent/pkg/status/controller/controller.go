@@ -10,21 +10,26 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/helper"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle/specapply"
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/apps"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/clusterlifecycle"
 	configCtrl "github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/config"
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/controlinfo"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/events"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/gitops"
 	localpolicies "github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/local_policies"
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/localplacement"
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/managedclusters"
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/placement"
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/policies"
+	specapplyctrl "github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/specapply"
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/syncintervals"
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/transport/producer"
 )
 
-// AddControllers adds all the controllers to the Manager.
+// AddControllers adds all the controllers to the Manager. the spec apply results are reported by the spec syncers.
 func AddControllers(mgr ctrl.Manager, pro producer.Producer,
-	configManager helper.ConfigManager, incarnation uint64,
+	configManager helper.ConfigManager, incarnation uint64, specApplyResults *specapply.Bundle,
 ) error {
 	config := &corev1.ConfigMap{}
 	if err := configCtrl.AddConfigController(mgr, config); err != nil {
@@ -41,17 +46,31 @@ func AddControllers(mgr ctrl.Manager, pro producer.Producer,
 		return fmt.Errorf("failed to add PoliciesStatusController controller: %w", err)
 	}
 
+	if err := managedclusters.AddClustersStatusController(mgr, pro, configManager,
+		incarnation, config, syncIntervals); err != nil {
+		return fmt.Errorf("failed to add ClustersStatusController controller: %w", err)
+	}
+
 	addControllerFunctions := []func(ctrl.Manager, producer.Producer, string, uint64,
 		*corev1.ConfigMap, *syncintervals.SyncIntervals) error{
-		managedclusters.AddClustersStatusController,
+		managedclusters.AddAddOnsStatusController,
+		clusterlifecycle.AddClusterDeploymentsController,
+		clusterlifecycle.AddClusterPoolClaimsController,
 		placement.AddPlacementRulesController,
 		placement.AddPlacementsController,
 		placement.AddPlacementDecisionsController,
+		policies.AddComplianceDetailsController,
+		policies.AddPolicySetsStatusController,
 		apps.AddSubscriptionStatusesController,
 		apps.AddSubscriptionReportsController,
+		gitops.AddArgoApplicationsController,
+		gitops.AddArgoApplicationSetsController,
 		localpolicies.AddLocalPoliciesController,
 		localplacement.AddLocalPlacementRulesController,
+		localplacement.AddLocalPlacementsController,
+		localplacement.AddLocalPlacementDecisionsController,
 		controlinfo.AddControlInfoController,
+		events.AddEventsController,
 	}
 
 	for _, addControllerFunction := range addControllerFunctions {
@@ -60,5 +79,10 @@ func AddControllers(mgr ctrl.Manager, pro producer.Producer,
 		}
 	}
 
+	if err := specapplyctrl.AddSpecApplyResultsController(mgr, pro, configManager.LeafHubName,
+		specApplyResults, syncIntervals); err != nil {
+		return fmt.Errorf("failed to add SpecApplyResultsController controller: %w", err)
+	}
+
 	return nil
 }
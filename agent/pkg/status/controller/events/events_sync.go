@@ -0,0 +1,241 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+	"k8s.io/client-go/util/flowcontrol"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle/events"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/syncintervals"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/transport/producer"
+	statusbundle "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+const (
+	eventsSyncLog = "events-sync"
+	// rewatchPeriod is the delay of listing and watching the events again once the watch stops.
+	rewatchPeriod = 5 * time.Second
+	// listPageSize is the number of the events listed per request.
+	listPageSize = 500
+	// maxEvents is the max number of the deduplicated events held in the bundle.
+	maxEvents = 1000
+	// maxEventAge is the time an event is relayed for since it occurred last.
+	maxEventAge = time.Hour
+	// eventsPerSecond and eventsBurst limit the rate of the new events relayed.
+	eventsPerSecond = 5
+	eventsBurst     = 100
+)
+
+// relayedGroups are the API groups of the objects the relayed events are about, the resources synced by the agent.
+var relayedGroups = map[string]struct{}{
+	"policy.open-cluster-management.io":  {},
+	"cluster.open-cluster-management.io": {},
+	"apps.open-cluster-management.io":    {},
+	"app.k8s.io":                         {},
+}
+
+type eventsController struct {
+	kubeClient              kubernetes.Interface
+	log                     logr.Logger
+	bundle                  *events.Bundle
+	transportBundleKey      string
+	transport               producer.Producer
+	resolveSyncIntervalFunc syncintervals.ResolveSyncIntervalFunc
+	lastSentBundleVersion   statusbundle.BundleVersion
+}
+
+// AddEventsController adds the controller that relays the events of the regional hub about the resources synced by
+// the agent, whether they are global resources or local ones. the events are watched directly rather than through
+// the cache of the manager, so the events of the other resources aren't held in memory.
+func AddEventsController(mgr ctrl.Manager, producer producer.Producer, leafHubName string, incarnation uint64,
+	_ *corev1.ConfigMap, syncIntervalsData *syncintervals.SyncIntervals,
+) error {
+	kubeClient, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create kube client - %w", err)
+	}
+
+	eventsCtrl := &eventsController{
+		kubeClient: kubeClient,
+		log:        ctrl.Log.WithName(eventsSyncLog),
+		bundle: events.NewBundle(leafHubName, incarnation, maxEvents, maxEventAge,
+			flowcontrol.NewTokenBucketRateLimiter(eventsPerSecond, eventsBurst)),
+		transportBundleKey:      fmt.Sprintf("%s.%s", leafHubName, constants.EventsMsgKey),
+		transport:               producer,
+		resolveSyncIntervalFunc: syncIntervalsData.Resolver(syncintervals.Events),
+		lastSentBundleVersion:   *statusbundle.NewBundleVersion(incarnation, 0),
+	}
+
+	if err := mgr.Add(eventsCtrl); err != nil {
+		return fmt.Errorf("failed to add events controller to the manager - %w", err)
+	}
+
+	return nil
+}
+
+// isRelayed returns true if the event is about an object of the relayed API groups.
+func isRelayed(event *corev1.Event) bool {
+	groupVersion, err := schema.ParseGroupVersion(event.InvolvedObject.APIVersion)
+	if err != nil {
+		return false
+	}
+
+	_, found := relayedGroups[groupVersion.Group]
+
+	return found
+}
+
+// Start function starts watching the events and the periodic sync of the bundle.
+func (c *eventsController) Start(ctx context.Context) error {
+	go c.watchEvents(ctx)
+
+	currentSyncInterval := c.resolveSyncIntervalFunc()
+	ticker := time.NewTicker(currentSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-ticker.C: // wait for next time interval
+			c.syncBundle()
+
+			resolvedInterval := c.resolveSyncIntervalFunc()
+
+			// reset ticker if sync interval has changed
+			if resolvedInterval != currentSyncInterval {
+				currentSyncInterval = resolvedInterval
+				ticker.Reset(currentSyncInterval)
+				c.log.Info(fmt.Sprintf("sync interval has been reset to %s", currentSyncInterval.String()))
+			}
+		}
+	}
+}
+
+// watchEvents relays the events until the context is done, the events are listed and watched again once the watch
+// stops, e.g. when its resource version expires. the deleted events are relayed until they're pruned by their age.
+func (c *eventsController) watchEvents(ctx context.Context) {
+	for {
+		if err := c.listAndWatchEvents(ctx); err != nil {
+			c.log.Error(err, "failed to watch events")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(rewatchPeriod):
+		}
+	}
+}
+
+func (c *eventsController) listAndWatchEvents(ctx context.Context) error {
+	resourceVersion, err := c.listEvents(ctx)
+	if err != nil {
+		return err
+	}
+
+	watcher, err := watchtools.NewRetryWatcher(resourceVersion, &cache.ListWatch{
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return c.kubeClient.CoreV1().Events(metav1.NamespaceAll).Watch(ctx, options)
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch events - %w", err)
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case watchEvent, ok := <-watcher.ResultChan():
+			if !ok {
+				return fmt.Errorf("events watch stopped")
+			}
+
+			switch watchEvent.Type {
+			case watch.Added, watch.Modified:
+				if event, ok := watchEvent.Object.(*corev1.Event); ok && isRelayed(event) {
+					c.bundle.UpdateEvent(event, time.Now())
+				}
+			case watch.Error:
+				return fmt.Errorf("events watch failed - %w", apierrors.FromObject(watchEvent.Object))
+			}
+		}
+	}
+}
+
+// listEvents relays the existing events and returns the resource version to watch the events from.
+func (c *eventsController) listEvents(ctx context.Context) (string, error) {
+	options := metav1.ListOptions{Limit: listPageSize}
+
+	for {
+		events, err := c.kubeClient.CoreV1().Events(metav1.NamespaceAll).List(ctx, options)
+		if err != nil {
+			return "", fmt.Errorf("failed to list events - %w", err)
+		}
+
+		for i := range events.Items {
+			if isRelayed(&events.Items[i]) {
+				c.bundle.UpdateEvent(&events.Items[i], time.Now())
+			}
+		}
+
+		if events.Continue == "" {
+			return events.ResourceVersion, nil
+		}
+
+		options.Continue = events.Continue
+	}
+}
+
+func (c *eventsController) syncBundle() {
+	if droppedEvents := c.bundle.GetDroppedEvents(); droppedEvents > 0 {
+		c.log.Info("dropped events by the rate limit", "events", droppedEvents)
+	}
+
+	c.bundle.Prune(time.Now())
+
+	bundleVersion := *c.bundle.GetBundleVersion()
+
+	// send to transport only if bundle has changed.
+	if !bundleVersion.NewerThan(&c.lastSentBundleVersion) {
+		return
+	}
+
+	payloadBytes, err := json.Marshal(c.bundle)
+	if err != nil {
+		c.log.Error(
+			fmt.Errorf("sync object from type %s with id %s - %w", constants.StatusBundle, c.transportBundleKey, err),
+			"failed to sync bundle")
+		return
+	}
+
+	if err := c.transport.SendAsync(&producer.Message{
+		Key:     c.transportBundleKey,
+		ID:      c.transportBundleKey,
+		MsgType: constants.StatusBundle,
+		Version: bundleVersion.String(),
+		Payload: payloadBytes,
+	}); err != nil { // the bundle is sent again on the next sync
+		c.log.Error(err, "failed to send bundle", "key", c.transportBundleKey)
+		return
+	}
+
+	c.lastSentBundleVersion = bundleVersion
+}
@@ -4,16 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/config"
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/syncintervals"
 	producer "github.com/stolostron/multicluster-global-hub/agent/pkg/transport/producer"
 	"github.com/stolostron/multicluster-global-hub/pkg/constants"
@@ -24,6 +29,10 @@ const REQUEUE_PERIOD = 5 * time.Second
 // CreateObjectFunction is a function for how to create an object that is stored inside the bundle.
 type CreateObjectFunction func() bundle.Object
 
+// CriticalChangeFunc is a function for whether the update of an object is a critical change, that is sent as soon as
+// the minimal send interval allows, without waiting for the sync interval or the debounce.
+type CriticalChangeFunc func(oldObject, newObject client.Object) bool
+
 type genericStatusSyncController struct {
 	client                  client.Client
 	log                     logr.Logger
@@ -31,15 +40,25 @@ type genericStatusSyncController struct {
 	orderedBundleCollection []*BundleCollectionEntry
 	finalizerName           string
 	createBundleObjFunc     func() bundle.Object
+	syncIntervals           *syncintervals.SyncIntervals
 	resolveSyncIntervalFunc syncintervals.ResolveSyncIntervalFunc
-	startOnce               sync.Once
-	lock                    sync.Mutex
+	// changes is notified of the changes of the objects, to send the bundles in the event-driven mode.
+	changes chan struct{}
+	// criticalChanges is notified of the critical changes of the objects, once they're updated in the bundles.
+	criticalChanges chan struct{}
+	criticalObjects map[types.NamespacedName]struct{}
+	criticalLock    sync.Mutex
+	lastSentAt      time.Time
+	startOnce       sync.Once
+	lock            sync.Mutex
 }
 
 // NewGenericStatusSyncController creates a new instance of genericStatusSyncController and adds it to the manager.
+// the bundles are sent periodically by the sync interval of the key, and shortly after the changes of the objects if
+// the debounce of the sync intervals is set. the critical changes are sent early if criticalChangeFunc is not nil.
 func NewGenericStatusSyncController(mgr ctrl.Manager, logName string, producer producer.Producer,
 	orderedBundleCollection []*BundleCollectionEntry, createObjFunc CreateObjectFunction, predicate predicate.Predicate,
-	resolveSyncIntervalFunc syncintervals.ResolveSyncIntervalFunc,
+	criticalChangeFunc CriticalChangeFunc, syncIntervals *syncintervals.SyncIntervals, syncIntervalKey string,
 ) error {
 	statusSyncCtrl := &genericStatusSyncController{
 		client:                  mgr.GetClient(),
@@ -48,12 +67,22 @@ func NewGenericStatusSyncController(mgr ctrl.Manager, logName string, producer p
 		orderedBundleCollection: orderedBundleCollection,
 		finalizerName:           constants.GlobalHubCleanupFinalizer,
 		createBundleObjFunc:     createObjFunc,
-		resolveSyncIntervalFunc: resolveSyncIntervalFunc,
+		syncIntervals:           syncIntervals,
+		resolveSyncIntervalFunc: syncIntervals.Resolver(syncIntervalKey),
+		changes:                 make(chan struct{}, 1),
+		criticalChanges:         make(chan struct{}, 1),
+		criticalObjects:         make(map[types.NamespacedName]struct{}),
 		lock:                    sync.Mutex{},
 	}
 	statusSyncCtrl.init()
 
-	controllerBuilder := ctrl.NewControllerManagedBy(mgr).For(createObjFunc())
+	var forOptions []builder.ForOption
+	if criticalChangeFunc != nil {
+		forOptions = append(forOptions,
+			builder.WithPredicates(statusSyncCtrl.criticalChangePredicate(criticalChangeFunc, predicate)))
+	}
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).For(createObjFunc(), forOptions...)
 	if predicate != nil {
 		controllerBuilder = controllerBuilder.WithEventFilter(predicate)
 	}
@@ -75,6 +104,8 @@ func (c *genericStatusSyncController) Reconcile(ctx context.Context, request ctr
 	reqLogger := c.log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 
 	object := c.createBundleObjFunc()
+	// the object of the event is already in the cache, so the critical change is in the object that's read next
+	criticalChange := c.isCriticalChange(request.NamespacedName)
 
 	if err := c.client.Get(ctx, request.NamespacedName, object); apierrors.IsNotFound(err) {
 		// the instance was deleted and it had no finalizer on it.
@@ -83,6 +114,10 @@ func (c *genericStatusSyncController) Reconcile(ctx context.Context, request ctr
 		// either way, no need to do anything in this state.
 		return ctrl.Result{}, nil
 	} else if err != nil {
+		if criticalChange { // the critical change is sent with the requeued reconciliation
+			c.setCriticalChange(request.NamespacedName)
+		}
+
 		return ctrl.Result{Requeue: true, RequeueAfter: REQUEUE_PERIOD},
 			fmt.Errorf("reconciliation failed: %w", err)
 	}
@@ -91,6 +126,10 @@ func (c *genericStatusSyncController) Reconcile(ctx context.Context, request ctr
 		c.deleteObject(ctx, object, reqLogger)
 	} else { // otherwise, the object was not deleted and no error occurred
 		c.updateObject(ctx, object, reqLogger)
+
+		if criticalChange {
+			notify(c.criticalChanges)
+		}
 	}
 
 	reqLogger.Info("Reconciliation complete.")
@@ -113,6 +152,8 @@ func (c *genericStatusSyncController) updateObject(ctx context.Context, object b
 	for _, entry := range c.orderedBundleCollection {
 		entry.bundle.UpdateObject(object) // update in each bundle from the collection according to their order.
 	}
+
+	c.notifyChange()
 }
 
 func (c *genericStatusSyncController) deleteObject(ctx context.Context, object bundle.Object,
@@ -125,15 +166,103 @@ func (c *genericStatusSyncController) deleteObject(ctx context.Context, object b
 	}
 
 	c.lock.Unlock() // not using defer since remove finalizer may get delayed. release lock as soon as possible.
+
+	c.notifyChange()
+}
+
+// notifyChange notifies the periodic sync of a change without blocking, a pending notification covers the change.
+func (c *genericStatusSyncController) notifyChange() {
+	notify(c.changes)
+}
+
+func notify(changes chan struct{}) {
+	select {
+	case changes <- struct{}{}:
+	default:
+	}
+}
+
+// criticalChangePredicate records the objects of the critical updates that pass the event filter, to send them early
+// once they're reconciled. it doesn't filter any event.
+func (c *genericStatusSyncController) criticalChangePredicate(criticalChangeFunc CriticalChangeFunc,
+	eventFilter predicate.Predicate,
+) predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if (eventFilter == nil || eventFilter.Update(e)) && criticalChangeFunc(e.ObjectOld, e.ObjectNew) {
+				c.setCriticalChange(client.ObjectKeyFromObject(e.ObjectNew))
+			}
+
+			return true
+		},
+	}
+}
+
+func (c *genericStatusSyncController) setCriticalChange(key types.NamespacedName) {
+	c.criticalLock.Lock()
+	defer c.criticalLock.Unlock()
+
+	c.criticalObjects[key] = struct{}{}
+}
+
+// isCriticalChange returns whether a critical change of the object is waiting to be sent, and clears it.
+func (c *genericStatusSyncController) isCriticalChange(key types.NamespacedName) bool {
+	c.criticalLock.Lock()
+	defer c.criticalLock.Unlock()
+
+	if _, found := c.criticalObjects[key]; !found {
+		return false
+	}
+
+	delete(c.criticalObjects, key)
+
+	return true
 }
 
 func (c *genericStatusSyncController) periodicSync() {
 	currentSyncInterval := c.resolveSyncIntervalFunc()
 	ticker := time.NewTicker(currentSyncInterval)
 
+	// the debounce timer is running while changes are waiting to be sent in the event-driven mode
+	debounceTimer := time.NewTimer(0)
+	<-debounceTimer.C
+	debouncing := false
+
 	for {
-		<-ticker.C // wait for next time interval
-		c.syncBundles()
+		select {
+		case <-ticker.C: // wait for next time interval
+			c.syncBundles()
+		case <-c.changes:
+			debounce := c.syncIntervals.GetDebounce()
+			if debouncing || debounce <= 0 { // the change is sent by the pending send or the next periodic sync
+				continue
+			}
+			// send after the debounce, but not sooner than the minimal interval since the last send
+			delay := debounce
+			untilMinInterval := time.Until(c.lastSentAt.Add(c.syncIntervals.GetMinSendInterval()))
+			if untilMinInterval > delay {
+				delay = untilMinInterval
+			}
+
+			debounceTimer.Reset(delay)
+			debouncing = true
+
+			continue
+		case <-c.criticalChanges:
+			// send as soon as the minimal interval since the last send allows, sooner than a pending send
+			delay := time.Until(c.lastSentAt.Add(c.syncIntervals.GetMinSendInterval()))
+			if debouncing && !debounceTimer.Stop() {
+				<-debounceTimer.C
+			}
+
+			debounceTimer.Reset(delay)
+			debouncing = true
+
+			continue
+		case <-debounceTimer.C:
+			debouncing = false
+			c.syncBundles()
+		}
 
 		resolvedInterval := c.resolveSyncIntervalFunc()
 
@@ -168,24 +297,46 @@ func (c *genericStatusSyncController) syncBundles() {
 					"failed to sync bundle")
 			}
 
+			payloadBytes, err = c.projectBundle(entry.transportBundleKey, payloadBytes)
+			if err != nil {
+				c.log.Error(err, "failed to project bundle, sending it as is", "key", entry.transportBundleKey)
+			}
+
 			transportMessageKey := entry.transportBundleKey
 			if deltaStateBundle, ok := entry.bundle.(bundle.DeltaStateBundle); ok {
 				transportMessageKey = fmt.Sprintf("%s@%d", entry.transportBundleKey, deltaStateBundle.GetTransportationID())
 			}
 
-			c.transport.SendAsync(&producer.Message{
+			if err := c.transport.SendAsync(&producer.Message{
 				Key:     transportMessageKey,
 				ID:      entry.transportBundleKey,
 				MsgType: constants.StatusBundle,
 				Version: entry.bundle.GetBundleVersion().String(),
 				Payload: payloadBytes,
-			})
+			}); err != nil { // the bundle is sent again on the next sync
+				c.log.Error(err, "failed to send bundle", "key", entry.transportBundleKey)
+				continue
+			}
 
 			entry.lastSentBundleVersion = *bundleVersion
+			c.lastSentAt = time.Now()
 		}
 	}
 }
 
+// projectBundle applies the configured projection of the bundle type to the objects of the marshalled bundle. the
+// bundle is returned as is if the projection fails.
+func (c *genericStatusSyncController) projectBundle(transportBundleKey string, payloadBytes []byte) ([]byte, error) {
+	bundleType := transportBundleKey[strings.LastIndex(transportBundleKey, ".")+1:] // the key is LH_ID.MSG_ID
+
+	projectedPayloadBytes, err := config.GetStatusProjections().Apply(bundleType, payloadBytes)
+	if err != nil {
+		return payloadBytes, err
+	}
+
+	return projectedPayloadBytes, nil
+}
+
 func cleanObject(object bundle.Object) {
 	object.SetManagedFields(nil)
 	object.SetFinalizers(nil)
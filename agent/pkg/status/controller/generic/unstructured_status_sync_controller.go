@@ -0,0 +1,157 @@
+package generic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/syncintervals"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/transport/producer"
+	statusbundle "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+// unstructuredStatusSyncController sends the statuses of the objects of a kind whose types the agent doesn't import,
+// the objects being deleted are sent until they're removed.
+type unstructuredStatusSyncController struct {
+	client                  client.Client
+	log                     logr.Logger
+	gvk                     schema.GroupVersionKind
+	bundle                  *bundle.UnstructuredStatusBundle
+	transportBundleKey      string
+	transport               producer.Producer
+	resolveSyncIntervalFunc syncintervals.ResolveSyncIntervalFunc
+	lastSentBundleVersion   statusbundle.BundleVersion
+}
+
+// AddUnstructuredStatusSyncController adds a controller that sends the statuses of the objects of the kind to the
+// manager, the controller isn't added if the kind isn't installed.
+func AddUnstructuredStatusSyncController(mgr ctrl.Manager, producer producer.Producer, leafHubName string,
+	incarnation uint64, syncIntervalsData *syncintervals.SyncIntervals, logName string,
+	gvk schema.GroupVersionKind, msgKey string, syncIntervalKey string, getStatusFunc bundle.GetStatusFunc,
+) error {
+	log := ctrl.Log.WithName(logName)
+
+	// the kinds of the optional operators, e.g. hive, aren't installed on every regional hub
+	if _, err := mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); meta.IsNoMatchError(err) {
+		log.Info("the kind isn't installed, skipping its status", "kind", gvk.Kind)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get the mapping of %s - %w", gvk.Kind, err)
+	}
+
+	statusCtrl := &unstructuredStatusSyncController{
+		client:                  mgr.GetClient(),
+		log:                     log,
+		gvk:                     gvk,
+		bundle:                  bundle.NewUnstructuredStatusBundle(leafHubName, incarnation, getStatusFunc),
+		transportBundleKey:      fmt.Sprintf("%s.%s", leafHubName, msgKey),
+		transport:               producer,
+		resolveSyncIntervalFunc: syncIntervalsData.Resolver(syncIntervalKey),
+		lastSentBundleVersion:   *statusbundle.NewBundleVersion(incarnation, 0),
+	}
+
+	if err := ctrl.NewControllerManagedBy(mgr).Named(logName).
+		For(statusCtrl.newObject()).
+		Complete(statusCtrl); err != nil {
+		return fmt.Errorf("failed to add %s controller to the manager - %w", gvk.Kind, err)
+	}
+
+	if err := mgr.Add(statusCtrl); err != nil {
+		return fmt.Errorf("failed to add %s controller to the manager - %w", gvk.Kind, err)
+	}
+
+	return nil
+}
+
+func (c *unstructuredStatusSyncController) newObject() *unstructured.Unstructured {
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(c.gvk)
+
+	return object
+}
+
+func (c *unstructuredStatusSyncController) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	object := c.newObject()
+
+	if err := c.client.Get(ctx, request.NamespacedName, object); apierrors.IsNotFound(err) {
+		object.SetNamespace(request.Namespace)
+		object.SetName(request.Name)
+		c.bundle.DeleteObject(object)
+
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		return ctrl.Result{Requeue: true, RequeueAfter: REQUEUE_PERIOD},
+			fmt.Errorf("reconciliation failed: %w", err)
+	}
+
+	c.bundle.UpdateObject(object) // the objects being deleted are updated too, e.g. the clusters being destroyed
+
+	return ctrl.Result{}, nil
+}
+
+// Start function starts the periodic sync of the bundle.
+func (c *unstructuredStatusSyncController) Start(ctx context.Context) error {
+	currentSyncInterval := c.resolveSyncIntervalFunc()
+	ticker := time.NewTicker(currentSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-ticker.C: // wait for next time interval
+			c.syncBundle()
+
+			resolvedInterval := c.resolveSyncIntervalFunc()
+
+			// reset ticker if sync interval has changed
+			if resolvedInterval != currentSyncInterval {
+				currentSyncInterval = resolvedInterval
+				ticker.Reset(currentSyncInterval)
+				c.log.Info(fmt.Sprintf("sync interval has been reset to %s", currentSyncInterval.String()))
+			}
+		}
+	}
+}
+
+func (c *unstructuredStatusSyncController) syncBundle() {
+	bundleVersion := *c.bundle.GetBundleVersion()
+
+	// send to transport only if bundle has changed.
+	if !bundleVersion.NewerThan(&c.lastSentBundleVersion) {
+		return
+	}
+
+	payloadBytes, err := json.Marshal(c.bundle)
+	if err != nil {
+		c.log.Error(
+			fmt.Errorf("sync object from type %s with id %s - %w", constants.StatusBundle, c.transportBundleKey, err),
+			"failed to sync bundle")
+		return
+	}
+
+	if err := c.transport.SendAsync(&producer.Message{
+		Key:     c.transportBundleKey,
+		ID:      c.transportBundleKey,
+		MsgType: constants.StatusBundle,
+		Version: bundleVersion.String(),
+		Payload: payloadBytes,
+	}); err != nil { // the bundle is sent again on the next sync
+		c.log.Error(err, "failed to send bundle", "key", c.transportBundleKey)
+		return
+	}
+
+	c.lastSentBundleVersion = bundleVersion
+}
@@ -0,0 +1,41 @@
+package gitops
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle/gitops"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/generic"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/syncintervals"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/transport/producer"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+const (
+	argoApplicationsSyncLog    = "argo-applications-sync"
+	argoApplicationSetsSyncLog = "argo-application-sets-sync"
+)
+
+var (
+	applicationGVK    = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Application"}
+	applicationSetGVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "ApplicationSet"}
+)
+
+// AddArgoApplicationsController adds the argo cd applications status controller to the manager.
+func AddArgoApplicationsController(mgr ctrl.Manager, producer producer.Producer, leafHubName string,
+	incarnation uint64, _ *corev1.ConfigMap, syncIntervalsData *syncintervals.SyncIntervals,
+) error {
+	return generic.AddUnstructuredStatusSyncController(mgr, producer, leafHubName, incarnation, syncIntervalsData,
+		argoApplicationsSyncLog, applicationGVK, constants.ArgoApplicationsMsgKey,
+		syncintervals.ArgoApplications, gitops.GetApplicationStatus)
+}
+
+// AddArgoApplicationSetsController adds the argo cd application sets status controller to the manager.
+func AddArgoApplicationSetsController(mgr ctrl.Manager, producer producer.Producer, leafHubName string,
+	incarnation uint64, _ *corev1.ConfigMap, syncIntervalsData *syncintervals.SyncIntervals,
+) error {
+	return generic.AddUnstructuredStatusSyncController(mgr, producer, leafHubName, incarnation, syncIntervalsData,
+		argoApplicationSetsSyncLog, applicationSetGVK, constants.ArgoApplicationSetsMsgKey,
+		syncintervals.ArgoApplicationSets, gitops.GetApplicationSetStatus)
+}
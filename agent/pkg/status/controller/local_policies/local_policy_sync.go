@@ -36,7 +36,8 @@ func AddLocalPoliciesController(mgr ctrl.Manager, transport producer.Producer, l
 	})
 
 	if err := generic.NewGenericStatusSyncController(mgr, localPoliciesStatusSyncLog, transport, bundleCollection,
-		createObjFunc, localPolicyPredicate, syncIntervalsData.GetPolicies); err != nil {
+		createObjFunc, localPolicyPredicate, nil, syncIntervalsData,
+		syncintervals.LocalPolicies); err != nil {
 		return fmt.Errorf("failed to add local policies controller to the manager - %w", err)
 	}
 
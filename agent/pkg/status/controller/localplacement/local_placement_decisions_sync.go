@@ -0,0 +1,54 @@
+package localplacement
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	clustersv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/helper"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/generic"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/syncintervals"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/transport/producer"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+const (
+	localPlacementDecisionStatusSyncLog = "local-placement-decision-status-sync"
+)
+
+// AddLocalPlacementDecisionsController adds a new local placement decisions controller.
+func AddLocalPlacementDecisionsController(mgr ctrl.Manager, transport producer.Producer, leafHubName string,
+	incarnation uint64, hubOfHubsConfig *corev1.ConfigMap, syncIntervalsData *syncintervals.SyncIntervals,
+) error {
+	createObjFunc := func() bundle.Object { return &clustersv1beta1.PlacementDecision{} }
+
+	localPlacementDecisionTransportKey := fmt.Sprintf("%s.%s", leafHubName, constants.LocalPlacementDecisionsMsgKey)
+
+	bundleCollection := []*generic.BundleCollectionEntry{
+		generic.NewBundleCollectionEntry(localPlacementDecisionTransportKey,
+			bundle.NewGenericStatusBundle(leafHubName, incarnation, nil),
+			func() bool { // bundle predicate
+				return hubOfHubsConfig.Data["enableLocalPolicies"] == "true"
+			}),
+	}
+	// controller predicate - the placement decisions of the placements that weren't created from the global hub, the
+	// ones whose placement isn't found are skipped. the decisions being deleted pass to be removed from the bundle.
+	localPlacementDecisionPredicate := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return !object.GetDeletionTimestamp().IsZero() || helper.GetPlacementDecisionOrigin(context.Background(),
+			mgr.GetClient(), object) == helper.PlacementDecisionOriginLocal
+	})
+
+	if err := generic.NewGenericStatusSyncController(mgr, localPlacementDecisionStatusSyncLog, transport,
+		bundleCollection, createObjFunc, localPlacementDecisionPredicate, nil, syncIntervalsData,
+		syncintervals.LocalPlacementDecisions); err != nil {
+		return fmt.Errorf("failed to add local placement decisions controller to the manager - %w", err)
+	}
+
+	return nil
+}
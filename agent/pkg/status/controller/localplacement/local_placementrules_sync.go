@@ -42,7 +42,8 @@ func AddLocalPlacementRulesController(mgr ctrl.Manager, transport producer.Produ
 	})
 
 	if err := generic.NewGenericStatusSyncController(mgr, localPlacementRuleStatusSyncLog, transport, bundleCollection,
-		createObjFunc, localPlacementRulePredicate, syncIntervalsData.GetPolicies); err != nil {
+		createObjFunc, localPlacementRulePredicate, nil, syncIntervalsData,
+		syncintervals.LocalPlacementRules); err != nil {
 		return fmt.Errorf("failed to add local placement rules controller to the manager - %w", err)
 	}
 
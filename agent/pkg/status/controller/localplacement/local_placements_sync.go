@@ -0,0 +1,60 @@
+package localplacement
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	clustersv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/helper"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/generic"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/syncintervals"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/transport/producer"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+const (
+	localPlacementStatusSyncLog = "local-placement-status-sync"
+)
+
+// AddLocalPlacementsController adds a new local placements controller.
+func AddLocalPlacementsController(mgr ctrl.Manager, transport producer.Producer, leafHubName string,
+	incarnation uint64, hubOfHubsConfig *corev1.ConfigMap, syncIntervalsData *syncintervals.SyncIntervals,
+) error {
+	createObjFunc := func() bundle.Object { return &clustersv1beta1.Placement{} }
+
+	localPlacementTransportKey := fmt.Sprintf("%s.%s", leafHubName, constants.LocalPlacementsMsgKey)
+
+	bundleCollection := []*generic.BundleCollectionEntry{
+		generic.NewBundleCollectionEntry(localPlacementTransportKey,
+			bundle.NewGenericStatusBundle(leafHubName, incarnation, cleanPlacement),
+			func() bool { // bundle predicate
+				return hubOfHubsConfig.Data["enableLocalPolicies"] == "true"
+			}),
+	}
+	// controller predicate
+	localPlacementPredicate := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return !helper.HasAnnotation(object, constants.OriginOwnerReferenceAnnotation)
+	})
+
+	if err := generic.NewGenericStatusSyncController(mgr, localPlacementStatusSyncLog, transport, bundleCollection,
+		createObjFunc, localPlacementPredicate, nil, syncIntervalsData,
+		syncintervals.LocalPlacements); err != nil {
+		return fmt.Errorf("failed to add local placements controller to the manager - %w", err)
+	}
+
+	return nil
+}
+
+func cleanPlacement(object bundle.Object) {
+	placement, ok := object.(*clustersv1beta1.Placement)
+	if !ok {
+		panic("Wrong instance passed to clean placement function, not a placement")
+	}
+	// the decisions are sent in the local placement decisions bundle
+	placement.Status = clustersv1beta1.PlacementStatus{}
+}
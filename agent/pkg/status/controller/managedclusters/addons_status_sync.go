@@ -0,0 +1,57 @@
+package managedclusters
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/generic"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/syncintervals"
+	producer "github.com/stolostron/multicluster-global-hub/agent/pkg/transport/producer"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+const (
+	addOnsStatusSyncLogName = "managed-cluster-addons-status-sync"
+)
+
+// AddAddOnsStatusController adds managed cluster add-ons status controller to the manager.
+func AddAddOnsStatusController(mgr ctrl.Manager, producer producer.Producer, leafHubName string,
+	incarnation uint64, hubOfHubsConfig *corev1.ConfigMap, syncIntervals *syncintervals.SyncIntervals,
+) error {
+	createObjFunction := func() bundle.Object { return &addonv1alpha1.ManagedClusterAddOn{} }
+	transportBundleKey := fmt.Sprintf("%s.%s", leafHubName, constants.ManagedClusterAddOnsMsgKey)
+
+	predicateFunc := func() bool { // bundle predicate
+		return hubOfHubsConfig.Data["aggregationLevel"] == "full" ||
+			hubOfHubsConfig.Data["aggregationLevel"] == "minimal"
+		// the add-ons are sent with the managed clusters even if aggregation level is minimal
+	}
+
+	bundleCollection := []*generic.BundleCollectionEntry{ // single bundle for managed cluster add-ons
+		generic.NewBundleCollectionEntry(transportBundleKey,
+			bundle.NewGenericStatusBundle(leafHubName, incarnation, cleanAddOn),
+			predicateFunc),
+	}
+
+	if err := generic.NewGenericStatusSyncController(mgr, addOnsStatusSyncLogName, producer, bundleCollection,
+		createObjFunction, nil, nil, syncIntervals,
+		syncintervals.ManagedClusterAddOns); err != nil {
+		return fmt.Errorf("failed to add managed cluster add-ons controller to the manager - %w", err)
+	}
+
+	return nil
+}
+
+func cleanAddOn(object bundle.Object) {
+	addOn, ok := object.(*addonv1alpha1.ManagedClusterAddOn)
+	if !ok {
+		panic("Wrong instance passed to clean add-on function, not a managed cluster add-on")
+	}
+	// only the health of the add-on is needed, the related objects and registrations are dropped.
+	addOn.Status.RelatedObjects = nil
+	addOn.Status.Registrations = nil
+}
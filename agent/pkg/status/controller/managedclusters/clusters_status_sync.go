@@ -4,8 +4,10 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	clusterV1 "open-cluster-management.io/api/cluster/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/helper"
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle"
@@ -19,13 +21,16 @@ const (
 	clusterStatusSyncLogName = "clusters-status-sync"
 )
 
-// mgr, pro, env.LeafHubID, incarnation, config, syncIntervals
 // AddClustersStatusController adds managed clusters status controller to the manager.
-func AddClustersStatusController(mgr ctrl.Manager, producer producer.Producer, leafHubName string,
+// the managed clusters are sent in complete state bundles, and in between in delta state bundles of the changed
+// clusters, if the transport supports delta bundles.
+func AddClustersStatusController(mgr ctrl.Manager, producer producer.Producer, env helper.ConfigManager,
 	incarnation uint64, hubOfHubsConfig *corev1.ConfigMap, syncIntervals *syncintervals.SyncIntervals,
 ) error {
+	leafHubName := env.LeafHubName
 	createObjFunction := func() bundle.Object { return &clusterV1.ManagedCluster{} }
-	transportBundleKey := fmt.Sprintf("%s.%s", leafHubName, constants.ManagedClustersMsgKey)
+	completeTransportBundleKey := fmt.Sprintf("%s.%s", leafHubName, constants.ManagedClustersMsgKey)
+	deltaTransportBundleKey := fmt.Sprintf("%s.%s", leafHubName, constants.DeltaManagedClustersMsgKey)
 
 	// update bundle object
 	manipulateObjFunc := func(object bundle.Object) {
@@ -40,16 +45,46 @@ func AddClustersStatusController(mgr ctrl.Manager, producer producer.Producer, l
 		// at this point send all managed clusters even if aggregation level is minimal
 	}
 
-	bundleCollection := []*generic.BundleCollectionEntry{ // single bundle for managed clusters
-		generic.NewBundleCollectionEntry(transportBundleKey,
-			bundle.NewGenericStatusBundle(leafHubName, incarnation, manipulateObjFunc),
-			predicateFunc),
+	completeStateBundle := bundle.NewGenericStatusBundle(leafHubName, incarnation, manipulateObjFunc)
+	deltaStateBundle := bundle.NewDeltaManagedClustersBundle(leafHubName,
+		completeStateBundle.(*bundle.GenericStatusBundle), incarnation, manipulateObjFunc)
+
+	completeStateBundleCollectionEntry := generic.NewBundleCollectionEntry(completeTransportBundleKey,
+		completeStateBundle, predicateFunc)
+	deltaStateBundleCollectionEntry := generic.NewBundleCollectionEntry(deltaTransportBundleKey,
+		deltaStateBundle, predicateFunc)
+
+	// apply a hybrid sync manager on the managed clusters bundles
+	if err := generic.NewHybridSyncManager(ctrl.Log.WithName("managed-clusters-hybrid-sync-manager"),
+		producer, completeStateBundleCollectionEntry, deltaStateBundleCollectionEntry,
+		env.StatusDeltaCountSwitchFactor); err != nil {
+		return fmt.Errorf("failed to initialize managed clusters hybrid sync manager - %w", err)
+	}
+
+	bundleCollection := []*generic.BundleCollectionEntry{ // complete and delta bundles for managed clusters
+		completeStateBundleCollectionEntry,
+		deltaStateBundleCollectionEntry,
 	}
 
 	if err := generic.NewGenericStatusSyncController(mgr, clusterStatusSyncLogName, producer, bundleCollection,
-		createObjFunction, nil, syncIntervals.GetManagerClusters); err != nil {
+		createObjFunction, nil, isClusterUnavailable, syncIntervals,
+		syncintervals.ManagedClusters); err != nil {
 		return fmt.Errorf("failed to add managed clusters controller to the manager - %w", err)
 	}
 
 	return nil
 }
+
+// isClusterUnavailable returns whether the managed cluster became unavailable, to send it without waiting for the
+// sync interval.
+func isClusterUnavailable(oldObject, newObject client.Object) bool {
+	oldCluster, oldOK := oldObject.(*clusterV1.ManagedCluster)
+	newCluster, newOK := newObject.(*clusterV1.ManagedCluster)
+
+	if !oldOK || !newOK {
+		return false
+	}
+
+	return meta.IsStatusConditionTrue(oldCluster.Status.Conditions, clusterV1.ManagedClusterConditionAvailable) &&
+		!meta.IsStatusConditionTrue(newCluster.Status.Conditions, clusterV1.ManagedClusterConditionAvailable)
+}
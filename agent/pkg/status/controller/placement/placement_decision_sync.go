@@ -1,12 +1,16 @@
 package placement
 
 import (
+	"context"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 	clustersv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/helper"
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle"
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/generic"
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/syncintervals"
@@ -30,8 +34,16 @@ func AddPlacementDecisionsController(mgr ctrl.Manager, transport producer.Produc
 			func() bool { return true }),
 	} // bundle predicate - always send placement decision.
 
+	// the placement decisions of the local placements are sent in the local placement decisions bundle, the ones whose
+	// placement isn't found are skipped. the decisions being deleted pass to be removed from the bundle if they're in it.
+	globalPlacementDecisionPredicate := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return !object.GetDeletionTimestamp().IsZero() || helper.GetPlacementDecisionOrigin(context.Background(),
+			mgr.GetClient(), object) == helper.PlacementDecisionOriginGlobal
+	})
+
 	if err := generic.NewGenericStatusSyncController(mgr, placementDecisionsSyncLog, transport, bundleCollection,
-		createObjFunction, nil, syncIntervalsData.GetPolicies); err != nil {
+		createObjFunction, globalPlacementDecisionPredicate, nil, syncIntervalsData,
+		syncintervals.PlacementDecisions); err != nil {
 		return fmt.Errorf("failed to add placement decisions controller to the manager - %w", err)
 	}
 
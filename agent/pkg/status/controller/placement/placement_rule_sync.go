@@ -41,7 +41,8 @@ func AddPlacementRulesController(mgr ctrl.Manager, transport producer.Producer,
 	})
 
 	if err := generic.NewGenericStatusSyncController(mgr, placementRuleSyncLog, transport, bundleCollection,
-		createObjFunction, ownerRefAnnotationPredicate, syncIntervalsData.GetPolicies); err != nil {
+		createObjFunction, ownerRefAnnotationPredicate, nil, syncIntervalsData,
+		syncintervals.PlacementRules); err != nil {
 		return fmt.Errorf("failed to add placement rules controller to the manager - %w", err)
 	}
 
@@ -38,7 +38,8 @@ func AddPlacementsController(mgr ctrl.Manager, transport producer.Producer, leaf
 	})
 
 	if err := generic.NewGenericStatusSyncController(mgr, placementSyncLog, transport, bundleCollection,
-		createObjFunction, ownerRefAnnotationPredicate, syncIntervalsData.GetPolicies); err != nil {
+		createObjFunction, ownerRefAnnotationPredicate, nil, syncIntervalsData,
+		syncintervals.Placements); err != nil {
 		return fmt.Errorf("failed to add placements controller to the manager - %w", err)
 	}
 
@@ -0,0 +1,181 @@
+package policies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	policiesV1 "open-cluster-management.io/governance-policy-propagator/api/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/helper"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle/grc"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/syncintervals"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/transport/producer"
+	statusbundle "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+const (
+	complianceDetailsSyncLog = "compliance-details-sync"
+	requeuePeriod            = 5 * time.Second
+)
+
+// complianceDetailsController sends the violations of the replicated policies of the non compliant clusters, if the
+// compliance details are enabled.
+type complianceDetailsController struct {
+	client                  client.Client
+	log                     logr.Logger
+	bundle                  *grc.ComplianceDetailsBundle
+	transportBundleKey      string
+	transport               producer.Producer
+	predicate               func() bool
+	resolveSyncIntervalFunc syncintervals.ResolveSyncIntervalFunc
+	lastSentBundleVersion   statusbundle.BundleVersion
+	enabled                 bool
+}
+
+// AddComplianceDetailsController adds the compliance details controller to the manager.
+func AddComplianceDetailsController(mgr ctrl.Manager, producer producer.Producer, leafHubName string,
+	incarnation uint64, hubOfHubsConfig *corev1.ConfigMap, syncIntervalsData *syncintervals.SyncIntervals,
+) error {
+	complianceDetailsCtrl := &complianceDetailsController{
+		client:             mgr.GetClient(),
+		log:                ctrl.Log.WithName(complianceDetailsSyncLog),
+		bundle:             grc.NewComplianceDetailsBundle(leafHubName, incarnation, extractPolicyID),
+		transportBundleKey: fmt.Sprintf("%s.%s", leafHubName, constants.PolicyComplianceDetailsMsgKey),
+		transport:          producer,
+		predicate: func() bool {
+			return hubOfHubsConfig.Data["aggregationLevel"] == "full" &&
+				hubOfHubsConfig.Data["complianceDetailsLevel"] == "violations"
+		},
+		resolveSyncIntervalFunc: syncIntervalsData.Resolver(syncintervals.ComplianceDetails),
+		lastSentBundleVersion:   *statusbundle.NewBundleVersion(incarnation, 0),
+	}
+
+	replicatedPolicyPredicate := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return helper.HasLabel(object, rootPolicyLabel) &&
+			helper.HasAnnotation(object, constants.OriginOwnerReferenceAnnotation)
+	})
+
+	// the root policies are watched by the policies status controller
+	if err := ctrl.NewControllerManagedBy(mgr).Named(complianceDetailsSyncLog).
+		For(&policiesV1.Policy{}).
+		WithEventFilter(replicatedPolicyPredicate).
+		Complete(complianceDetailsCtrl); err != nil {
+		return fmt.Errorf("failed to add compliance details controller to the manager - %w", err)
+	}
+
+	if err := mgr.Add(complianceDetailsCtrl); err != nil {
+		return fmt.Errorf("failed to add compliance details controller to the manager - %w", err)
+	}
+
+	return nil
+}
+
+func (c *complianceDetailsController) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	policy := &policiesV1.Policy{}
+
+	if err := c.client.Get(ctx, request.NamespacedName, policy); apierrors.IsNotFound(err) {
+		// the replicated policies are deleted without a finalizer, the details are removed by the policy key
+		policy.SetNamespace(request.Namespace)
+		policy.SetName(request.Name)
+		c.bundle.DeleteObject(policy)
+
+		return ctrl.Result{}, nil
+	} else if err != nil {
+		return ctrl.Result{Requeue: true, RequeueAfter: requeuePeriod},
+			fmt.Errorf("reconciliation failed: %w", err)
+	}
+
+	if policy.GetDeletionTimestamp().IsZero() {
+		c.bundle.UpdateObject(policy)
+	} else {
+		c.bundle.DeleteObject(policy)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// Start function starts the periodic sync of the compliance details bundle.
+func (c *complianceDetailsController) Start(ctx context.Context) error {
+	currentSyncInterval := c.resolveSyncIntervalFunc()
+	ticker := time.NewTicker(currentSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-ticker.C: // wait for next time interval
+			c.syncBundle()
+
+			resolvedInterval := c.resolveSyncIntervalFunc()
+
+			// reset ticker if sync interval has changed
+			if resolvedInterval != currentSyncInterval {
+				currentSyncInterval = resolvedInterval
+				ticker.Reset(currentSyncInterval)
+				c.log.Info(fmt.Sprintf("sync interval has been reset to %s", currentSyncInterval.String()))
+			}
+		}
+	}
+}
+
+func (c *complianceDetailsController) syncBundle() {
+	enabled := c.predicate()
+
+	if enabled != c.enabled { // the details are sent again when enabled, and cleared on the global hub when disabled
+		c.bundle.Refresh()
+	}
+
+	bundleVersion := *c.bundle.GetBundleVersion()
+
+	// send to transport only if bundle has changed.
+	if !bundleVersion.NewerThan(&c.lastSentBundleVersion) {
+		return
+	}
+
+	var payloadBytes []byte
+	var err error
+
+	if enabled {
+		payloadBytes, err = json.Marshal(c.bundle)
+	} else if c.enabled {
+		payloadBytes, err = json.Marshal(&statusbundle.BaseComplianceDetailsBundle{
+			Objects:       make([]*statusbundle.PolicyComplianceDetails, 0),
+			LeafHubName:   c.bundle.LeafHubName,
+			BundleVersion: &bundleVersion,
+		})
+	} else {
+		return // the details are disabled and were cleared already
+	}
+
+	if err != nil {
+		c.log.Error(
+			fmt.Errorf("sync object from type %s with id %s - %w", constants.StatusBundle, c.transportBundleKey, err),
+			"failed to sync bundle")
+		return
+	}
+
+	if err := c.transport.SendAsync(&producer.Message{
+		Key:     c.transportBundleKey,
+		ID:      c.transportBundleKey,
+		MsgType: constants.StatusBundle,
+		Version: bundleVersion.String(),
+		Payload: payloadBytes,
+	}); err != nil { // the bundle is sent again on the next sync
+		c.log.Error(err, "failed to send bundle", "key", c.transportBundleKey)
+		return
+	}
+
+	c.lastSentBundleVersion = bundleVersion
+	c.enabled = enabled
+}
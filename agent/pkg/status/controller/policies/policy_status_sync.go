@@ -46,12 +46,39 @@ func AddPoliciesStatusController(mgr ctrl.Manager, producer producer.Producer, e
 	// initialize policy status controller (contains multiple bundles)
 	if err := generic.NewGenericStatusSyncController(mgr, policiesStatusSyncLog, producer, bundleCollection,
 		createObjFunction, predicate.And(rootPolicyPredicate, ownerRefAnnotationPredicate),
-		syncIntervalsData.GetPolicies); err != nil {
+		isPolicyNonCompliant, syncIntervalsData, syncintervals.Policies); err != nil {
 		return fmt.Errorf("failed to add policies controller to the manager - %w", err)
 	}
 	return nil
 }
 
+// isPolicyNonCompliant returns whether the policy became non compliant on a cluster, to send it without waiting for
+// the sync interval.
+func isPolicyNonCompliant(oldObject, newObject client.Object) bool {
+	oldPolicy, oldOK := oldObject.(*policiesV1.Policy)
+	newPolicy, newOK := newObject.(*policiesV1.Policy)
+
+	if !oldOK || !newOK {
+		return false
+	}
+
+	oldNonCompliantClusters := make(map[string]struct{})
+	for _, clusterStatus := range oldPolicy.Status.Status {
+		if clusterStatus.ComplianceState == policiesV1.NonCompliant {
+			oldNonCompliantClusters[clusterStatus.ClusterName] = struct{}{}
+		}
+	}
+
+	for _, clusterStatus := range newPolicy.Status.Status {
+		if _, found := oldNonCompliantClusters[clusterStatus.ClusterName]; !found &&
+			clusterStatus.ComplianceState == policiesV1.NonCompliant {
+			return true
+		}
+	}
+
+	return false
+}
+
 func createBundleCollection(pro producer.Producer, env helper.ConfigManager, incarnation uint64,
 	hubOfHubsConfig *corev1.ConfigMap,
 ) ([]*generic.BundleCollectionEntry, error) {
@@ -0,0 +1,57 @@
+package policies
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "open-cluster-management.io/governance-policy-propagator/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/helper"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/generic"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/syncintervals"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/transport/producer"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+const (
+	policySetSyncLog = "policysets-sync"
+)
+
+// AddPolicySetsStatusController adds policy sets status controller to the manager, the compliance of the policy sets
+// received from the global hub is sent.
+func AddPolicySetsStatusController(mgr ctrl.Manager, transport producer.Producer, leafHubName string,
+	incarnation uint64, _ *corev1.ConfigMap, syncIntervalsData *syncintervals.SyncIntervals,
+) error {
+	createObjFunction := func() bundle.Object { return &policyv1beta1.PolicySet{} }
+
+	bundleCollection := []*generic.BundleCollectionEntry{
+		generic.NewBundleCollectionEntry(fmt.Sprintf("%s.%s", leafHubName, constants.PolicySetMsgKey),
+			bundle.NewGenericStatusBundle(leafHubName, incarnation, cleanPolicySet),
+			func() bool { return true }),
+	} // bundle predicate - always send policy sets.
+
+	ownerRefAnnotationPredicate := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return helper.HasAnnotation(object, constants.OriginOwnerReferenceAnnotation)
+	})
+
+	if err := generic.NewGenericStatusSyncController(mgr, policySetSyncLog, transport, bundleCollection,
+		createObjFunction, ownerRefAnnotationPredicate, nil, syncIntervalsData,
+		syncintervals.PolicySets); err != nil {
+		return fmt.Errorf("failed to add policy sets controller to the manager - %w", err)
+	}
+
+	return nil
+}
+
+func cleanPolicySet(object bundle.Object) {
+	policySet, ok := object.(*policyv1beta1.PolicySet)
+	if !ok {
+		panic("Wrong instance passed to clean policy set function, not a policy set")
+	}
+	// clean spec. no need for it.
+	policySet.Spec = policyv1beta1.PolicySetSpec{}
+}
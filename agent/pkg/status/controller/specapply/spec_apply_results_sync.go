@@ -0,0 +1,119 @@
+package specapply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/bundle/specapply"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/status/controller/syncintervals"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/transport/producer"
+	statusbundle "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+const (
+	specApplyResultsLogName = "spec-apply-results"
+)
+
+// SpecApplyResultsController sends the results of applying the spec objects back to the global hub.
+type SpecApplyResultsController struct {
+	log                     logr.Logger
+	bundle                  *specapply.Bundle
+	lastSentBundleVersion   statusbundle.BundleVersion
+	transportBundleKey      string
+	transport               producer.Producer
+	resolveSyncIntervalFunc syncintervals.ResolveSyncIntervalFunc
+}
+
+// AddSpecApplyResultsController creates a new instance of spec apply results controller and adds it to the manager.
+// the results in the bundle are reported by the spec syncers.
+func AddSpecApplyResultsController(mgr ctrl.Manager, transport producer.Producer, leafHubName string,
+	specApplyResults *specapply.Bundle, syncIntervalsData *syncintervals.SyncIntervals,
+) error {
+	specApplyResultsCtrl := &SpecApplyResultsController{
+		log:                     ctrl.Log.WithName(specApplyResultsLogName),
+		bundle:                  specApplyResults,
+		lastSentBundleVersion:   *statusbundle.NewBundleVersion(specApplyResults.GetBundleVersion().Incarnation, 0),
+		transportBundleKey:      fmt.Sprintf("%s.%s", leafHubName, constants.SpecApplyResultsMsgKey),
+		transport:               transport,
+		resolveSyncIntervalFunc: syncIntervalsData.GetSpecApplyResults,
+	}
+
+	if err := mgr.Add(specApplyResultsCtrl); err != nil {
+		return fmt.Errorf("failed to add controller to the manager - %w", err)
+	}
+
+	return nil
+}
+
+// Start function starts spec apply results controller.
+func (c *SpecApplyResultsController) Start(ctx context.Context) error {
+	c.log.Info("Starting Controller")
+
+	go c.periodicSync(ctx)
+
+	<-ctx.Done() // blocking wait for stop event
+	c.log.Info("Stopping Controller")
+
+	return nil
+}
+
+func (c *SpecApplyResultsController) periodicSync(ctx context.Context) {
+	currentSyncInterval := c.resolveSyncIntervalFunc()
+	ticker := time.NewTicker(currentSyncInterval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+
+		case <-ticker.C: // wait for next time interval
+			c.syncBundle()
+
+			resolvedInterval := c.resolveSyncIntervalFunc()
+
+			// reset ticker if sync interval has changed
+			if resolvedInterval != currentSyncInterval {
+				currentSyncInterval = resolvedInterval
+				ticker.Reset(currentSyncInterval)
+				c.log.Info(fmt.Sprintf("sync interval has been reset to %s", currentSyncInterval.String()))
+			}
+		}
+	}
+}
+
+func (c *SpecApplyResultsController) syncBundle() {
+	bundleVersion := *c.bundle.GetBundleVersion()
+
+	// send to transport only if bundle has changed.
+	if !bundleVersion.NewerThan(&c.lastSentBundleVersion) {
+		return
+	}
+
+	payloadBytes, err := json.Marshal(c.bundle)
+	if err != nil {
+		c.log.Error(
+			fmt.Errorf("sync object from type %s with id %s - %w", constants.StatusBundle, c.transportBundleKey, err),
+			"failed to sync bundle")
+		return
+	}
+
+	if err := c.transport.SendAsync(&producer.Message{
+		Key:     c.transportBundleKey,
+		ID:      c.transportBundleKey,
+		MsgType: constants.StatusBundle,
+		Version: bundleVersion.String(),
+		Payload: payloadBytes,
+	}); err != nil { // the bundle is sent again on the next sync
+		c.log.Error(err, "failed to send bundle", "key", c.transportBundleKey)
+		return
+	}
+
+	c.lastSentBundleVersion = bundleVersion
+}
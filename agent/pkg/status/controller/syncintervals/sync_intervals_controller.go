@@ -22,6 +22,14 @@ const (
 	REQUEUE_PERIOD = 5 * time.Second
 )
 
+// syncIntervalKeys are the keys of the sync intervals in the configmap.
+var syncIntervalKeys = []string{
+	ManagedClusters, ManagedClusterAddOns, ClusterDeployments, ClusterPoolClaims, Policies, ComplianceDetails,
+	PolicySets, LocalPolicies, PlacementRules, Placements, PlacementDecisions, LocalPlacementRules, LocalPlacements,
+	LocalPlacementDecisions, SubscriptionStatuses, SubscriptionReports, ArgoApplications, ArgoApplicationSets, Events,
+	ControlInfo, SpecApplyResults,
+}
+
 type syncIntervalsController struct {
 	client            client.Client
 	log               logr.Logger
@@ -64,27 +72,40 @@ func (c *syncIntervalsController) Reconcile(ctx context.Context, request ctrl.Re
 			fmt.Errorf("reconciliation failed: %w", err)
 	}
 
-	c.setSyncInterval(configMap, "managed_clusters", &c.syncIntervalsData.managedClusters)
-	c.setSyncInterval(configMap, "policies", &c.syncIntervalsData.policies)
-	c.setSyncInterval(configMap, "control_info", &c.syncIntervalsData.controlInfo)
+	intervals := make(map[string]time.Duration)
+
+	for _, key := range syncIntervalKeys {
+		if interval, found := c.getDuration(configMap, key); found && interval > 0 {
+			intervals[key] = interval
+		}
+	}
+
+	debounce, _ := c.getDuration(configMap, Debounce) // the bundles are sent only periodically if it's not set
+
+	minSendInterval, found := c.getDuration(configMap, MinSendInterval)
+	if !found {
+		minSendInterval = DEFAULT_MIN_SEND_INTERVAL
+	}
+
+	c.syncIntervalsData.update(intervals, debounce, minSendInterval)
 
 	reqLogger.Info("Reconciliation complete.")
 
 	return ctrl.Result{}, nil
 }
 
-func (c *syncIntervalsController) setSyncInterval(configMap *v1.ConfigMap, key string, syncInterval *time.Duration) {
-	intervalStr, found := configMap.Data[key]
+// getDuration returns the duration of the key in the configmap, false if it's not set or has an invalid format.
+func (c *syncIntervalsController) getDuration(configMap *v1.ConfigMap, key string) (time.Duration, bool) {
+	durationStr, found := configMap.Data[key]
 	if !found {
-		c.log.Info(fmt.Sprintf("%s sync interval not defined, using %s", key, syncInterval.String()))
-		return
+		return 0, false
 	}
 
-	interval, err := time.ParseDuration(intervalStr)
-	if err != nil {
-		c.log.Info(fmt.Sprintf("%s sync interval has invalid format, using %s", key, syncInterval.String()))
-		return
+	duration, err := time.ParseDuration(durationStr)
+	if err != nil || duration < 0 {
+		c.log.Info(fmt.Sprintf("%s has invalid format, using the default", key))
+		return 0, false
 	}
 
-	*syncInterval = interval
+	return duration, true
 }
@@ -1,44 +1,179 @@
 package syncintervals
 
 import (
+	"sync"
 	"time"
 )
 
 const (
 	DEFAULT_STATUS_SYNC_INTERVAL       = 5 * time.Second
 	DEFAULT_CONTROL_INFO_SYNC_INTERVAL = 60 * time.Second
+	// DEFAULT_MIN_SEND_INTERVAL is the shortest time between two event-driven sends of the bundles of a controller.
+	DEFAULT_MIN_SEND_INTERVAL = 1 * time.Second
 )
 
+// the keys of the sync intervals in the sync intervals configmap.
+const (
+	ManagedClusters         = "managed_clusters"
+	ManagedClusterAddOns    = "managed_cluster_addons"
+	ClusterDeployments      = "cluster_deployments"
+	ClusterPoolClaims       = "cluster_pool_claims"
+	Policies                = "policies"
+	ComplianceDetails       = "compliance_details"
+	PolicySets              = "policy_sets"
+	LocalPolicies           = "local_policies"
+	PlacementRules          = "placement_rules"
+	Placements              = "placements"
+	PlacementDecisions      = "placement_decisions"
+	LocalPlacementRules     = "local_placement_rules"
+	LocalPlacements         = "local_placements"
+	LocalPlacementDecisions = "local_placement_decisions"
+	SubscriptionStatuses    = "subscription_statuses"
+	SubscriptionReports     = "subscription_reports"
+	ArgoApplications        = "argo_applications"
+	ArgoApplicationSets     = "argo_application_sets"
+	Events                  = "events"
+	ControlInfo             = "control_info"
+	SpecApplyResults        = "spec_apply_results"
+)
+
+// the keys of the event-driven sends in the sync intervals configmap.
+const (
+	// Debounce is the time the bundles are sent in after a change, the changes in this time are sent together.
+	// the bundles are sent only periodically if it's not set.
+	Debounce = "debounce"
+	// MinSendInterval is the shortest time between two event-driven sends of the bundles of a controller.
+	MinSendInterval = "min_send_interval"
+)
+
+// defaultSyncIntervals are the sync intervals of the keys that aren't set and have no fallback key.
+var defaultSyncIntervals = map[string]time.Duration{
+	ManagedClusters:  DEFAULT_STATUS_SYNC_INTERVAL,
+	Policies:         DEFAULT_STATUS_SYNC_INTERVAL,
+	ControlInfo:      DEFAULT_CONTROL_INFO_SYNC_INTERVAL,
+	SpecApplyResults: DEFAULT_STATUS_SYNC_INTERVAL,
+}
+
+// fallbackKeys is a map of key -> the key whose sync interval is used if the key isn't set, the bundle types used to
+// share the sync intervals of the managed clusters and the policies before they got their own keys.
+var fallbackKeys = map[string]string{
+	ManagedClusterAddOns:    ManagedClusters,
+	ClusterDeployments:      ManagedClusters,
+	ClusterPoolClaims:       ManagedClusters,
+	ComplianceDetails:       Policies,
+	PolicySets:              Policies,
+	LocalPolicies:           Policies,
+	PlacementRules:          Policies,
+	Placements:              Policies,
+	PlacementDecisions:      Policies,
+	LocalPlacementRules:     Policies,
+	LocalPlacements:         Policies,
+	LocalPlacementDecisions: Policies,
+	SubscriptionStatuses:    Policies,
+	SubscriptionReports:     Policies,
+	ArgoApplications:        Policies,
+	ArgoApplicationSets:     Policies,
+	Events:                  Policies,
+}
+
 // ResolveSyncIntervalFunc is a function for resolving corresponding sync interval from SyncIntervals data structure.
 type ResolveSyncIntervalFunc func() time.Duration
 
 // SyncIntervals holds periodic sync intervals.
 type SyncIntervals struct {
-	managedClusters time.Duration
-	policies        time.Duration
-	controlInfo     time.Duration
+	// intervals holds the sync intervals that are set in the configmap.
+	intervals       map[string]time.Duration
+	debounce        time.Duration
+	minSendInterval time.Duration
+	lock            sync.RWMutex
 }
 
 // NewSyncIntervals returns new HohConfigMapData object initialized with default periodic sync intervals.
 func NewSyncIntervals() *SyncIntervals {
 	return &SyncIntervals{
-		managedClusters: DEFAULT_STATUS_SYNC_INTERVAL,
-		policies:        DEFAULT_STATUS_SYNC_INTERVAL,
-		controlInfo:     DEFAULT_CONTROL_INFO_SYNC_INTERVAL,
+		intervals:       map[string]time.Duration{},
+		debounce:        0,
+		minSendInterval: DEFAULT_MIN_SEND_INTERVAL,
+		lock:            sync.RWMutex{},
+	}
+}
+
+// Resolver returns the function resolving the sync interval of the key, the sync interval of the fallback key is
+// used if the key isn't set.
+func (syncIntervals *SyncIntervals) Resolver(key string) ResolveSyncIntervalFunc {
+	return func() time.Duration {
+		return syncIntervals.getSyncInterval(key)
 	}
 }
 
+func (syncIntervals *SyncIntervals) getSyncInterval(key string) time.Duration {
+	syncIntervals.lock.RLock()
+	defer syncIntervals.lock.RUnlock()
+
+	for {
+		if interval, found := syncIntervals.intervals[key]; found {
+			return interval
+		}
+
+		fallbackKey, found := fallbackKeys[key]
+		if !found {
+			break
+		}
+
+		key = fallbackKey
+	}
+
+	if interval, found := defaultSyncIntervals[key]; found {
+		return interval
+	}
+
+	return DEFAULT_STATUS_SYNC_INTERVAL
+}
+
 // GetManagerClusters returns managed clusters sync interval.
 func (syncIntervals *SyncIntervals) GetManagerClusters() time.Duration {
-	return syncIntervals.managedClusters
+	return syncIntervals.getSyncInterval(ManagedClusters)
 }
 
 // GetPolicies returns policies sync interval.
 func (syncIntervals *SyncIntervals) GetPolicies() time.Duration {
-	return syncIntervals.policies
+	return syncIntervals.getSyncInterval(Policies)
 }
 
 // GetControlInfo returns control info sync interval.
 func (syncIntervals *SyncIntervals) GetControlInfo() time.Duration {
-	return syncIntervals.controlInfo
+	return syncIntervals.getSyncInterval(ControlInfo)
+}
+
+// GetSpecApplyResults returns spec apply results sync interval.
+func (syncIntervals *SyncIntervals) GetSpecApplyResults() time.Duration {
+	return syncIntervals.getSyncInterval(SpecApplyResults)
+}
+
+// GetDebounce returns the time the bundles are sent in after a change, zero if the bundles are sent only
+// periodically.
+func (syncIntervals *SyncIntervals) GetDebounce() time.Duration {
+	syncIntervals.lock.RLock()
+	defer syncIntervals.lock.RUnlock()
+
+	return syncIntervals.debounce
+}
+
+// GetMinSendInterval returns the shortest time between two event-driven sends of the bundles of a controller.
+func (syncIntervals *SyncIntervals) GetMinSendInterval() time.Duration {
+	syncIntervals.lock.RLock()
+	defer syncIntervals.lock.RUnlock()
+
+	return syncIntervals.minSendInterval
+}
+
+func (syncIntervals *SyncIntervals) update(intervals map[string]time.Duration, debounce time.Duration,
+	minSendInterval time.Duration,
+) {
+	syncIntervals.lock.Lock()
+	defer syncIntervals.lock.Unlock()
+
+	syncIntervals.intervals = intervals
+	syncIntervals.debounce = debounce
+	syncIntervals.minSendInterval = minSendInterval
 }
@@ -0,0 +1,28 @@
+package syncintervals
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolver(t *testing.T) {
+	syncIntervals := NewSyncIntervals()
+
+	if interval := syncIntervals.Resolver(SubscriptionStatuses)(); interval != DEFAULT_STATUS_SYNC_INTERVAL {
+		t.Errorf("expected the default sync interval, got %s", interval)
+	}
+
+	syncIntervals.update(map[string]time.Duration{Policies: 10 * time.Second, SubscriptionReports: time.Minute},
+		0, DEFAULT_MIN_SEND_INTERVAL)
+
+	for key, expectedInterval := range map[string]time.Duration{
+		SubscriptionStatuses: 10 * time.Second, // falls back to the policies
+		SubscriptionReports:  time.Minute,
+		ManagedClusterAddOns: DEFAULT_STATUS_SYNC_INTERVAL,
+		ControlInfo:          DEFAULT_CONTROL_INFO_SYNC_INTERVAL,
+	} {
+		if interval := syncIntervals.Resolver(key)(); interval != expectedInterval {
+			t.Errorf("expected the sync interval of %s to be %s, got %s", key, expectedInterval, interval)
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 	bundle "github.com/stolostron/multicluster-global-hub/agent/pkg/spec/bundle"
 	"github.com/stolostron/multicluster-global-hub/agent/pkg/transport"
 	"github.com/stolostron/multicluster-global-hub/pkg/compressor"
+	"github.com/stolostron/multicluster-global-hub/pkg/encryptor"
 	"github.com/stolostron/multicluster-global-hub/pkg/kafka/headers"
 	kafkaconsumer "github.com/stolostron/multicluster-global-hub/pkg/kafka/kafka-consumer"
 )
@@ -24,7 +25,9 @@ type KafkaComsumer struct {
 	leafHubName    string
 	kafkaConsumer  *kafkaconsumer.KafkaConsumer
 	compressorsMap map[compressor.CompressionType]compressor.Compressor
-	topic          string
+	// keyStore holds the keys to decrypt the messages with, the messages aren't encrypted if it's nil.
+	keyStore *encryptor.KeyStore
+	topic    string
 
 	// messageChan get the message from kafka and put it to the genericBundleChan
 	messageChan                     chan *kafka.Message
@@ -52,6 +55,13 @@ func NewKafkaConsumer(log logr.Logger, environmentManager *helper.ConfigManager,
 		return nil, fmt.Errorf("failed to get kafka configMap: %w", err)
 	}
 
+	var keyStore *encryptor.KeyStore
+	if environmentManager.TransportEncryptionKeysPath != "" {
+		if keyStore, err = encryptor.NewKeyStore(environmentManager.TransportEncryptionKeysPath); err != nil {
+			return nil, fmt.Errorf("failed to load transport encryption keys: %w", err)
+		}
+	}
+
 	messageChan := make(chan *kafka.Message)
 	kafkaConsumer, err := kafkaconsumer.NewKafkaConsumer(kafkaConfigMap, messageChan, log)
 	if err != nil {
@@ -72,6 +82,7 @@ func NewKafkaConsumer(log logr.Logger, environmentManager *helper.ConfigManager,
 		leafHubName:                     leafHubName,
 		kafkaConsumer:                   kafkaConsumer,
 		compressorsMap:                  make(map[compressor.CompressionType]compressor.Compressor),
+		keyStore:                        keyStore,
 		topic:                           topic,
 		messageChan:                     messageChan,
 		genericBundlesChan:              genericBundlesChan,
@@ -130,7 +141,22 @@ func (c *KafkaComsumer) processMessage(message *kafka.Message) {
 		return
 	}
 
-	decompressedPayload, err := c.decompressPayload(message.Value,
+	payload := message.Value
+
+	if envelopeBytes, found := c.lookupHeaderValue(message, headers.Encryption); found {
+		decryptedPayload, err := c.decryptPayload(payload, envelopeBytes)
+		if err != nil {
+			c.logError(err, "failed to decrypt bundle bytes", message)
+			return
+		}
+
+		payload = decryptedPayload
+	} else if c.keyStore != nil { // the spec of the global hub is always encrypted once the keys are provisioned
+		c.logError(errors.New("message isn't encrypted"), "dropping bundle", message)
+		return
+	}
+
+	decompressedPayload, err := c.decompressPayload(payload,
 		compressor.CompressionType(compressionTypeBytes))
 	if err != nil {
 		c.logError(err, "failed to decompress bundle bytes", message)
@@ -145,7 +171,7 @@ func (c *KafkaComsumer) processMessage(message *kafka.Message) {
 
 	customBundleRegistration, found := c.customBundleIDToRegistrationMap[transportMessage.ID]
 	if !found { // received generic bundle
-		if err := c.syncGenericBundle(transportMessage.Payload); err != nil {
+		if err := c.syncGenericBundle(transportMessage.ID, transportMessage.Payload); err != nil {
 			c.log.Error(err, "failed to parse bundle", "MessageID", transportMessage.ID,
 				"MessageType", transportMessage.MsgType, "Version", transportMessage.Version)
 		}
@@ -158,11 +184,12 @@ func (c *KafkaComsumer) processMessage(message *kafka.Message) {
 	}
 }
 
-func (c *KafkaComsumer) syncGenericBundle(payload []byte) error {
+func (c *KafkaComsumer) syncGenericBundle(msgID string, payload []byte) error {
 	receivedBundle := bundle.NewGenericBundle()
 	if err := json.Unmarshal(payload, receivedBundle); err != nil {
 		return fmt.Errorf("failed to parse bundle - %w", err)
 	}
+	receivedBundle.ID = msgID
 	c.genericBundlesChan <- receivedBundle
 	return nil
 }
@@ -203,6 +230,21 @@ func (c *KafkaComsumer) decompressPayload(payload []byte, compressionType compre
 	return decompressedBytes, nil
 }
 
+func (c *KafkaComsumer) decryptPayload(payload []byte, envelopeBytes []byte) ([]byte, error) {
+	if c.keyStore == nil {
+		return nil, fmt.Errorf("%w: transport encryption keys aren't configured", encryptor.ErrKeyNotFound)
+	}
+
+	envelope := &encryptor.Envelope{}
+	if err := json.Unmarshal(envelopeBytes, envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse encryption envelope: %w", err)
+	}
+
+	return encryptor.Open(payload, envelope, c.leafHubName, func(keyID string) ([]byte, bool) {
+		return c.keyStore.GetKey(c.leafHubName, keyID)
+	})
+}
+
 func (c *KafkaComsumer) lookupHeaderValue(message *kafka.Message, headerKey string) ([]byte, bool) {
 	for _, header := range message.Headers {
 		if header.Key == headerKey {
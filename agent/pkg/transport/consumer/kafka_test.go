@@ -0,0 +1,131 @@
+package consumer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/go-logr/logr"
+
+	bundle "github.com/stolostron/multicluster-global-hub/agent/pkg/spec/bundle"
+	"github.com/stolostron/multicluster-global-hub/agent/pkg/transport"
+	"github.com/stolostron/multicluster-global-hub/pkg/compressor"
+	"github.com/stolostron/multicluster-global-hub/pkg/encryptor"
+	"github.com/stolostron/multicluster-global-hub/pkg/kafka/headers"
+)
+
+func TestProcessMessageEncryption(t *testing.T) {
+	keyID := encryptor.NewKeyID(time.Now())
+	hub1Key := writeTestKey(t, t.TempDir(), "hub1", keyID)
+	hub2Dir := t.TempDir()
+	hub2Key := writeTestKey(t, hub2Dir, "hub2", keyID)
+
+	keyStore, err := encryptor.NewKeyStore(hub2Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gzipCompressor, err := compressor.NewCompressor(compressor.GZip)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msgBytes, err := json.Marshal(&transport.Message{ID: "Policies", MsgType: "spec", Version: "1.1",
+		Payload: []byte(`{"bundleVersion":"1.1"}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	compressedBytes, err := gzipCompressor.Compress(msgBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newMessage := func(keys map[string]*encryptor.Key) *kafka.Message {
+		message := &kafka.Message{
+			Value:   compressedBytes,
+			Headers: []kafka.Header{{Key: headers.CompressionType, Value: []byte(gzipCompressor.GetType())}},
+		}
+
+		if keys == nil {
+			return message
+		}
+
+		encryptedBytes, envelope, err := encryptor.Seal(compressedBytes, keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		envelopeBytes, err := json.Marshal(envelope)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		message.Value = encryptedBytes
+		message.Headers = append(message.Headers, kafka.Header{Key: headers.Encryption, Value: envelopeBytes})
+
+		return message
+	}
+
+	cases := []struct {
+		name     string
+		keyStore *encryptor.KeyStore
+		message  *kafka.Message
+		received bool
+	}{
+		{"encrypted for the hub", keyStore, newMessage(map[string]*encryptor.Key{"hub2": hub2Key}), true},
+		{"broadcasted", keyStore, newMessage(map[string]*encryptor.Key{"hub1": hub1Key, "hub2": hub2Key}), true},
+		{"encrypted for another hub", keyStore, newMessage(map[string]*encryptor.Key{"hub1": hub1Key}), false},
+		{"not encrypted", keyStore, newMessage(nil), false},
+		{"not encrypted without keys", nil, newMessage(nil), true},
+		{"encrypted without keys", nil, newMessage(map[string]*encryptor.Key{"hub2": hub2Key}), false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			consumer := &KafkaComsumer{
+				log:                             logr.Discard(),
+				leafHubName:                     "hub2",
+				compressorsMap:                  make(map[compressor.CompressionType]compressor.Compressor),
+				keyStore:                        c.keyStore,
+				genericBundlesChan:              make(chan *bundle.GenericBundle, 1),
+				customBundleIDToRegistrationMap: make(map[string]*bundle.CustomBundleRegistration),
+			}
+
+			consumer.processMessage(c.message)
+
+			select {
+			case receivedBundle := <-consumer.genericBundlesChan:
+				if !c.received {
+					t.Fatal("expected the message to be dropped")
+				}
+
+				if receivedBundle.ID != "Policies" || receivedBundle.BundleVersion != "1.1" {
+					t.Errorf("unexpected bundle %+v", receivedBundle)
+				}
+			default:
+				if c.received {
+					t.Fatal("expected the bundle to be received")
+				}
+			}
+		})
+	}
+}
+
+func writeTestKey(t *testing.T, dir, hubName, keyID string) *encryptor.Key {
+	t.Helper()
+
+	keyBytes, err := encryptor.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, encryptor.KeyName(hubName, keyID)), keyBytes, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return &encryptor.Key{ID: keyID, Bytes: keyBytes}
+}
@@ -120,7 +120,7 @@ func (s *SyncService) handleBundle(objectMetaData *client.ObjectMetaData) error
 
 	customBundleRegistration, found := s.customBundleIDToRegistrationMap[msgID]
 	if !found { // received generic bundle
-		if err := s.syncGenericBundle(decompressedPayload); err != nil {
+		if err := s.syncGenericBundle(msgID, decompressedPayload); err != nil {
 			return fmt.Errorf("failed to sync generic bundle - %w", err)
 		}
 	} else {
@@ -136,11 +136,12 @@ func (s *SyncService) handleBundle(objectMetaData *client.ObjectMetaData) error
 	return nil
 }
 
-func (s *SyncService) syncGenericBundle(payload []byte) error {
+func (s *SyncService) syncGenericBundle(msgID string, payload []byte) error {
 	receivedBundle := bundle.NewGenericBundle()
 	if err := json.Unmarshal(payload, receivedBundle); err != nil {
 		return fmt.Errorf("failed to parse bundle - %w", err)
 	}
+	receivedBundle.ID = msgID
 
 	s.genericBundlesChan <- receivedBundle
 
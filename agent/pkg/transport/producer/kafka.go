@@ -117,21 +117,17 @@ func (p *KafkaProducer) SupportsDeltaBundles() bool {
 }
 
 // SendAsync sends a message to the sync service asynchronously.
-func (p *KafkaProducer) SendAsync(msg *Message) {
+func (p *KafkaProducer) SendAsync(msg *Message) error {
 	msgBytes, err := json.Marshal(msg)
 	if err != nil {
-		p.log.Error(err, "failed to send message", "MessageId", msg.ID, "MessageType", msg.MsgType,
-			"Version", msg.Version)
-
-		return
+		InvokeCallback(p.eventSubscriptionMap, msg.ID, DeliveryFailure)
+		return fmt.Errorf("failed to marshal message - %w", err)
 	}
 
 	compressedBytes, err := p.compressor.Compress(msgBytes)
 	if err != nil {
-		p.log.Error(err, "failed to compress bundle", "CompressorType", p.compressor.GetType(),
-			"MessageId", msg.ID, "MessageType", msg.MsgType, "Version", msg.Version)
-
-		return
+		InvokeCallback(p.eventSubscriptionMap, msg.ID, DeliveryFailure)
+		return fmt.Errorf("failed to compress bundle with %s - %w", p.compressor.GetType(), err)
 	}
 
 	messageHeaders := []kafka.Header{
@@ -139,12 +135,11 @@ func (p *KafkaProducer) SendAsync(msg *Message) {
 	}
 
 	if err = p.kafkaProducer.ProduceAsync(msg.Key, p.topic, partition, messageHeaders, compressedBytes); err != nil {
-		p.log.Error(err, "failed to send message", "MessageKey", msg.Key, "MessageId", msg.ID,
-			"MessageType", msg.MsgType, "Version", msg.Version)
-		InvokeCallback(p.eventSubscriptionMap, string(msg.ID), DeliveryFailure)
-
-		return
+		InvokeCallback(p.eventSubscriptionMap, msg.ID, DeliveryFailure)
+		return fmt.Errorf("failed to send message with key %s - %w", msg.Key, err)
 	}
-	InvokeCallback(p.eventSubscriptionMap, string(msg.ID), DeliveryAttempt)
+	InvokeCallback(p.eventSubscriptionMap, msg.ID, DeliveryAttempt)
 	p.log.Info("Message sent successfully", "MessageId", msg.ID, "MessageType", msg.MsgType, "Version", msg.Version)
+
+	return nil
 }
@@ -1,8 +1,9 @@
 package producer
 
 type Producer interface {
-	// SendAsync sends a message to the transport component asynchronously.
-	SendAsync(message *Message)
+	// SendAsync sends a message to the transport component asynchronously. returns an error if the message can't be
+	// sent, so the message is sent again.
+	SendAsync(message *Message) error
 	// Subscribe adds a callback to be delegated when a given event occurs for a message with the given ID.
 	Subscribe(messageID string, callbacks map[EventType]EventCallback)
 	// Start starts the transport.
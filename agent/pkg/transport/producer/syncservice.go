@@ -76,8 +76,10 @@ func (s *SyncServiceProducer) SupportsDeltaBundles() bool {
 }
 
 // SendAsync function sends a message to the sync service asynchronously.
-func (s *SyncServiceProducer) SendAsync(message *Message) {
+func (s *SyncServiceProducer) SendAsync(message *Message) error {
 	s.msgChan <- message
+
+	return nil
 }
 
 func (s *SyncServiceProducer) sendMessages() {
@@ -0,0 +1,93 @@
+// Copyright (c) 2022 Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/operator-framework/operator-sdk/pkg/log/zap"
+	"github.com/spf13/pflag"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/backup"
+)
+
+const (
+	backupCommand  = "backup"
+	restoreCommand = "restore"
+)
+
+func isBackupCommand(args []string) bool {
+	return len(args) > 1 && (args[1] == backupCommand || args[1] == restoreCommand)
+}
+
+func parseBackupFlags(command string, args []string) (*backup.Config, error) {
+	backupConfig := &backup.Config{}
+
+	flagSet := pflag.NewFlagSet(command, pflag.ContinueOnError)
+	flagSet.StringVar(&backupConfig.DatabaseURL, "database-url", "",
+		"The URL of database server, the user must own the tables of the global hub schemas.")
+	flagSet.StringVar(&backupConfig.Location, "location", "",
+		"The path of the archive, a directory to create the archive in, or a http(s) URL of the archive object.")
+
+	// add flags for logger
+	flagSet.AddFlagSet(zap.FlagSet())
+	flagSet.AddGoFlagSet(flag.CommandLine)
+
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if backupConfig.DatabaseURL == "" {
+		return nil, fmt.Errorf("database url: %w", errFlagParameterEmpty)
+	}
+
+	if backupConfig.Location == "" {
+		return nil, fmt.Errorf("location: %w", errFlagParameterEmpty)
+	}
+
+	return backupConfig, nil
+}
+
+// doBackup runs the backup or restore command with the given arguments.
+func doBackup(command string, args []string) int {
+	log := initializeLogger()
+
+	backupConfig, err := parseBackupFlags(command, args)
+	if err != nil {
+		log.Error(err, "flags parse error")
+		return 1
+	}
+
+	kubeClient, err := client.New(ctrl.GetConfigOrDie(), client.Options{})
+	if err != nil {
+		log.Error(err, initializationFailMsg, initializationFailKey, "kubernetes client")
+		return 1
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+
+	if command == backupCommand {
+		location, err := backup.Backup(ctx, log.WithName(backupCommand), backupConfig, kubeClient)
+		if err != nil {
+			log.Error(err, "backup failed")
+			return 1
+		}
+
+		log.Info("backup completed", "location", location)
+
+		return 0
+	}
+
+	if err := backup.Restore(ctx, log.WithName(restoreCommand), backupConfig, kubeClient); err != nil {
+		log.Error(err, "restore failed")
+		return 1
+	}
+
+	log.Info("restore completed", "location", backupConfig.Location)
+
+	return 0
+}
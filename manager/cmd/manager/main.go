@@ -38,6 +38,8 @@ import (
 	statuskafka "github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/transport/kafka"
 	statussyncservice "github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/transport/syncservice"
 	"github.com/stolostron/multicluster-global-hub/pkg/compressor"
+	"github.com/stolostron/multicluster-global-hub/pkg/encryptor"
+	kafkaclient "github.com/stolostron/multicluster-global-hub/pkg/kafka"
 )
 
 const (
@@ -72,6 +74,7 @@ type syncerConfig struct {
 	specSyncInterval              time.Duration
 	statusSyncInterval            time.Duration
 	deletedLabelsTrimmingInterval time.Duration
+	eventsRetention               time.Duration
 }
 
 type databaseConfig struct {
@@ -83,11 +86,14 @@ type transportCommonConfig struct {
 	transportType      string
 	msgCompressionType string
 	committerInterval  time.Duration
+	encryptionKeysPath string
 }
 
 type kafkaConfig struct {
 	bootstrapServer string
 	SslCa           string
+	saslUser        string
+	saslPassword    string
 	producerConfig  *speckafka.KafkaProducerConfig
 	consumerConfig  *statuskafka.KafkaConsumerConfig
 }
@@ -116,6 +122,8 @@ func parseFlags() (*hohManagerConfig, error) {
 		"The synchronization interval of resources in status.")
 	pflag.DurationVar(&managerConfig.syncerConfig.deletedLabelsTrimmingInterval, "deleted-labels-trimming-interval",
 		5*time.Second, "The trimming interval of deleted labels.")
+	pflag.DurationVar(&managerConfig.syncerConfig.eventsRetention, "events-retention", 7*24*time.Hour,
+		"The retention of the events of the regional hubs, at least 24h.")
 	pflag.StringVar(&managerConfig.databaseConfig.processDatabaseURL, "process-database-url", "",
 		"The URL of database server for the process user.")
 	pflag.StringVar(&managerConfig.databaseConfig.transportBridgeDatabaseURL,
@@ -124,11 +132,16 @@ func parseFlags() (*hohManagerConfig, error) {
 		"The transport type, 'kafka' or 'sync-service'.")
 	pflag.StringVar(&managerConfig.transportCommonConfig.msgCompressionType, "transport-message-compression-type",
 		"gzip", "The message compression type for transport layer, 'gzip' or 'no-op'.")
+	pflag.StringVar(&managerConfig.transportCommonConfig.encryptionKeysPath, "transport-encryption-keys-path", "",
+		"The directory of the keys of the regional hubs to encrypt the spec messages with, empty disables encryption.")
 	pflag.DurationVar(&managerConfig.transportCommonConfig.committerInterval, "transport-committer-interval",
 		40*time.Second, "The committer interval for transport layer.")
 	pflag.StringVar(&managerConfig.kafkaConfig.bootstrapServer, "kafka-bootstrap-server",
 		"kafka-brokers-cluster-kafka-bootstrap.kafka.svc:9092", "The bootstrap server for kafka.")
 	pflag.StringVar(&managerConfig.kafkaConfig.SslCa, "kafka-ssl-ca", "", "The CA for kafka bootstrap server.")
+	pflag.StringVar(&managerConfig.kafkaConfig.saslUser, "kafka-sasl-user", "",
+		"The SCRAM user to authenticate to kafka with, empty disables the SASL authentication. the password is "+
+			"read from the "+kafkaclient.SASLPasswordEnvVar+" environment variable.")
 	pflag.StringVar(&managerConfig.kafkaConfig.producerConfig.ProducerID, "kakfa-producer-id",
 		"multicluster-global-hub", "ID for the kafka producer.")
 	pflag.StringVar(&managerConfig.kafkaConfig.producerConfig.ProducerTopic, "kakfa-producer-topic",
@@ -179,6 +192,13 @@ func parseFlags() (*hohManagerConfig, error) {
 			speckafka.MaxMessageSizeLimit, "kafka-message-size-limit")
 	}
 
+	// the producer and the consumer authenticate as the same kafka user
+	managerConfig.kafkaConfig.saslPassword = os.Getenv(kafkaclient.SASLPasswordEnvVar)
+	managerConfig.kafkaConfig.producerConfig.SaslUser = managerConfig.kafkaConfig.saslUser
+	managerConfig.kafkaConfig.producerConfig.SaslPassword = managerConfig.kafkaConfig.saslPassword
+	managerConfig.kafkaConfig.consumerConfig.SaslUser = managerConfig.kafkaConfig.saslUser
+	managerConfig.kafkaConfig.consumerConfig.SaslPassword = managerConfig.kafkaConfig.saslPassword
+
 	return managerConfig, nil
 }
 
@@ -227,7 +247,14 @@ func getSpecTransport(transportCommonConfig *transportCommonConfig, kafkaBootstr
 
 	switch transportCommonConfig.transportType {
 	case kafkaTransportTypeName:
-		kafkaProducer, err := speckafka.NewProducer(msgCompressor, kafkaBootstrapServer, kafkaCA,
+		var keyStore *encryptor.KeyStore
+		if transportCommonConfig.encryptionKeysPath != "" {
+			if keyStore, err = encryptor.NewKeyStore(transportCommonConfig.encryptionKeysPath); err != nil {
+				return nil, fmt.Errorf("failed to load transport encryption keys: %w", err)
+			}
+		}
+
+		kafkaProducer, err := speckafka.NewProducer(msgCompressor, keyStore, kafkaBootstrapServer, kafkaCA,
 			kafkaProducerConfig, ctrl.Log.WithName("kafka-producer"))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create kafka-producer: %w", err)
@@ -324,8 +351,8 @@ func createManager(managerConfig *hohManagerConfig, processPostgreSQL,
 		return nil, fmt.Errorf("failed to add spec-to-db controllers: %w", err)
 	}
 
-	if err := specsyncer.AddDB2TransportSyncers(mgr, transportBridgePostgreSQL, specTransportObj,
-		managerConfig.syncerConfig.specSyncInterval); err != nil {
+	if err := specsyncer.AddDB2TransportSyncers(mgr, transportBridgePostgreSQL, transportBridgePostgreSQL,
+		specTransportObj, managerConfig.syncerConfig.specSyncInterval); err != nil {
 		return nil, fmt.Errorf("failed to add db-to-transport syncers: %w", err)
 	}
 
@@ -348,6 +375,11 @@ func createManager(managerConfig *hohManagerConfig, processPostgreSQL,
 		return nil, fmt.Errorf("failed to add transport-to-db syncers: %w", err)
 	}
 
+	if err := statussyncer.AddEventsRetention(mgr, workersPool.GetDB(),
+		managerConfig.syncerConfig.eventsRetention); err != nil {
+		return nil, fmt.Errorf("failed to add events retention: %w", err)
+	}
+
 	return mgr, nil
 }
 
@@ -450,5 +482,9 @@ func doMain() int {
 }
 
 func main() {
+	if isBackupCommand(os.Args) {
+		os.Exit(doBackup(os.Args[1], os.Args[2:]))
+	}
+
 	os.Exit(doMain())
 }
@@ -0,0 +1,293 @@
+// Copyright (c) 2022 Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/jackc/pgx/v4"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	archiveVersion    = "v1"
+	manifestEntryName = "manifest.json"
+	databaseEntryDir  = "database"
+	configEntryName   = "multiclusterglobalhubs.yaml"
+	csvExtension      = ".csv"
+)
+
+var (
+	// backupSchemas are the database schemas included in the backup.
+	backupSchemas = []string{"history", "local_spec", "local_status", "spec", "status"}
+
+	errInvalidArchive = errors.New("invalid backup archive")
+)
+
+// Config is the configuration of the backup and restore.
+type Config struct {
+	// DatabaseURL is the url of the global hub database, the user must own the backed up tables.
+	DatabaseURL string
+	// Location is the path of the archive or of a directory on the local filesystem, or a http(s) url of an object.
+	Location string
+}
+
+// manifest is the first entry of the backup archive, it describes the content of the archive.
+type manifest struct {
+	Version   string    `json:"version"`
+	Timestamp time.Time `json:"timestamp"`
+	Tables    []string  `json:"tables"`
+}
+
+// Backup writes the tables of the global hub database schemas and the MulticlusterGlobalHub config into a gzipped
+// tar archive in the location. the tables are read in a single read only transaction to get a consistent snapshot.
+// returns the resolved location of the archive.
+func Backup(ctx context.Context, log logr.Logger, config *Config, kubeClient client.Client) (string, error) {
+	conn, err := pgx.Connect(ctx, config.DatabaseURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to database - %w", err)
+	}
+	defer conn.Close(ctx)
+
+	tx, err := conn.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction - %w", err)
+	}
+	defer tx.Rollback(ctx) // read only, nothing to commit
+
+	tables, err := listTables(ctx, tx)
+	if err != nil {
+		return "", err
+	}
+
+	configData, err := backupConfig(ctx, log, kubeClient)
+	if err != nil {
+		return "", err
+	}
+
+	timestamp := time.Now()
+	writer, location, err := newLocationWriter(ctx, config.Location, timestamp)
+	if err != nil {
+		return "", err
+	}
+
+	if err := writeArchive(ctx, log, tx, writer, &manifest{
+		Version:   archiveVersion,
+		Timestamp: timestamp,
+		Tables:    tables,
+	}, configData); err != nil {
+		writer.Close()
+		return "", err
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	return location, nil
+}
+
+func writeArchive(ctx context.Context, log logr.Logger, tx pgx.Tx, writer io.Writer, archiveManifest *manifest,
+	configData []byte,
+) error {
+	gzipWriter := gzip.NewWriter(writer)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	manifestData, err := json.Marshal(archiveManifest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest - %w", err)
+	}
+
+	if err := writeEntry(tarWriter, manifestEntryName, manifestData, archiveManifest.Timestamp); err != nil {
+		return err
+	}
+
+	for _, table := range archiveManifest.Tables {
+		size, err := writeTableEntry(ctx, tx, tarWriter, table, archiveManifest.Timestamp)
+		if err != nil {
+			return err
+		}
+		log.Info("table backed up", "table", table, "size", size)
+	}
+
+	if configData != nil {
+		if err := writeEntry(tarWriter, configEntryName, configData, archiveManifest.Timestamp); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("failed to close archive - %w", err)
+	}
+
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("failed to close archive - %w", err)
+	}
+
+	return nil
+}
+
+func writeEntry(tarWriter *tar.Writer, name string, data []byte, timestamp time.Time) error {
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    int64(len(data)),
+		ModTime: timestamp,
+	}); err != nil {
+		return fmt.Errorf("failed to write archive entry %s - %w", name, err)
+	}
+
+	if _, err := tarWriter.Write(data); err != nil {
+		return fmt.Errorf("failed to write archive entry %s - %w", name, err)
+	}
+
+	return nil
+}
+
+// Restore truncates the backed up tables and loads them from the archive in the location in a single transaction,
+// then it restores the MulticlusterGlobalHub config. the spec tables are marked as updated and a resync is
+// requested, so the spec is republished to the regional hubs and they resend their complete status.
+func Restore(ctx context.Context, log logr.Logger, config *Config, kubeClient client.Client) error {
+	reader, err := newLocationReader(ctx, config.Location)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return fmt.Errorf("%w - %v", errInvalidArchive, err)
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+
+	archiveManifest, err := readManifest(tarReader)
+	if err != nil {
+		return err
+	}
+
+	log.Info("restoring backup", "version", archiveManifest.Version, "timestamp", archiveManifest.Timestamp)
+
+	conn, err := pgx.Connect(ctx, config.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database - %w", err)
+	}
+	defer conn.Close(ctx)
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction - %w", err)
+	}
+	defer tx.Rollback(ctx) // no-op if the transaction is committed
+
+	// the archives taken before the tables were excluded may still include them
+	restoredTables := make([]string, 0, len(archiveManifest.Tables))
+	for _, table := range archiveManifest.Tables {
+		if !contains(excludedTables, table) {
+			restoredTables = append(restoredTables, table)
+		}
+	}
+
+	if err := truncateTables(ctx, tx, restoredTables); err != nil {
+		return err
+	}
+
+	var configData []byte
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("%w - %v", errInvalidArchive, err)
+		}
+
+		switch {
+		case header.Name == configEntryName:
+			if configData, err = io.ReadAll(tarReader); err != nil {
+				return fmt.Errorf("failed to read archive entry %s - %w", header.Name, err)
+			}
+		case path.Dir(header.Name) == databaseEntryDir && strings.HasSuffix(header.Name, csvExtension):
+			table := strings.TrimSuffix(path.Base(header.Name), csvExtension)
+			if !contains(archiveManifest.Tables, table) {
+				return fmt.Errorf("%w - table %s is not in the manifest", errInvalidArchive, table)
+			}
+
+			if !contains(restoredTables, table) {
+				log.Info("skip excluded table", "table", table)
+				continue
+			}
+
+			if err := copyTableFrom(ctx, tx, table, tarReader); err != nil {
+				return err
+			}
+			log.Info("table restored", "table", table)
+		default:
+			log.Info("skip unknown archive entry", "name", header.Name)
+		}
+	}
+
+	if err := requestResync(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit restore - %w", err)
+	}
+
+	if configData == nil {
+		log.Info("skip the config restore, the archive doesn't contain the config")
+		return nil
+	}
+
+	return restoreConfig(ctx, log, kubeClient, configData)
+}
+
+func readManifest(tarReader *tar.Reader) (*manifest, error) {
+	header, err := tarReader.Next()
+	if err != nil {
+		return nil, fmt.Errorf("%w - %v", errInvalidArchive, err)
+	}
+
+	if header.Name != manifestEntryName {
+		return nil, fmt.Errorf("%w - the first entry is %s instead of %s", errInvalidArchive, header.Name,
+			manifestEntryName)
+	}
+
+	archiveManifest := &manifest{}
+	if err := json.NewDecoder(tarReader).Decode(archiveManifest); err != nil {
+		return nil, fmt.Errorf("%w - failed to parse manifest - %v", errInvalidArchive, err)
+	}
+
+	if archiveManifest.Version != archiveVersion {
+		return nil, fmt.Errorf("%w - unsupported version %s", errInvalidArchive, archiveManifest.Version)
+	}
+
+	return archiveManifest, nil
+}
+
+func tableEntryName(table string) string {
+	return path.Join(databaseEntryDir, table+csvExtension)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2022 Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArchiveManifestRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	timestamp := time.Date(2022, 9, 1, 10, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+
+	writer, location, err := newLocationWriter(ctx, dir, timestamp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := filepath.Join(dir, "multicluster-global-hub-backup-20220901100000.tar.gz"); location != expected {
+		t.Fatalf("expected location %s, got %s", expected, location)
+	}
+
+	expectedManifest := &manifest{
+		Version:   archiveVersion,
+		Timestamp: timestamp,
+		Tables:    []string{"spec.policies", "status.compliance"},
+	}
+
+	tarWriter := tar.NewWriter(writer)
+	manifestData, err := json.Marshal(expectedManifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeEntry(tarWriter, manifestEntryName, manifestData, timestamp); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := newLocationReader(ctx, location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	archiveManifest, err := readManifest(tar.NewReader(reader))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(archiveManifest, expectedManifest) {
+		t.Fatalf("expected manifest %v, got %v", expectedManifest, archiveManifest)
+	}
+}
+
+func TestParseHeader(t *testing.T) {
+	reader := bufio.NewReader(strings.NewReader("id,payload,\"leaf hub\"\n1,{},hub1\n"))
+
+	columns, err := parseHeader(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{`"id"`, `"payload"`, `"leaf hub"`}; !reflect.DeepEqual(columns, expected) {
+		t.Fatalf("expected columns %v, got %v", expected, columns)
+	}
+
+	rest, err := reader.ReadString('\n')
+	if err != nil || rest != "1,{},hub1\n" {
+		t.Fatalf("expected the rows to be left in the reader, got %q", rest)
+	}
+}
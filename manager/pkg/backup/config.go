@@ -0,0 +1,85 @@
+// Copyright (c) 2022 Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+var mghGVK = schema.GroupVersionKind{
+	Group:   "operator.open-cluster-management.io",
+	Version: "v1alpha2",
+	Kind:    "MulticlusterGlobalHub",
+}
+
+// backupConfig returns the MulticlusterGlobalHub instances without the status and the server populated metadata,
+// nil is returned if the MulticlusterGlobalHub CRD isn't installed.
+func backupConfig(ctx context.Context, log logr.Logger, kubeClient client.Client) ([]byte, error) {
+	mghList := &unstructured.UnstructuredList{}
+	mghList.SetGroupVersionKind(mghGVK.GroupVersion().WithKind(mghGVK.Kind + "List"))
+	if err := kubeClient.List(ctx, mghList); err != nil {
+		if meta.IsNoMatchError(err) {
+			log.Info("skip the config backup, the MulticlusterGlobalHub CRD isn't installed")
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list MulticlusterGlobalHub - %w", err)
+	}
+
+	mghs := make([]map[string]interface{}, 0, len(mghList.Items))
+	for _, item := range mghList.Items {
+		mgh := &unstructured.Unstructured{}
+		mgh.SetGroupVersionKind(mghGVK)
+		mgh.SetName(item.GetName())
+		mgh.SetNamespace(item.GetNamespace())
+		mgh.SetLabels(item.GetLabels())
+		mgh.SetAnnotations(item.GetAnnotations())
+		if spec, found := item.Object["spec"]; found {
+			mgh.Object["spec"] = spec
+		}
+		mghs = append(mghs, mgh.Object)
+	}
+
+	return yaml.Marshal(mghs)
+}
+
+// restoreConfig creates the MulticlusterGlobalHub instances from the backup, or updates the spec of the existing ones.
+func restoreConfig(ctx context.Context, log logr.Logger, kubeClient client.Client, data []byte) error {
+	mghs := []map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &mghs); err != nil {
+		return fmt.Errorf("failed to parse MulticlusterGlobalHub backup - %w", err)
+	}
+
+	for _, obj := range mghs {
+		mgh := &unstructured.Unstructured{Object: obj}
+		existingMGH := &unstructured.Unstructured{}
+		existingMGH.SetGroupVersionKind(mghGVK)
+		err := kubeClient.Get(ctx, client.ObjectKeyFromObject(mgh), existingMGH)
+		if apierrors.IsNotFound(err) {
+			log.Info("creating MulticlusterGlobalHub", "namespace", mgh.GetNamespace(), "name", mgh.GetName())
+			if err := kubeClient.Create(ctx, mgh); err != nil {
+				return fmt.Errorf("failed to create MulticlusterGlobalHub %s - %w", mgh.GetName(), err)
+			}
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get MulticlusterGlobalHub %s - %w", mgh.GetName(), err)
+		}
+
+		log.Info("updating MulticlusterGlobalHub", "namespace", mgh.GetNamespace(), "name", mgh.GetName())
+		existingMGH.Object["spec"] = mgh.Object["spec"]
+		if err := kubeClient.Update(ctx, existingMGH); err != nil {
+			return fmt.Errorf("failed to update MulticlusterGlobalHub %s - %w", mgh.GetName(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2022 Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	specSchema        = "spec"
+	resyncsTableName  = "resyncs"
+	updatedAtColumn   = "updated_at"
+	tableNameSplitter = "."
+)
+
+// excludedTables aren't backed up nor restored, the resync requests of the backup are stale once it is restored,
+// a new one is inserted by the restore instead.
+var excludedTables = []string{specSchema + tableNameSplitter + resyncsTableName}
+
+// listTables returns the ordinary tables of the backed up schemas as "schema.table", except the excluded tables.
+// the partitioned tables and their partitions aren't backed up, they hold the events which are kept only for the
+// retention days and can't be restored without their partitions.
+func listTables(ctx context.Context, tx pgx.Tx) ([]string, error) {
+	rows, err := tx.Query(ctx, `SELECT n.nspname,c.relname FROM pg_catalog.pg_class c JOIN pg_catalog.pg_namespace n
+		ON n.oid = c.relnamespace WHERE n.nspname = ANY($1) AND c.relkind = 'r' AND NOT c.relispartition
+		ORDER BY n.nspname,c.relname`, backupSchemas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables - %w", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+
+	for rows.Next() {
+		var schema, table string
+		if err := rows.Scan(&schema, &table); err != nil {
+			return nil, fmt.Errorf("failed to list tables - %w", err)
+		}
+
+		if table := schema + tableNameSplitter + table; !contains(excludedTables, table) {
+			tables = append(tables, table)
+		}
+	}
+
+	return tables, rows.Err()
+}
+
+// writeTableEntry copies the table as csv with header into the archive. the table is copied to a temporary file
+// first since the size of the archive entry must be known in advance. returns the size of the entry.
+func writeTableEntry(ctx context.Context, tx pgx.Tx, tarWriter *tar.Writer, table string,
+	timestamp time.Time,
+) (int64, error) {
+	tmpFile, err := os.CreateTemp("", "multicluster-global-hub-backup-*.csv")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create temporary file - %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	if _, err := tx.Conn().PgConn().CopyTo(ctx, tmpFile,
+		fmt.Sprintf("COPY %s TO STDOUT WITH (FORMAT csv, HEADER true)", sanitizeTable(table))); err != nil {
+		return 0, fmt.Errorf("failed to copy table %s - %w", table, err)
+	}
+
+	size, err := tmpFile.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get size of table %s - %w", table, err)
+	}
+
+	if _, err := tmpFile.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to rewind table %s - %w", table, err)
+	}
+
+	name := tableEntryName(table)
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    size,
+		ModTime: timestamp,
+	}); err != nil {
+		return 0, fmt.Errorf("failed to write archive entry %s - %w", name, err)
+	}
+
+	if _, err := io.Copy(tarWriter, tmpFile); err != nil {
+		return 0, fmt.Errorf("failed to write archive entry %s - %w", name, err)
+	}
+
+	return size, nil
+}
+
+// truncateTables deletes the content of the tables, all of them must exist in the database.
+func truncateTables(ctx context.Context, tx pgx.Tx, tables []string) error {
+	if len(tables) == 0 {
+		return nil
+	}
+
+	sanitizedTables := make([]string, len(tables))
+	for i, table := range tables {
+		sanitizedTables[i] = sanitizeTable(table)
+	}
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf("TRUNCATE %s", strings.Join(sanitizedTables, ","))); err != nil {
+		return fmt.Errorf("failed to truncate tables - %w", err)
+	}
+
+	return nil
+}
+
+// copyTableFrom loads the csv with header into the table, the header is used as the column list so the archive
+// doesn't depend on the column order of the table.
+func copyTableFrom(ctx context.Context, tx pgx.Tx, table string, reader io.Reader) error {
+	bufReader := bufio.NewReader(reader)
+
+	columns, err := parseHeader(bufReader)
+	if err != nil {
+		return fmt.Errorf("failed to parse header of table %s - %w", table, err)
+	}
+
+	if _, err := tx.Conn().PgConn().CopyFrom(ctx, bufReader, fmt.Sprintf("COPY %s (%s) FROM STDIN WITH (FORMAT csv)",
+		sanitizeTable(table), strings.Join(columns, ","))); err != nil {
+		return fmt.Errorf("failed to copy table %s - %w", table, err)
+	}
+
+	return nil
+}
+
+// parseHeader reads the csv header line and returns the sanitized column names.
+func parseHeader(reader *bufio.Reader) ([]string, error) {
+	headerLine, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := csv.NewReader(strings.NewReader(headerLine)).Read()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, column := range columns {
+		columns[i] = pgx.Identifier{column}.Sanitize()
+	}
+
+	return columns, nil
+}
+
+// requestResync marks the restored spec tables as updated so they are republished to the regional hubs, and inserts
+// a resync request so the regional hubs resend the complete state of their status bundles.
+func requestResync(ctx context.Context, tx pgx.Tx) error {
+	rows, err := tx.Query(ctx, `SELECT table_name FROM information_schema.columns WHERE table_schema = $1 AND
+		column_name = $2 AND table_name <> $3`, specSchema, updatedAtColumn, resyncsTableName)
+	if err != nil {
+		return fmt.Errorf("failed to list spec tables - %w", err)
+	}
+
+	var specTables []string
+
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to list spec tables - %w", err)
+		}
+
+		specTables = append(specTables, table)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to list spec tables - %w", err)
+	}
+
+	for _, table := range specTables {
+		if _, err := tx.Exec(ctx, fmt.Sprintf("UPDATE %s SET %s = now()", pgx.Identifier{specSchema, table}.Sanitize(),
+			updatedAtColumn)); err != nil {
+			return fmt.Errorf("failed to update spec table %s - %w", table, err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf("INSERT INTO %s (id) VALUES ($1)",
+		pgx.Identifier{specSchema, resyncsTableName}.Sanitize()), uuid.New().String()); err != nil {
+		return fmt.Errorf("failed to request resync - %w", err)
+	}
+
+	return nil
+}
+
+func sanitizeTable(table string) string {
+	return pgx.Identifier(strings.SplitN(table, tableNameSplitter, 2)).Sanitize()
+}
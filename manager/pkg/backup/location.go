@@ -0,0 +1,143 @@
+// Copyright (c) 2022 Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const archiveNameFormat = "multicluster-global-hub-backup-20060102150405.tar.gz"
+
+// isURL returns true if the location is an object store compatible http(s) url, e.g. a presigned url,
+// otherwise the location is a path on the local filesystem, e.g. a mounted PVC.
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+// newLocationWriter returns a writer of the backup archive and the resolved location. If the location is an existing
+// directory, the archive is written to a new file named by the timestamp inside it.
+func newLocationWriter(ctx context.Context, location string, timestamp time.Time) (io.WriteCloser, string, error) {
+	if isURL(location) {
+		// object stores require the content length of the uploaded object, so the archive is buffered first
+		tmpFile, err := os.CreateTemp("", "multicluster-global-hub-backup-*.tar.gz")
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create temporary archive - %w", err)
+		}
+		return &urlWriter{ctx: ctx, url: location, file: tmpFile}, location, nil
+	}
+
+	if info, err := os.Stat(location); err == nil && info.IsDir() {
+		location = filepath.Join(location, timestamp.UTC().Format(archiveNameFormat))
+	}
+
+	// write to a temporary file next to the archive, so an incomplete archive never overrides a valid one
+	file, err := os.Create(location + ".tmp")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create archive %s - %w", location, err)
+	}
+
+	return &fileWriter{path: location, file: file}, location, nil
+}
+
+// newLocationReader returns a reader of the backup archive in the location.
+func newLocationReader(ctx context.Context, location string) (io.ReadCloser, error) {
+	if !isURL(location) {
+		file, err := os.Open(location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open archive %s - %w", location, err)
+		}
+		return file, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create download request - %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download archive - %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download archive - unexpected status %s", resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+type fileWriter struct {
+	path string
+	file *os.File
+}
+
+func (w *fileWriter) Write(p []byte) (int, error) {
+	return w.file.Write(p)
+}
+
+// Close flushes the archive to disk and moves it to the final path.
+func (w *fileWriter) Close() error {
+	if err := w.file.Sync(); err != nil {
+		w.file.Close()
+		return fmt.Errorf("failed to flush archive %s - %w", w.path, err)
+	}
+
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("failed to close archive %s - %w", w.path, err)
+	}
+
+	return os.Rename(w.file.Name(), w.path)
+}
+
+type urlWriter struct {
+	ctx  context.Context
+	url  string
+	file *os.File
+}
+
+func (w *urlWriter) Write(p []byte) (int, error) {
+	return w.file.Write(p)
+}
+
+// Close uploads the buffered archive to the url.
+func (w *urlWriter) Close() error {
+	defer os.Remove(w.file.Name())
+	defer w.file.Close()
+
+	size, err := w.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("failed to get archive size - %w", err)
+	}
+
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind archive - %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(w.ctx, http.MethodPut, w.url, w.file)
+	if err != nil {
+		return fmt.Errorf("failed to create upload request - %w", err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/gzip")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload archive - %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("failed to upload archive - unexpected status %s", resp.Status)
+	}
+
+	return nil
+}
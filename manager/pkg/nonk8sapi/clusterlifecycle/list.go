@@ -0,0 +1,132 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package clusterlifecycle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/nonk8sapi/util"
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+)
+
+// hibernatingPowerState is the power state of a hibernating hive cluster.
+const hibernatingPowerState = "Hibernating"
+
+// RegionalHubClusterLifecycle is the lifecycle of the clusters provisioned by hive on a regional hub.
+type RegionalHubClusterLifecycle struct {
+	RegionalHub        string                            `json:"regionalHub"`
+	Counts             Counts                            `json:"counts"`
+	ClusterDeployments []*status.ClusterDeploymentStatus `json:"clusterDeployments"`
+	ClusterClaims      []*status.ClusterPoolClaimStatus  `json:"clusterClaims"`
+}
+
+// Counts are the numbers of the cluster deployments of a regional hub by their provision status, and of the pending
+// cluster claims.
+type Counts struct {
+	Provisioning     int `json:"provisioning"`
+	ProvisionFailed  int `json:"provisionFailed"`
+	ProvisionStopped int `json:"provisionStopped"`
+	Installed        int `json:"installed"`
+	Hibernating      int `json:"hibernating"`
+	Destroying       int `json:"destroying"`
+	PendingClaims    int `json:"pendingClaims"`
+}
+
+// List middleware, lists the lifecycle of the hive clusters of the regional hubs, optionally filtered by the
+// regionalHub query parameter.
+func List(dbConnectionPool *pgxpool.Pool) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		regionalHubName := ginCtx.Query("regionalHub")
+		regionalHubs := make(map[string]*RegionalHubClusterLifecycle)
+
+		getRegionalHub := func(leafHubName string) *RegionalHubClusterLifecycle {
+			regionalHub, found := regionalHubs[leafHubName]
+			if !found {
+				regionalHub = &RegionalHubClusterLifecycle{
+					RegionalHub:        leafHubName,
+					ClusterDeployments: make([]*status.ClusterDeploymentStatus, 0),
+					ClusterClaims:      make([]*status.ClusterPoolClaimStatus, 0),
+				}
+				regionalHubs[leafHubName] = regionalHub
+			}
+
+			return regionalHub
+		}
+
+		if err := util.QueryStatuses(ginCtx, dbConnectionPool, "cluster_deployments", regionalHubName,
+			func(leafHubName string, payload []byte) error {
+				clusterDeployment := &status.ClusterDeploymentStatus{}
+				if err := json.Unmarshal(payload, clusterDeployment); err != nil {
+					return err
+				}
+
+				regionalHub := getRegionalHub(leafHubName)
+				regionalHub.ClusterDeployments = append(regionalHub.ClusterDeployments, clusterDeployment)
+				regionalHub.Counts.addClusterDeployment(clusterDeployment)
+
+				return nil
+			}); err != nil {
+			ginCtx.String(http.StatusInternalServerError, "internal error")
+			fmt.Fprintf(gin.DefaultWriter, "error in querying cluster deployments: %v\n", err)
+
+			return
+		}
+
+		if err := util.QueryStatuses(ginCtx, dbConnectionPool, "cluster_pool_claims", regionalHubName,
+			func(leafHubName string, payload []byte) error {
+				clusterClaim := &status.ClusterPoolClaimStatus{}
+				if err := json.Unmarshal(payload, clusterClaim); err != nil {
+					return err
+				}
+
+				regionalHub := getRegionalHub(leafHubName)
+				regionalHub.ClusterClaims = append(regionalHub.ClusterClaims, clusterClaim)
+
+				if clusterClaim.Pending {
+					regionalHub.Counts.PendingClaims++
+				}
+
+				return nil
+			}); err != nil {
+			ginCtx.String(http.StatusInternalServerError, "internal error")
+			fmt.Fprintf(gin.DefaultWriter, "error in querying cluster claims: %v\n", err)
+
+			return
+		}
+
+		clusterLifecycles := make([]*RegionalHubClusterLifecycle, 0, len(regionalHubs))
+		for _, regionalHub := range regionalHubs {
+			clusterLifecycles = append(clusterLifecycles, regionalHub)
+		}
+
+		sort.Slice(clusterLifecycles, func(i, j int) bool {
+			return clusterLifecycles[i].RegionalHub < clusterLifecycles[j].RegionalHub
+		})
+
+		ginCtx.JSON(http.StatusOK, clusterLifecycles)
+	}
+}
+
+func (counts *Counts) addClusterDeployment(clusterDeployment *status.ClusterDeploymentStatus) {
+	switch clusterDeployment.ProvisionStatus {
+	case status.ClusterProvisioning:
+		counts.Provisioning++
+	case status.ClusterProvisionFailed:
+		counts.ProvisionFailed++
+	case status.ClusterProvisionStopped:
+		counts.ProvisionStopped++
+	case status.ClusterInstalled:
+		counts.Installed++
+		if clusterDeployment.PowerState == hibernatingPowerState {
+			counts.Hibernating++
+		}
+	case status.ClusterDestroying:
+		counts.Destroying++
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+)
+
+const (
+	defaultLimit = 500
+	maxLimit     = 5000
+	// watchInterval is the interval of polling the new events of a watch.
+	watchInterval = 4 * time.Second
+	// watchedEventAge is the time a watch tracks an event since it occurred last, the events are updated by the
+	// regional hubs for an hour since they occurred last.
+	watchedEventAge = 2 * time.Hour
+)
+
+// Event is an event of a regional hub.
+type Event struct {
+	RegionalHub string `json:"regionalHub"`
+	status.Event
+	// sequence is assigned by the database when the event is inserted or updated.
+	sequence int64
+}
+
+// WatchEvent is a change of the events of a watch.
+type WatchEvent struct {
+	// Type is ADDED for a new event, or MODIFIED for a new occurrence of an event.
+	Type   string `json:"type"`
+	Object *Event `json:"object"`
+}
+
+// filters are the query parameters the events are filtered by, and their columns.
+var filters = []struct {
+	queryParameter string
+	column         string
+}{
+	{queryParameter: "regionalHub", column: "leaf_hub_name"},
+	{queryParameter: "kind", column: "involved_kind"},
+	{queryParameter: "namespace", column: "involved_namespace"},
+	{queryParameter: "name", column: "involved_name"},
+	{queryParameter: "reason", column: "reason"},
+	{queryParameter: "type", column: "type"},
+}
+
+type eventKey struct {
+	regionalHub    string
+	involvedObject status.EventInvolvedObject
+	reason         string
+	firstTimestamp time.Time
+}
+
+// List middleware, lists the events of the regional hubs from the most recent, optionally filtered by the
+// regionalHub, kind, namespace, name, reason, type and since query parameters, and limited by the limit query
+// parameter. the watch query parameter streams the new events.
+func List(dbConnectionPool *pgxpool.Pool) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		query := `SELECT leaf_hub_name, event_namespace, event_name, involved_api_version, involved_kind,
+			involved_namespace, involved_name, involved_uid, reason, message, type, source, count, first_timestamp,
+			last_timestamp, sequence FROM status.events WHERE TRUE`
+		args := []interface{}{}
+
+		for _, filter := range filters {
+			if value := ginCtx.Query(filter.queryParameter); value != "" {
+				args = append(args, value)
+				query += fmt.Sprintf(" AND %s = $%d", filter.column, len(args))
+			}
+		}
+
+		since, err := parseSince(ginCtx.Query("since"), time.Now())
+		if err != nil {
+			ginCtx.String(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		limit := defaultLimit
+		if limitQuery := ginCtx.Query("limit"); limitQuery != "" {
+			if limit, err = strconv.Atoi(limitQuery); err != nil || limit <= 0 || limit > maxLimit {
+				ginCtx.String(http.StatusBadRequest, fmt.Sprintf("invalid limit, expected 1 to %d", maxLimit))
+				return
+			}
+		}
+
+		if !since.IsZero() {
+			args = append(args, since.UTC())
+			query += fmt.Sprintf(" AND last_timestamp >= $%d", len(args))
+		}
+
+		if _, watch := ginCtx.GetQuery("watch"); watch {
+			handleWatch(ginCtx, dbConnectionPool, query, args, since.IsZero())
+			return
+		}
+
+		query += fmt.Sprintf(" ORDER BY last_timestamp DESC LIMIT %d", limit)
+
+		events, err := queryEvents(ginCtx, dbConnectionPool, query, args)
+		if err != nil {
+			ginCtx.String(http.StatusInternalServerError, "internal error")
+			fmt.Fprintf(gin.DefaultWriter, "error in querying events: %v\n", err)
+
+			return
+		}
+
+		ginCtx.JSON(http.StatusOK, events)
+	}
+}
+
+// parseSince parses the since query parameter, either a time in RFC3339 format or a duration before now, e.g. 1h.
+func parseSince(since string, now time.Time) (time.Time, error) {
+	if since == "" {
+		return time.Time{}, nil
+	}
+
+	if timestamp, err := time.Parse(time.RFC3339, since); err == nil {
+		return timestamp, nil
+	}
+
+	duration, err := time.ParseDuration(since)
+	if err != nil || duration < 0 {
+		return time.Time{}, fmt.Errorf("invalid since %q, expected a time in RFC3339 format or a duration", since)
+	}
+
+	return now.Add(-duration), nil
+}
+
+// handleWatch streams the events inserted or updated after the watch started, and the events that occurred after the
+// since time if it's set. the events are watched by their sequence, which is assigned by the database, so the clocks
+// of the regional hubs don't affect the watch. the sequences of the previous poll are queried again, so the events of
+// the transactions committed out of the order of their sequences aren't missed.
+func handleWatch(ginCtx *gin.Context, dbConnectionPool *pgxpool.Pool, query string, args []interface{},
+	fromNow bool,
+) {
+	ctx := ginCtx.Request.Context()
+
+	var cursor int64
+	if fromNow {
+		if err := dbConnectionPool.QueryRow(ctx,
+			"SELECT COALESCE(MAX(sequence), 0) FROM status.events").Scan(&cursor); err != nil {
+			ginCtx.String(http.StatusInternalServerError, "internal error")
+			fmt.Fprintf(gin.DefaultWriter, "error in querying events: %v\n", err)
+
+			return
+		}
+	}
+
+	writer := ginCtx.Writer
+	header := writer.Header()
+	header.Set("Transfer-Encoding", "chunked")
+	header.Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+
+	// the events sent in the watch and their last sent sequence, to tell new events from new occurrences
+	watchedEvents := make(map[eventKey]*Event)
+	query += fmt.Sprintf(" AND sequence > $%d ORDER BY sequence", len(args)+1)
+	watchArgs := make([]interface{}, len(args)+1)
+	copy(watchArgs, args)
+
+	previousCursor := cursor
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			watchArgs[len(args)] = previousCursor
+
+			events, err := queryEvents(ctx, dbConnectionPool, query, watchArgs)
+			if err != nil {
+				fmt.Fprintf(gin.DefaultWriter, "error in querying events: %v\n", err)
+				continue
+			}
+
+			previousCursor = cursor
+			latestTimestamp := time.Time{}
+
+			for _, event := range events {
+				key := eventKey{
+					regionalHub:    event.RegionalHub,
+					involvedObject: event.InvolvedObject,
+					reason:         event.Reason,
+					firstTimestamp: event.FirstTimestamp,
+				}
+
+				if event.sequence > cursor {
+					cursor = event.sequence
+				}
+
+				if event.LastTimestamp.After(latestTimestamp) {
+					latestTimestamp = event.LastTimestamp
+				}
+
+				watchEvent := &WatchEvent{Type: "ADDED", Object: event}
+				if watchedEvent, found := watchedEvents[key]; found {
+					if watchedEvent.sequence >= event.sequence { // sent already by the previous poll
+						continue
+					}
+
+					watchEvent.Type = "MODIFIED"
+				}
+
+				watchedEvents[key] = event
+
+				if err := json.NewEncoder(writer).Encode(watchEvent); err != nil {
+					fmt.Fprintf(gin.DefaultWriter, "error in writing response: %v\n", err)
+					return
+				}
+			}
+
+			for key, watchedEvent := range watchedEvents {
+				if watchedEvent.LastTimestamp.Before(latestTimestamp.Add(-watchedEventAge)) {
+					delete(watchedEvents, key)
+				}
+			}
+
+			writer.Flush()
+		}
+	}
+}
+
+func queryEvents(ctx context.Context, dbConnectionPool *pgxpool.Pool, query string,
+	args []interface{},
+) ([]*Event, error) {
+	rows, err := dbConnectionPool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	events := make([]*Event, 0)
+
+	for rows.Next() {
+		event := &Event{}
+		if err := rows.Scan(&event.RegionalHub, &event.EventNamespace, &event.EventName,
+			&event.InvolvedObject.APIVersion, &event.InvolvedObject.Kind, &event.InvolvedObject.Namespace,
+			&event.InvolvedObject.Name, &event.InvolvedObject.UID, &event.Reason, &event.Message, &event.Type,
+			&event.Source, &event.Count, &event.FirstTimestamp, &event.LastTimestamp, &event.sequence); err != nil {
+			return nil, err
+		}
+
+		// the timestamps are stored in utc
+		event.FirstTimestamp = event.FirstTimestamp.UTC()
+		event.LastTimestamp = event.LastTimestamp.UTC()
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
@@ -0,0 +1,34 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2022, time.October, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, test := range []struct {
+		since    string
+		expected time.Time
+		valid    bool
+	}{
+		{"", time.Time{}, true},
+		{"1h", now.Add(-time.Hour), true},
+		{"2022-10-01T10:00:00Z", now.Add(-2 * time.Hour), true},
+		{"-1h", time.Time{}, false},
+		{"yesterday", time.Time{}, false},
+	} {
+		since, err := parseSince(test.since, now)
+		if (err == nil) != test.valid {
+			t.Errorf("since %q: unexpected error %v", test.since, err)
+			continue
+		}
+
+		if !since.Equal(test.expected) {
+			t.Errorf("since %q: expected %s, got %s", test.since, test.expected, since)
+		}
+	}
+}
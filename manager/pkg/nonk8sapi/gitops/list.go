@@ -0,0 +1,135 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package gitops
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/nonk8sapi/util"
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+)
+
+// RegionalHubGitOps is the status of the argo cd applications and application sets of a regional hub.
+type RegionalHubGitOps struct {
+	RegionalHub     string                             `json:"regionalHub"`
+	Counts          Counts                             `json:"counts"`
+	Applications    []*status.ArgoApplicationStatus    `json:"applications"`
+	ApplicationSets []*status.ArgoApplicationSetStatus `json:"applicationSets"`
+}
+
+// Counts are the numbers of the applications of a regional hub by their sync and health status, and of the
+// application sets that failed to generate their applications.
+type Counts struct {
+	Synced                int `json:"synced"`
+	OutOfSync             int `json:"outOfSync"`
+	Healthy               int `json:"healthy"`
+	Progressing           int `json:"progressing"`
+	Degraded              int `json:"degraded"`
+	Suspended             int `json:"suspended"`
+	Missing               int `json:"missing"`
+	Unknown               int `json:"unknown"`
+	FailedApplicationSets int `json:"failedApplicationSets"`
+}
+
+// List middleware, lists the status of the argo cd applications and application sets of the regional hubs,
+// optionally filtered by the regionalHub query parameter.
+func List(dbConnectionPool *pgxpool.Pool) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		regionalHubName := ginCtx.Query("regionalHub")
+		regionalHubs := make(map[string]*RegionalHubGitOps)
+
+		getRegionalHub := func(leafHubName string) *RegionalHubGitOps {
+			regionalHub, found := regionalHubs[leafHubName]
+			if !found {
+				regionalHub = &RegionalHubGitOps{
+					RegionalHub:     leafHubName,
+					Applications:    make([]*status.ArgoApplicationStatus, 0),
+					ApplicationSets: make([]*status.ArgoApplicationSetStatus, 0),
+				}
+				regionalHubs[leafHubName] = regionalHub
+			}
+
+			return regionalHub
+		}
+
+		if err := util.QueryStatuses(ginCtx, dbConnectionPool, "argo_applications", regionalHubName,
+			func(leafHubName string, payload []byte) error {
+				application := &status.ArgoApplicationStatus{}
+				if err := json.Unmarshal(payload, application); err != nil {
+					return err
+				}
+
+				regionalHub := getRegionalHub(leafHubName)
+				regionalHub.Applications = append(regionalHub.Applications, application)
+				regionalHub.Counts.addApplication(application)
+
+				return nil
+			}); err != nil {
+			ginCtx.String(http.StatusInternalServerError, "internal error")
+			fmt.Fprintf(gin.DefaultWriter, "error in querying argo applications: %v\n", err)
+
+			return
+		}
+
+		if err := util.QueryStatuses(ginCtx, dbConnectionPool, "argo_application_sets", regionalHubName,
+			func(leafHubName string, payload []byte) error {
+				applicationSet := &status.ArgoApplicationSetStatus{}
+				if err := json.Unmarshal(payload, applicationSet); err != nil {
+					return err
+				}
+
+				regionalHub := getRegionalHub(leafHubName)
+				regionalHub.ApplicationSets = append(regionalHub.ApplicationSets, applicationSet)
+
+				if applicationSet.ErrorOccurred {
+					regionalHub.Counts.FailedApplicationSets++
+				}
+
+				return nil
+			}); err != nil {
+			ginCtx.String(http.StatusInternalServerError, "internal error")
+			fmt.Fprintf(gin.DefaultWriter, "error in querying argo application sets: %v\n", err)
+
+			return
+		}
+
+		gitOps := make([]*RegionalHubGitOps, 0, len(regionalHubs))
+		for _, regionalHub := range regionalHubs {
+			gitOps = append(gitOps, regionalHub)
+		}
+
+		sort.Slice(gitOps, func(i, j int) bool { return gitOps[i].RegionalHub < gitOps[j].RegionalHub })
+
+		ginCtx.JSON(http.StatusOK, gitOps)
+	}
+}
+
+func (counts *Counts) addApplication(application *status.ArgoApplicationStatus) {
+	switch application.SyncStatus {
+	case "Synced":
+		counts.Synced++
+	case "OutOfSync":
+		counts.OutOfSync++
+	}
+
+	switch application.HealthStatus {
+	case "Healthy":
+		counts.Healthy++
+	case "Progressing":
+		counts.Progressing++
+	case "Degraded":
+		counts.Degraded++
+	case "Suspended":
+		counts.Suspended++
+	case "Missing":
+		counts.Missing++
+	default:
+		counts.Unknown++
+	}
+}
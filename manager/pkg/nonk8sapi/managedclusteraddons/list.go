@@ -0,0 +1,125 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package managedclusteraddons
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+)
+
+// AddOnHealth is the health of an add-on on a managed cluster.
+type AddOnHealth struct {
+	RegionalHub string `json:"regionalHub"`
+	ClusterName string `json:"clusterName"`
+	Name        string `json:"name"`
+	// Healthy is true if the add-on is available and isn't degraded.
+	Healthy   bool                   `json:"healthy"`
+	Available metav1.ConditionStatus `json:"available"`
+	Degraded  metav1.ConditionStatus `json:"degraded"`
+	// Message is the message of the condition the add-on is unhealthy by, or of the available condition.
+	Message string `json:"message,omitempty"`
+}
+
+// List middleware, lists the health of the add-ons of the managed clusters, optionally filtered by the cluster,
+// name and healthy query parameters.
+func List(dbConnectionPool *pgxpool.Pool) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		query := "SELECT leaf_hub_name, payload FROM status.managed_cluster_addons WHERE TRUE"
+		args := []interface{}{}
+
+		if clusterName := ginCtx.Query("cluster"); clusterName != "" {
+			args = append(args, clusterName)
+			query += fmt.Sprintf(" AND payload -> 'metadata' ->> 'namespace' = $%d", len(args))
+		}
+
+		if name := ginCtx.Query("name"); name != "" {
+			args = append(args, name)
+			query += fmt.Sprintf(" AND payload -> 'metadata' ->> 'name' = $%d", len(args))
+		}
+
+		query += " ORDER BY payload -> 'metadata' ->> 'namespace', payload -> 'metadata' ->> 'name', leaf_hub_name"
+
+		var healthyFilter *bool
+
+		if healthyQuery, found := ginCtx.GetQuery("healthy"); found {
+			healthy, err := strconv.ParseBool(healthyQuery)
+			if err != nil {
+				ginCtx.String(http.StatusBadRequest, "invalid healthy query parameter, expected true or false")
+				return
+			}
+
+			healthyFilter = &healthy
+		}
+
+		rows, err := dbConnectionPool.Query(ginCtx, query, args...)
+		if err != nil {
+			ginCtx.String(http.StatusInternalServerError, "internal error")
+			fmt.Fprintf(gin.DefaultWriter, "error in querying managed cluster add-ons: %v\n", err)
+
+			return
+		}
+
+		defer rows.Close()
+
+		addOnsHealth := make([]*AddOnHealth, 0)
+
+		for rows.Next() {
+			var leafHubName string
+
+			addOn := &addonv1alpha1.ManagedClusterAddOn{}
+			if err := rows.Scan(&leafHubName, addOn); err != nil {
+				fmt.Fprintf(gin.DefaultWriter, "error in scanning a managed cluster add-on: %v\n", err)
+				continue
+			}
+
+			addOnHealth := getAddOnHealth(leafHubName, addOn)
+			if healthyFilter != nil && addOnHealth.Healthy != *healthyFilter {
+				continue
+			}
+
+			addOnsHealth = append(addOnsHealth, addOnHealth)
+		}
+
+		ginCtx.JSON(http.StatusOK, addOnsHealth)
+	}
+}
+
+// getAddOnHealth returns the health of the add-on by its available and degraded conditions, the add-on is deployed
+// in the namespace of its managed cluster.
+func getAddOnHealth(leafHubName string, addOn *addonv1alpha1.ManagedClusterAddOn) *AddOnHealth {
+	addOnHealth := &AddOnHealth{
+		RegionalHub: leafHubName,
+		ClusterName: addOn.GetNamespace(),
+		Name:        addOn.GetName(),
+		Available:   metav1.ConditionUnknown,
+		Degraded:    metav1.ConditionUnknown,
+	}
+
+	available := meta.FindStatusCondition(addOn.Status.Conditions,
+		addonv1alpha1.ManagedClusterAddOnConditionAvailable)
+	if available != nil {
+		addOnHealth.Available = available.Status
+		addOnHealth.Message = available.Message
+	}
+
+	degraded := meta.FindStatusCondition(addOn.Status.Conditions,
+		addonv1alpha1.ManagedClusterAddOnConditionDegraded)
+	if degraded != nil {
+		addOnHealth.Degraded = degraded.Status
+		if degraded.Status == metav1.ConditionTrue {
+			addOnHealth.Message = degraded.Message
+		}
+	}
+
+	addOnHealth.Healthy = addOnHealth.Available == metav1.ConditionTrue &&
+		addOnHealth.Degraded != metav1.ConditionTrue
+
+	return addOnHealth
+}
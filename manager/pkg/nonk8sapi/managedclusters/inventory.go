@@ -0,0 +1,178 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package managedclusters
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// inventoryField is a field of the managed cluster inventory that the managed clusters are selected by.
+type inventoryField struct {
+	column string
+	// quantity is true for the numeric fields, they are compared as resource quantities, e.g. 100 or 256Gi.
+	quantity bool
+}
+
+// inventoryFields is a map of field selector name -> inventory field.
+var inventoryFields = map[string]inventoryField{
+	"platform":    {column: "i.platform"},
+	"product":     {column: "i.product"},
+	"version":     {column: "i.version"},
+	"region":      {column: "i.region"},
+	"kubeVersion": {column: "i.kube_version"},
+	"available":   {column: "i.available"},
+	"cpu":         {column: "i.cpu_cores", quantity: true},
+	"memory":      {column: "i.memory_bytes", quantity: true},
+}
+
+// selectorOperators are the operators of the field selector requirements, the two characters operators first.
+var selectorOperators = []string{"!=", "==", ">=", "<=", "=", ">", "<"}
+
+// inventoryColumnDefinitions are the columns of the inventory added to the table of the managed clusters.
+var inventoryColumnDefinitions = []metav1.TableColumnDefinition{
+	{Name: "Platform", Type: "string"},
+	{Name: "Product", Type: "string"},
+	{Name: "Version", Type: "string"},
+	{Name: "Region", Type: "string"},
+	{Name: "Kube Version", Type: "string", Priority: 1},
+	{Name: "CPU", Type: "integer", Priority: 1},
+	{Name: "Memory", Type: "string", Priority: 1},
+	{Name: "Available", Type: "string", Priority: 1},
+}
+
+// clusterInventory is the inventory row of a managed cluster, the fields are nil if the cluster has no inventory yet.
+type clusterInventory struct {
+	platform    *string
+	product     *string
+	version     *string
+	region      *string
+	kubeVersion *string
+	cpuCores    *int64
+	memoryBytes *int64
+	available   *string
+}
+
+// scanArgs returns the destinations of the inventory columns of the list query.
+func (inventory *clusterInventory) scanArgs() []interface{} {
+	return []interface{}{
+		&inventory.platform, &inventory.product, &inventory.version, &inventory.region, &inventory.kubeVersion,
+		&inventory.cpuCores, &inventory.memoryBytes, &inventory.available,
+	}
+}
+
+// cells returns the cells of the inventory columns of the table.
+func (inventory *clusterInventory) cells() []interface{} {
+	var memory interface{}
+	if inventory.memoryBytes != nil {
+		memory = resource.NewQuantity(*inventory.memoryBytes, resource.BinarySI).String()
+	}
+
+	var cpu interface{}
+	if inventory.cpuCores != nil {
+		cpu = *inventory.cpuCores
+	}
+
+	return []interface{}{
+		stringCell(inventory.platform), stringCell(inventory.product), stringCell(inventory.version),
+		stringCell(inventory.region), stringCell(inventory.kubeVersion), cpu, memory,
+		stringCell(inventory.available),
+	}
+}
+
+func stringCell(value *string) interface{} {
+	if value == nil || *value == "" {
+		return nil
+	}
+
+	return *value
+}
+
+// parseFieldSelector returns the conditions of the list query by the field selector, e.g.
+// platform=AWS,version=4.10,cpu>100. the arguments of the conditions are appended to args.
+func parseFieldSelector(fieldSelector string, args []interface{}) (string, []interface{}, error) {
+	var conditions strings.Builder
+
+	for _, requirement := range strings.Split(fieldSelector, ",") {
+		requirement = strings.TrimSpace(requirement)
+		if requirement == "" {
+			continue
+		}
+
+		operatorIndex := strings.IndexAny(requirement, "!=<>")
+		if operatorIndex <= 0 {
+			return "", nil, fmt.Errorf("invalid field selector requirement %q", requirement)
+		}
+
+		fieldName := strings.TrimSpace(requirement[:operatorIndex])
+
+		field, found := inventoryFields[fieldName]
+		if !found {
+			return "", nil, fmt.Errorf("unsupported field selector field %q", fieldName)
+		}
+
+		operator := ""
+
+		for _, selectorOperator := range selectorOperators {
+			if strings.HasPrefix(requirement[operatorIndex:], selectorOperator) {
+				operator = selectorOperator
+				break
+			}
+		}
+
+		value := strings.TrimSpace(requirement[operatorIndex+len(operator):])
+
+		condition, conditionArgs, err := getCondition(field, operator, value, len(args))
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid field selector requirement %q - %w", requirement, err)
+		}
+
+		conditions.WriteString(" AND " + condition)
+
+		args = append(args, conditionArgs...)
+	}
+
+	return conditions.String(), args, nil
+}
+
+func getCondition(field inventoryField, operator string, value string, numberOfArgs int) (string, []interface{},
+	error,
+) {
+	placeholder := fmt.Sprintf("$%d", numberOfArgs+1)
+
+	if field.quantity {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid quantity %q", value)
+		}
+
+		switch operator {
+		case "=", "==":
+			operator = "="
+		case "!=":
+			return fmt.Sprintf("%s IS DISTINCT FROM %s", field.column, placeholder), []interface{}{quantity.Value()},
+				nil
+		}
+
+		return fmt.Sprintf("%s %s %s", field.column, operator, placeholder), []interface{}{quantity.Value()}, nil
+	}
+
+	// the versions match their patch versions as well, e.g. version=4.10 matches 4.10.3
+	condition := fmt.Sprintf("%s = %s", field.column, placeholder)
+	if field.column == inventoryFields["version"].column {
+		condition = fmt.Sprintf("(%s = %s OR %s LIKE %s || '.%%')", field.column, placeholder, field.column,
+			placeholder)
+	}
+
+	switch operator {
+	case "=", "==":
+		return condition, []interface{}{value}, nil
+	case "!=":
+		return fmt.Sprintf("NOT COALESCE(%s, FALSE)", condition), []interface{}{value}, nil
+	default:
+		return "", nil, fmt.Errorf("operator %s is supported only by the numeric fields", operator)
+	}
+}
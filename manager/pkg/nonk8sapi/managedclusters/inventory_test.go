@@ -0,0 +1,45 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package managedclusters
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFieldSelector(t *testing.T) {
+	conditions, args, err := parseFieldSelector("product=OpenShift,version=4.10, platform==AWS,cpu>100,memory>=1Gi",
+		[]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedConditions := " AND i.product = $1" +
+		" AND (i.version = $2 OR i.version LIKE $2 || '.%')" +
+		" AND i.platform = $3" +
+		" AND i.cpu_cores > $4" +
+		" AND i.memory_bytes >= $5"
+	if conditions != expectedConditions {
+		t.Errorf("expected conditions %q, got %q", expectedConditions, conditions)
+	}
+
+	expectedArgs := []interface{}{"OpenShift", "4.10", "AWS", int64(100), int64(1 << 30)}
+	if !reflect.DeepEqual(args, expectedArgs) {
+		t.Errorf("expected args %v, got %v", expectedArgs, args)
+	}
+
+	conditions, _, err = parseFieldSelector("region!=us-east-1", []interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if conditions != " AND NOT COALESCE(i.region = $1, FALSE)" {
+		t.Errorf("unexpected conditions %q", conditions)
+	}
+
+	for _, fieldSelector := range []string{"name=cluster1", "platform>AWS", "cpu=many", "=AWS"} {
+		if _, _, err := parseFieldSelector(fieldSelector, []interface{}{}); err == nil {
+			t.Errorf("expected field selector %q to be invalid", fieldSelector)
+		}
+	}
+}
@@ -42,20 +42,35 @@ func List(dbConnectionPool *pgxpool.Pool) gin.HandlerFunc {
 		clusterv1.GroupVersion.Version)
 
 	return func(ginCtx *gin.Context) {
-		query := "SELECT payload FROM status.managed_clusters WHERE TRUE" +
-			" ORDER BY payload -> 'metadata' ->> 'name'"
-		fmt.Fprintf(gin.DefaultWriter, "query: %v\n", query)
+		// the managed clusters are selected by the fields of their inventory
+		conditions, args, err := parseFieldSelector(ginCtx.Query("fieldSelector"), []interface{}{})
+		if err != nil {
+			ginCtx.String(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		fromClause := " FROM status.managed_clusters c LEFT JOIN status.managed_cluster_inventory i" +
+			" ON i.leaf_hub_name = c.leaf_hub_name AND i.cluster_name = c.payload -> 'metadata' ->> 'name'" +
+			" WHERE TRUE" + conditions + " ORDER BY c.payload -> 'metadata' ->> 'name'"
 
 		if _, watch := ginCtx.GetQuery("watch"); watch {
-			handleRowsForWatch(ginCtx, query, dbConnectionPool)
+			query := "SELECT c.payload" + fromClause
+			fmt.Fprintf(gin.DefaultWriter, "query: %v\n", query)
+
+			handleRowsForWatch(ginCtx, query, args, dbConnectionPool)
+
 			return
 		}
 
-		handleRows(ginCtx, query, dbConnectionPool, customResourceColumnDefinitions)
+		query := "SELECT c.payload, i.platform, i.product, i.version, i.region, i.kube_version, i.cpu_cores," +
+			" i.memory_bytes, i.available" + fromClause
+		fmt.Fprintf(gin.DefaultWriter, "query: %v\n", query)
+
+		handleRows(ginCtx, query, args, dbConnectionPool, customResourceColumnDefinitions)
 	}
 }
 
-func handleRowsForWatch(ginCtx *gin.Context, query string, dbConnectionPool *pgxpool.Pool) {
+func handleRowsForWatch(ginCtx *gin.Context, query string, args []interface{}, dbConnectionPool *pgxpool.Pool) {
 	writer := ginCtx.Writer
 	header := writer.Header()
 	header.Set("Transfer-Encoding", "chunked")
@@ -87,15 +102,15 @@ func handleRowsForWatch(ginCtx *gin.Context, query string, dbConnectionPool *pgx
 				return
 			}
 
-			doHandleRowsForWatch(ctx, writer, query, dbConnectionPool, previouslyAddedManagedClusterNames)
+			doHandleRowsForWatch(ctx, writer, query, args, dbConnectionPool, previouslyAddedManagedClusterNames)
 		}
 	}
 }
 
-func doHandleRowsForWatch(ctx context.Context, writer io.Writer, query string, dbConnectionPool *pgxpool.Pool,
-	previouslyAddedManagedClusterNames set.Set,
+func doHandleRowsForWatch(ctx context.Context, writer io.Writer, query string, args []interface{},
+	dbConnectionPool *pgxpool.Pool, previouslyAddedManagedClusterNames set.Set,
 ) {
-	rows, err := dbConnectionPool.Query(ctx, query)
+	rows, err := dbConnectionPool.Query(ctx, query, args...)
 	if err != nil {
 		fmt.Fprintf(gin.DefaultWriter, "error in quering managed clusters: %v\n", err)
 	}
@@ -179,27 +194,30 @@ func sendWatchEvent(watchEvent *metav1.WatchEvent, writer io.Writer) {
 	}
 }
 
-func handleRows(ginCtx *gin.Context, query string, dbConnectionPool *pgxpool.Pool,
+func handleRows(ginCtx *gin.Context, query string, args []interface{}, dbConnectionPool *pgxpool.Pool,
 	customResourceColumnDefinitions []apiextensionsv1.CustomResourceColumnDefinition,
 ) {
-	rows, err := dbConnectionPool.Query(context.TODO(), query)
+	rows, err := dbConnectionPool.Query(context.TODO(), query, args...)
 	if err != nil {
 		ginCtx.String(http.StatusInternalServerError, "internal error")
 		fmt.Fprintf(gin.DefaultWriter, "error in quering managed clusters: %v\n", err)
 	}
 
 	managedClusters := []*clusterv1.ManagedCluster{}
+	inventories := []*clusterInventory{}
 
 	for rows.Next() {
 		managedCluster := &clusterv1.ManagedCluster{}
+		inventory := &clusterInventory{}
 
-		err := rows.Scan(managedCluster)
+		err := rows.Scan(append([]interface{}{managedCluster}, inventory.scanArgs()...)...)
 		if err != nil {
 			fmt.Fprintf(gin.DefaultWriter, "error in scanning a managed cluster: %v\n", err)
 			continue
 		}
 
 		managedClusters = append(managedClusters, managedCluster)
+		inventories = append(inventories, inventory)
 	}
 
 	if shouldReturnAsTable(ginCtx) {
@@ -223,6 +241,12 @@ func handleRows(ginCtx *gin.Context, query string, dbConnectionPool *pgxpool.Poo
 			return
 		}
 
+		// the rows of the table are in the order of the managed clusters
+		table.ColumnDefinitions = append(table.ColumnDefinitions, inventoryColumnDefinitions...)
+		for i := range table.Rows {
+			table.Rows[i].Cells = append(table.Rows[i].Cells, inventories[i].cells()...)
+		}
+
 		table.Kind = "Table"
 		table.APIVersion = metav1.SchemeGroupVersion.String()
 		ginCtx.JSON(http.StatusOK, table)
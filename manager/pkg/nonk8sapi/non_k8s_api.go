@@ -17,7 +17,13 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/nonk8sapi/authentication"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/nonk8sapi/clusterlifecycle"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/nonk8sapi/events"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/nonk8sapi/gitops"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/nonk8sapi/managedclusteraddons"
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/nonk8sapi/managedclusters"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/nonk8sapi/policies"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/nonk8sapi/preview"
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
 )
 
@@ -81,6 +87,13 @@ func AddNonK8sApiServer(mgr ctrl.Manager, database db.DB, nonK8sAPIServerConfig
 	routerGroup := router.Group(nonK8sAPIServerConfig.ServerBasePath)
 	routerGroup.GET("/managedclusters", managedclusters.List(database.GetConn()))
 	routerGroup.PATCH("/managedclusters/:cluster", managedclusters.Patch(database.GetConn()))
+	routerGroup.GET("/managedclusteraddons", managedclusteraddons.List(database.GetConn()))
+	routerGroup.GET("/clusterlifecycle", clusterlifecycle.List(database.GetConn()))
+	routerGroup.GET("/gitops", gitops.List(database.GetConn()))
+	routerGroup.GET("/events", events.List(database.GetConn()))
+	routerGroup.GET("/compliancedetails", policies.ListComplianceDetails(database.GetConn()))
+	routerGroup.GET("/previews", preview.List(database))
+	routerGroup.POST("/previews", preview.Create(database))
 
 	err = mgr.Add(&nonK8sApiServer{
 		log: ctrl.Log.WithName("non-k8s-api-server"),
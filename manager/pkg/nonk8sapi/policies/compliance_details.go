@@ -0,0 +1,77 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package policies
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4/pgxpool"
+
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+)
+
+// ComplianceDetails are the violations of a global policy on a non compliant managed cluster.
+type ComplianceDetails struct {
+	PolicyID        string                      `json:"policyId"`
+	PolicyNamespace string                      `json:"policyNamespace"`
+	PolicyName      string                      `json:"policyName"`
+	RegionalHub     string                      `json:"regionalHub"`
+	ClusterName     string                      `json:"clusterName"`
+	Violations      []*status.TemplateViolation `json:"violations"`
+	// OmittedViolations is the number of violations that weren't sent by the regional hub to keep the status small.
+	OmittedViolations int       `json:"omittedViolations,omitempty"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+// ListComplianceDetails middleware, lists the violations of the global policies on the non compliant managed
+// clusters, optionally filtered by the policyId and cluster query parameters.
+func ListComplianceDetails(dbConnectionPool *pgxpool.Pool) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		query := `SELECT d.id, p.payload -> 'metadata' ->> 'namespace', p.payload -> 'metadata' ->> 'name',
+			d.leaf_hub_name, d.cluster_name, d.violations, d.omitted_violations, d.updated_at
+			FROM status.compliance_details d JOIN spec.policies p ON p.id = d.id
+			WHERE p.deleted = FALSE`
+		args := []interface{}{}
+
+		if policyID := ginCtx.Query("policyId"); policyID != "" {
+			args = append(args, policyID)
+			query += fmt.Sprintf(" AND d.id::text = $%d", len(args))
+		}
+
+		if clusterName := ginCtx.Query("cluster"); clusterName != "" {
+			args = append(args, clusterName)
+			query += fmt.Sprintf(" AND d.cluster_name = $%d", len(args))
+		}
+
+		query += " ORDER BY 2, 3, d.leaf_hub_name, d.cluster_name"
+
+		rows, err := dbConnectionPool.Query(ginCtx, query, args...)
+		if err != nil {
+			ginCtx.String(http.StatusInternalServerError, "internal error")
+			fmt.Fprintf(gin.DefaultWriter, "error in querying compliance details: %v\n", err)
+
+			return
+		}
+
+		defer rows.Close()
+
+		complianceDetails := make([]*ComplianceDetails, 0)
+
+		for rows.Next() {
+			details := &ComplianceDetails{}
+			if err := rows.Scan(&details.PolicyID, &details.PolicyNamespace, &details.PolicyName,
+				&details.RegionalHub, &details.ClusterName, &details.Violations, &details.OmittedViolations,
+				&details.UpdatedAt); err != nil {
+				fmt.Fprintf(gin.DefaultWriter, "error in scanning compliance details: %v\n", err)
+				continue
+			}
+
+			complianceDetails = append(complianceDetails, details)
+		}
+
+		ginCtx.JSON(http.StatusOK, complianceDetails)
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package preview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/gin-gonic/gin"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/syncer/dbsyncer"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+const (
+	genericResourcesTableName          = "generic_resources"
+	managedClusterSetBindingsTableName = "managedclustersetbindings"
+)
+
+// kindTables is a map of kind -> the spec table of the kind, the kinds that aren't listed are propagated through the
+// generic resources table.
+var kindTables = map[schema.GroupKind]string{
+	{Group: "policy.open-cluster-management.io", Kind: "Policy"}:                    "policies",
+	{Group: "policy.open-cluster-management.io", Kind: "PolicySet"}:                 "policysets",
+	{Group: "policy.open-cluster-management.io", Kind: "PolicyAutomation"}:          "policyautomations",
+	{Group: "policy.open-cluster-management.io", Kind: "PlacementBinding"}:          "placementbindings",
+	{Group: "cluster.open-cluster-management.io", Kind: "Placement"}:                "placements",
+	{Group: "cluster.open-cluster-management.io", Kind: "ManagedClusterSet"}:        "managedclustersets",
+	{Group: "cluster.open-cluster-management.io", Kind: "ManagedClusterSetBinding"}: managedClusterSetBindingsTableName,
+	{Group: "apps.open-cluster-management.io", Kind: "PlacementRule"}:               "placementrules",
+	{Group: "apps.open-cluster-management.io", Kind: "Subscription"}:                "subscriptions",
+	{Group: "apps.open-cluster-management.io", Kind: "Channel"}:                     "channels",
+	{Group: "app.k8s.io", Kind: "Application"}:                                      "applications",
+}
+
+// Preview is the impact of distributing a version of a global resource.
+type Preview struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Held is true if the version is held from the distribution.
+	Held bool `json:"held"`
+	// RegionalHubs are the regional hubs the version is distributed to.
+	RegionalHubs []string `json:"regionalHubs"`
+	// ManagedClusters are the managed clusters selected by the version, it's null for the kinds that don't select
+	// managed clusters.
+	ManagedClusters []SelectedCluster `json:"managedClusters"`
+	// NumberOfClusters is the number of the managed clusters selected on each regional hub, if it's limited.
+	NumberOfClusters *int32 `json:"numberOfClusters,omitempty"`
+	// Distributed is true if a version of the resource is distributed already.
+	Distributed bool `json:"distributed"`
+	// Diff is the JSON merge patch from the distributed version to the version.
+	Diff json.RawMessage `json:"diff,omitempty"`
+}
+
+// SelectedCluster is a managed cluster selected by a global resource.
+type SelectedCluster struct {
+	Name        string `json:"name"`
+	RegionalHub string `json:"regionalHub"`
+}
+
+type heldObject struct {
+	tableName string
+	object    *unstructured.Unstructured
+}
+
+// Create middleware, previews the distribution of the global resource in the request body.
+func Create(database db.DB) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		object := &unstructured.Unstructured{}
+
+		if err := ginCtx.BindJSON(object); err != nil {
+			fmt.Fprintf(gin.DefaultWriter, "failed to bind: %s\n", err.Error())
+			return
+		}
+
+		if object.GetKind() == "" || object.GetName() == "" {
+			ginCtx.String(http.StatusBadRequest, "kind and name of the resource are required")
+			return
+		}
+
+		tableName, found := kindTables[object.GroupVersionKind().GroupKind()]
+		if !found {
+			tableName = genericResourcesTableName
+		}
+
+		preview, err := previewObject(ginCtx, database, tableName, object)
+		if err != nil {
+			ginCtx.String(http.StatusInternalServerError, "internal error")
+			fmt.Fprintf(gin.DefaultWriter, "error in previewing the resource: %v\n", err)
+
+			return
+		}
+
+		preview.Held = isHeld(object)
+
+		ginCtx.JSON(http.StatusOK, preview)
+	}
+}
+
+// List middleware, previews the distribution of the global resources held from the distribution.
+func List(database db.DB) gin.HandlerFunc {
+	return func(ginCtx *gin.Context) {
+		rows, err := database.GetConn().Query(ginCtx, `SELECT table_name, payload FROM spec.held_resources
+			ORDER BY table_name, payload -> 'metadata' ->> 'namespace', payload -> 'metadata' ->> 'name'`)
+		if err != nil {
+			ginCtx.String(http.StatusInternalServerError, "internal error")
+			fmt.Fprintf(gin.DefaultWriter, "error in querying held resources: %v\n", err)
+
+			return
+		}
+
+		heldObjects := make([]*heldObject, 0)
+
+		for rows.Next() {
+			heldObject := &heldObject{object: &unstructured.Unstructured{}}
+			if err := rows.Scan(&heldObject.tableName, &heldObject.object.Object); err != nil {
+				fmt.Fprintf(gin.DefaultWriter, "error in scanning a held resource: %v\n", err)
+				continue
+			}
+
+			heldObjects = append(heldObjects, heldObject)
+		}
+
+		rows.Close() // the connection is used again to preview the held resources
+
+		previews := make([]*Preview, 0, len(heldObjects))
+
+		for _, heldObject := range heldObjects {
+			preview, err := previewObject(ginCtx, database, heldObject.tableName, heldObject.object)
+			if err != nil {
+				ginCtx.String(http.StatusInternalServerError, "internal error")
+				fmt.Fprintf(gin.DefaultWriter, "error in previewing a held resource: %v\n", err)
+
+				return
+			}
+
+			preview.Held = true
+			previews = append(previews, preview)
+		}
+
+		ginCtx.JSON(http.StatusOK, previews)
+	}
+}
+
+func previewObject(ctx context.Context, specDB db.SpecDB, tableName string,
+	object *unstructured.Unstructured,
+) (*Preview, error) {
+	preview := &Preview{
+		Kind:      getKind(tableName, object),
+		Namespace: object.GetNamespace(),
+		Name:      object.GetName(),
+	}
+
+	var err error
+	if tableName == placementsTableName || tableName == placementRulesTableName {
+		// a placement is distributed to the regional hubs with managed clusters it selects, so the version itself
+		// is evaluated on all the regional hubs rather than resolving the regional hubs of the distributed version.
+		err = previewPlacement(ctx, specDB, tableName, object, preview)
+	} else {
+		err = previewPlacedObject(ctx, specDB, tableName, object, preview)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	distributedObject, err := getDistributedObject(ctx, specDB, tableName, object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the distributed version - %w", err)
+	}
+
+	if distributedObject != nil {
+		preview.Distributed = true
+
+		if preview.Diff, err = diff(distributedObject, object); err != nil {
+			return nil, fmt.Errorf("failed to diff the distributed version - %w", err)
+		}
+	}
+
+	return preview, nil
+}
+
+func previewPlacement(ctx context.Context, specDB db.SpecDB, tableName string,
+	object *unstructured.Unstructured, preview *Preview,
+) error {
+	leafHubNames, err := specDB.GetLeafHubNames(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the regional hubs - %w", err)
+	}
+
+	if preview.ManagedClusters, preview.NumberOfClusters, err = selectManagedClusters(ctx, specDB, tableName,
+		object, leafHubNames); err != nil {
+		return fmt.Errorf("failed to select the managed clusters - %w", err)
+	}
+
+	regionalHubs := make([]string, 0)
+
+	for _, cluster := range preview.ManagedClusters { // the clusters are sorted by regional hub
+		if len(regionalHubs) == 0 || regionalHubs[len(regionalHubs)-1] != cluster.RegionalHub {
+			regionalHubs = append(regionalHubs, cluster.RegionalHub)
+		}
+	}
+
+	preview.RegionalHubs = regionalHubs
+
+	return nil
+}
+
+func previewPlacedObject(ctx context.Context, specDB db.SpecDB, tableName string,
+	object *unstructured.Unstructured, preview *Preview,
+) error {
+	var err error
+	if preview.RegionalHubs, err = dbsyncer.GetDestinationLeafHubs(ctx, specDB, tableName,
+		object); err != nil {
+		return fmt.Errorf("failed to get the regional hubs - %w", err)
+	}
+
+	if preview.ManagedClusters, preview.NumberOfClusters, err = selectManagedClusters(ctx, specDB, tableName,
+		object, preview.RegionalHubs); err != nil {
+		return fmt.Errorf("failed to select the managed clusters - %w", err)
+	}
+
+	return nil
+}
+
+// getKind returns the kind of the object, the objects of the dedicated tables are stored without their kind.
+func getKind(tableName string, object *unstructured.Unstructured) string {
+	if object.GetKind() != "" {
+		return object.GetKind()
+	}
+
+	for groupKind, kindTableName := range kindTables {
+		if kindTableName == tableName {
+			return groupKind.Kind
+		}
+	}
+
+	return ""
+}
+
+// getDistributedObject returns the version of the object in the spec table, or nil if it isn't distributed.
+func getDistributedObject(ctx context.Context, specDB db.SpecDB, tableName string,
+	object *unstructured.Unstructured,
+) (*unstructured.Unstructured, error) {
+	gvk := schema.GroupVersionKind{} // the objects of the dedicated tables are stored without their type
+	if tableName == genericResourcesTableName {
+		gvk = object.GroupVersionKind()
+	}
+
+	return specDB.GetDistributedObject(ctx, tableName, gvk, object.GetName(), object.GetNamespace())
+}
+
+// diff returns the JSON merge patch from the distributed version of the object to the object.
+func diff(distributedObject, object *unstructured.Unstructured) (json.RawMessage, error) {
+	original, err := json.Marshal(cleanObject(distributedObject))
+	if err != nil {
+		return nil, err
+	}
+
+	modified, err := json.Marshal(cleanObject(object))
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonpatch.CreateMergePatch(original, modified)
+}
+
+// cleanObject returns a copy of the object without the fields that aren't distributed.
+func cleanObject(object *unstructured.Unstructured) map[string]interface{} {
+	cleanObject := object.DeepCopy()
+
+	// the objects of the dedicated tables are stored without their type
+	unstructured.RemoveNestedField(cleanObject.Object, "apiVersion")
+	unstructured.RemoveNestedField(cleanObject.Object, "kind")
+	unstructured.RemoveNestedField(cleanObject.Object, "status")
+
+	for _, field := range []string{
+		"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "finalizers",
+		"ownerReferences",
+	} {
+		unstructured.RemoveNestedField(cleanObject.Object, "metadata", field)
+	}
+
+	annotations := cleanObject.GetAnnotations()
+	delete(annotations, constants.HoldDistributionAnnotation)
+	delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+
+	if len(annotations) == 0 {
+		unstructured.RemoveNestedField(cleanObject.Object, "metadata", "annotations")
+	} else {
+		cleanObject.SetAnnotations(annotations)
+	}
+
+	return cleanObject.Object
+}
+
+func isHeld(object metav1.Object) bool {
+	_, found := object.GetAnnotations()[constants.HoldDistributionAnnotation]
+	return found
+}
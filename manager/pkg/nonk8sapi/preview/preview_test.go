@@ -0,0 +1,323 @@
+package preview
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	policyv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
+	policyv1beta1 "open-cluster-management.io/governance-policy-propagator/api/v1beta1"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/bundle"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+// fakeSpecDB holds the managed clusters of the regional hubs and the distributed objects of the spec tables.
+type fakeSpecDB struct {
+	db.SpecDB
+	managedClusters map[string][]*clusterv1.ManagedCluster
+	// objects is a map of spec table -> the distributed objects of the table.
+	objects map[string][]*unstructured.Unstructured
+}
+
+func (f *fakeSpecDB) GetLeafHubNames(context.Context) ([]string, error) {
+	leafHubNames := make([]string, 0, len(f.managedClusters))
+	for leafHubName := range f.managedClusters {
+		leafHubNames = append(leafHubNames, leafHubName)
+	}
+
+	sort.Strings(leafHubNames)
+
+	return leafHubNames, nil
+}
+
+func (f *fakeSpecDB) GetManagedClusters(context.Context) (map[string][]*clusterv1.ManagedCluster, error) {
+	return f.managedClusters, nil
+}
+
+func (f *fakeSpecDB) GetDistributedObjects(_ context.Context, tableName string,
+	createObjFunc bundle.CreateObjectFunction,
+) ([]metav1.Object, error) {
+	objects := make([]metav1.Object, 0, len(f.objects[tableName]))
+
+	for _, distributedObject := range f.objects[tableName] {
+		object := createObjFunc()
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(distributedObject.Object,
+			object); err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+func (f *fakeSpecDB) GetDistributedObject(_ context.Context, tableName string, gvk schema.GroupVersionKind,
+	name, namespace string,
+) (*unstructured.Unstructured, error) {
+	for _, object := range f.objects[tableName] {
+		if object.GetName() == name && object.GetNamespace() == namespace &&
+			(gvk.Empty() || object.GroupVersionKind() == gvk) {
+			return object.DeepCopy(), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (f *fakeSpecDB) GetClusterSetsPerNamespace(_ context.Context, tableName string) (map[string][]string, error) {
+	clusterSetsPerNamespace := make(map[string][]string)
+
+	for _, object := range f.objects[tableName] {
+		clusterSet, _, _ := unstructured.NestedString(object.Object, "spec", "clusterSet")
+		clusterSetsPerNamespace[object.GetNamespace()] = append(clusterSetsPerNamespace[object.GetNamespace()],
+			clusterSet)
+	}
+
+	return clusterSetsPerNamespace, nil
+}
+
+// newFakeSpecDB returns the database of two regional hubs with two managed clusters each:
+// - hub1: cluster1 (env=prod, set1), cluster2 (env=dev, set1, tainted by maintenance)
+// - hub2: cluster3 (env=prod, set2), cluster4 (env=dev, set2, claim region=us-east)
+// the default namespace is bound to set1 and set2, the restricted namespace to set1. the policy1 and the policy set
+// set-a of policy2 are bound to the placement prod-placement of env=prod in the default namespace.
+func newFakeSpecDB(t *testing.T) *fakeSpecDB {
+	t.Helper()
+
+	newCluster := func(name, env, clusterSet string) *clusterv1.ManagedCluster {
+		return &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"env": env, clusterv1beta1.ClusterSetLabel: clusterSet},
+		}}
+	}
+
+	cluster2 := newCluster("cluster2", "dev", "set1")
+	cluster2.Spec.Taints = []clusterv1.Taint{{Key: "maintenance", Effect: clusterv1.TaintEffectNoSelect}}
+
+	cluster4 := newCluster("cluster4", "dev", "set2")
+	cluster4.Status.ClusterClaims = []clusterv1.ManagedClusterClaim{{Name: "region", Value: "us-east"}}
+
+	return &fakeSpecDB{
+		managedClusters: map[string][]*clusterv1.ManagedCluster{
+			"hub1": {newCluster("cluster1", "prod", "set1"), cluster2},
+			"hub2": {newCluster("cluster3", "prod", "set2"), cluster4},
+		},
+		objects: map[string][]*unstructured.Unstructured{
+			managedClusterSetBindingsTableName: {
+				newClusterSetBinding(t, "default", "set1"),
+				newClusterSetBinding(t, "default", "set2"),
+				newClusterSetBinding(t, "restricted", "set1"),
+			},
+			placementsTableName: {
+				newPlacement(t, "default", "prod-placement", func(placement *clusterv1beta1.Placement) {
+					placement.Spec.Predicates = labelPredicates("prod")
+				}),
+			},
+			placementBindingsTableName: {
+				toUnstructured(t, &policyv1.PlacementBinding{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "prod-binding"},
+					PlacementRef: policyv1.PlacementSubject{
+						APIGroup: clusterv1beta1.GroupName,
+						Kind:     placementKind,
+						Name:     "prod-placement",
+					},
+					Subjects: []policyv1.Subject{
+						{APIGroup: policyv1.GroupVersion.Group, Kind: "Policy", Name: "policy1"},
+						{APIGroup: policyv1.GroupVersion.Group, Kind: "PolicySet", Name: "set-a"},
+					},
+				}),
+			},
+			policySetsTableName: {
+				toUnstructured(t, &policyv1beta1.PolicySet{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "set-a"},
+					Spec:       policyv1beta1.PolicySetSpec{Policies: []policyv1beta1.NonEmptyString{"policy2"}},
+				}),
+			},
+			genericResourcesTableName: {newConfigMap("default", "config", "1")},
+		},
+	}
+}
+
+func TestPreviewObject(t *testing.T) {
+	heldPlacement := newPlacement(t, "default", "prod-placement", func(placement *clusterv1beta1.Placement) {
+		placement.Spec.Predicates = labelPredicates("dev")
+	})
+	heldPlacement.SetAnnotations(map[string]string{constants.HoldDistributionAnnotation: ""})
+
+	cases := []struct {
+		name              string
+		tableName         string
+		object            *unstructured.Unstructured
+		expectedKind      string
+		expectedHubs      []string
+		expectedClusters  []SelectedCluster
+		expectedDiff      string
+		expectDistributed bool
+	}{
+		{
+			name:             "held placement",
+			tableName:        placementsTableName,
+			object:           heldPlacement,
+			expectedKind:     placementKind,
+			expectedHubs:     []string{"hub2"}, // the tainted cluster2 isn't selected
+			expectedClusters: []SelectedCluster{{Name: "cluster4", RegionalHub: "hub2"}},
+			expectedDiff: `{"spec":{"predicates":[{"requiredClusterSelector":{"claimSelector":{},` +
+				`"labelSelector":{"matchLabels":{"env":"dev"}}}}]}}`,
+			expectDistributed: true,
+		},
+		{
+			name:      "new placement",
+			tableName: placementsTableName,
+			object: newPlacement(t, "default", "new-placement", func(placement *clusterv1beta1.Placement) {
+				placement.Spec.ClusterSets = []string{"set1"}
+			}),
+			expectedKind:     placementKind,
+			expectedHubs:     []string{"hub1"},
+			expectedClusters: []SelectedCluster{{Name: "cluster1", RegionalHub: "hub1"}},
+		},
+		{
+			name:              "generic resource",
+			tableName:         genericResourcesTableName,
+			object:            newConfigMap("default", "config", "2"),
+			expectedKind:      "ConfigMap",
+			expectedHubs:      []string{"hub1", "hub2"}, // the hubs of the placements in the namespace
+			expectedDiff:      `{"data":{"value":"2"}}`,
+			expectDistributed: true,
+		},
+		{
+			name:         "generic resource of another kind",
+			tableName:    genericResourcesTableName,
+			object:       newSecret("default", "config"),
+			expectedKind: "Secret",
+			expectedHubs: []string{"hub1", "hub2"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			preview, err := previewObject(context.Background(), newFakeSpecDB(t), c.tableName, c.object)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if preview.Kind != c.expectedKind || !reflect.DeepEqual(preview.RegionalHubs, c.expectedHubs) ||
+				!reflect.DeepEqual(preview.ManagedClusters, c.expectedClusters) {
+				t.Errorf("unexpected preview kind %s, regional hubs %v, managed clusters %v", preview.Kind,
+					preview.RegionalHubs, preview.ManagedClusters)
+			}
+
+			if preview.Distributed != c.expectDistributed || string(preview.Diff) != c.expectedDiff {
+				t.Errorf("expected distributed %v with diff %s, got %v with diff %s", c.expectDistributed,
+					c.expectedDiff, preview.Distributed, preview.Diff)
+			}
+		})
+	}
+}
+
+func TestDiffIgnoresUndistributedFields(t *testing.T) {
+	distributedObject := newConfigMap("default", "config", "1")
+	object := newConfigMap("default", "config", "1")
+	object.SetUID("uid")
+	object.SetResourceVersion("2")
+	object.SetFinalizers([]string{constants.GlobalHubCleanupFinalizer})
+	object.SetAnnotations(map[string]string{constants.HoldDistributionAnnotation: ""})
+
+	patch, err := diff(distributedObject, object)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(patch) != "{}" {
+		t.Errorf("expected no diff, got %s", patch)
+	}
+
+	object.SetAnnotations(map[string]string{constants.HoldDistributionAnnotation: "", "owner": "team-a"})
+
+	if patch, err = diff(distributedObject, object); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": map[string]interface{}{"owner": "team-a"}},
+	}
+	actual := map[string]interface{}{}
+
+	if err := json.Unmarshal(patch, &actual); err != nil || !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected the annotation in the diff, got %s", patch)
+	}
+}
+
+func toUnstructured(t *testing.T, object interface{}) *unstructured.Unstructured {
+	t.Helper()
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the objects of the dedicated tables are stored without their type and status
+	delete(content, "status")
+
+	return &unstructured.Unstructured{Object: content}
+}
+
+func newPlacement(t *testing.T, namespace, name string,
+	mutate func(placement *clusterv1beta1.Placement),
+) *unstructured.Unstructured {
+	t.Helper()
+
+	placement := &clusterv1beta1.Placement{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	mutate(placement)
+
+	return toUnstructured(t, placement)
+}
+
+func labelPredicates(env string) []clusterv1beta1.ClusterPredicate {
+	return []clusterv1beta1.ClusterPredicate{{
+		RequiredClusterSelector: clusterv1beta1.ClusterSelector{
+			LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": env}},
+		},
+	}}
+}
+
+func newClusterSetBinding(t *testing.T, namespace, clusterSet string) *unstructured.Unstructured {
+	t.Helper()
+
+	return toUnstructured(t, &clusterv1beta1.ManagedClusterSetBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: clusterSet},
+		Spec:       clusterv1beta1.ManagedClusterSetBindingSpec{ClusterSet: clusterSet},
+	})
+}
+
+func newConfigMap(namespace, name, value string) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{Object: map[string]interface{}{
+		"data": map[string]interface{}{"value": value},
+	}}
+	object.SetAPIVersion("v1")
+	object.SetKind("ConfigMap")
+	object.SetNamespace(namespace)
+	object.SetName(name)
+
+	return object
+}
+
+func newSecret(namespace, name string) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{}
+	object.SetAPIVersion("v1")
+	object.SetKind("Secret")
+	object.SetNamespace(namespace)
+	object.SetName(name)
+
+	return object
+}
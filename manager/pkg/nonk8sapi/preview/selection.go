@@ -0,0 +1,377 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package preview
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	policyv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
+	policyv1beta1 "open-cluster-management.io/governance-policy-propagator/api/v1beta1"
+	placementrulev1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/apps/placementrule/v1"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/bundle"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
+	placementmatcher "github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/placement"
+)
+
+const (
+	placementsTableName        = "placements"
+	placementRulesTableName    = "placementrules"
+	placementBindingsTableName = "placementbindings"
+	policySetsTableName        = "policysets"
+	placementKind              = "Placement"
+	placementRuleKind          = "PlacementRule"
+)
+
+// clusterSelector selects the managed clusters of the regional hubs a global resource is distributed to, the way the
+// placements are evaluated on the regional hubs. the related resources, e.g. the placement of a policy, are read in
+// their distributed version.
+type clusterSelector struct {
+	ctx             context.Context
+	database        db.SpecDB
+	regionalHubs    map[string]struct{}
+	managedClusters []*managedCluster
+}
+
+type managedCluster struct {
+	leafHubName string
+	cluster     *clusterv1.ManagedCluster
+}
+
+// selectManagedClusters returns the managed clusters selected by the object and the number of the clusters selected
+// on each regional hub if it's limited. the clusters are nil if the object doesn't select managed clusters.
+func selectManagedClusters(ctx context.Context, database db.SpecDB, tableName string,
+	object *unstructured.Unstructured, regionalHubs []string,
+) ([]SelectedCluster, *int32, error) {
+	selector := &clusterSelector{
+		ctx:          ctx,
+		database:     database,
+		regionalHubs: make(map[string]struct{}, len(regionalHubs)),
+	}
+
+	for _, regionalHub := range regionalHubs {
+		selector.regionalHubs[regionalHub] = struct{}{}
+	}
+
+	switch tableName {
+	case placementsTableName:
+		return selector.selectByPlacement(object)
+	case placementRulesTableName:
+		return selector.selectByPlacementRule(object)
+	case "policies":
+		return selector.selectBySubject(object.GetNamespace(), "Policy", object.GetName())
+	case policySetsTableName:
+		return selector.selectBySubject(object.GetNamespace(), "PolicySet", object.GetName())
+	case placementBindingsTableName:
+		binding := &policyv1.PlacementBinding{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, binding); err != nil {
+			return nil, nil, fmt.Errorf("invalid placement binding - %w", err)
+		}
+
+		return selector.selectByPlacementRef(binding.Namespace, binding.PlacementRef.Kind, binding.PlacementRef.Name)
+	case "subscriptions":
+		name, _, _ := unstructured.NestedString(object.Object, "spec", "placement", "placementRef", "name")
+		kind, _, _ := unstructured.NestedString(object.Object, "spec", "placement", "placementRef", "kind")
+
+		if name == "" {
+			return []SelectedCluster{}, nil, nil
+		}
+
+		if kind == "" {
+			kind = placementRuleKind
+		}
+
+		return selector.selectByPlacementRef(object.GetNamespace(), kind, name)
+	case "managedclustersets":
+		clusters, err := selector.selectByClusterSets(map[string]struct{}{object.GetName(): {}})
+		return clusters, nil, err
+	case managedClusterSetBindingsTableName:
+		clusterSet, _, _ := unstructured.NestedString(object.Object, "spec", "clusterSet")
+		clusters, err := selector.selectByClusterSets(map[string]struct{}{clusterSet: {}})
+
+		return clusters, nil, err
+	default:
+		return nil, nil, nil
+	}
+}
+
+// selectByPlacement selects the managed clusters in the cluster sets of the placement, that match any of its
+// predicates and tolerate the taints of the clusters.
+func (selector *clusterSelector) selectByPlacement(object *unstructured.Unstructured) ([]SelectedCluster, *int32,
+	error,
+) {
+	placement := &clusterv1beta1.Placement{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, placement); err != nil {
+		return nil, nil, fmt.Errorf("invalid placement - %w", err)
+	}
+
+	clusterSetsPerNamespace, err := selector.database.GetClusterSetsPerNamespace(selector.ctx,
+		managedClusterSetBindingsTableName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matcher, err := placementmatcher.NewPlacementMatcher(placement, clusterSetsPerNamespace[placement.Namespace])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clusters, err := selector.selectByMatcher(matcher)
+
+	return clusters, placement.Spec.NumberOfClusters, err
+}
+
+// selectByPlacementRule selects the managed clusters listed in the placement rule or matching its selector, with the
+// conditions of the placement rule.
+func (selector *clusterSelector) selectByPlacementRule(object *unstructured.Unstructured) ([]SelectedCluster, *int32,
+	error,
+) {
+	placementRule := &placementrulev1.PlacementRule{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, placementRule); err != nil {
+		return nil, nil, fmt.Errorf("invalid placement rule - %w", err)
+	}
+
+	matcher, err := placementmatcher.NewPlacementRuleMatcher(placementRule)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clusters, err := selector.selectByMatcher(matcher)
+
+	return clusters, placementRule.Spec.ClusterReplicas, err
+}
+
+// selectByMatcher selects the managed clusters matched by the matcher of a placement or a placement rule.
+func (selector *clusterSelector) selectByMatcher(matcher *placementmatcher.Matcher) ([]SelectedCluster, error) {
+	candidates, err := selector.getManagedClusters()
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := make([]SelectedCluster, 0)
+
+	for _, candidate := range candidates {
+		if matcher.Matches(candidate.cluster) {
+			clusters = append(clusters, SelectedCluster{Name: candidate.cluster.Name, RegionalHub: candidate.leafHubName})
+		}
+	}
+
+	return clusters, nil
+}
+
+// selectBySubject selects the managed clusters of the placements bound to the policy or the policy set, a policy is
+// also bound through the policy sets that contain it.
+func (selector *clusterSelector) selectBySubject(namespace, kind, name string) ([]SelectedCluster, *int32, error) {
+	subjects := map[string][]string{kind: {name}}
+
+	if kind == "Policy" {
+		policySets, err := selector.getNamespacedObjects(policySetsTableName, namespace,
+			func() metav1.Object { return &policyv1beta1.PolicySet{} })
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, object := range policySets {
+			policySet, ok := object.(*policyv1beta1.PolicySet)
+			if !ok {
+				continue
+			}
+
+			for _, policy := range policySet.Spec.Policies {
+				if string(policy) == name {
+					subjects["PolicySet"] = append(subjects["PolicySet"], policySet.Name)
+				}
+			}
+		}
+	}
+
+	bindings, err := selector.getNamespacedObjects(placementBindingsTableName, namespace,
+		func() metav1.Object { return &policyv1.PlacementBinding{} })
+	if err != nil {
+		return nil, nil, err
+	}
+
+	selections := make([][]SelectedCluster, 0)
+	var numberOfClusters *int32
+
+	for _, object := range bindings {
+		binding, ok := object.(*policyv1.PlacementBinding)
+		if !ok {
+			continue
+		}
+
+		if !bindsSubjects(binding, subjects) {
+			continue
+		}
+
+		clusters, bindingNumberOfClusters, err := selector.selectByPlacementRef(namespace, binding.PlacementRef.Kind,
+			binding.PlacementRef.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		selections = append(selections, clusters)
+		numberOfClusters = bindingNumberOfClusters
+	}
+
+	if len(selections) != 1 { // the number of clusters of each placement doesn't limit the union of the clusters
+		numberOfClusters = nil
+	}
+
+	return union(selections), numberOfClusters, nil
+}
+
+// selectByPlacementRef selects the managed clusters of the distributed placement or placement rule.
+func (selector *clusterSelector) selectByPlacementRef(namespace, kind, name string) ([]SelectedCluster, *int32,
+	error,
+) {
+	tableName := placementRulesTableName
+	if kind == placementKind {
+		tableName = placementsTableName
+	}
+
+	object := &unstructured.Unstructured{}
+	object.SetNamespace(namespace)
+	object.SetName(name)
+
+	placement, err := getDistributedObject(selector.ctx, selector.database, tableName, object)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if placement == nil {
+		return []SelectedCluster{}, nil, nil
+	}
+
+	if kind == placementKind {
+		return selector.selectByPlacement(placement)
+	}
+
+	return selector.selectByPlacementRule(placement)
+}
+
+// selectByClusterSets selects the managed clusters in the cluster sets.
+func (selector *clusterSelector) selectByClusterSets(clusterSets map[string]struct{}) ([]SelectedCluster, error) {
+	candidates, err := selector.getManagedClusters()
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := make([]SelectedCluster, 0)
+
+	for _, candidate := range candidates {
+		if _, found := clusterSets[candidate.cluster.Labels[clusterv1beta1.ClusterSetLabel]]; found {
+			clusters = append(clusters, SelectedCluster{Name: candidate.cluster.Name, RegionalHub: candidate.leafHubName})
+		}
+	}
+
+	return clusters, nil
+}
+
+// getManagedClusters returns the managed clusters of the regional hubs sorted by regional hub and name.
+func (selector *clusterSelector) getManagedClusters() ([]*managedCluster, error) {
+	if selector.managedClusters != nil {
+		return selector.managedClusters, nil
+	}
+
+	clustersPerLeafHub, err := selector.database.GetManagedClusters(selector.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	managedClusters := make([]*managedCluster, 0)
+
+	for leafHubName, clusters := range clustersPerLeafHub {
+		if _, found := selector.regionalHubs[leafHubName]; !found {
+			continue
+		}
+
+		for _, cluster := range clusters {
+			managedClusters = append(managedClusters, &managedCluster{leafHubName: leafHubName, cluster: cluster})
+		}
+	}
+
+	sort.Slice(managedClusters, func(i, j int) bool {
+		if managedClusters[i].leafHubName != managedClusters[j].leafHubName {
+			return managedClusters[i].leafHubName < managedClusters[j].leafHubName
+		}
+
+		return managedClusters[i].cluster.Name < managedClusters[j].cluster.Name
+	})
+
+	selector.managedClusters = managedClusters
+
+	return managedClusters, nil
+}
+
+// getNamespacedObjects returns the distributed objects of the spec table in the namespace.
+func (selector *clusterSelector) getNamespacedObjects(tableName, namespace string,
+	createObjFunc bundle.CreateObjectFunction,
+) ([]metav1.Object, error) {
+	objects, err := selector.database.GetDistributedObjects(selector.ctx, tableName, createObjFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	namespacedObjects := make([]metav1.Object, 0)
+
+	for _, object := range objects {
+		if object.GetNamespace() == namespace {
+			namespacedObjects = append(namespacedObjects, object)
+		}
+	}
+
+	return namespacedObjects, nil
+}
+
+func bindsSubjects(binding *policyv1.PlacementBinding, subjects map[string][]string) bool {
+	for _, subject := range binding.Subjects {
+		if contains(subjects[subject.Kind], subject.Name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// union returns the sorted managed clusters selected by any of the selections.
+func union(selections [][]SelectedCluster) []SelectedCluster {
+	clusters := make([]SelectedCluster, 0)
+	selected := make(map[SelectedCluster]struct{})
+
+	for _, selection := range selections {
+		for _, cluster := range selection {
+			if _, found := selected[cluster]; !found {
+				selected[cluster] = struct{}{}
+				clusters = append(clusters, cluster)
+			}
+		}
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].RegionalHub != clusters[j].RegionalHub {
+			return clusters[i].RegionalHub < clusters[j].RegionalHub
+		}
+
+		return clusters[i].Name < clusters[j].Name
+	})
+
+	return clusters
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,215 @@
+package preview
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	policyv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
+	placementrulev1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/apps/placementrule/v1"
+)
+
+func TestSelectManagedClusters(t *testing.T) {
+	one := int32(1)
+	cluster1 := SelectedCluster{Name: "cluster1", RegionalHub: "hub1"}
+	cluster2 := SelectedCluster{Name: "cluster2", RegionalHub: "hub1"}
+	cluster3 := SelectedCluster{Name: "cluster3", RegionalHub: "hub2"}
+	cluster4 := SelectedCluster{Name: "cluster4", RegionalHub: "hub2"}
+
+	newObject := func(namespace, name string) *unstructured.Unstructured {
+		object := &unstructured.Unstructured{}
+		object.SetNamespace(namespace)
+		object.SetName(name)
+
+		return object
+	}
+
+	cases := []struct {
+		name                     string
+		tableName                string
+		object                   *unstructured.Unstructured
+		regionalHubs             []string
+		expectedClusters         []SelectedCluster
+		expectedNumberOfClusters *int32
+	}{
+		{
+			name:      "label selector",
+			tableName: placementsTableName,
+			object: newPlacement(t, "default", "placement", func(placement *clusterv1beta1.Placement) {
+				placement.Spec.Predicates = labelPredicates("prod")
+			}),
+			expectedClusters: []SelectedCluster{cluster1, cluster3},
+		},
+		{
+			name:      "claim selector",
+			tableName: placementsTableName,
+			object: newPlacement(t, "default", "placement", func(placement *clusterv1beta1.Placement) {
+				placement.Spec.Predicates = []clusterv1beta1.ClusterPredicate{{
+					RequiredClusterSelector: clusterv1beta1.ClusterSelector{
+						ClaimSelector: clusterv1beta1.ClusterClaimSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{{
+								Key:      "region",
+								Operator: metav1.LabelSelectorOpIn,
+								Values:   []string{"us-east"},
+							}},
+						},
+					},
+				}}
+			}),
+			expectedClusters: []SelectedCluster{cluster4},
+		},
+		{
+			name:      "cluster sets bound to the namespace",
+			tableName: placementsTableName,
+			object: newPlacement(t, "restricted", "placement", func(placement *clusterv1beta1.Placement) {
+				placement.Spec.Predicates = labelPredicates("prod")
+			}),
+			expectedClusters: []SelectedCluster{cluster1},
+		},
+		{
+			name:      "cluster sets of the placement",
+			tableName: placementsTableName,
+			object: newPlacement(t, "default", "placement", func(placement *clusterv1beta1.Placement) {
+				placement.Spec.ClusterSets = []string{"set2"}
+			}),
+			expectedClusters: []SelectedCluster{cluster3, cluster4},
+		},
+		{
+			name:      "namespace without cluster sets",
+			tableName: placementsTableName,
+			object: newPlacement(t, "unbound", "placement", func(placement *clusterv1beta1.Placement) {
+			}),
+			expectedClusters: []SelectedCluster{},
+		},
+		{
+			name:      "tainted cluster",
+			tableName: placementsTableName,
+			object: newPlacement(t, "default", "placement", func(placement *clusterv1beta1.Placement) {
+				placement.Spec.Predicates = labelPredicates("dev")
+			}),
+			expectedClusters: []SelectedCluster{cluster4},
+		},
+		{
+			name:      "tolerated taint",
+			tableName: placementsTableName,
+			object: newPlacement(t, "default", "placement", func(placement *clusterv1beta1.Placement) {
+				placement.Spec.Predicates = labelPredicates("dev")
+				placement.Spec.Tolerations = []clusterv1beta1.Toleration{{
+					Key:      "maintenance",
+					Operator: clusterv1beta1.TolerationOpExists,
+				}}
+			}),
+			expectedClusters: []SelectedCluster{cluster2, cluster4},
+		},
+		{
+			name:      "number of clusters",
+			tableName: placementsTableName,
+			object: newPlacement(t, "default", "placement", func(placement *clusterv1beta1.Placement) {
+				placement.Spec.NumberOfClusters = &one
+			}),
+			expectedClusters:         []SelectedCluster{cluster1, cluster3, cluster4},
+			expectedNumberOfClusters: &one,
+		},
+		{
+			name:      "placement rule",
+			tableName: placementRulesTableName,
+			object: toUnstructured(t, &placementrulev1.PlacementRule{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "placement-rule"},
+				Spec: placementrulev1.PlacementRuleSpec{
+					GenericPlacementFields: placementrulev1.GenericPlacementFields{
+						Clusters: []placementrulev1.GenericClusterReference{{Name: "cluster2"}, {Name: "cluster3"}},
+					},
+					ClusterReplicas: &one,
+				},
+			}),
+			expectedClusters:         []SelectedCluster{cluster2, cluster3},
+			expectedNumberOfClusters: &one,
+		},
+		{
+			name:             "regional hubs",
+			tableName:        placementsTableName,
+			object:           newPlacement(t, "default", "placement", func(*clusterv1beta1.Placement) {}),
+			regionalHubs:     []string{"hub2"},
+			expectedClusters: []SelectedCluster{cluster3, cluster4},
+		},
+		{
+			name:             "policy bound to a placement",
+			tableName:        "policies",
+			object:           newObject("default", "policy1"),
+			expectedClusters: []SelectedCluster{cluster1, cluster3},
+		},
+		{
+			name:             "policy bound through a policy set",
+			tableName:        "policies",
+			object:           newObject("default", "policy2"),
+			expectedClusters: []SelectedCluster{cluster1, cluster3},
+		},
+		{
+			name:             "unbound policy",
+			tableName:        "policies",
+			object:           newObject("default", "policy3"),
+			expectedClusters: []SelectedCluster{},
+		},
+		{
+			name:      "placement binding",
+			tableName: placementBindingsTableName,
+			object: toUnstructured(t, &policyv1.PlacementBinding{
+				ObjectMeta:   metav1.ObjectMeta{Namespace: "default", Name: "binding"},
+				PlacementRef: policyv1.PlacementSubject{Kind: placementKind, Name: "prod-placement"},
+			}),
+			expectedClusters: []SelectedCluster{cluster1, cluster3},
+		},
+		{
+			name:      "placement binding of a placement that isn't distributed",
+			tableName: placementBindingsTableName,
+			object: toUnstructured(t, &policyv1.PlacementBinding{
+				ObjectMeta:   metav1.ObjectMeta{Namespace: "default", Name: "binding"},
+				PlacementRef: policyv1.PlacementSubject{Kind: placementKind, Name: "other-placement"},
+			}),
+			expectedClusters: []SelectedCluster{},
+		},
+		{
+			name:             "managed cluster set",
+			tableName:        "managedclustersets",
+			object:           newObject("", "set2"),
+			expectedClusters: []SelectedCluster{cluster3, cluster4},
+		},
+		{
+			name:             "managed cluster set binding",
+			tableName:        managedClusterSetBindingsTableName,
+			object:           newClusterSetBinding(t, "default", "set1"),
+			expectedClusters: []SelectedCluster{cluster1, cluster2},
+		},
+		{
+			name:      "generic resource",
+			tableName: genericResourcesTableName,
+			object:    newConfigMap("default", "config", "1"),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			regionalHubs := c.regionalHubs
+			if regionalHubs == nil {
+				regionalHubs = []string{"hub1", "hub2"}
+			}
+
+			clusters, numberOfClusters, err := selectManagedClusters(context.Background(), newFakeSpecDB(t),
+				c.tableName, c.object, regionalHubs)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(clusters, c.expectedClusters) {
+				t.Errorf("expected managed clusters %v, got %v", c.expectedClusters, clusters)
+			}
+
+			if !reflect.DeepEqual(numberOfClusters, c.expectedNumberOfClusters) {
+				t.Errorf("expected number of clusters %v, got %v", c.expectedNumberOfClusters, numberOfClusters)
+			}
+		})
+	}
+}
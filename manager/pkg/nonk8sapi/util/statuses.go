@@ -0,0 +1,50 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// QueryStatuses calls handleStatus for each status of the table ordered by the regional hub, namespace and name.
+func QueryStatuses(ctx context.Context, dbConnectionPool *pgxpool.Pool, tableName string, regionalHubName string,
+	handleStatus func(leafHubName string, payload []byte) error,
+) error {
+	query := fmt.Sprintf("SELECT leaf_hub_name, payload FROM status.%s", tableName)
+	args := []interface{}{}
+
+	if regionalHubName != "" {
+		query += " WHERE leaf_hub_name = $1"
+		args = append(args, regionalHubName)
+	}
+
+	query += " ORDER BY leaf_hub_name, payload ->> 'namespace', payload ->> 'name'"
+
+	rows, err := dbConnectionPool.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			leafHubName string
+			payload     []byte
+		)
+
+		if err := rows.Scan(&leafHubName, &payload); err != nil {
+			return err
+		}
+
+		if err := handleStatus(leafHubName, payload); err != nil {
+			fmt.Fprintf(gin.DefaultWriter, "error in parsing a status of %s: %v\n", tableName, err)
+		}
+	}
+
+	return rows.Err()
+}
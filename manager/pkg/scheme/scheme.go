@@ -7,9 +7,11 @@ import (
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
 	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
 	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 	policyv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
+	policyv1beta1 "open-cluster-management.io/governance-policy-propagator/api/v1beta1"
 	channelv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
 	placementrulev1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/apps/placementrule/v1"
 	appsv1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/apps/v1"
@@ -22,6 +24,7 @@ import (
 // AddToScheme adds all the resources to be processed to the Scheme.
 func AddToScheme(scheme *runtime.Scheme) error {
 	schemeInstallFuncs := []func(scheme *runtime.Scheme) error{
+		clusterv1.Install,
 		clusterv1alpha1.Install,
 		clusterv1beta1.Install,
 	}
@@ -52,7 +55,7 @@ func AddToScheme(scheme *runtime.Scheme) error {
 
 func getSchemeBuilders() []*scheme.Builder {
 	return []*scheme.Builder{
-		policyv1.SchemeBuilder, placementrulev1.SchemeBuilder,
+		policyv1.SchemeBuilder, policyv1beta1.SchemeBuilder, placementrulev1.SchemeBuilder,
 		appsv1.SchemeBuilder, appsv1alpha1.SchemeBuilder, channelv1.SchemeBuilder,
 		subscriptionv1.SchemeBuilder, applicationv1beta1.SchemeBuilder,
 	}
@@ -15,8 +15,10 @@ func NewBaseObjectsBundle() ObjectsBundle {
 }
 
 type baseObjectsBundle struct {
-	Objects        []metav1.Object `json:"objects"`
-	DeletedObjects []metav1.Object `json:"deletedObjects"`
+	BundleVersion     string          `json:"bundleVersion,omitempty"`
+	BaseBundleVersion string          `json:"baseBundleVersion,omitempty"`
+	Objects           []metav1.Object `json:"objects"`
+	DeletedObjects    []metav1.Object `json:"deletedObjects"`
 }
 
 // AddObject adds an object to the bundle.
@@ -30,6 +32,12 @@ func (b *baseObjectsBundle) AddDeletedObject(object metav1.Object) {
 	b.DeletedObjects = append(b.DeletedObjects, object)
 }
 
+// SetBundleVersion sets the version of the bundle and the version a delta bundle is based on.
+func (b *baseObjectsBundle) SetBundleVersion(version string, baseVersion string) {
+	b.BundleVersion = version
+	b.BaseBundleVersion = baseVersion
+}
+
 // setMetaDataAnnotation sets metadata annotation on the given object.
 func setMetaDataAnnotation(object metav1.Object, key string, value string) {
 	annotations := object.GetAnnotations()
@@ -17,4 +17,7 @@ type ObjectsBundle interface {
 	AddObject(object metav1.Object, objectUID string)
 	// AddDeletedObject adds a deleted object to the bundle.
 	AddDeletedObject(object metav1.Object)
+	// SetBundleVersion sets the version of the bundle, the base version is the version of the bundle a delta bundle
+	// is based on, it's empty if the bundle holds the full state.
+	SetBundleVersion(version string, baseVersion string)
 }
@@ -8,6 +8,10 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v4/pgxpool"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/bundle"
@@ -31,7 +35,11 @@ type SpecDB interface {
 	GetLastUpdateTimestamp(ctx context.Context, tableName string, filterLocalResources bool) (*time.Time, error)
 
 	ObjectsSpecDB
+	GenericResourcesSpecDB
+	HeldSpecDB
 	ManagedClusterLabelsSpecDB
+	ResyncSpecDB
+	SpecDestinationsDB
 }
 
 // ObjectsSpecDB is the interface needed by the spec syncer and spec transport bridge to and from sync objects tables.
@@ -45,6 +53,38 @@ type ObjectsSpecDB interface {
 	// GetObjectsBundle returns a bundle of objects from a specific table.
 	GetObjectsBundle(ctx context.Context, tableName string, createObjFunc bundle.CreateObjectFunction,
 		intoBundle bundle.ObjectsBundle) (*time.Time, error)
+	// GetObjects returns the objects of a specific table with their update time, and the last update timestamp.
+	GetObjects(ctx context.Context, tableName string, createObjFunc bundle.CreateObjectFunction) ([]*SpecObject,
+		*time.Time, error)
+}
+
+// SpecObject is an object of a spec table.
+type SpecObject struct {
+	UID       string
+	Object    metav1.Object
+	Deleted   bool
+	UpdatedAt time.Time
+}
+
+// GenericResourcesSpecDB is the interface needed by the spec syncer to sync the objects of the configured kinds to
+// a table that holds objects of different kinds.
+type GenericResourcesSpecDB interface {
+	// DeleteGenericSpecObject deletes the object of a kind with name and namespace from a specific table.
+	DeleteGenericSpecObject(ctx context.Context, tableName string, gvk schema.GroupVersionKind,
+		name, namespace string) error
+	// DeleteGenericSpecObjects deletes all the objects of a kind from a specific table.
+	DeleteGenericSpecObjects(ctx context.Context, tableName string, gvk schema.GroupVersionKind) error
+	// GetGenericSpecKinds returns the kinds of the objects that aren't deleted in a specific table.
+	GetGenericSpecKinds(ctx context.Context, tableName string) ([]schema.GroupVersionKind, error)
+}
+
+// HeldSpecDB is the interface needed by the spec syncer to keep the objects held from the distribution to the leaf
+// hubs. a held object is kept out of its table, so the table holds the distributed version of the object.
+type HeldSpecDB interface {
+	// HoldSpecObject inserts or updates the held object of a specific table with object UID.
+	HoldSpecObject(ctx context.Context, tableName, objUID string, object *client.Object) error
+	// ReleaseSpecObject deletes the held object of a specific table with object UID, if it exists.
+	ReleaseSpecObject(ctx context.Context, tableName, objUID string) error
 }
 
 // ManagedClusterLabelsSpecDB is the interface needed by the spec transport bridge to sync managed-cluster labels table.
@@ -75,11 +115,42 @@ type TempManagedClusterLabelsSpecDB interface {
 		managedClusterName string, leafHubName string) error
 }
 
+// ResyncSpecDB is the interface needed by the spec transport bridge to sync resync requests table.
+type ResyncSpecDB interface {
+	// GetLatestResyncBundle returns the latest resync request from a specific table.
+	GetLatestResyncBundle(ctx context.Context, tableName string) (*spec.ResyncSpecBundle, error)
+}
+
+// SpecDestinationsDB is the interface needed by the spec transport bridge to resolve the leaf hubs that the spec
+// objects are relevant to.
+type SpecDestinationsDB interface {
+	// GetLeafHubNames returns the names of the leaf hubs that reported a heartbeat or managed clusters.
+	GetLeafHubNames(ctx context.Context) ([]string, error)
+	// GetManagedClusters returns a map of leaf hub -> the managed clusters of the leaf hub.
+	GetManagedClusters(ctx context.Context) (map[string][]*clusterv1.ManagedCluster, error)
+	// GetDistributedObjects returns the objects of a specific table that aren't deleted or local.
+	GetDistributedObjects(ctx context.Context, tableName string,
+		createObjFunc bundle.CreateObjectFunction) ([]metav1.Object, error)
+	// GetDistributedObject returns the object with the name and namespace of a specific table if it isn't deleted, or
+	// nil if it isn't distributed. the api version and kind are matched if the gvk isn't empty, the objects of the
+	// dedicated tables are stored without them.
+	GetDistributedObject(ctx context.Context, tableName string, gvk schema.GroupVersionKind,
+		name, namespace string) (*unstructured.Unstructured, error)
+	// GetClusterSetsPerNamespace returns a map of namespace -> managed-cluster-sets bound to the namespace from a
+	// specific table of managed-cluster-set-bindings.
+	GetClusterSetsPerNamespace(ctx context.Context, tableName string) (map[string][]string, error)
+	// GetFullStateRequests returns a map of leaf hub -> the time the leaf hub requested the full state of a bundle.
+	GetFullStateRequests(ctx context.Context, bundleID string) (map[string]time.Time, error)
+}
+
 // StatusDB is the needed interface for the db transport bridge to fetch information from status DB.
 type StatusDB interface {
 	// GetManagedClusterLabelsStatus gets the labels present in managed-cluster CR metadata from a specific table.
 	GetManagedClusterLabelsStatus(ctx context.Context, tableName string, leafHubName string,
 		managedClusterName string) (map[string]string, error)
+	// GetNonCompliantPercentages returns a map of leaf hub -> the percentage of the non compliant policy statuses of
+	// the managed clusters of the leaf hub.
+	GetNonCompliantPercentages(ctx context.Context) (map[string]float64, error)
 	TempStatusDB
 }
 
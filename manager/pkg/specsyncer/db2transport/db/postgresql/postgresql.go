@@ -12,9 +12,14 @@ import (
 
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/bundle"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
 	"github.com/stolostron/multicluster-global-hub/pkg/bundle/spec"
 )
 
@@ -115,6 +120,92 @@ func (p *PostgreSQL) DeleteSpecObject(ctx context.Context, tableName, name, name
 	return nil
 }
 
+// HoldSpecObject inserts or updates the held object of a specific table with object UID.
+func (p *PostgreSQL) HoldSpecObject(ctx context.Context, tableName, objUID string, object *client.Object) error {
+	if _, err := p.conn.Exec(ctx, `INSERT INTO spec.held_resources (table_name,id,payload) values($1, $2, $3::jsonb)
+		ON CONFLICT (table_name,id) DO UPDATE SET payload = EXCLUDED.payload
+		WHERE spec.held_resources.payload IS DISTINCT FROM EXCLUDED.payload`, tableName, objUID, object); err != nil {
+		return fmt.Errorf("failed to hold the instance in the database: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseSpecObject deletes the held object of a specific table with object UID, if it exists.
+func (p *PostgreSQL) ReleaseSpecObject(ctx context.Context, tableName, objUID string) error {
+	if _, err := p.conn.Exec(ctx, `DELETE FROM spec.held_resources WHERE table_name = $1 AND id = $2`,
+		tableName, objUID); err != nil {
+		return fmt.Errorf("failed to release the held instance in the database: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteGenericSpecObject deletes the object of a kind with name and namespace from a specific table.
+func (p *PostgreSQL) DeleteGenericSpecObject(ctx context.Context, tableName string, gvk schema.GroupVersionKind,
+	name, namespace string,
+) error {
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+
+	var err error
+	if namespace != "" {
+		query := fmt.Sprintf(`UPDATE spec.%s SET deleted = true WHERE payload ->> 'apiVersion' = $1 AND
+			payload ->> 'kind' = $2 AND payload -> 'metadata' ->> 'name' = $3 AND
+			payload -> 'metadata' ->> 'namespace' = $4 AND deleted = false`, tableName)
+		_, err = p.conn.Exec(ctx, query, apiVersion, kind, name, namespace)
+	} else {
+		query := fmt.Sprintf(`UPDATE spec.%s SET deleted = true WHERE payload ->> 'apiVersion' = $1 AND
+			payload ->> 'kind' = $2 AND payload -> 'metadata' ->> 'name' = $3 AND
+			payload -> 'metadata' ->> 'namespace' IS NULL AND deleted = false`, tableName)
+		_, err = p.conn.Exec(ctx, query, apiVersion, kind, name)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to delete instance from the database: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteGenericSpecObjects deletes all the objects of a kind from a specific table.
+func (p *PostgreSQL) DeleteGenericSpecObjects(ctx context.Context, tableName string,
+	gvk schema.GroupVersionKind,
+) error {
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+
+	if _, err := p.conn.Exec(ctx, fmt.Sprintf(`UPDATE spec.%s SET deleted = true WHERE
+		payload ->> 'apiVersion' = $1 AND payload ->> 'kind' = $2 AND deleted = false`, tableName),
+		apiVersion, kind); err != nil {
+		return fmt.Errorf("failed to delete instances of %s from the database: %w", gvk.String(), err)
+	}
+
+	return nil
+}
+
+// GetGenericSpecKinds returns the kinds of the objects that aren't deleted in a specific table.
+func (p *PostgreSQL) GetGenericSpecKinds(ctx context.Context, tableName string) ([]schema.GroupVersionKind, error) {
+	rows, err := p.conn.Query(ctx, fmt.Sprintf(`SELECT DISTINCT payload ->> 'apiVersion', payload ->> 'kind'
+		FROM spec.%s WHERE deleted = false`, tableName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table spec.%s - %w", tableName, err)
+	}
+
+	defer rows.Close()
+
+	kinds := make([]schema.GroupVersionKind, 0)
+
+	for rows.Next() {
+		var apiVersion, kind string
+		if err := rows.Scan(&apiVersion, &kind); err != nil {
+			return nil, fmt.Errorf("error reading from table spec.%s - %w", tableName, err)
+		}
+
+		kinds = append(kinds, schema.FromAPIVersionAndKind(apiVersion, kind))
+	}
+
+	return kinds, nil
+}
+
 // GetObjectsBundle returns a bundle of objects from a specific table.
 func (p *PostgreSQL) GetObjectsBundle(ctx context.Context, tableName string, createObjFunc bundle.CreateObjectFunction,
 	intoBundle bundle.ObjectsBundle,
@@ -154,6 +245,38 @@ func (p *PostgreSQL) GetObjectsBundle(ctx context.Context, tableName string, cre
 	return timestamp, nil
 }
 
+// GetObjects returns the objects of a specific table with their update time, and the last update timestamp.
+func (p *PostgreSQL) GetObjects(ctx context.Context, tableName string, createObjFunc bundle.CreateObjectFunction,
+) ([]*db.SpecObject, *time.Time, error) {
+	timestamp, err := p.GetLastUpdateTimestamp(ctx, tableName, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := p.conn.Query(ctx, fmt.Sprintf(`SELECT id,payload,deleted,updated_at FROM spec.%s WHERE
+		payload->'metadata'->'labels'->'global-hub.open-cluster-management.io/local-resource' IS NULL`,
+		tableName))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query table spec.%s - %w", tableName, err)
+	}
+
+	defer rows.Close()
+
+	objects := make([]*db.SpecObject, 0)
+
+	for rows.Next() {
+		specObject := &db.SpecObject{Object: createObjFunc()}
+		if err := rows.Scan(&specObject.UID, &specObject.Object, &specObject.Deleted,
+			&specObject.UpdatedAt); err != nil {
+			return nil, nil, fmt.Errorf("error reading from table spec.%s - %w", tableName, err)
+		}
+
+		objects = append(objects, specObject)
+	}
+
+	return objects, timestamp, nil
+}
+
 // GetUpdatedManagedClusterLabelsBundles returns a map of leaf-hub -> ManagedClusterLabelsSpecBundle of objects
 // belonging to a leaf-hub that had at least once update since the given timestamp, from a specific table.
 func (p *PostgreSQL) GetUpdatedManagedClusterLabelsBundles(ctx context.Context, tableName string,
@@ -204,6 +327,18 @@ func (p *PostgreSQL) GetUpdatedManagedClusterLabelsBundles(ctx context.Context,
 	return leafHubToLabelsSpecBundleMap, nil
 }
 
+// GetLatestResyncBundle returns the latest resync request from a specific table.
+func (p *PostgreSQL) GetLatestResyncBundle(ctx context.Context, tableName string,
+) (*spec.ResyncSpecBundle, error) {
+	resyncBundle := &spec.ResyncSpecBundle{}
+	if err := p.conn.QueryRow(ctx, fmt.Sprintf(`SELECT id,updated_at FROM spec.%s ORDER BY updated_at DESC LIMIT 1`,
+		tableName)).Scan(&resyncBundle.ID, &resyncBundle.RequestTimestamp); err != nil {
+		return nil, fmt.Errorf("failed to read from table spec.%s - %w", tableName, err)
+	}
+
+	return resyncBundle, nil
+}
+
 // GetEntriesWithDeletedLabels returns a map of leaf-hub -> ManagedClusterLabelsSpecBundle of objects that have a
 // none-empty deleted-label-keys column.
 func (p *PostgreSQL) GetEntriesWithDeletedLabels(ctx context.Context,
@@ -318,6 +453,169 @@ func (p *PostgreSQL) UpdateLeafHubName(ctx context.Context, tableName string, re
 	return nil
 }
 
+// GetLeafHubNames returns the names of the leaf hubs that reported a heartbeat or managed clusters.
+func (p *PostgreSQL) GetLeafHubNames(ctx context.Context) ([]string, error) {
+	rows, err := p.conn.Query(ctx, `SELECT leaf_hub_name FROM status.leaf_hub_heartbeats 
+		UNION SELECT DISTINCT(leaf_hub_name) FROM status.managed_clusters ORDER BY leaf_hub_name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query leaf hub names - %w", err)
+	}
+
+	defer rows.Close()
+
+	leafHubNames := make([]string, 0)
+
+	for rows.Next() {
+		var leafHubName string
+		if err := rows.Scan(&leafHubName); err != nil {
+			return nil, fmt.Errorf("error reading leaf hub names - %w", err)
+		}
+
+		leafHubNames = append(leafHubNames, leafHubName)
+	}
+
+	return leafHubNames, nil
+}
+
+// GetManagedClusters returns a map of leaf hub -> the managed clusters of the leaf hub.
+func (p *PostgreSQL) GetManagedClusters(ctx context.Context) (map[string][]*clusterv1.ManagedCluster, error) {
+	rows, err := p.conn.Query(ctx, `SELECT leaf_hub_name, payload FROM status.managed_clusters 
+		ORDER BY leaf_hub_name, payload->'metadata'->>'name'`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table status.managed_clusters - %w", err)
+	}
+
+	defer rows.Close()
+
+	managedClusters := make(map[string][]*clusterv1.ManagedCluster)
+
+	for rows.Next() {
+		var leafHubName string
+
+		cluster := &clusterv1.ManagedCluster{}
+		if err := rows.Scan(&leafHubName, cluster); err != nil {
+			return nil, fmt.Errorf("error reading from table status.managed_clusters - %w", err)
+		}
+
+		managedClusters[leafHubName] = append(managedClusters[leafHubName], cluster)
+	}
+
+	return managedClusters, nil
+}
+
+// GetDistributedObjects returns the objects of a specific table that aren't deleted or local.
+func (p *PostgreSQL) GetDistributedObjects(ctx context.Context, tableName string,
+	createObjFunc bundle.CreateObjectFunction,
+) ([]metav1.Object, error) {
+	rows, err := p.conn.Query(ctx, fmt.Sprintf(`SELECT payload FROM spec.%s WHERE deleted = FALSE AND 
+		payload->'metadata'->'labels'->'global-hub.open-cluster-management.io/local-resource' IS NULL`, tableName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table spec.%s - %w", tableName, err)
+	}
+
+	defer rows.Close()
+
+	objects := make([]metav1.Object, 0)
+
+	for rows.Next() {
+		object := createObjFunc()
+		if err := rows.Scan(object); err != nil {
+			return nil, fmt.Errorf("error reading from table spec.%s - %w", tableName, err)
+		}
+
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+// GetDistributedObject returns the object with the name and namespace of a specific table if it isn't deleted, or
+// nil if it isn't distributed. the api version and kind are matched if the gvk isn't empty.
+func (p *PostgreSQL) GetDistributedObject(ctx context.Context, tableName string, gvk schema.GroupVersionKind,
+	name, namespace string,
+) (*unstructured.Unstructured, error) {
+	query := fmt.Sprintf(`SELECT payload FROM spec.%s WHERE deleted = FALSE AND
+		payload -> 'metadata' ->> 'name' = $1 AND COALESCE(payload -> 'metadata' ->> 'namespace', '') = $2`,
+		tableName)
+	args := []interface{}{name, namespace}
+
+	if !gvk.Empty() {
+		apiVersion, kind := gvk.ToAPIVersionAndKind()
+		query += ` AND payload ->> 'apiVersion' = $3 AND payload ->> 'kind' = $4`
+		args = append(args, apiVersion, kind)
+	}
+
+	object := &unstructured.Unstructured{}
+	if err := p.conn.QueryRow(ctx, query, args...).Scan(&object.Object); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to query table spec.%s - %w", tableName, err)
+	}
+
+	return object, nil
+}
+
+// GetClusterSetsPerNamespace returns a map of namespace -> managed-cluster-sets bound to the namespace from a
+// specific table of managed-cluster-set-bindings.
+func (p *PostgreSQL) GetClusterSetsPerNamespace(ctx context.Context, tableName string) (map[string][]string, error) {
+	rows, err := p.conn.Query(ctx, fmt.Sprintf(`SELECT payload->'metadata'->>'namespace', 
+		payload->'spec'->>'clusterSet' FROM spec.%s WHERE deleted = FALSE AND 
+		payload->'metadata'->'labels'->'global-hub.open-cluster-management.io/local-resource' IS NULL 
+		ORDER BY payload->'spec'->>'clusterSet'`, tableName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table spec.%s - %w", tableName, err)
+	}
+
+	defer rows.Close()
+
+	return buildKeyToValuesMapFromRows(rows)
+}
+
+// GetFullStateRequests returns a map of leaf hub -> the time the leaf hub requested the full state of a bundle.
+func (p *PostgreSQL) GetFullStateRequests(ctx context.Context, bundleID string) (map[string]time.Time, error) {
+	rows, err := p.conn.Query(ctx, `SELECT leaf_hub_name, requested_at FROM status.spec_full_state_requests 
+		WHERE bundle_id = $1`, bundleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query table status.spec_full_state_requests - %w", err)
+	}
+
+	defer rows.Close()
+
+	requests := make(map[string]time.Time)
+
+	for rows.Next() {
+		var (
+			leafHubName string
+			requestedAt time.Time
+		)
+
+		if err := rows.Scan(&leafHubName, &requestedAt); err != nil {
+			return nil, fmt.Errorf("error reading full state requests - %w", err)
+		}
+
+		requests[leafHubName] = requestedAt
+	}
+
+	return requests, nil
+}
+
+func buildKeyToValuesMapFromRows(rows pgx.Rows) (map[string][]string, error) {
+	result := make(map[string][]string)
+
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("error creating key values map from rows - %w", err)
+		}
+
+		result[key] = append(result[key], value)
+	}
+
+	return result, nil
+}
+
 // GetManagedClusterLabelsStatus gets the labels present in managed-cluster CR metadata from a specific table.
 func (p *PostgreSQL) GetManagedClusterLabelsStatus(ctx context.Context, tableName string, leafHubName string,
 	managedClusterName string,
@@ -333,6 +631,35 @@ func (p *PostgreSQL) GetManagedClusterLabelsStatus(ctx context.Context, tableNam
 	return labels, nil
 }
 
+// GetNonCompliantPercentages returns a map of leaf hub -> the percentage of the non compliant policy statuses of the
+// managed clusters of the leaf hub.
+func (p *PostgreSQL) GetNonCompliantPercentages(ctx context.Context) (map[string]float64, error) {
+	rows, err := p.conn.Query(ctx, `SELECT leaf_hub_name, (100.0 * COUNT(*) FILTER (WHERE
+		compliance = 'non_compliant') / COUNT(*))::float8 FROM status.compliance GROUP BY leaf_hub_name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query compliance - %w", err)
+	}
+
+	defer rows.Close()
+
+	percentages := make(map[string]float64)
+
+	for rows.Next() {
+		var (
+			leafHubName string
+			percentage  float64
+		)
+
+		if err := rows.Scan(&leafHubName, &percentage); err != nil {
+			return nil, fmt.Errorf("error reading from table status.compliance - %w", err)
+		}
+
+		percentages[leafHubName] = percentage
+	}
+
+	return percentages, nil
+}
+
 // GetManagedClusterLeafHubName returns leaf-hub name for a given managed cluster from a specific table.
 // TODO: once non-k8s-restapi exposes hub names, remove line.
 func (p *PostgreSQL) GetManagedClusterLeafHubName(ctx context.Context, tableName string,
@@ -0,0 +1,165 @@
+package placement
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	placementrulev1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/apps/placementrule/v1"
+)
+
+// Matcher matches the managed clusters a placement or a placement rule selects, the way they're evaluated on the
+// regional hubs. the number of the selected clusters isn't limited by the matcher.
+type Matcher struct {
+	matchFunc func(cluster *clusterv1.ManagedCluster) bool
+}
+
+// Matches returns true if the managed cluster is selected.
+func (matcher *Matcher) Matches(cluster *clusterv1.ManagedCluster) bool {
+	return matcher.matchFunc(cluster)
+}
+
+// NewPlacementMatcher returns a matcher of the managed clusters in the cluster sets bound to the namespace of the
+// placement, restricted to the cluster sets of the placement if set, that match any of its predicates and tolerate
+// the taints of the clusters.
+func NewPlacementMatcher(placement *clusterv1beta1.Placement, boundClusterSets []string) (*Matcher, error) {
+	clusterSets := make(map[string]struct{})
+
+	for _, clusterSet := range boundClusterSets {
+		if len(placement.Spec.ClusterSets) == 0 || contains(placement.Spec.ClusterSets, clusterSet) {
+			clusterSets[clusterSet] = struct{}{}
+		}
+	}
+
+	type predicateSelectors struct {
+		labelSelector labels.Selector
+		claimSelector labels.Selector
+	}
+
+	predicates := make([]*predicateSelectors, 0, len(placement.Spec.Predicates))
+
+	for _, predicate := range placement.Spec.Predicates {
+		labelSelector, err := metav1.LabelSelectorAsSelector(&predicate.RequiredClusterSelector.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector - %w", err)
+		}
+
+		claimSelector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+			MatchExpressions: predicate.RequiredClusterSelector.ClaimSelector.MatchExpressions,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid claim selector - %w", err)
+		}
+
+		predicates = append(predicates, &predicateSelectors{labelSelector: labelSelector, claimSelector: claimSelector})
+	}
+
+	return &Matcher{matchFunc: func(cluster *clusterv1.ManagedCluster) bool {
+		if _, found := clusterSets[cluster.Labels[clusterv1beta1.ClusterSetLabel]]; !found ||
+			!toleratesTaints(placement.Spec.Tolerations, cluster.Spec.Taints) {
+			return false
+		}
+
+		if len(predicates) == 0 {
+			return true
+		}
+
+		claims := make(labels.Set, len(cluster.Status.ClusterClaims))
+		for _, claim := range cluster.Status.ClusterClaims {
+			claims[claim.Name] = claim.Value
+		}
+
+		for _, predicate := range predicates {
+			if predicate.labelSelector.Matches(labels.Set(cluster.Labels)) && predicate.claimSelector.Matches(claims) {
+				return true
+			}
+		}
+
+		return false
+	}}, nil
+}
+
+// NewPlacementRuleMatcher returns a matcher of the managed clusters listed in the placement rule or matching its
+// selector, with the conditions of the placement rule.
+func NewPlacementRuleMatcher(placementRule *placementrulev1.PlacementRule) (*Matcher, error) {
+	clusterSelector := labels.Everything()
+
+	if placementRule.Spec.ClusterSelector != nil {
+		var err error
+		if clusterSelector, err = metav1.LabelSelectorAsSelector(placementRule.Spec.ClusterSelector); err != nil {
+			return nil, fmt.Errorf("invalid cluster selector - %w", err)
+		}
+	}
+
+	clusterNames := make([]string, 0, len(placementRule.Spec.Clusters))
+	for _, cluster := range placementRule.Spec.Clusters {
+		clusterNames = append(clusterNames, cluster.Name)
+	}
+
+	return &Matcher{matchFunc: func(cluster *clusterv1.ManagedCluster) bool {
+		if len(clusterNames) > 0 && !contains(clusterNames, cluster.Name) {
+			return false
+		}
+
+		return clusterSelector.Matches(labels.Set(cluster.Labels)) &&
+			hasConditions(placementRule.Spec.ClusterConditions, cluster)
+	}}, nil
+}
+
+// toleratesTaints returns true if the tolerations tolerate the taints of the cluster that prevent selecting it.
+func toleratesTaints(tolerations []clusterv1beta1.Toleration, taints []clusterv1.Taint) bool {
+	for _, taint := range taints {
+		if taint.Effect == clusterv1.TaintEffectNoSelect && !isTolerated(tolerations, taint) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isTolerated(tolerations []clusterv1beta1.Toleration, taint clusterv1.Taint) bool {
+	for _, toleration := range tolerations {
+		if (toleration.Key == "" && toleration.Operator != clusterv1beta1.TolerationOpExists) ||
+			(toleration.Key != "" && toleration.Key != taint.Key) ||
+			(toleration.Effect != "" && toleration.Effect != taint.Effect) {
+			continue
+		}
+
+		if toleration.Operator == clusterv1beta1.TolerationOpExists || toleration.Value == taint.Value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasConditions(conditions []placementrulev1.ClusterConditionFilter, cluster *clusterv1.ManagedCluster) bool {
+	for _, condition := range conditions {
+		found := false
+
+		for _, clusterCondition := range cluster.Status.Conditions {
+			if clusterCondition.Type == condition.Type && clusterCondition.Status == condition.Status {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
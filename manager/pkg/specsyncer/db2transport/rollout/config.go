@@ -0,0 +1,230 @@
+package rollout
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+)
+
+// defaultSoakTime is the time the leaf hubs of a wave run the changes before the next wave gets them, if the soak
+// time isn't set.
+const defaultSoakTime = 5 * time.Minute
+
+// Config is the rollout of the spec changes to the leaf hubs, it's read from the rollout configmap.
+type Config struct {
+	// Paused stops starting new waves, the leaf hubs of the wave in progress keep getting the changes.
+	Paused bool `json:"paused,omitempty"`
+	// Aborted stops sending the changes to all the leaf hubs that didn't get them yet.
+	Aborted bool `json:"aborted,omitempty"`
+	// SoakTime is the time the leaf hubs of a wave run the changes before the next wave gets them.
+	SoakTime *metav1.Duration `json:"soakTime,omitempty"`
+	// MaxNonCompliantPercentage is the highest increase of the percentage of non compliant policy statuses of a
+	// healthy leaf hub since it got the changes, the next wave gets the changes only once the leaf hubs of the
+	// previous waves are healthy.
+	MaxNonCompliantPercentage float64 `json:"maxNonCompliantPercentage,omitempty"`
+	// MaintenanceWindows are the times the selected leaf hubs get the changes in, the leaf hubs that aren't selected
+	// by any of the windows get the changes at any time.
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	// Waves are the ordered groups of leaf hubs that get the changes one after the other, a leaf hub belongs to the
+	// first wave that selects it. the leaf hubs that aren't selected by any of the waves belong to a last wave.
+	Waves []Wave `json:"waves,omitempty"`
+}
+
+// MaintenanceWindow is a recurring time range the selected leaf hubs get the changes in.
+type MaintenanceWindow struct {
+	// HubSelector selects the leaf hubs by the labels of their managed clusters, all the leaf hubs if it isn't set.
+	HubSelector *metav1.LabelSelector `json:"hubSelector,omitempty"`
+	// Days are the week days the window starts on, e.g. Saturday, every day if they aren't set.
+	Days []string `json:"days,omitempty"`
+	// Start is the time of the day the window starts at, in the 15:04 format.
+	Start string `json:"start"`
+	// Duration is the length of the window.
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone is the IANA time zone of the start time, UTC if it isn't set.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// Wave is a group of leaf hubs that get the changes together.
+type Wave struct {
+	Name string `json:"name"`
+	// HubSelector selects the leaf hubs by the labels of their managed clusters, all the leaf hubs if it isn't set.
+	HubSelector *metav1.LabelSelector `json:"hubSelector,omitempty"`
+}
+
+// rolloutPlan is the validated rollout config.
+type rolloutPlan struct {
+	paused                    bool
+	aborted                   bool
+	soakTime                  time.Duration
+	maxNonCompliantPercentage float64
+	windows                   []*window
+	waves                     []labels.Selector
+}
+
+type window struct {
+	hubSelector labels.Selector
+	days        map[time.Weekday]struct{}
+	start       time.Duration // since the start of the day
+	duration    time.Duration
+	location    *time.Location
+}
+
+// parseRolloutPlan parses and validates the rollout config.
+func parseRolloutPlan(data string) (*rolloutPlan, error) {
+	config := &Config{}
+	if err := yaml.Unmarshal([]byte(data), config); err != nil {
+		return nil, fmt.Errorf("failed to parse rollout config - %w", err)
+	}
+
+	return newRolloutPlan(config)
+}
+
+// newRolloutPlan validates the rollout config.
+func newRolloutPlan(config *Config) (*rolloutPlan, error) {
+	plan := &rolloutPlan{
+		paused:                    config.Paused,
+		aborted:                   config.Aborted,
+		soakTime:                  defaultSoakTime,
+		maxNonCompliantPercentage: config.MaxNonCompliantPercentage,
+		windows:                   make([]*window, 0, len(config.MaintenanceWindows)),
+		waves:                     make([]labels.Selector, 0, len(config.Waves)),
+	}
+
+	if config.SoakTime != nil {
+		plan.soakTime = config.SoakTime.Duration
+	}
+
+	for i, maintenanceWindow := range config.MaintenanceWindows {
+		window, err := newWindow(maintenanceWindow)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %d - %w", i, err)
+		}
+
+		plan.windows = append(plan.windows, window)
+	}
+
+	for _, wave := range config.Waves {
+		hubSelector, err := newHubSelector(wave.HubSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hub selector of wave %s - %w", wave.Name, err)
+		}
+
+		plan.waves = append(plan.waves, hubSelector)
+	}
+
+	return plan, nil
+}
+
+func newWindow(maintenanceWindow MaintenanceWindow) (*window, error) {
+	hubSelector, err := newHubSelector(maintenanceWindow.HubSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hub selector - %w", err)
+	}
+
+	start, err := time.Parse("15:04", maintenanceWindow.Start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start time %s - %w", maintenanceWindow.Start, err)
+	}
+
+	if maintenanceWindow.Duration.Duration <= 0 {
+		return nil, fmt.Errorf("invalid duration %s", maintenanceWindow.Duration.Duration)
+	}
+
+	location, err := time.LoadLocation(maintenanceWindow.TimeZone) // UTC if the time zone is empty
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %s - %w", maintenanceWindow.TimeZone, err)
+	}
+
+	days := make(map[time.Weekday]struct{}, len(maintenanceWindow.Days))
+
+	for _, day := range maintenanceWindow.Days {
+		weekday, found := parseWeekday(day)
+		if !found {
+			return nil, fmt.Errorf("invalid day %s", day)
+		}
+
+		days[weekday] = struct{}{}
+	}
+
+	return &window{
+		hubSelector: hubSelector,
+		days:        days,
+		start:       time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute,
+		duration:    maintenanceWindow.Duration.Duration,
+		location:    location,
+	}, nil
+}
+
+func newHubSelector(labelSelector *metav1.LabelSelector) (labels.Selector, error) {
+	if labelSelector == nil {
+		return labels.Everything(), nil
+	}
+
+	return metav1.LabelSelectorAsSelector(labelSelector)
+}
+
+func parseWeekday(day string) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(weekday.String(), day) || strings.EqualFold(weekday.String()[:3], day) {
+			return weekday, true
+		}
+	}
+
+	return time.Sunday, false
+}
+
+// isOpen returns true if the time is in one of the occurrences of the window, an occurrence may have started on one
+// of the previous days.
+func (w *window) isOpen(now time.Time) bool {
+	now = now.In(w.location)
+	year, month, day := now.Date()
+
+	for daysBack := 0; time.Duration(daysBack)*24*time.Hour < w.start+w.duration; daysBack++ {
+		dayStart := time.Date(year, month, day-daysBack, 0, 0, 0, 0, w.location)
+		if _, found := w.days[dayStart.Weekday()]; len(w.days) > 0 && !found {
+			continue
+		}
+
+		start := dayStart.Add(w.start)
+		if !now.Before(start) && now.Before(start.Add(w.duration)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inWindow returns true if the leaf hub may get the changes now, the leaf hubs that aren't selected by any of the
+// windows get the changes at any time.
+func (plan *rolloutPlan) inWindow(hubLabels labels.Set, now time.Time) bool {
+	selected := false
+
+	for _, window := range plan.windows {
+		if !window.hubSelector.Matches(hubLabels) {
+			continue
+		}
+
+		if window.isOpen(now) {
+			return true
+		}
+
+		selected = true
+	}
+
+	return !selected
+}
+
+// waveOf returns the index of the first wave that selects the leaf hub, or the index of the last wave if none does.
+func (plan *rolloutPlan) waveOf(hubLabels labels.Set) int {
+	for i, hubSelector := range plan.waves {
+		if hubSelector.Matches(hubLabels) {
+			return i
+		}
+	}
+
+	return len(plan.waves)
+}
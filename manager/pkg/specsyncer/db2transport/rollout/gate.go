@@ -0,0 +1,231 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+const (
+	// rolloutConfigKey is the key of the rollout config in the configmap.
+	rolloutConfigKey = "rollout"
+	// refreshInterval is the interval of reading the rollout config, the labels and the health of the leaf hubs.
+	refreshInterval = 10 * time.Second
+)
+
+// Gate holds back the spec changes of the leaf hubs outside their maintenance windows, and of the leaf hubs of a wave
+// until the leaf hubs of the previous waves run the changes healthy for the soak time. a nil gate doesn't hold back
+// any changes.
+type Gate struct {
+	log      logr.Logger
+	client   client.Client
+	statusDB db.StatusDB
+	lock     sync.Mutex
+	state    *gateState
+	// baselines is a map of leaf hub -> the percentage of the non compliant policy statuses of the leaf hub before
+	// it got its last changes, the health of the leaf hub is measured against it.
+	baselines map[string]float64
+}
+
+// gateState is the state the changes are admitted by, it's nil until it's read for the first time.
+type gateState struct {
+	// plan is nil if the rollout isn't configured.
+	plan                   *rolloutPlan
+	hubLabels              map[string]labels.Set
+	nonCompliantPercentage map[string]float64
+}
+
+// AddGate adds the rollout gate to the manager and returns it.
+func AddGate(mgr ctrl.Manager, statusDB db.StatusDB) (*Gate, error) {
+	gate := &Gate{
+		log:       ctrl.Log.WithName("spec-rollout-gate"),
+		client:    mgr.GetClient(),
+		statusDB:  statusDB,
+		baselines: make(map[string]float64),
+	}
+
+	if err := mgr.Add(gate); err != nil {
+		return nil, fmt.Errorf("failed to add spec rollout gate - %w", err)
+	}
+
+	return gate, nil
+}
+
+// Start refreshes the state of the gate periodically.
+func (gate *Gate) Start(ctx context.Context) error {
+	gate.refresh(ctx)
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			gate.refresh(ctx)
+		}
+	}
+}
+
+func (gate *Gate) refresh(ctx context.Context) {
+	state, err := gate.readState(ctx)
+	if err != nil {
+		gate.log.Error(err, "failed to refresh spec rollout gate")
+		return
+	}
+
+	gate.lock.Lock()
+	defer gate.lock.Unlock()
+
+	gate.state = state
+
+	for leafHubName := range gate.baselines {
+		if _, found := state.hubLabels[leafHubName]; !found && state.hubLabels != nil {
+			delete(gate.baselines, leafHubName)
+		}
+	}
+}
+
+func (gate *Gate) readState(ctx context.Context) (*gateState, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := gate.client.Get(ctx, client.ObjectKey{
+		Namespace: constants.HohSystemNamespace,
+		Name:      constants.RolloutConfigName,
+	}, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return &gateState{}, nil
+		}
+
+		return nil, fmt.Errorf("failed to get the rollout configmap - %w", err)
+	}
+
+	plan, err := parseRolloutPlan(configMap.Data[rolloutConfigKey])
+	if err != nil { // the changes are held back until the configmap is fixed
+		gate.log.Error(err, "invalid rollout configmap, holding back the spec changes", "key", rolloutConfigKey)
+		plan = &rolloutPlan{aborted: true}
+	}
+
+	managedClusters := &clusterv1.ManagedClusterList{}
+	if err := gate.client.List(ctx, managedClusters); err != nil {
+		return nil, fmt.Errorf("failed to list the managed clusters of the leaf hubs - %w", err)
+	}
+
+	hubLabels := make(map[string]labels.Set, len(managedClusters.Items))
+	for _, managedCluster := range managedClusters.Items {
+		hubLabels[managedCluster.Name] = managedCluster.Labels
+	}
+
+	nonCompliantPercentage, err := gate.statusDB.GetNonCompliantPercentages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the health of the leaf hubs - %w", err)
+	}
+
+	return &gateState{
+		plan:                   plan,
+		hubLabels:              hubLabels,
+		nonCompliantPercentage: nonCompliantPercentage,
+	}, nil
+}
+
+// AdmitLeafHubs returns the leaf hubs with pending changes of a table that may get them now. changesSentAt is a map of
+// leaf hub -> the time the leaf hub got the last changes of the table, for the leaf hubs without pending changes.
+// all the changes are held back until the state of the gate is read.
+func (gate *Gate) AdmitLeafHubs(pendingLeafHubs map[string]struct{},
+	changesSentAt map[string]time.Time,
+) map[string]struct{} {
+	if gate == nil {
+		return pendingLeafHubs
+	}
+
+	gate.lock.Lock()
+	defer gate.lock.Unlock()
+
+	if gate.state == nil {
+		return map[string]struct{}{}
+	}
+
+	return gate.state.admitLeafHubs(pendingLeafHubs, changesSentAt, gate.baselines, time.Now())
+}
+
+// admitLeafHubs returns the admitted leaf hubs, the baselines of the admitted leaf hubs are updated to their current
+// non compliant percentage unless they're unhealthy, so a regression isn't hidden by the next changes.
+func (state *gateState) admitLeafHubs(pendingLeafHubs map[string]struct{}, changesSentAt map[string]time.Time,
+	baselines map[string]float64, now time.Time,
+) map[string]struct{} {
+	if state.plan == nil {
+		return pendingLeafHubs
+	}
+
+	admittedLeafHubs := make(map[string]struct{})
+
+	if state.plan.aborted || len(pendingLeafHubs) == 0 {
+		return admittedLeafHubs
+	}
+
+	// the current wave is the first wave with pending changes, the previous waves have the changes already
+	currentWave := len(state.plan.waves)
+	for leafHubName := range pendingLeafHubs {
+		if wave := state.plan.waveOf(state.hubLabels[leafHubName]); wave < currentWave {
+			currentWave = wave
+		}
+	}
+
+	waveStarted := false
+
+	for leafHubName, sentAt := range changesSentAt {
+		wave := state.plan.waveOf(state.hubLabels[leafHubName])
+		if wave == currentWave {
+			waveStarted = true
+		}
+
+		if wave < currentWave && (now.Sub(sentAt) < state.plan.soakTime ||
+			!state.isHealthy(leafHubName, baselines)) {
+			return admittedLeafHubs // the previous waves aren't done yet
+		}
+	}
+
+	if state.plan.paused && !waveStarted { // a wave is in progress once any of its leaf hubs has the changes
+		return admittedLeafHubs
+	}
+
+	for leafHubName := range pendingLeafHubs {
+		hubLabels := state.hubLabels[leafHubName]
+		if state.plan.waveOf(hubLabels) == currentWave && state.plan.inWindow(hubLabels, now) {
+			admittedLeafHubs[leafHubName] = struct{}{}
+
+			if state.isHealthy(leafHubName, baselines) {
+				baselines[leafHubName] = state.nonCompliantPercentage[leafHubName]
+			}
+		}
+	}
+
+	return admittedLeafHubs
+}
+
+// isHealthy returns true if the non compliant percentage of the leaf hub increased by at most the max percentage
+// since it got the changes. a leaf hub without a baseline, e.g. after a restart of the manager, is measured against
+// its current percentage.
+func (state *gateState) isHealthy(leafHubName string, baselines map[string]float64) bool {
+	percentage := state.nonCompliantPercentage[leafHubName]
+
+	baseline, found := baselines[leafHubName]
+	if !found {
+		baselines[leafHubName] = percentage
+		return true
+	}
+
+	return percentage-baseline <= state.plan.maxNonCompliantPercentage
+}
@@ -0,0 +1,138 @@
+package rollout
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestWindowIsOpen(t *testing.T) {
+	plan, err := parseRolloutPlan(`
+maintenanceWindows:
+- days: [Saturday]
+  start: "22:00"
+  duration: 4h
+  timeZone: UTC
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	window := plan.windows[0]
+	saturday := time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, test := range []struct {
+		at   time.Time
+		open bool
+	}{
+		{saturday.Add(21 * time.Hour), false},
+		{saturday.Add(22 * time.Hour), true},
+		{saturday.Add(25 * time.Hour), true}, // the window of saturday is open on sunday morning
+		{saturday.Add(26 * time.Hour), false},
+		{saturday.Add(-2 * time.Hour), false}, // friday
+	} {
+		if open := window.isOpen(test.at); open != test.open {
+			t.Errorf("expected the window to be open %t at %s", test.open, test.at)
+		}
+	}
+}
+
+func TestAdmitLeafHubs(t *testing.T) {
+	plan, err := parseRolloutPlan(`
+soakTime: 10m
+waves:
+- name: canary
+  hubSelector:
+    matchLabels:
+      wave: canary
+maintenanceWindows:
+- hubSelector:
+    matchLabels:
+      env: production
+  start: "22:00"
+  duration: 1h
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := &gateState{
+		plan: plan,
+		hubLabels: map[string]labels.Set{
+			"canary":     {"wave": "canary"},
+			"staging":    {},
+			"production": {"env": "production"},
+		},
+		nonCompliantPercentage: map[string]float64{},
+	}
+	baselines := map[string]float64{}
+	now := time.Date(2022, time.October, 1, 12, 0, 0, 0, time.UTC)
+
+	admit := func(pendingLeafHubs []string, changesSentAt map[string]time.Time, expected ...string) {
+		t.Helper()
+
+		pending := make(map[string]struct{})
+		for _, leafHubName := range pendingLeafHubs {
+			pending[leafHubName] = struct{}{}
+		}
+
+		admittedLeafHubs := state.admitLeafHubs(pending, changesSentAt, baselines, now)
+
+		admitted := make([]string, 0)
+		for _, leafHubName := range []string{"canary", "staging", "production"} {
+			if _, found := admittedLeafHubs[leafHubName]; found {
+				admitted = append(admitted, leafHubName)
+			}
+		}
+
+		if expected == nil {
+			expected = []string{}
+		}
+
+		if !reflect.DeepEqual(admitted, expected) {
+			t.Errorf("expected %v to be admitted, got %v", expected, admitted)
+		}
+	}
+
+	// the first wave gets the changes first
+	admit([]string{"canary", "staging", "production"}, map[string]time.Time{}, "canary")
+
+	// the next wave waits for the soak time of the previous wave
+	admit([]string{"staging", "production"}, map[string]time.Time{"canary": now.Add(-time.Minute)})
+
+	// the leaf hubs outside their maintenance window are held back
+	admit([]string{"staging", "production"}, map[string]time.Time{"canary": now.Add(-time.Hour)}, "staging")
+
+	// the next wave waits for the previous wave to be healthy
+	state.nonCompliantPercentage["canary"] = 10
+	admit([]string{"staging", "production"}, map[string]time.Time{"canary": now.Add(-time.Hour)})
+
+	// the baseline of an unhealthy leaf hub isn't updated by the next changes
+	admit([]string{"canary"}, map[string]time.Time{}, "canary")
+
+	if baselines["canary"] != 0 {
+		t.Errorf("expected the baseline of the unhealthy leaf hub to be kept, got %v", baselines["canary"])
+	}
+
+	// the health is measured against the non compliant percentage before the changes
+	baselines["canary"] = 10
+	admit([]string{"staging"}, map[string]time.Time{"canary": now.Add(-time.Hour)}, "staging")
+
+	state.nonCompliantPercentage["canary"] = 0
+
+	// a paused rollout doesn't start the next wave, the wave in progress goes on
+	state.plan.paused = true
+	admit([]string{"staging", "production"}, map[string]time.Time{"canary": now.Add(-time.Hour)})
+
+	now = now.Add(10 * time.Hour) // in the maintenance window
+	admit([]string{"production"}, map[string]time.Time{
+		"canary":  now.Add(-time.Hour),
+		"staging": now.Add(-time.Hour),
+	}, "production")
+
+	// an aborted rollout holds back all the changes
+	state.plan.aborted = true
+	admit([]string{"production"}, map[string]time.Time{"canary": now.Add(-time.Hour)})
+}
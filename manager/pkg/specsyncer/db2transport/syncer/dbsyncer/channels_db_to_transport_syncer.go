@@ -12,6 +12,7 @@ import (
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/bundle"
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/intervalpolicy"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/rollout"
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/transport"
 )
 
@@ -22,17 +23,18 @@ const (
 
 // AddChannelsDBToTransportSyncer adds channels db to transport syncer to the manager.
 func AddChannelsDBToTransportSyncer(mgr ctrl.Manager, specDB db.SpecDB, transportObj transport.Transport,
-	specSyncInterval time.Duration,
+	destinationsResolver *DestinationsResolver, rolloutGate *rollout.Gate, specSyncInterval time.Duration,
 ) error {
 	createObjFunc := func() metav1.Object { return &channelv1.Channel{} }
-	lastSyncTimestampPtr := &time.Time{}
+	syncState := &leafHubsSyncState{}
 
 	if err := mgr.Add(&genericDBToTransportSyncer{
 		log:            ctrl.Log.WithName("channels-db-to-transport-syncer"),
 		intervalPolicy: intervalpolicy.NewExponentialBackoffPolicy(specSyncInterval),
 		syncBundleFunc: func(ctx context.Context) (bool, error) {
-			return syncObjectsBundle(ctx, transportObj, channelsMsgKey, specDB, channelsTableName,
-				createObjFunc, bundle.NewBaseObjectsBundle, lastSyncTimestampPtr)
+			return syncObjectsBundlesPerLeafHub(ctx, transportObj, channelsMsgKey, specDB,
+				channelsTableName, createObjFunc, bundle.NewBaseObjectsBundle, channelLeafHubs,
+				destinationsResolver, rolloutGate, syncState)
 		},
 	}); err != nil {
 		return fmt.Errorf("failed to add channels db to transport syncer - %w", err)
@@ -0,0 +1,540 @@
+package dbsyncer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	policyv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
+	policyv1beta1 "open-cluster-management.io/governance-policy-propagator/api/v1beta1"
+	placementrulev1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/apps/placementrule/v1"
+	subscriptionv1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/apps/v1"
+	applicationv1beta1 "sigs.k8s.io/application/api/v1beta1"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/placement"
+)
+
+const (
+	placementKind     = "Placement"
+	placementRuleKind = "PlacementRule"
+	policyKind        = "Policy"
+	policySetKind     = "PolicySet"
+	channelKind       = "Channel"
+	subscriptionKind  = "Subscription"
+)
+
+// destinationsFunction returns the leaf hubs an object is relevant to, unrestricted is true if the object is
+// relevant to all the leaf hubs. the returned set is shared and must not be modified.
+type destinationsFunction func(object metav1.Object, destinations *specDestinations) (leafHubs map[string]struct{},
+	unrestricted bool)
+
+// specDestinations holds the leaf hubs the placements and the placement rules select managed clusters on, and the
+// leaf hubs of the objects they place, which are used to resolve the leaf hubs the spec objects are relevant to.
+type specDestinations struct {
+	LeafHubNames []string
+	// LeafHubsPerClusterSet is a map of managed-cluster-set -> leaf hubs with managed clusters in the set.
+	LeafHubsPerClusterSet map[string]map[string]struct{}
+	// LeafHubsPerObject is a map of the key of a placement, placement rule, policy, policy set, subscription or
+	// channel -> leaf hubs with managed clusters selected by it or by the placements bound to it.
+	LeafHubsPerObject map[string]map[string]struct{}
+	// LeafHubsPerNamespace is a map of namespace -> leaf hubs of the placements, placement rules and channels in it.
+	LeafHubsPerNamespace map[string]map[string]struct{}
+	// SubscriptionsPerNamespace is a map of namespace -> the subscriptions in it, the applications select them.
+	SubscriptionsPerNamespace map[string][]*subscriptionDestinations
+}
+
+// subscriptionDestinations holds the labels of a subscription and the leaf hubs of its placement.
+type subscriptionDestinations struct {
+	Labels   map[string]string
+	LeafHubs map[string]struct{}
+}
+
+// tableDestinationsFuncs is a map of spec table -> the function resolving the leaf hubs of the objects of the table,
+// the objects of the tables that aren't listed are restricted to the leaf hubs of their namespace.
+var tableDestinationsFuncs = map[string]destinationsFunction{
+	configTableName:                    unrestrictedLeafHubs,
+	managedClusterSetsTableName:        managedClusterSetLeafHubs,
+	managedClusterSetBindingsTableName: managedClusterSetBindingLeafHubs,
+	placementsTableName:                placementLeafHubs,
+	placementRulesTableName:            placementRuleLeafHubs,
+	placementBindingsTableName:         placementBindingLeafHubs,
+	policiesTableName:                  policyLeafHubs,
+	policySetsTableName:                policySetLeafHubs,
+	policyAutomationsTableName:         policyAutomationLeafHubs,
+	subscriptionsTableName:             subscriptionLeafHubs,
+	channelsTableName:                  channelLeafHubs,
+	applicationsTableName:              applicationLeafHubs,
+}
+
+// DestinationsResolver resolves the spec destinations at most once per interval, the destinations are shared by the
+// syncers of the spec tables.
+type DestinationsResolver struct {
+	specDB       db.SpecDB
+	interval     time.Duration
+	lock         sync.Mutex
+	destinations *specDestinations
+	resolvedAt   time.Time
+}
+
+// NewDestinationsResolver creates a new instance of DestinationsResolver.
+func NewDestinationsResolver(specDB db.SpecDB, interval time.Duration) *DestinationsResolver {
+	return &DestinationsResolver{
+		specDB:   specDB,
+		interval: interval,
+	}
+}
+
+// get returns the destinations resolved in the last interval, or resolves them again.
+func (resolver *DestinationsResolver) get(ctx context.Context) (*specDestinations, error) {
+	resolver.lock.Lock()
+	defer resolver.lock.Unlock()
+
+	if resolver.destinations != nil && time.Since(resolver.resolvedAt) < resolver.interval {
+		return resolver.destinations, nil
+	}
+
+	destinations, err := getSpecDestinations(ctx, resolver.specDB)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver.destinations = destinations
+	resolver.resolvedAt = time.Now()
+
+	return destinations, nil
+}
+
+// GetDestinationLeafHubs returns the sorted leaf hubs an object of the spec table is distributed to.
+func GetDestinationLeafHubs(ctx context.Context, specDB db.SpecDB, tableName string,
+	object metav1.Object,
+) ([]string, error) {
+	destinations, err := getSpecDestinations(ctx, specDB)
+	if err != nil {
+		return nil, err
+	}
+
+	destinationsFunc, found := tableDestinationsFuncs[tableName]
+	if !found {
+		destinationsFunc = namespaceLeafHubs
+	}
+
+	leafHubs := make([]string, 0)
+	for leafHubName := range destinations.getLeafHubs(object, destinationsFunc) {
+		leafHubs = append(leafHubs, leafHubName)
+	}
+
+	sort.Strings(leafHubs)
+
+	return leafHubs, nil
+}
+
+// getSpecDestinations evaluates the placements and the placement rules on the managed clusters of the leaf hubs, and
+// resolves the leaf hubs of the objects placed by them.
+func getSpecDestinations(ctx context.Context, specDB db.SpecDB) (*specDestinations, error) {
+	leafHubNames, err := specDB.GetLeafHubNames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaf hubs - %w", err)
+	}
+
+	managedClusters, err := specDB.GetManagedClusters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get managed clusters - %w", err)
+	}
+
+	clusterSetsPerNamespace, err := specDB.GetClusterSetsPerNamespace(ctx, managedClusterSetBindingsTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get managed-cluster-sets per namespace - %w", err)
+	}
+
+	destinations := &specDestinations{
+		LeafHubNames:              leafHubNames,
+		LeafHubsPerClusterSet:     make(map[string]map[string]struct{}),
+		LeafHubsPerObject:         make(map[string]map[string]struct{}),
+		LeafHubsPerNamespace:      make(map[string]map[string]struct{}),
+		SubscriptionsPerNamespace: make(map[string][]*subscriptionDestinations),
+	}
+
+	for leafHubName, clusters := range managedClusters {
+		for _, cluster := range clusters {
+			if clusterSet, found := cluster.Labels[clusterv1beta1.ClusterSetLabel]; found {
+				addLeafHubs(destinations.LeafHubsPerClusterSet, clusterSet, map[string]struct{}{leafHubName: {}})
+			}
+		}
+	}
+
+	placements, err := specDB.GetDistributedObjects(ctx, placementsTableName,
+		func() metav1.Object { return &clusterv1beta1.Placement{} })
+	if err != nil {
+		return nil, fmt.Errorf("failed to get placements - %w", err)
+	}
+
+	for _, object := range placements {
+		placementObj, ok := object.(*clusterv1beta1.Placement)
+		if !ok {
+			continue
+		}
+
+		// a placement with an invalid predicate selects no managed clusters
+		if matcher, err := placement.NewPlacementMatcher(placementObj,
+			clusterSetsPerNamespace[placementObj.Namespace]); err == nil {
+			destinations.addPlacementLeafHubs(placementKind, placementObj, matcher, managedClusters)
+		}
+	}
+
+	placementRules, err := specDB.GetDistributedObjects(ctx, placementRulesTableName,
+		func() metav1.Object { return &placementrulev1.PlacementRule{} })
+	if err != nil {
+		return nil, fmt.Errorf("failed to get placement rules - %w", err)
+	}
+
+	for _, object := range placementRules {
+		placementRule, ok := object.(*placementrulev1.PlacementRule)
+		if !ok {
+			continue
+		}
+
+		if matcher, err := placement.NewPlacementRuleMatcher(placementRule); err == nil {
+			destinations.addPlacementLeafHubs(placementRuleKind, placementRule, matcher, managedClusters)
+		}
+	}
+
+	if err := destinations.addPolicyLeafHubs(ctx, specDB); err != nil {
+		return nil, err
+	}
+
+	if err := destinations.addSubscriptionLeafHubs(ctx, specDB, managedClusters); err != nil {
+		return nil, err
+	}
+
+	return destinations, nil
+}
+
+// addPlacementLeafHubs adds the leaf hubs with managed clusters matched by the placement or placement rule.
+func (destinations *specDestinations) addPlacementLeafHubs(kind string, object metav1.Object,
+	matcher *placement.Matcher, managedClusters map[string][]*clusterv1.ManagedCluster,
+) {
+	leafHubs := getMatchedLeafHubs(matcher, managedClusters)
+
+	addLeafHubs(destinations.LeafHubsPerObject, objectKey(kind, object.GetNamespace(), object.GetName()), leafHubs)
+	addLeafHubs(destinations.LeafHubsPerNamespace, object.GetNamespace(), leafHubs)
+}
+
+// getMatchedLeafHubs returns the leaf hubs with managed clusters matched by the matcher.
+func getMatchedLeafHubs(matcher *placement.Matcher,
+	managedClusters map[string][]*clusterv1.ManagedCluster,
+) map[string]struct{} {
+	leafHubs := make(map[string]struct{})
+
+	for leafHubName, clusters := range managedClusters {
+		for _, cluster := range clusters {
+			if matcher.Matches(cluster) {
+				leafHubs[leafHubName] = struct{}{}
+				break
+			}
+		}
+	}
+
+	return leafHubs
+}
+
+// addPolicyLeafHubs adds the leaf hubs of the placements bound to the policies and the policy sets, a policy is also
+// bound through the policy sets that contain it.
+func (destinations *specDestinations) addPolicyLeafHubs(ctx context.Context, specDB db.SpecDB) error {
+	placementBindings, err := specDB.GetDistributedObjects(ctx, placementBindingsTableName,
+		func() metav1.Object { return &policyv1.PlacementBinding{} })
+	if err != nil {
+		return fmt.Errorf("failed to get placement bindings - %w", err)
+	}
+
+	for _, object := range placementBindings {
+		binding, ok := object.(*policyv1.PlacementBinding)
+		if !ok {
+			continue
+		}
+
+		leafHubs := destinations.LeafHubsPerObject[objectKey(binding.PlacementRef.Kind, binding.Namespace,
+			binding.PlacementRef.Name)]
+
+		for _, subject := range binding.Subjects {
+			addLeafHubs(destinations.LeafHubsPerObject, objectKey(subject.Kind, binding.Namespace, subject.Name),
+				leafHubs)
+		}
+	}
+
+	policySets, err := specDB.GetDistributedObjects(ctx, policySetsTableName,
+		func() metav1.Object { return &policyv1beta1.PolicySet{} })
+	if err != nil {
+		return fmt.Errorf("failed to get policy sets - %w", err)
+	}
+
+	for _, object := range policySets {
+		policySet, ok := object.(*policyv1beta1.PolicySet)
+		if !ok {
+			continue
+		}
+
+		leafHubs := destinations.LeafHubsPerObject[objectKey(policySetKind, policySet.Namespace, policySet.Name)]
+
+		for _, policyName := range policySet.Spec.Policies {
+			addLeafHubs(destinations.LeafHubsPerObject, objectKey(policyKind, policySet.Namespace,
+				string(policyName)), leafHubs)
+		}
+	}
+
+	return nil
+}
+
+// addSubscriptionLeafHubs adds the subscriptions with the leaf hubs of their placements, and the leaf hubs of the
+// subscriptions to their channels.
+func (destinations *specDestinations) addSubscriptionLeafHubs(ctx context.Context, specDB db.SpecDB,
+	managedClusters map[string][]*clusterv1.ManagedCluster,
+) error {
+	subscriptions, err := specDB.GetDistributedObjects(ctx, subscriptionsTableName,
+		func() metav1.Object { return &subscriptionv1.Subscription{} })
+	if err != nil {
+		return fmt.Errorf("failed to get subscriptions - %w", err)
+	}
+
+	for _, object := range subscriptions {
+		subscription, ok := object.(*subscriptionv1.Subscription)
+		if !ok {
+			continue
+		}
+
+		leafHubs := destinations.getSubscriptionLeafHubs(subscription, managedClusters)
+		addLeafHubs(destinations.LeafHubsPerObject, objectKey(subscriptionKind, subscription.Namespace,
+			subscription.Name), leafHubs)
+
+		destinations.SubscriptionsPerNamespace[subscription.Namespace] = append(
+			destinations.SubscriptionsPerNamespace[subscription.Namespace],
+			&subscriptionDestinations{Labels: subscription.Labels, LeafHubs: leafHubs})
+
+		// the channel is referenced as namespace/name
+		channelNamespace, channelName, found := strings.Cut(subscription.Spec.Channel, "/")
+		if !found {
+			continue
+		}
+
+		addLeafHubs(destinations.LeafHubsPerObject, objectKey(channelKind, channelNamespace, channelName), leafHubs)
+		addLeafHubs(destinations.LeafHubsPerNamespace, channelNamespace, leafHubs)
+	}
+
+	return nil
+}
+
+// getSubscriptionLeafHubs returns the leaf hubs of the placement or placement rule of the subscription, or of the
+// managed clusters selected by the placement fields of the subscription itself.
+func (destinations *specDestinations) getSubscriptionLeafHubs(subscription *subscriptionv1.Subscription,
+	managedClusters map[string][]*clusterv1.ManagedCluster,
+) map[string]struct{} {
+	subscriptionPlacement := subscription.Spec.Placement
+	if subscriptionPlacement == nil {
+		return nil
+	}
+
+	if subscriptionPlacement.PlacementRef != nil {
+		kind := subscriptionPlacement.PlacementRef.Kind
+		if kind == "" {
+			kind = placementRuleKind
+		}
+
+		return destinations.LeafHubsPerObject[objectKey(kind, subscription.Namespace,
+			subscriptionPlacement.PlacementRef.Name)]
+	}
+
+	if subscriptionPlacement.ClusterSelector == nil && len(subscriptionPlacement.Clusters) == 0 {
+		return nil
+	}
+
+	matcher, err := placement.NewPlacementRuleMatcher(&placementrulev1.PlacementRule{
+		Spec: placementrulev1.PlacementRuleSpec{GenericPlacementFields: subscriptionPlacement.GenericPlacementFields},
+	})
+	if err != nil {
+		return nil
+	}
+
+	return getMatchedLeafHubs(matcher, managedClusters)
+}
+
+// getLeafHubs returns the set of leaf hubs the object is relevant to.
+func (destinations *specDestinations) getLeafHubs(object metav1.Object,
+	destinationsFunc destinationsFunction,
+) map[string]struct{} {
+	leafHubs, unrestricted := destinationsFunc(object, destinations)
+	if !unrestricted {
+		return leafHubs
+	}
+
+	allLeafHubs := make(map[string]struct{}, len(destinations.LeafHubNames))
+	for _, leafHubName := range destinations.LeafHubNames {
+		allLeafHubs[leafHubName] = struct{}{}
+	}
+
+	return allLeafHubs
+}
+
+// unrestrictedLeafHubs doesn't restrict the object, it's used for the configuration of the leaf hubs.
+func unrestrictedLeafHubs(metav1.Object, *specDestinations) (map[string]struct{}, bool) {
+	return nil, true
+}
+
+// namespaceLeafHubs restricts the object to the leaf hubs of the placements, placement rules and channels in its
+// namespace, it's used for the resources the placed objects depend on, e.g. the secret of a channel. the cluster
+// scoped objects aren't restricted.
+func namespaceLeafHubs(object metav1.Object, destinations *specDestinations) (map[string]struct{}, bool) {
+	if object.GetNamespace() == "" {
+		return nil, true
+	}
+
+	return destinations.LeafHubsPerNamespace[object.GetNamespace()], false
+}
+
+// managedClusterSetLeafHubs restricts the managed-cluster-set to the leaf hubs with clusters in it.
+func managedClusterSetLeafHubs(object metav1.Object, destinations *specDestinations) (map[string]struct{}, bool) {
+	return destinations.LeafHubsPerClusterSet[object.GetName()], false
+}
+
+// managedClusterSetBindingLeafHubs restricts the binding to the leaf hubs with clusters in the bound set.
+func managedClusterSetBindingLeafHubs(object metav1.Object, destinations *specDestinations) (map[string]struct{},
+	bool,
+) {
+	binding, ok := object.(*clusterv1beta1.ManagedClusterSetBinding)
+	if !ok {
+		binding = &clusterv1beta1.ManagedClusterSetBinding{}
+		if !fromUnstructured(object, binding) {
+			return nil, false
+		}
+	}
+
+	return destinations.LeafHubsPerClusterSet[binding.Spec.ClusterSet], false
+}
+
+// placementLeafHubs restricts the placement to the leaf hubs with clusters it selects.
+func placementLeafHubs(object metav1.Object, destinations *specDestinations) (map[string]struct{}, bool) {
+	return destinations.LeafHubsPerObject[objectKey(placementKind, object.GetNamespace(), object.GetName())], false
+}
+
+// placementRuleLeafHubs restricts the placement rule to the leaf hubs with clusters it selects.
+func placementRuleLeafHubs(object metav1.Object, destinations *specDestinations) (map[string]struct{}, bool) {
+	return destinations.LeafHubsPerObject[objectKey(placementRuleKind, object.GetNamespace(), object.GetName())],
+		false
+}
+
+// placementBindingLeafHubs restricts the placement binding to the leaf hubs of its placement.
+func placementBindingLeafHubs(object metav1.Object, destinations *specDestinations) (map[string]struct{}, bool) {
+	binding, ok := object.(*policyv1.PlacementBinding)
+	if !ok {
+		binding = &policyv1.PlacementBinding{}
+		if !fromUnstructured(object, binding) {
+			return nil, false
+		}
+	}
+
+	return destinations.LeafHubsPerObject[objectKey(binding.PlacementRef.Kind, binding.Namespace,
+		binding.PlacementRef.Name)], false
+}
+
+// policyLeafHubs restricts the policy to the leaf hubs of the placements bound to it or to its policy sets.
+func policyLeafHubs(object metav1.Object, destinations *specDestinations) (map[string]struct{}, bool) {
+	return destinations.LeafHubsPerObject[objectKey(policyKind, object.GetNamespace(), object.GetName())], false
+}
+
+// policySetLeafHubs restricts the policy set to the leaf hubs of the placements bound to it.
+func policySetLeafHubs(object metav1.Object, destinations *specDestinations) (map[string]struct{}, bool) {
+	return destinations.LeafHubsPerObject[objectKey(policySetKind, object.GetNamespace(), object.GetName())], false
+}
+
+// policyAutomationLeafHubs restricts the policy automation to the leaf hubs of its policy.
+func policyAutomationLeafHubs(object metav1.Object, destinations *specDestinations) (map[string]struct{}, bool) {
+	policyAutomation, ok := object.(*policyv1beta1.PolicyAutomation)
+	if !ok {
+		policyAutomation = &policyv1beta1.PolicyAutomation{}
+		if !fromUnstructured(object, policyAutomation) {
+			return nil, false
+		}
+	}
+
+	return destinations.LeafHubsPerObject[objectKey(policyKind, policyAutomation.Namespace,
+		policyAutomation.Spec.PolicyRef)], false
+}
+
+// subscriptionLeafHubs restricts the subscription to the leaf hubs of its placement.
+func subscriptionLeafHubs(object metav1.Object, destinations *specDestinations) (map[string]struct{}, bool) {
+	return destinations.LeafHubsPerObject[objectKey(subscriptionKind, object.GetNamespace(), object.GetName())],
+		false
+}
+
+// channelLeafHubs restricts the channel to the leaf hubs of the subscriptions of the channel.
+func channelLeafHubs(object metav1.Object, destinations *specDestinations) (map[string]struct{}, bool) {
+	return destinations.LeafHubsPerObject[objectKey(channelKind, object.GetNamespace(), object.GetName())], false
+}
+
+// applicationLeafHubs restricts the application to the leaf hubs of the subscriptions it selects.
+func applicationLeafHubs(object metav1.Object, destinations *specDestinations) (map[string]struct{}, bool) {
+	application, ok := object.(*applicationv1beta1.Application)
+	if !ok {
+		application = &applicationv1beta1.Application{}
+		if !fromUnstructured(object, application) {
+			return nil, false
+		}
+	}
+
+	if application.Spec.Selector == nil {
+		return nil, false
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(application.Spec.Selector)
+	if err != nil {
+		return nil, false
+	}
+
+	leafHubs := make(map[string]struct{})
+
+	for _, subscription := range destinations.SubscriptionsPerNamespace[application.Namespace] {
+		if selector.Matches(labels.Set(subscription.Labels)) {
+			for leafHubName := range subscription.LeafHubs {
+				leafHubs[leafHubName] = struct{}{}
+			}
+		}
+	}
+
+	return leafHubs, false
+}
+
+// fromUnstructured converts the unstructured object into the typed object, the objects are unstructured when they're
+// previewed.
+func fromUnstructured(object metav1.Object, into runtime.Object) bool {
+	unstructuredObject, ok := object.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredObject.Object, into) == nil
+}
+
+func objectKey(kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+// addLeafHubs adds the leaf hubs to the set of the key.
+func addLeafHubs(leafHubsPerKey map[string]map[string]struct{}, key string, leafHubs map[string]struct{}) {
+	keyLeafHubs, found := leafHubsPerKey[key]
+	if !found {
+		keyLeafHubs = make(map[string]struct{}, len(leafHubs))
+		leafHubsPerKey[key] = keyLeafHubs
+	}
+
+	for leafHubName := range leafHubs {
+		keyLeafHubs[leafHubName] = struct{}{}
+	}
+}
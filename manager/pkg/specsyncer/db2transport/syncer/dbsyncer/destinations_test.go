@@ -0,0 +1,164 @@
+package dbsyncer
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	policyv1beta1 "open-cluster-management.io/governance-policy-propagator/api/v1beta1"
+	channelv1 "open-cluster-management.io/multicloud-operators-channel/pkg/apis/apps/v1"
+	placementrulev1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/apps/placementrule/v1"
+	subscriptionv1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/apps/v1"
+	applicationv1beta1 "sigs.k8s.io/application/api/v1beta1"
+)
+
+func TestGetSpecDestinations(t *testing.T) {
+	specDB := &fakeSpecDB{
+		leafHubNames: []string{"hub1", "hub2", "hub3"},
+		managedClusters: map[string][]*clusterv1.ManagedCluster{
+			"hub1": {newManagedCluster("cluster1", map[string]string{
+				clusterv1beta1.ClusterSetLabel: "set1", "env": "prod",
+			})},
+			"hub2": {newManagedCluster("cluster2", map[string]string{
+				clusterv1beta1.ClusterSetLabel: "set1", "env": "dev",
+			})},
+			"hub3": {newManagedCluster("cluster3", map[string]string{"env": "prod"})},
+		},
+		clusterSetsPerNamespace: map[string][]string{"policy-ns": {"set1"}},
+		distributedObjects: map[string][]metav1.Object{
+			placementsTableName: {
+				&clusterv1beta1.Placement{
+					ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "policy-ns"},
+					Spec: clusterv1beta1.PlacementSpec{Predicates: []clusterv1beta1.ClusterPredicate{{
+						RequiredClusterSelector: clusterv1beta1.ClusterSelector{
+							LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+						},
+					}}},
+				},
+				// the namespace isn't bound to cluster sets
+				newPlacement("unbound", "other-ns"),
+			},
+			placementRulesTableName: {newPlacementRule("dev", "app-ns", map[string]string{"env": "dev"})},
+			placementBindingsTableName: {
+				newPlacementBinding("binding", "policy-ns", placementKind, "prod", policySetKind, "set"),
+			},
+			policySetsTableName: {
+				&policyv1beta1.PolicySet{
+					ObjectMeta: metav1.ObjectMeta{Name: "set", Namespace: "policy-ns"},
+					Spec:       policyv1beta1.PolicySetSpec{Policies: []policyv1beta1.NonEmptyString{"policy"}},
+				},
+			},
+			subscriptionsTableName: {
+				&subscriptionv1.Subscription{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "subscription", Namespace: "app-ns", Labels: map[string]string{"app": "app"},
+					},
+					Spec: subscriptionv1.SubscriptionSpec{
+						Channel: "channel-ns/channel",
+						Placement: &placementrulev1.Placement{
+							PlacementRef: &corev1.ObjectReference{Name: "dev", Kind: placementRuleKind},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	destinations, err := getSpecDestinations(context.Background(), specDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		object           metav1.Object
+		destinationsFunc destinationsFunction
+		expected         []string
+	}{
+		{
+			name:             "placement selects the matching clusters in the bound cluster sets",
+			object:           newPlacement("prod", "policy-ns"),
+			destinationsFunc: placementLeafHubs,
+			expected:         []string{"hub1"},
+		},
+		{
+			name:             "placement in a namespace without bindings selects no clusters",
+			object:           newPlacement("unbound", "other-ns"),
+			destinationsFunc: placementLeafHubs,
+			expected:         []string{},
+		},
+		{
+			name:             "policy is placed through its policy set",
+			object:           newPolicy("policy", "policy-ns"),
+			destinationsFunc: policyLeafHubs,
+			expected:         []string{"hub1"},
+		},
+		{
+			name:             "policy without a binding isn't placed",
+			object:           newPolicy("unbound", "policy-ns"),
+			destinationsFunc: policyLeafHubs,
+			expected:         []string{},
+		},
+		{
+			name:             "placement rule selects the matching clusters",
+			object:           newPlacementRule("dev", "app-ns", nil),
+			destinationsFunc: placementRuleLeafHubs,
+			expected:         []string{"hub2"},
+		},
+		{
+			name: "channel follows its subscriptions",
+			object: &channelv1.Channel{
+				ObjectMeta: metav1.ObjectMeta{Name: "channel", Namespace: "channel-ns"},
+			},
+			destinationsFunc: channelLeafHubs,
+			expected:         []string{"hub2"},
+		},
+		{
+			name: "application follows the subscriptions it selects",
+			object: &applicationv1beta1.Application{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "app-ns"},
+				Spec: applicationv1beta1.ApplicationSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "app"}},
+				},
+			},
+			destinationsFunc: applicationLeafHubs,
+			expected:         []string{"hub2"},
+		},
+		{
+			name:             "generic resource follows the channels in its namespace",
+			object:           &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "channel-ns"}},
+			destinationsFunc: namespaceLeafHubs,
+			expected:         []string{"hub2"},
+		},
+		{
+			name:             "managed cluster set goes to the hubs with clusters in it",
+			object:           &clusterv1beta1.ManagedClusterSet{ObjectMeta: metav1.ObjectMeta{Name: "set1"}},
+			destinationsFunc: managedClusterSetLeafHubs,
+			expected:         []string{"hub1", "hub2"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			leafHubs := make([]string, 0)
+			for leafHubName := range destinations.getLeafHubs(test.object, test.destinationsFunc) {
+				leafHubs = append(leafHubs, leafHubName)
+			}
+
+			sort.Strings(leafHubs)
+
+			if !reflect.DeepEqual(test.expected, leafHubs) {
+				t.Errorf("expected %v, got %v", test.expected, leafHubs)
+			}
+		})
+	}
+}
+
+func newPlacement(name, namespace string) *clusterv1beta1.Placement {
+	return &clusterv1beta1.Placement{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+}
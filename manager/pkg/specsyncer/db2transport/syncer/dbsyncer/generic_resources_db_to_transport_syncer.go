@@ -0,0 +1,45 @@
+package dbsyncer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/bundle"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/intervalpolicy"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/rollout"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/transport"
+)
+
+const (
+	genericResourcesTableName = "generic_resources"
+	genericResourcesMsgKey    = "GenericResources"
+)
+
+// AddGenericResourcesDBToTransportSyncer adds generic resources db to transport syncer to the manager. the table holds
+// the resources of all the propagated kinds, so the bundles hold objects of different kinds.
+func AddGenericResourcesDBToTransportSyncer(mgr ctrl.Manager, specDB db.SpecDB, transportObj transport.Transport,
+	destinationsResolver *DestinationsResolver, rolloutGate *rollout.Gate, specSyncInterval time.Duration,
+) error {
+	createObjFunc := func() metav1.Object { return &unstructured.Unstructured{} }
+	syncState := &leafHubsSyncState{}
+
+	if err := mgr.Add(&genericDBToTransportSyncer{
+		log:            ctrl.Log.WithName("generic-resources-db-to-transport-syncer"),
+		intervalPolicy: intervalpolicy.NewExponentialBackoffPolicy(specSyncInterval),
+		syncBundleFunc: func(ctx context.Context) (bool, error) {
+			return syncObjectsBundlesPerLeafHub(ctx, transportObj, genericResourcesMsgKey, specDB,
+				genericResourcesTableName, createObjFunc, bundle.NewBaseObjectsBundle, namespaceLeafHubs,
+				destinationsResolver, rolloutGate, syncState)
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to add generic resources db to transport syncer - %w", err)
+	}
+
+	return nil
+}
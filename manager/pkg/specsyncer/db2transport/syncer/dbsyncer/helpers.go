@@ -58,8 +58,10 @@ func syncToTransport(transportObj transport.Transport, destination string, objID
 		return fmt.Errorf("failed to sync {objID: %s, destination: %s} to transport - %w", objID, destination, err)
 	}
 
-	transportObj.SendAsync(destination, objID, constants.SpecBundle,
-		timestamp.Format(timeFormat), payloadBytes)
+	if err := transportObj.SendAsync(destination, objID, constants.SpecBundle, timestamp.Format(timeFormat),
+		payloadBytes); err != nil {
+		return fmt.Errorf("failed to sync {objID: %s, destination: %s} to transport - %w", objID, destination, err)
+	}
 
 	return nil
 }
@@ -0,0 +1,277 @@
+package dbsyncer
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/bundle"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/rollout"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/transport"
+)
+
+// fullStateSyncInterval is the interval of sending the full state of the objects to the leaf hubs as reconciliation,
+// between the full state bundles the leaf hubs get delta bundles of the objects changed since their last bundle.
+const fullStateSyncInterval = 10 * time.Minute
+
+// bundleVersionPrefix makes the bundle versions unique across the restarts of the manager.
+var bundleVersionPrefix = strconv.FormatInt(time.Now().UnixNano(), 10)
+
+// leafHubsSyncState holds the state of syncing an objects table to the leaf hubs between calls.
+type leafHubsSyncState struct {
+	lastSyncTimestamp time.Time
+	lastDestinations  *specDestinations
+	// leafHubs is a map of leaf hub -> the state of the bundles sent to the leaf hub.
+	leafHubs         map[string]*leafHubSyncState
+	bundleGeneration uint64
+	// heldLeafHubs is the set of leaf hubs with changes held back by the rollout gate or failed to be sent.
+	heldLeafHubs map[string]struct{}
+}
+
+// leafHubSyncState holds the state of the bundles sent to a leaf hub.
+type leafHubSyncState struct {
+	bundleVersion string
+	// lastSyncTimestamp is the last update timestamp of the table when the last bundle was sent.
+	lastSyncTimestamp    time.Time
+	lastFullStateSync    time.Time
+	lastFullStateRequest time.Time
+	// changesSentAt is the time the last bundle with changes was sent, the periodic full state bundles without
+	// changes don't update it.
+	changesSentAt time.Time
+	// sentObjects is the set of uids of the objects the leaf hub has.
+	sentObjects map[string]struct{}
+}
+
+// syncObjectsBundlesPerLeafHub performs the sync logic of objects that are relevant only to some of the leaf hubs,
+// every leaf hub gets a bundle of the objects relevant to it. the objects that are no longer relevant to a leaf hub
+// are sent to it as deleted objects. the leaf hubs get delta bundles of the changed objects, the full state is sent
+// periodically, to new leaf hubs and to leaf hubs that requested it after detecting a gap in the delta bundles.
+// the changes of the leaf hubs that aren't admitted by the rollout gate are held back until they're admitted.
+// returns true if bundles were committed to transport, otherwise false.
+func syncObjectsBundlesPerLeafHub(ctx context.Context, transportObj transport.Transport, transportBundleKey string,
+	specDB db.SpecDB, dbTableName string, createObjFunc bundle.CreateObjectFunction,
+	createBundleFunc bundle.CreateBundleFunction, destinationsFunc destinationsFunction,
+	destinationsResolver *DestinationsResolver, rolloutGate *rollout.Gate, state *leafHubsSyncState,
+) (bool, error) {
+	lastUpdateTimestamp, err := specDB.GetLastUpdateTimestamp(ctx, dbTableName, true) // filter local resources
+	if err != nil {
+		return false, fmt.Errorf("unable to sync bundle - %w", err)
+	}
+
+	destinations, err := destinationsResolver.get(ctx)
+	if err != nil {
+		return false, fmt.Errorf("unable to sync bundle - %w", err)
+	}
+
+	fullStateRequests, err := specDB.GetFullStateRequests(ctx, transportBundleKey)
+	if err != nil {
+		return false, fmt.Errorf("unable to sync bundle - %w", err)
+	}
+
+	fullStateLeafHubs := state.getFullStateLeafHubs(destinations.LeafHubNames, fullStateRequests)
+
+	// sync only if something has changed in the table or in the relevance of the objects to the leaf hubs,
+	// or if the full state should be sent to some of the leaf hubs, or if changes are held back from some of them
+	if !lastUpdateTimestamp.After(state.lastSyncTimestamp) &&
+		reflect.DeepEqual(destinations, state.lastDestinations) && len(fullStateLeafHubs) == 0 &&
+		len(state.heldLeafHubs) == 0 {
+		return false, nil
+	}
+
+	objects, lastUpdateTimestamp, err := specDB.GetObjects(ctx, dbTableName, createObjFunc)
+	if err != nil {
+		return false, fmt.Errorf("unable to sync bundle - %w", err)
+	}
+
+	relevantLeafHubs := make([]map[string]struct{}, len(objects))
+	for i, object := range objects {
+		if !object.Deleted {
+			relevantLeafHubs[i] = destinations.getLeafHubs(object.Object, destinationsFunc)
+		}
+	}
+
+	leafHubBundles := make(map[string]bundle.ObjectsBundle, len(destinations.LeafHubNames))
+	leafHubsSentObjects := make(map[string]map[string]struct{}, len(destinations.LeafHubNames))
+	pendingLeafHubs := make(map[string]struct{})
+	changesSentAt := make(map[string]time.Time)
+
+	for _, leafHubName := range destinations.LeafHubNames {
+		lastState := state.leafHubs[leafHubName]
+		_, fullState := fullStateLeafHubs[leafHubName]
+
+		leafHubBundle := createBundleFunc()
+		sentObjects, changed := addLeafHubObjects(leafHubBundle, leafHubName, objects, relevantLeafHubs, lastState,
+			fullState)
+
+		if changed {
+			leafHubBundles[leafHubName] = leafHubBundle
+			leafHubsSentObjects[leafHubName] = sentObjects
+		}
+
+		// a full state bundle has changes if the leaf hub is new or has changes for a delta bundle
+		pending := changed && (lastState == nil || !fullState)
+		if changed && !pending {
+			_, pending = addLeafHubObjects(createBundleFunc(), leafHubName, objects, relevantLeafHubs, lastState,
+				false)
+		}
+
+		if pending {
+			pendingLeafHubs[leafHubName] = struct{}{}
+		} else {
+			changesSentAt[leafHubName] = lastState.changesSentAt
+		}
+	}
+
+	admittedLeafHubs := rolloutGate.AdmitLeafHubs(pendingLeafHubs, changesSentAt)
+	leafHubStates := make(map[string]*leafHubSyncState, len(destinations.LeafHubNames))
+	heldLeafHubs := make(map[string]struct{})
+	synced := false
+
+	var syncErr error
+
+	for _, leafHubName := range destinations.LeafHubNames {
+		lastState := state.leafHubs[leafHubName]
+		_, pending := pendingLeafHubs[leafHubName]
+		_, admitted := admittedLeafHubs[leafHubName]
+
+		if pending && !admitted { // the leaf hub keeps its last state until the changes are sent
+			heldLeafHubs[leafHubName] = struct{}{}
+			if lastState != nil {
+				leafHubStates[leafHubName] = lastState
+			}
+
+			continue
+		}
+
+		leafHubBundle, changed := leafHubBundles[leafHubName]
+		if !changed { // nothing to send in a delta bundle
+			lastState.lastSyncTimestamp = *lastUpdateTimestamp
+			leafHubStates[leafHubName] = lastState
+
+			continue
+		}
+
+		_, fullState := fullStateLeafHubs[leafHubName]
+
+		state.bundleGeneration++
+		leafHubState := &leafHubSyncState{
+			bundleVersion:     fmt.Sprintf("%s.%d", bundleVersionPrefix, state.bundleGeneration),
+			lastSyncTimestamp: *lastUpdateTimestamp,
+			sentObjects:       leafHubsSentObjects[leafHubName],
+		}
+
+		if pending {
+			leafHubState.changesSentAt = time.Now()
+		} else {
+			leafHubState.changesSentAt = lastState.changesSentAt
+		}
+
+		baseBundleVersion := ""
+		if fullState {
+			leafHubState.lastFullStateSync = time.Now()
+			leafHubState.lastFullStateRequest = fullStateRequests[leafHubName]
+		} else {
+			baseBundleVersion = lastState.bundleVersion
+			leafHubState.lastFullStateSync = lastState.lastFullStateSync
+			leafHubState.lastFullStateRequest = lastState.lastFullStateRequest
+		}
+
+		leafHubBundle.SetBundleVersion(leafHubState.bundleVersion, baseBundleVersion)
+
+		if err := syncToTransport(transportObj, leafHubName, transportBundleKey, lastUpdateTimestamp,
+			leafHubBundle); err != nil { // the leaf hub keeps its last state, so the changes are sent again
+			heldLeafHubs[leafHubName] = struct{}{}
+			if lastState != nil {
+				leafHubStates[leafHubName] = lastState
+			}
+
+			syncErr = fmt.Errorf("unable to sync bundle to transport - %w", err)
+
+			continue
+		}
+
+		leafHubStates[leafHubName] = leafHubState
+		synced = true
+	}
+
+	state.lastSyncTimestamp = *lastUpdateTimestamp
+	state.lastDestinations = destinations
+	state.leafHubs = leafHubStates
+	state.heldLeafHubs = heldLeafHubs
+
+	return synced, syncErr
+}
+
+// getFullStateLeafHubs returns the set of leaf hubs that should get the full state: the leaf hubs without sent
+// bundles, the leaf hubs that didn't get the full state for the full state interval and the leaf hubs with a new
+// full state request.
+func (state *leafHubsSyncState) getFullStateLeafHubs(leafHubNames []string,
+	fullStateRequests map[string]time.Time,
+) map[string]struct{} {
+	fullStateLeafHubs := make(map[string]struct{})
+
+	for _, leafHubName := range leafHubNames {
+		leafHubState, found := state.leafHubs[leafHubName]
+		requestedAt, requested := fullStateRequests[leafHubName]
+
+		if !found || time.Since(leafHubState.lastFullStateSync) >= fullStateSyncInterval ||
+			(requested && !requestedAt.Equal(leafHubState.lastFullStateRequest)) {
+			fullStateLeafHubs[leafHubName] = struct{}{}
+		}
+	}
+
+	return fullStateLeafHubs
+}
+
+// addLeafHubObjects adds the objects to the bundle of the leaf hub, a delta bundle gets only the objects changed
+// since the last bundle of the leaf hub, the objects that became relevant and the objects that are no longer relevant
+// as deleted objects. returns the set of the objects the leaf hub has and whether the bundle has any changes.
+func addLeafHubObjects(leafHubBundle bundle.ObjectsBundle, leafHubName string, objects []*db.SpecObject,
+	relevantLeafHubs []map[string]struct{}, lastState *leafHubSyncState, fullState bool,
+) (map[string]struct{}, bool) {
+	sentObjects := make(map[string]struct{})
+	changed := fullState
+
+	isUpdated := func(object *db.SpecObject) bool {
+		return fullState || object.UpdatedAt.After(lastState.lastSyncTimestamp)
+	}
+
+	for i, object := range objects {
+		if object.Deleted {
+			if isUpdated(object) {
+				leafHubBundle.AddDeletedObject(object.Object)
+				changed = true
+			}
+
+			continue
+		}
+
+		wasSent := false
+		if lastState != nil {
+			_, wasSent = lastState.sentObjects[object.UID]
+		}
+
+		if _, relevant := relevantLeafHubs[i][leafHubName]; relevant {
+			sentObjects[object.UID] = struct{}{}
+
+			if !wasSent || isUpdated(object) {
+				leafHubBundle.AddObject(object.Object, object.UID)
+				changed = true
+			}
+
+			continue
+		}
+
+		// the objects sent to the leaf hub before this manager started are unknown, the irrelevant objects are
+		// deleted from such leaf hubs in case they were relevant before.
+		if wasSent || lastState == nil {
+			leafHubBundle.AddDeletedObject(object.Object)
+			changed = true
+		}
+	}
+
+	return sentObjects, changed
+}
@@ -0,0 +1,258 @@
+package dbsyncer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	policyv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
+	placementrulev1 "open-cluster-management.io/multicloud-operators-subscription/pkg/apis/apps/placementrule/v1"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/bundle"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
+)
+
+type fakeSpecDB struct {
+	db.SpecDB
+	timestamp               time.Time
+	policies                map[string]*policyv1.Policy
+	updatedAt               map[string]time.Time
+	leafHubNames            []string
+	managedClusters         map[string][]*clusterv1.ManagedCluster
+	clusterSetsPerNamespace map[string][]string
+	distributedObjects      map[string][]metav1.Object
+	fullStateRequests       map[string]time.Time
+}
+
+func (f *fakeSpecDB) GetLastUpdateTimestamp(context.Context, string, bool) (*time.Time, error) {
+	return &f.timestamp, nil
+}
+
+func (f *fakeSpecDB) GetObjects(context.Context, string, bundle.CreateObjectFunction) ([]*db.SpecObject,
+	*time.Time, error,
+) {
+	objects := make([]*db.SpecObject, 0, len(f.policies))
+	for uid, policy := range f.policies {
+		objects = append(objects, &db.SpecObject{UID: uid, Object: policy.DeepCopy(), UpdatedAt: f.updatedAt[uid]})
+	}
+
+	return objects, &f.timestamp, nil
+}
+
+func (f *fakeSpecDB) GetFullStateRequests(context.Context, string) (map[string]time.Time, error) {
+	return f.fullStateRequests, nil
+}
+
+// update marks the policy as updated in the table.
+func (f *fakeSpecDB) update(uid string) {
+	f.timestamp = f.timestamp.Add(time.Second)
+	f.updatedAt[uid] = f.timestamp
+}
+
+func (f *fakeSpecDB) GetLeafHubNames(context.Context) ([]string, error) {
+	return f.leafHubNames, nil
+}
+
+func (f *fakeSpecDB) GetManagedClusters(context.Context) (map[string][]*clusterv1.ManagedCluster, error) {
+	return f.managedClusters, nil
+}
+
+func (f *fakeSpecDB) GetDistributedObjects(_ context.Context, tableName string,
+	_ bundle.CreateObjectFunction,
+) ([]metav1.Object, error) {
+	return f.distributedObjects[tableName], nil
+}
+
+func (f *fakeSpecDB) GetClusterSetsPerNamespace(context.Context, string) (map[string][]string, error) {
+	return f.clusterSetsPerNamespace, nil
+}
+
+type fakeTransport struct {
+	sentBundles    map[string]*bundleNames
+	bundleVersions map[string]string
+	// sendErrors is a map of leaf hub -> the error of sending bundles to the leaf hub.
+	sendErrors map[string]error
+}
+
+type bundleNames struct {
+	BaseBundleVersion string `json:",omitempty"`
+	Objects           []string
+	DeletedObjects    []string
+}
+
+func (f *fakeTransport) SendAsync(destinationHubName string, _ string, _ string, _ string, payload []byte) error {
+	if err := f.sendErrors[destinationHubName]; err != nil {
+		return err
+	}
+
+	received := struct {
+		BundleVersion     string             `json:"bundleVersion"`
+		BaseBundleVersion string             `json:"baseBundleVersion"`
+		Objects           []*policyv1.Policy `json:"objects"`
+		DeletedObjects    []*policyv1.Policy `json:"deletedObjects"`
+	}{}
+	_ = json.Unmarshal(payload, &received)
+
+	// the base version is compared with the version of the previous bundle of the leaf hub
+	names := &bundleNames{}
+	if received.BaseBundleVersion != "" {
+		names.BaseBundleVersion = "previous"
+		if received.BaseBundleVersion != f.bundleVersions[destinationHubName] {
+			names.BaseBundleVersion = "unexpected"
+		}
+	}
+	f.bundleVersions[destinationHubName] = received.BundleVersion
+
+	for _, object := range received.Objects {
+		names.Objects = append(names.Objects, object.Name)
+	}
+
+	for _, object := range received.DeletedObjects {
+		names.DeletedObjects = append(names.DeletedObjects, object.Name)
+	}
+
+	sort.Strings(names.Objects)
+	sort.Strings(names.DeletedObjects)
+	f.sentBundles[destinationHubName] = names
+
+	return nil
+}
+
+func (f *fakeTransport) Start() {}
+
+func (f *fakeTransport) Stop() {}
+
+func newPolicy(name string, namespace string) *policyv1.Policy {
+	return &policyv1.Policy{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+}
+
+func newManagedCluster(name string, labels map[string]string) *clusterv1.ManagedCluster {
+	return &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func newPlacementRule(name, namespace string, matchLabels map[string]string) *placementrulev1.PlacementRule {
+	return &placementrulev1.PlacementRule{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: placementrulev1.PlacementRuleSpec{
+			GenericPlacementFields: placementrulev1.GenericPlacementFields{
+				ClusterSelector: &metav1.LabelSelector{MatchLabels: matchLabels},
+			},
+		},
+	}
+}
+
+func newPlacementBinding(name, namespace, placementKind, placementName, subjectKind,
+	subjectName string,
+) *policyv1.PlacementBinding {
+	return &policyv1.PlacementBinding{
+		ObjectMeta:   metav1.ObjectMeta{Name: name, Namespace: namespace},
+		PlacementRef: policyv1.PlacementSubject{Kind: placementKind, Name: placementName},
+		Subjects:     []policyv1.Subject{{Kind: subjectKind, Name: subjectName}},
+	}
+}
+
+func TestSyncObjectsBundlesPerLeafHub(t *testing.T) {
+	ctx := context.Background()
+	specDB := &fakeSpecDB{
+		timestamp: time.Now(),
+		policies: map[string]*policyv1.Policy{
+			"uid-1": newPolicy("bound", "bound-ns"),
+			"uid-2": newPolicy("unbound", "unbound-ns"),
+		},
+		updatedAt:    map[string]time.Time{},
+		leafHubNames: []string{"hub1", "hub2"},
+		managedClusters: map[string][]*clusterv1.ManagedCluster{
+			"hub1": {newManagedCluster("cluster1", map[string]string{"env": "prod"})},
+			"hub2": {newManagedCluster("cluster2", map[string]string{"env": "dev"})},
+		},
+		distributedObjects: map[string][]metav1.Object{
+			placementRulesTableName: {newPlacementRule("rule", "bound-ns", map[string]string{"env": "prod"})},
+			placementBindingsTableName: {
+				newPlacementBinding("binding", "bound-ns", placementRuleKind, "rule", policyKind, "bound"),
+			},
+		},
+		fullStateRequests: map[string]time.Time{},
+	}
+	transportObj := &fakeTransport{bundleVersions: map[string]string{}}
+	state := &leafHubsSyncState{}
+	destinationsResolver := NewDestinationsResolver(specDB, 0)
+
+	syncBundles := func() (bool, error) {
+		transportObj.sentBundles = map[string]*bundleNames{}
+
+		return syncObjectsBundlesPerLeafHub(ctx, transportObj, policiesMsgKey, specDB, policiesTableName,
+			func() metav1.Object { return &policyv1.Policy{} }, bundle.NewBaseObjectsBundle, policyLeafHubs,
+			destinationsResolver, nil, state)
+	}
+
+	sync := func(expected map[string]*bundleNames) {
+		synced, err := syncBundles()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if synced != (len(expected) > 0) {
+			t.Fatalf("expected synced to be %t", len(expected) > 0)
+		}
+
+		if !reflect.DeepEqual(expected, transportObj.sentBundles) {
+			t.Fatalf("expected %s, got %s", toJSON(expected), toJSON(transportObj.sentBundles))
+		}
+	}
+
+	// the leaf hubs get the full state first, the objects that aren't placed on their clusters are deleted from them
+	sync(map[string]*bundleNames{
+		"hub1": {Objects: []string{"bound"}, DeletedObjects: []string{"unbound"}},
+		"hub2": {DeletedObjects: []string{"bound", "unbound"}},
+	})
+
+	// nothing changed
+	sync(map[string]*bundleNames{})
+
+	// only the updated object is sent in a delta bundle to the leaf hub it's placed on
+	specDB.update("uid-1")
+	sync(map[string]*bundleNames{
+		"hub1": {BaseBundleVersion: "previous", Objects: []string{"bound"}},
+	})
+
+	// the placement rule selects the cluster of the other hub, the object is deleted only from the hub it was sent to
+	specDB.distributedObjects[placementRulesTableName] = []metav1.Object{
+		newPlacementRule("rule", "bound-ns", map[string]string{"env": "dev"}),
+	}
+	sync(map[string]*bundleNames{
+		"hub1": {BaseBundleVersion: "previous", DeletedObjects: []string{"bound"}},
+		"hub2": {BaseBundleVersion: "previous", Objects: []string{"bound"}},
+	})
+
+	// the leaf hub that requested the full state gets it
+	specDB.fullStateRequests["hub2"] = time.Now()
+	sync(map[string]*bundleNames{
+		"hub2": {Objects: []string{"bound"}},
+	})
+
+	// the request is served once
+	sync(map[string]*bundleNames{})
+
+	// the bundle that fails to be sent is sent again on the next sync, based on the last sent bundle
+	specDB.update("uid-1")
+	transportObj.sendErrors = map[string]error{"hub2": errors.New("no keys of the destination hub")}
+	if _, err := syncBundles(); err == nil {
+		t.Fatal("expected an error of sending the bundle")
+	}
+
+	transportObj.sendErrors = nil
+	sync(map[string]*bundleNames{
+		"hub2": {BaseBundleVersion: "previous", Objects: []string{"bound"}},
+	})
+}
+
+func toJSON(sentBundles map[string]*bundleNames) string {
+	data, _ := json.Marshal(sentBundles)
+	return string(data)
+}
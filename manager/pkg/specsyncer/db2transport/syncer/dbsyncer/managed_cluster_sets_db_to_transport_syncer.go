@@ -12,6 +12,7 @@ import (
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/bundle"
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/intervalpolicy"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/rollout"
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/transport"
 )
 
@@ -22,17 +23,18 @@ const (
 
 // AddManagedClusterSetsDBToTransportSyncer adds managed-cluster-sets db to transport syncer to the manager.
 func AddManagedClusterSetsDBToTransportSyncer(mgr ctrl.Manager, specDB db.SpecDB, transportObj transport.Transport,
-	specSyncInterval time.Duration,
+	destinationsResolver *DestinationsResolver, rolloutGate *rollout.Gate, specSyncInterval time.Duration,
 ) error {
 	createObjFunc := func() metav1.Object { return &clusterv1beta1.ManagedClusterSet{} }
-	lastSyncTimestampPtr := &time.Time{}
+	syncState := &leafHubsSyncState{}
 
 	if err := mgr.Add(&genericDBToTransportSyncer{
 		log:            ctrl.Log.WithName("managed-cluster-sets-db-to-transport-syncer"),
 		intervalPolicy: intervalpolicy.NewExponentialBackoffPolicy(specSyncInterval),
 		syncBundleFunc: func(ctx context.Context) (bool, error) {
-			return syncObjectsBundle(ctx, transportObj, managedClusterSetsMsgKey, specDB, managedClusterSetsTableName,
-				createObjFunc, bundle.NewBaseObjectsBundle, lastSyncTimestampPtr)
+			return syncObjectsBundlesPerLeafHub(ctx, transportObj, managedClusterSetsMsgKey, specDB,
+				managedClusterSetsTableName, createObjFunc, bundle.NewBaseObjectsBundle, managedClusterSetLeafHubs,
+				destinationsResolver, rolloutGate, syncState)
 		},
 	}); err != nil {
 		return fmt.Errorf("failed to add managed-cluster-sets db to transport syncer - %w", err)
@@ -0,0 +1,44 @@
+package dbsyncer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	policyv1beta1 "open-cluster-management.io/governance-policy-propagator/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/bundle"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/intervalpolicy"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/rollout"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/transport"
+)
+
+const (
+	policyAutomationsTableName = "policyautomations"
+	policyAutomationsMsgKey    = "PolicyAutomations"
+)
+
+// AddPolicyAutomationsDBToTransportSyncer adds policy automations db to transport syncer to the manager.
+func AddPolicyAutomationsDBToTransportSyncer(mgr ctrl.Manager, specDB db.SpecDB, transportObj transport.Transport,
+	destinationsResolver *DestinationsResolver, rolloutGate *rollout.Gate, specSyncInterval time.Duration,
+) error {
+	createObjFunc := func() metav1.Object { return &policyv1beta1.PolicyAutomation{} }
+	syncState := &leafHubsSyncState{}
+
+	if err := mgr.Add(&genericDBToTransportSyncer{
+		log:            ctrl.Log.WithName("policyautomations-db-to-transport-syncer"),
+		intervalPolicy: intervalpolicy.NewExponentialBackoffPolicy(specSyncInterval),
+		syncBundleFunc: func(ctx context.Context) (bool, error) {
+			return syncObjectsBundlesPerLeafHub(ctx, transportObj, policyAutomationsMsgKey, specDB,
+				policyAutomationsTableName, createObjFunc, bundle.NewBaseObjectsBundle, policyAutomationLeafHubs,
+				destinationsResolver, rolloutGate, syncState)
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to add policy automations db to transport syncer - %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package dbsyncer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/intervalpolicy"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/transport"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+const resyncsDBTableName = "resyncs"
+
+// AddResyncDBToTransportSyncer adds resync requests db to transport syncer to the manager.
+func AddResyncDBToTransportSyncer(mgr ctrl.Manager, specDB db.SpecDB, transportObj transport.Transport,
+	specSyncInterval time.Duration,
+) error {
+	lastSyncTimestampPtr := &time.Time{}
+
+	if err := mgr.Add(&genericDBToTransportSyncer{
+		log:            ctrl.Log.WithName("resync-db-to-transport-syncer"),
+		intervalPolicy: intervalpolicy.NewExponentialBackoffPolicy(specSyncInterval),
+		syncBundleFunc: func(ctx context.Context) (bool, error) {
+			return syncResyncBundle(ctx, transportObj, constants.ResyncMsgKey, specDB,
+				resyncsDBTableName, lastSyncTimestampPtr)
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to add resync db to transport syncer - %w", err)
+	}
+
+	return nil
+}
+
+// syncResyncBundle broadcasts the latest resync request and returns true if bundle was committed to transport,
+// otherwise false.
+func syncResyncBundle(ctx context.Context, transportObj transport.Transport, transportBundleKey string,
+	specDB db.SpecDB, dbTableName string, lastSyncTimestampPtr *time.Time,
+) (bool, error) {
+	lastUpdateTimestamp, err := specDB.GetLastUpdateTimestamp(ctx, dbTableName, false) // no resources in table
+	if err != nil {
+		return false, fmt.Errorf("unable to sync bundle - %w", err)
+	}
+
+	if !lastUpdateTimestamp.After(*lastSyncTimestampPtr) { // sync only if a new resync is requested
+		return false, nil
+	}
+
+	resyncBundle, err := specDB.GetLatestResyncBundle(ctx, dbTableName)
+	if err != nil {
+		return false, fmt.Errorf("unable to sync bundle - %w", err)
+	}
+
+	if err := syncToTransport(transportObj, transport.Broadcast, transportBundleKey, lastUpdateTimestamp,
+		resyncBundle); err != nil {
+		return false, fmt.Errorf("unable to sync bundle to transport - %w", err)
+	}
+
+	// updating value to retain same ptr between calls
+	*lastSyncTimestampPtr = *lastUpdateTimestamp
+
+	return true, nil
+}
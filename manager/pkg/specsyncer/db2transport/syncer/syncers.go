@@ -7,30 +7,54 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/rollout"
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/syncer/dbsyncer"
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/syncer/statuswatcher"
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/transport"
 )
 
 // AddDB2TransportSyncers adds the controllers that send info from DB to transport layer to the Manager.
-func AddDB2TransportSyncers(mgr ctrl.Manager, specDB db.SpecDB, transportObj transport.Transport,
-	specSyncInterval time.Duration,
+func AddDB2TransportSyncers(mgr ctrl.Manager, specDB db.SpecDB, statusDB db.StatusDB,
+	transportObj transport.Transport, specSyncInterval time.Duration,
 ) error {
 	addDBSyncerFunctions := []func(ctrl.Manager, db.SpecDB, transport.Transport, time.Duration) error{
 		dbsyncer.AddHoHConfigDBToTransportSyncer,
+		dbsyncer.AddManagedClusterLabelsDBToTransportSyncer,
+		dbsyncer.AddResyncDBToTransportSyncer,
+	}
+	for _, addDBSyncerFunction := range addDBSyncerFunctions {
+		if err := addDBSyncerFunction(mgr, specDB, transportObj, specSyncInterval); err != nil {
+			return fmt.Errorf("failed to add DB Syncer: %w", err)
+		}
+	}
+
+	rolloutGate, err := rollout.AddGate(mgr, statusDB)
+	if err != nil {
+		return fmt.Errorf("failed to add spec rollout gate: %w", err)
+	}
+
+	// the leaf hubs the objects are relevant to are resolved once per interval for all the syncers
+	destinationsResolver := dbsyncer.NewDestinationsResolver(specDB, specSyncInterval)
+
+	// the changes of the objects are rolled out to the leaf hubs by the rollout gate
+	addRolledOutDBSyncerFunctions := []func(ctrl.Manager, db.SpecDB, transport.Transport,
+		*dbsyncer.DestinationsResolver, *rollout.Gate, time.Duration) error{
 		dbsyncer.AddPoliciesDBToTransportSyncer,
 		dbsyncer.AddPlacementRulesDBToTransportSyncer,
 		dbsyncer.AddPlacementBindingsDBToTransportSyncer,
 		dbsyncer.AddApplicationsDBToTransportSyncer,
 		dbsyncer.AddSubscriptionsDBToTransportSyncer,
 		dbsyncer.AddChannelsDBToTransportSyncer,
-		dbsyncer.AddManagedClusterLabelsDBToTransportSyncer,
 		dbsyncer.AddPlacementsDBToTransportSyncer,
 		dbsyncer.AddManagedClusterSetsDBToTransportSyncer,
 		dbsyncer.AddManagedClusterSetBindingsDBToTransportSyncer,
+		dbsyncer.AddPolicySetsDBToTransportSyncer,
+		dbsyncer.AddPolicyAutomationsDBToTransportSyncer,
+		dbsyncer.AddGenericResourcesDBToTransportSyncer,
 	}
-	for _, addDBSyncerFunction := range addDBSyncerFunctions {
-		if err := addDBSyncerFunction(mgr, specDB, transportObj, specSyncInterval); err != nil {
+	for _, addDBSyncerFunction := range addRolledOutDBSyncerFunctions {
+		if err := addDBSyncerFunction(mgr, specDB, transportObj, destinationsResolver, rolloutGate,
+			specSyncInterval); err != nil {
 			return fmt.Errorf("failed to add DB Syncer: %w", err)
 		}
 	}
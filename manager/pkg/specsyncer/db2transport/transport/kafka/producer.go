@@ -10,6 +10,7 @@ import (
 
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/transport"
 	"github.com/stolostron/multicluster-global-hub/pkg/compressor"
+	"github.com/stolostron/multicluster-global-hub/pkg/encryptor"
 	kafkaclient "github.com/stolostron/multicluster-global-hub/pkg/kafka"
 	"github.com/stolostron/multicluster-global-hub/pkg/kafka/headers"
 	kafkaproducer "github.com/stolostron/multicluster-global-hub/pkg/kafka/kafka-producer"
@@ -25,10 +26,13 @@ type KafkaProducerConfig struct {
 	ProducerID     string
 	ProducerTopic  string
 	MsgSizeLimitKB int
+	SaslUser       string
+	SaslPassword   string
 }
 
-// NewProducer returns a new instance of Producer object.
-func NewProducer(compressor compressor.Compressor, bootstrapServer, SslCa string,
+// NewProducer returns a new instance of Producer object. the messages are encrypted with the keys of the regional
+// hubs if the key store is set.
+func NewProducer(compressor compressor.Compressor, keyStore *encryptor.KeyStore, bootstrapServer, SslCa string,
 	producerConfig *KafkaProducerConfig, log logr.Logger,
 ) (*Producer, error) {
 	kafkaConfigMap := &kafka.ConfigMap{
@@ -44,6 +48,11 @@ func NewProducer(compressor compressor.Compressor, bootstrapServer, SslCa string
 		return nil, err
 	}
 
+	if err := kafkaclient.SetSASLCredential(kafkaConfigMap, producerConfig.SaslUser,
+		producerConfig.SaslPassword); err != nil {
+		return nil, err
+	}
+
 	deliveryChan := make(chan kafka.Event)
 	kafkaProducer, err := kafkaproducer.NewKafkaProducer(kafkaConfigMap,
 		producerConfig.MsgSizeLimitKB*kiloBytesToBytes,
@@ -58,6 +67,7 @@ func NewProducer(compressor compressor.Compressor, bootstrapServer, SslCa string
 		kafkaProducer: kafkaProducer,
 		topic:         producerConfig.ProducerTopic,
 		compressor:    compressor,
+		keyStore:      keyStore,
 		deliveryChan:  deliveryChan,
 		stopChan:      make(chan struct{}),
 	}, nil
@@ -83,12 +93,19 @@ func loadSslToConfigMap(SslCa string, kafkaConfigMap *kafka.ConfigMap) error {
 	return nil
 }
 
+// messageProducer produces the messages to the kafka brokers, it's implemented by the kafka-producer.
+type messageProducer interface {
+	ProduceAsync(key string, topic string, partition int32, headers []kafka.Header, payload []byte) error
+	Close()
+}
+
 // Producer abstracts hub-of-hubs/pkg/kafka kafka-producer's generic usage.
 type Producer struct {
 	log           logr.Logger
-	kafkaProducer *kafkaproducer.KafkaProducer
+	kafkaProducer messageProducer
 	topic         string
 	compressor    compressor.Compressor
+	keyStore      *encryptor.KeyStore
 	deliveryChan  chan kafka.Event
 	stopChan      chan struct{}
 	startOnce     sync.Once
@@ -113,7 +130,9 @@ func (p *Producer) Stop() {
 }
 
 // SendAsync sends a message to the sync service asynchronously.
-func (p *Producer) SendAsync(destinationHubName string, id string, msgType string, version string, payload []byte) {
+func (p *Producer) SendAsync(destinationHubName string, id string, msgType string, version string,
+	payload []byte,
+) error {
 	msg := &transport.Message{
 		Destination: destinationHubName,
 		ID:          id,
@@ -124,24 +143,28 @@ func (p *Producer) SendAsync(destinationHubName string, id string, msgType strin
 
 	msgBytes, err := json.Marshal(msg)
 	if err != nil {
-		p.log.Error(err, "Failed to send message", "MessageId", msg.ID, "MessageType", msg.MsgType,
-			"Version", msg.Version)
-
-		return
+		return fmt.Errorf("failed to marshal message - %w", err)
 	}
 
 	compressedBytes, err := p.compressor.Compress(msgBytes)
 	if err != nil {
-		p.log.Error(err, "Failed to compress bundle", "CompressorType", p.compressor.GetType(),
-			"MessageId", msg.ID, "MessageType", msg.MsgType, "Version", msg.Version)
-
-		return
+		return fmt.Errorf("failed to compress bundle with %s - %w", p.compressor.GetType(), err)
 	}
 
 	messageHeaders := []kafka.Header{
 		{Key: headers.CompressionType, Value: []byte(p.compressor.GetType())},
 	}
 
+	if p.keyStore != nil {
+		encryptedBytes, envelope, err := p.encrypt(destinationHubName, compressedBytes)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt bundle - %w", err)
+		}
+
+		compressedBytes = encryptedBytes
+		messageHeaders = append(messageHeaders, kafka.Header{Key: headers.Encryption, Value: envelope})
+	}
+
 	msgKey := msg.ID
 	if destinationHubName != transport.Broadcast { // set destination if specified
 		msgKey = fmt.Sprintf("%s.%s", destinationHubName, msg.ID)
@@ -153,12 +176,41 @@ func (p *Producer) SendAsync(destinationHubName string, id string, msgType strin
 	}
 
 	if err = p.kafkaProducer.ProduceAsync(msgKey, p.topic, partition, messageHeaders, compressedBytes); err != nil {
-		p.log.Error(err, "Failed to send message", "MessageId", msg.ID, "MessageType", msg.MsgType,
-			"Version", msg.Version, "Destination", msg.Destination)
+		return fmt.Errorf("failed to send message - %w", err)
 	}
 
 	p.log.Info("Message sent successfully", "MessageId", msg.ID, "MessageType", msg.MsgType,
 		"Version", msg.Version, "Destination", msg.Destination)
+
+	return nil
+}
+
+// encrypt encrypts the message for the destination hub, or for all the regional hubs with keys if it's broadcasted.
+// returns the encrypted message and the envelope of its data key.
+func (p *Producer) encrypt(destinationHubName string, msgBytes []byte) ([]byte, []byte, error) {
+	keys := make(map[string]*encryptor.Key)
+
+	if destinationHubName == transport.Broadcast {
+		keys = p.keyStore.PrimaryKeys()
+	} else if key, found := p.keyStore.PrimaryKey(destinationHubName); found {
+		keys[destinationHubName] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, nil, fmt.Errorf("%w: no keys of the destination hub", encryptor.ErrKeyNotFound)
+	}
+
+	encryptedBytes, envelope, err := encryptor.Seal(msgBytes, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	envelopeBytes, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal envelope - %w", err)
+	}
+
+	return encryptedBytes, envelopeBytes, nil
 }
 
 func (p *Producer) deliveryReportHandler() {
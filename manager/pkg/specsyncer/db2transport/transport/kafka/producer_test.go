@@ -0,0 +1,172 @@
+package kafka
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/go-logr/logr"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/transport"
+	"github.com/stolostron/multicluster-global-hub/pkg/compressor"
+	"github.com/stolostron/multicluster-global-hub/pkg/encryptor"
+	"github.com/stolostron/multicluster-global-hub/pkg/kafka/headers"
+)
+
+type producedMessage struct {
+	key     string
+	headers []kafka.Header
+	payload []byte
+}
+
+// fakeMessageProducer keeps the produced messages instead of sending them to the kafka brokers.
+type fakeMessageProducer struct {
+	messages []*producedMessage
+}
+
+func (p *fakeMessageProducer) ProduceAsync(key string, _ string, _ int32, headers []kafka.Header,
+	payload []byte,
+) error {
+	p.messages = append(p.messages, &producedMessage{key: key, headers: headers, payload: payload})
+	return nil
+}
+
+func (p *fakeMessageProducer) Close() {}
+
+func TestSendAsyncEncryption(t *testing.T) {
+	keyID := encryptor.NewKeyID(time.Now().Add(-encryptor.KeyActivationDelay - time.Minute))
+	keyStore := newTestKeyStore(t, keyID, "hub1", "hub2")
+
+	gzipCompressor, err := compressor.NewCompressor(compressor.GZip)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messageProducer := &fakeMessageProducer{}
+	producer := &Producer{
+		log:           logr.Discard(),
+		kafkaProducer: messageProducer,
+		compressor:    gzipCompressor,
+		keyStore:      keyStore,
+	}
+
+	if err := producer.SendAsync("hub1", "Policies", "spec", "1.1", []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	message := messageProducer.messages[0]
+	if message.key != "hub1.Policies" || getHeader(message, headers.DestinationHub) != "hub1" {
+		t.Errorf("expected the message to be sent to hub1, got key %s", message.key)
+	}
+
+	if msg := openMessage(t, keyStore, message, "hub1"); msg.ID != "Policies" || string(msg.Payload) != `{}` {
+		t.Errorf("unexpected message %+v", msg)
+	}
+
+	if _, err := openEnvelope(keyStore, message, "hub2"); !errors.Is(err, encryptor.ErrKeyNotFound) {
+		t.Errorf("expected the message to hub1 not to be opened by hub2, got %v", err)
+	}
+
+	if err := producer.SendAsync(transport.Broadcast, "ManagedClusterSets", "spec", "1.1",
+		[]byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, hubName := range []string{"hub1", "hub2"} {
+		if msg := openMessage(t, keyStore, messageProducer.messages[1], hubName); msg.ID != "ManagedClusterSets" {
+			t.Errorf("%s: unexpected message %+v", hubName, msg)
+		}
+	}
+
+	if err := producer.SendAsync("hub3", "Policies", "spec", "1.1", []byte(`{}`)); !errors.Is(err,
+		encryptor.ErrKeyNotFound) || len(messageProducer.messages) != 2 {
+		t.Errorf("expected the message to a hub without keys not to be sent, got %v", err)
+	}
+
+	producer.keyStore = nil
+	if err := producer.SendAsync("hub3", "Policies", "spec", "1.1", []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if getHeader(messageProducer.messages[2], headers.Encryption) != "" {
+		t.Error("expected the message not to be encrypted without the key store")
+	}
+}
+
+func newTestKeyStore(t *testing.T, keyID string, hubNames ...string) *encryptor.KeyStore {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for _, hubName := range hubNames {
+		key, err := encryptor.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, encryptor.KeyName(hubName, keyID)), key, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	keyStore, err := encryptor.NewKeyStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return keyStore
+}
+
+func getHeader(message *producedMessage, key string) string {
+	for _, header := range message.headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+
+	return ""
+}
+
+func openEnvelope(keyStore *encryptor.KeyStore, message *producedMessage, hubName string) ([]byte, error) {
+	envelope := &encryptor.Envelope{}
+	if err := json.Unmarshal([]byte(getHeader(message, headers.Encryption)), envelope); err != nil {
+		return nil, err
+	}
+
+	return encryptor.Open(message.payload, envelope, hubName, func(keyID string) ([]byte, bool) {
+		return keyStore.GetKey(hubName, keyID)
+	})
+}
+
+func openMessage(t *testing.T, keyStore *encryptor.KeyStore, message *producedMessage,
+	hubName string,
+) *transport.Message {
+	t.Helper()
+
+	compressedBytes, err := openEnvelope(keyStore, message, hubName)
+	if err != nil {
+		t.Fatalf("%s: %v", hubName, err)
+	}
+
+	msgCompressor, err := compressor.NewCompressor(compressor.CompressionType(getHeader(message,
+		headers.CompressionType)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msgBytes, err := msgCompressor.Decompress(compressedBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := &transport.Message{}
+	if err := json.Unmarshal(msgBytes, msg); err != nil {
+		t.Fatal(err)
+	}
+
+	return msg
+}
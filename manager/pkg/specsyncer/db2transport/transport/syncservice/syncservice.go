@@ -65,7 +65,9 @@ func (s *SyncService) Stop() {
 }
 
 // SendAsync sends a message to the sync service asynchronously.
-func (s *SyncService) SendAsync(destinationHubName string, id string, msgType string, version string, payload []byte) {
+func (s *SyncService) SendAsync(destinationHubName string, id string, msgType string, version string,
+	payload []byte,
+) error {
 	message := &transport.Message{
 		Destination: destinationHubName,
 		ID:          id,
@@ -74,6 +76,8 @@ func (s *SyncService) SendAsync(destinationHubName string, id string, msgType st
 		Payload:     payload,
 	}
 	s.msgChan <- message
+
+	return nil
 }
 
 func (s *SyncService) distributeMessages() {
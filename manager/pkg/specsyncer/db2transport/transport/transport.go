@@ -5,10 +5,11 @@ const Broadcast = ""
 
 // Transport is the transport layer interface to be consumed by the spec transport bridge.
 type Transport interface {
-	// SendAsync sends a message to the transport component asynchronously.
+	// SendAsync sends a message to the transport component asynchronously. returns an error if the message can't be
+	// sent, e.g. it fails to be encrypted, so the message is sent again.
 	//
 	// destinationHubName specifies a specific destination for distribution or specifies broadcasting if empty.
-	SendAsync(destinationHubName string, id string, msgType string, version string, payload []byte)
+	SendAsync(destinationHubName string, id string, msgType string, version string, payload []byte) error
 	// Start starts the transport.
 	Start()
 	// Stop stops the transport.
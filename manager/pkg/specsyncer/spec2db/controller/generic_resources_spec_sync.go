@@ -0,0 +1,548 @@
+// Copyright (c) 2022 Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/yaml"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+const (
+	genericResourcesTableName = "generic_resources"
+	// genericResourcesConfigKey is the key of the list of the propagated kinds in the configmap.
+	genericResourcesConfigKey = "resources"
+)
+
+// dedicatedKinds are the kinds synced by the dedicated spec-to-db controllers, they can't be propagated generically.
+var dedicatedKinds = map[schema.GroupKind]struct{}{
+	{Group: "policy.open-cluster-management.io", Kind: "Policy"}:                    {},
+	{Group: "policy.open-cluster-management.io", Kind: "PlacementBinding"}:          {},
+	{Group: "policy.open-cluster-management.io", Kind: "PolicySet"}:                 {},
+	{Group: "policy.open-cluster-management.io", Kind: "PolicyAutomation"}:          {},
+	{Group: "apps.open-cluster-management.io", Kind: "PlacementRule"}:               {},
+	{Group: "apps.open-cluster-management.io", Kind: "Subscription"}:                {},
+	{Group: "apps.open-cluster-management.io", Kind: "Channel"}:                     {},
+	{Group: "app.k8s.io", Kind: "Application"}:                                      {},
+	{Group: "cluster.open-cluster-management.io", Kind: "ManagedClusterSet"}:        {},
+	{Group: "cluster.open-cluster-management.io", Kind: "ManagedClusterSetBinding"}: {},
+	{Group: "cluster.open-cluster-management.io", Kind: "Placement"}:                {},
+}
+
+// excludedNamespacePrefixes are the prefixes of the system and the global hub namespaces, their resources are never
+// propagated.
+var excludedNamespacePrefixes = []string{"kube-", "openshift", "open-cluster-management"}
+
+// genericResource is a kind of resources propagated to the regional hubs, only the resources that match the label
+// selector and are in the listed namespaces are propagated. at least one of them is required, and the secrets
+// require a label selector.
+type genericResource struct {
+	Group         string                `json:"group,omitempty"`
+	Version       string                `json:"version"`
+	Kind          string                `json:"kind"`
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	Namespaces    []string              `json:"namespaces,omitempty"`
+}
+
+// validate returns an error if the resources of the kind can't be propagated as configured.
+func (resource *genericResource) validate() error {
+	if resource.Version == "" || resource.Kind == "" {
+		return fmt.Errorf("version and kind are required")
+	}
+
+	if _, found := dedicatedKinds[schema.GroupKind{Group: resource.Group, Kind: resource.Kind}]; found {
+		return fmt.Errorf("the kind is synced by a dedicated controller")
+	}
+
+	if _, err := metav1.LabelSelectorAsSelector(resource.LabelSelector); err != nil {
+		return fmt.Errorf("invalid label selector: %w", err)
+	}
+
+	hasLabelSelector := resource.LabelSelector != nil &&
+		(len(resource.LabelSelector.MatchLabels) > 0 || len(resource.LabelSelector.MatchExpressions) > 0)
+
+	if resource.Group == "" && resource.Kind == "Secret" && !hasLabelSelector {
+		return fmt.Errorf("secrets require a label selector")
+	}
+
+	if !hasLabelSelector && len(resource.Namespaces) == 0 {
+		return fmt.Errorf("a label selector or namespaces are required")
+	}
+
+	return nil
+}
+
+// isRelevant returns a function that returns true if the object is propagated, the objects in the excluded
+// namespaces and the local objects aren't propagated.
+func (resource *genericResource) isRelevant() func(object client.Object) bool {
+	selector := labels.Everything()
+	if resource.LabelSelector != nil {
+		selector, _ = metav1.LabelSelectorAsSelector(resource.LabelSelector) // validated when parsing the configmap
+	}
+
+	namespaces := make(map[string]struct{}, len(resource.Namespaces))
+	for _, namespace := range resource.Namespaces {
+		namespaces[namespace] = struct{}{}
+	}
+
+	return func(object client.Object) bool {
+		for _, prefix := range excludedNamespacePrefixes {
+			if strings.HasPrefix(object.GetNamespace(), prefix) {
+				return false
+			}
+		}
+
+		if _, found := namespaces[object.GetNamespace()]; len(namespaces) > 0 && !found {
+			return false
+		}
+
+		objectLabels := object.GetLabels()
+		if _, found := objectLabels[constants.GlobalHubLocalResource]; found {
+			return false
+		}
+
+		return selector.Matches(labels.Set(objectLabels))
+	}
+}
+
+// kindController is a running spec-to-db controller of a propagated kind.
+type kindController struct {
+	resource genericResource
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func (c *kindController) isRunning() bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// genericResourcesReconciler reconciles the configmap that lists the kinds of the resources propagated to the
+// regional hubs. a spec-to-db controller is started per listed kind and stopped once the kind is removed from the
+// list, the resources of the removed kinds are deleted from the database and so from the regional hubs.
+type genericResourcesReconciler struct {
+	log                        logr.Logger
+	mgr                        ctrl.Manager
+	client                     client.Client
+	apiReader                  client.Reader
+	specDB                     db.SpecDB
+	ctx                        context.Context
+	initializationWaitingGroup sync.WaitGroup
+	lock                       sync.Mutex
+	kindControllers            map[schema.GroupVersionKind]*kindController
+}
+
+// AddGenericResourcesController adds the controller that syncs the resources of the kinds listed in the propagated
+// resources configmap to the database.
+func AddGenericResourcesController(mgr ctrl.Manager, specDB db.SpecDB) error {
+	reconciler := &genericResourcesReconciler{
+		log:                        ctrl.Log.WithName("generic-resources-spec-syncer"),
+		mgr:                        mgr,
+		client:                     mgr.GetClient(),
+		apiReader:                  mgr.GetAPIReader(),
+		specDB:                     specDB,
+		initializationWaitingGroup: sync.WaitGroup{},
+		lock:                       sync.Mutex{},
+		kindControllers:            make(map[schema.GroupVersionKind]*kindController),
+	}
+
+	reconciler.initializationWaitingGroup.Add(1)
+
+	if err := mgr.Add(reconciler); err != nil {
+		return fmt.Errorf("failed to add generic resources syncer to the manager: %w", err)
+	}
+
+	if err := ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.ConfigMap{}).
+		WithEventFilter(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return object.GetNamespace() == constants.HohSystemNamespace &&
+				object.GetName() == constants.GenericResourcesConfigName
+		})).
+		Complete(reconciler); err != nil {
+		return fmt.Errorf("failed to add generic resources controller to the manager: %w", err)
+	}
+
+	return nil
+}
+
+// Start function saves the context the kind controllers are started with, and syncs the kinds once in case the
+// configmap doesn't exist.
+func (r *genericResourcesReconciler) Start(ctx context.Context) error {
+	r.ctx = ctx
+	r.initializationWaitingGroup.Done() // once context is saved, it's safe to start the kind controllers.
+
+	if err := r.syncKinds(ctx); err != nil {
+		r.log.Error(err, "failed to sync the propagated kinds")
+	}
+
+	<-ctx.Done() // blocking wait for stop event
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, kindController := range r.kindControllers {
+		<-kindController.done
+	}
+
+	return nil
+}
+
+func (r *genericResourcesReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	r.initializationWaitingGroup.Wait() // the kind controllers are started only after the context is saved.
+
+	if err := r.syncKinds(ctx); err != nil {
+		r.log.Error(err, "Reconciliation failed")
+		return ctrl.Result{Requeue: true, RequeueAfter: requeuePeriodSeconds * time.Second}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// syncKinds starts the controllers of the kinds listed in the configmap, restarts the controllers of the kinds
+// whose label selector changed and stops the controllers of the kinds that were removed from the configmap.
+func (r *genericResourcesReconciler) syncKinds(ctx context.Context) error {
+	resources, err := r.getGenericResources(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for gvk, kindController := range r.kindControllers {
+		if resource, found := resources[gvk]; !found ||
+			!equality.Semantic.DeepEqual(resource, kindController.resource) {
+			r.stopKindController(gvk)
+		}
+	}
+
+	for gvk, resource := range resources {
+		if kindController, found := r.kindControllers[gvk]; found && kindController.isRunning() {
+			continue
+		}
+
+		if err := r.startKindController(gvk, resource); err != nil {
+			return fmt.Errorf("failed to start the controller of %s: %w", gvk.String(), err)
+		}
+	}
+
+	kinds, err := r.specDB.GetGenericSpecKinds(ctx, genericResourcesTableName)
+	if err != nil {
+		return fmt.Errorf("failed to get the propagated kinds from the database: %w", err)
+	}
+
+	for _, gvk := range kinds {
+		if _, found := resources[gvk]; !found {
+			if err := r.removeKind(ctx, gvk); err != nil {
+				return fmt.Errorf("failed to remove the resources of %s: %w", gvk.String(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getGenericResources returns a map of kind -> the propagated resources of the kind from the configmap.
+func (r *genericResourcesReconciler) getGenericResources(
+	ctx context.Context,
+) (map[schema.GroupVersionKind]genericResource, error) {
+	configMap := &corev1.ConfigMap{}
+	resources := make(map[schema.GroupVersionKind]genericResource)
+
+	if err := r.apiReader.Get(ctx, client.ObjectKey{
+		Namespace: constants.HohSystemNamespace,
+		Name:      constants.GenericResourcesConfigName,
+	}, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return resources, nil
+		}
+
+		return nil, fmt.Errorf("failed to get the propagated resources configmap: %w", err)
+	}
+
+	var configuredResources []genericResource
+	if err := yaml.Unmarshal([]byte(configMap.Data[genericResourcesConfigKey]), &configuredResources); err != nil {
+		r.log.Error(err, "invalid propagated resources configmap", "key", genericResourcesConfigKey)
+		return resources, nil
+	}
+
+	for _, resource := range configuredResources {
+		gvk := schema.GroupVersionKind{Group: resource.Group, Version: resource.Version, Kind: resource.Kind}
+
+		if err := resource.validate(); err != nil {
+			r.log.Error(err, "skipping invalid propagated resource", "resource", gvk.String())
+			continue
+		}
+
+		resources[gvk] = resource
+	}
+
+	return resources, nil
+}
+
+// startKindController starts the spec-to-db controller of the kind, the controller isn't managed by the manager so
+// it's stopped when the kind is removed from the configmap and a kind that fails to be watched doesn't stop the
+// manager. the controller watches the kind through a cache of its own that lists only the resources matching the
+// label selector in the listed namespaces, the cache is stopped with the controller.
+func (r *genericResourcesReconciler) startKindController(gvk schema.GroupVersionKind,
+	resource genericResource,
+) error {
+	name := strings.ToLower(fmt.Sprintf("generic-resources-spec-syncer-%s.%s.%s", gvk.Kind, gvk.Version,
+		gvk.Group))
+	isRelevant := resource.isRelevant()
+
+	kindCache, err := r.newKindCache(resource)
+	if err != nil {
+		return fmt.Errorf("failed to create cache: %w", err)
+	}
+
+	kindCtrl, err := controller.NewUnmanaged(name, r.mgr, controller.Options{
+		Reconciler: &genericSpecToDBReconciler{
+			client:        r.client,
+			specDB:        &kindSpecDB{SpecDB: r.specDB, gvk: gvk},
+			log:           r.log.WithValues("kind", gvk.String()),
+			tableName:     genericResourcesTableName,
+			finalizerName: constants.GlobalHubCleanupFinalizer,
+			createInstance: func() client.Object {
+				object := &unstructured.Unstructured{}
+				object.SetGroupVersionKind(gvk)
+
+				return object
+			},
+			cleanStatus: cleanGenericResourceStatus,
+			areEqual:    areGenericResourcesEqual,
+			isRelevant:  isRelevant,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create controller: %w", err)
+	}
+
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(gvk)
+
+	// the resources that stop matching the label selector are deleted from the cache and still have the finalizer,
+	// they're removed from the database by the controller.
+	if err := kindCtrl.Watch(source.NewKindWithCache(object, kindCache), &handler.EnqueueRequestForObject{},
+		predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return isRelevant(object) || controllerutil.ContainsFinalizer(object, constants.GlobalHubCleanupFinalizer)
+		})); err != nil {
+		return fmt.Errorf("failed to watch: %w", err)
+	}
+
+	if err := kindCtrl.Watch(r.irrelevantResourcesSource(gvk, isRelevant),
+		&handler.EnqueueRequestForObject{}); err != nil {
+		return fmt.Errorf("failed to watch the irrelevant resources: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(r.ctx)
+	running := &kindController{resource: resource, cancel: cancel, done: make(chan struct{})}
+	r.kindControllers[gvk] = running
+
+	cacheDone := make(chan struct{})
+
+	go func() {
+		defer close(cacheDone)
+
+		if err := kindCache.Start(ctx); err != nil {
+			r.log.Error(err, "cache stopped", "kind", gvk.String())
+		}
+	}()
+
+	go func() {
+		defer close(running.done) // the controller is started again once the configmap changes
+
+		if err := kindCtrl.Start(ctx); err != nil {
+			r.log.Error(err, "controller stopped", "kind", gvk.String())
+		}
+
+		cancel() // the cache is stopped with the controller
+		<-cacheDone
+	}()
+
+	r.log.Info("started syncing propagated resources", "kind", gvk.String())
+
+	return nil
+}
+
+// newKindCache creates a cache that lists only the resources of the kind that match the label selector in the
+// listed namespaces and aren't local, so the other resources of the kind, e.g. all the secrets of the global hub,
+// aren't cached by the manager.
+func (r *genericResourcesReconciler) newKindCache(resource genericResource) (cache.Cache, error) {
+	selector := labels.Everything()
+	if resource.LabelSelector != nil {
+		selector, _ = metav1.LabelSelectorAsSelector(resource.LabelSelector) // validated when parsing the configmap
+	}
+
+	notLocal, err := labels.NewRequirement(constants.GlobalHubLocalResource, selection.DoesNotExist, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	options := cache.Options{
+		Scheme:          r.mgr.GetScheme(),
+		Mapper:          r.mgr.GetRESTMapper(),
+		DefaultSelector: cache.ObjectSelector{Label: selector.Add(*notLocal)},
+	}
+
+	if len(resource.Namespaces) > 0 {
+		return cache.MultiNamespacedCacheBuilder(resource.Namespaces)(r.mgr.GetConfig(), options)
+	}
+
+	return cache.New(r.mgr.GetConfig(), options)
+}
+
+// irrelevantResourcesSource returns a source that enqueues the resources of the kind that have the finalizer but
+// aren't relevant once the controller starts, e.g. the resources that matched the label selector before it changed.
+// they aren't in the cache of the kind, so they're listed by their metadata once from the api server.
+func (r *genericResourcesReconciler) irrelevantResourcesSource(gvk schema.GroupVersionKind,
+	isRelevant func(object client.Object) bool,
+) source.Source {
+	return source.Func(func(ctx context.Context, _ handler.EventHandler, queue workqueue.RateLimitingInterface,
+		_ ...predicate.Predicate,
+	) error {
+		objects := &metav1.PartialObjectMetadataList{}
+		objects.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+		if err := r.apiReader.List(ctx, objects); err != nil {
+			return fmt.Errorf("failed to list: %w", err)
+		}
+
+		for i := range objects.Items {
+			object := &objects.Items[i]
+			if controllerutil.ContainsFinalizer(object, constants.GlobalHubCleanupFinalizer) && !isRelevant(object) {
+				queue.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(object)})
+			}
+		}
+
+		return nil
+	})
+}
+
+// stopKindController stops the controller of the kind and its cache, and waits for its running reconciliations.
+func (r *genericResourcesReconciler) stopKindController(gvk schema.GroupVersionKind) {
+	kindController := r.kindControllers[gvk]
+	delete(r.kindControllers, gvk)
+
+	kindController.cancel()
+	<-kindController.done
+
+	r.log.Info("stopped syncing propagated resources", "kind", gvk.String())
+}
+
+// removeKind removes the finalizer from the resources of a kind that is no longer propagated and deletes them from
+// the database.
+func (r *genericResourcesReconciler) removeKind(ctx context.Context, gvk schema.GroupVersionKind) error {
+	objects := &unstructured.UnstructuredList{}
+	objects.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+	if err := r.apiReader.List(ctx, objects); err != nil && !meta.IsNoMatchError(err) {
+		return fmt.Errorf("failed to list: %w", err)
+	}
+
+	for i := range objects.Items {
+		object := &objects.Items[i]
+		if !controllerutil.ContainsFinalizer(object, constants.GlobalHubCleanupFinalizer) {
+			continue
+		}
+
+		controllerutil.RemoveFinalizer(object, constants.GlobalHubCleanupFinalizer)
+
+		if err := r.client.Update(ctx, object); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to remove a finalizer: %w", err)
+		}
+
+		if err := r.specDB.ReleaseSpecObject(ctx, genericResourcesTableName, string(object.GetUID())); err != nil {
+			return err
+		}
+	}
+
+	if err := r.specDB.DeleteGenericSpecObjects(ctx, genericResourcesTableName, gvk); err != nil {
+		return err
+	}
+
+	r.log.Info("removed propagated resources", "kind", gvk.String())
+
+	return nil
+}
+
+// kindSpecDB scopes the deletion of the objects by name and namespace to a kind, as the generic resources table
+// holds objects of different kinds.
+type kindSpecDB struct {
+	db.SpecDB
+	gvk schema.GroupVersionKind
+}
+
+func (s *kindSpecDB) DeleteSpecObject(ctx context.Context, tableName, name, namespace string) error {
+	return s.DeleteGenericSpecObject(ctx, tableName, s.gvk, name, namespace)
+}
+
+func cleanGenericResourceStatus(instance client.Object) {
+	object, ok := instance.(*unstructured.Unstructured)
+
+	if !ok {
+		panic("wrong instance passed to cleanGenericResourceStatus: not unstructured.Unstructured")
+	}
+
+	unstructured.RemoveNestedField(object.Object, "status")
+}
+
+func areGenericResourcesEqual(instance1, instance2 client.Object) bool {
+	object1, ok1 := instance1.(*unstructured.Unstructured)
+	object2, ok2 := instance2.(*unstructured.Unstructured)
+
+	if !ok1 || !ok2 {
+		return false
+	}
+
+	specMatch := equality.Semantic.DeepEqual(withoutMetadata(object1), withoutMetadata(object2))
+	annotationsMatch := equality.Semantic.DeepEqual(instance1.GetAnnotations(), instance2.GetAnnotations())
+	labelsMatch := equality.Semantic.DeepEqual(instance1.GetLabels(), instance2.GetLabels())
+
+	return specMatch && annotationsMatch && labelsMatch
+}
+
+// withoutMetadata returns the fields of the object other than the metadata and the status.
+func withoutMetadata(object *unstructured.Unstructured) map[string]interface{} {
+	fields := make(map[string]interface{}, len(object.Object))
+
+	for field, value := range object.Object {
+		if field != "metadata" && field != "status" {
+			fields[field] = value
+		}
+	}
+
+	return fields
+}
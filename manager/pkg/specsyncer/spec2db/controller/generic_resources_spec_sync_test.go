@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+func TestGenericResourceScope(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"propagate": "true"}}
+
+	invalidResources := map[string]genericResource{
+		"no selector or namespaces": {Version: "v1", Kind: "ConfigMap"},
+		"empty selector":            {Version: "v1", Kind: "ConfigMap", LabelSelector: &metav1.LabelSelector{}},
+		"secrets without selector":  {Version: "v1", Kind: "Secret", Namespaces: []string{"apps"}},
+		"dedicated kind": {
+			Group: "policy.open-cluster-management.io", Version: "v1", Kind: "Policy", LabelSelector: selector,
+		},
+	}
+
+	for name, resource := range invalidResources {
+		if err := resource.validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	resource := genericResource{Version: "v1", Kind: "Secret", LabelSelector: selector, Namespaces: []string{"apps"}}
+	if err := resource.validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	newSecret := func(namespace string, labels map[string]string) client.Object {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: namespace, Labels: labels}}
+	}
+
+	objects := []struct {
+		name     string
+		object   client.Object
+		relevant bool
+	}{
+		{"selected", newSecret("apps", selector.MatchLabels), true},
+		{"not matching the selector", newSecret("apps", nil), false},
+		{"out of the namespaces", newSecret("other", selector.MatchLabels), false},
+		{"local", newSecret("apps", map[string]string{"propagate": "true", constants.GlobalHubLocalResource: ""}), false},
+	}
+
+	isRelevant := resource.isRelevant()
+
+	for _, o := range objects {
+		if isRelevant(o.object) != o.relevant {
+			t.Errorf("%s: expected relevant to be %v", o.name, o.relevant)
+		}
+	}
+
+	// the system and global hub namespaces are excluded even when they're listed
+	resource = genericResource{Version: "v1", Kind: "ConfigMap", Namespaces: []string{constants.HohSystemNamespace}}
+	if resource.isRelevant()(newSecret(constants.HohSystemNamespace, nil)) {
+		t.Error("expected the objects of the global hub namespace not to be relevant")
+	}
+}
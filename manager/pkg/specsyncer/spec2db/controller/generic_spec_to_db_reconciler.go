@@ -17,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
 )
 
 type genericSpecToDBReconciler struct {
@@ -28,6 +29,8 @@ type genericSpecToDBReconciler struct {
 	createInstance func() client.Object
 	cleanStatus    func(client.Object)
 	areEqual       func(client.Object, client.Object) bool
+	// isRelevant is optional, the instances that aren't relevant are removed from the database.
+	isRelevant func(client.Object) bool
 }
 
 const (
@@ -46,6 +49,23 @@ func (r *genericSpecToDBReconciler) Reconcile(ctx context.Context, request ctrl.
 		return ctrl.Result{}, nil
 	}
 
+	// the held instance is kept out of the table, so the distributed version of the instance stays in the table
+	if isInstanceHeld(instance) {
+		reqLogger.Info("The instance is held from the distribution, holding it in the database")
+
+		if err := r.specDB.HoldSpecObject(ctx, r.tableName, instanceUID, &instance); err != nil {
+			reqLogger.Error(err, "Reconciliation failed")
+			return ctrl.Result{Requeue: true, RequeueAfter: requeuePeriodSeconds * time.Second}, err
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.specDB.ReleaseSpecObject(ctx, r.tableName, instanceUID); err != nil {
+		reqLogger.Error(err, "Reconciliation failed")
+		return ctrl.Result{Requeue: true, RequeueAfter: requeuePeriodSeconds * time.Second}, err
+	}
+
 	instanceInTheDatabase, err := r.processInstanceInTheDatabase(ctx, instance, instanceUID, reqLogger)
 	if err != nil {
 		reqLogger.Error(err, "Reconciliation failed")
@@ -80,7 +100,7 @@ func (r *genericSpecToDBReconciler) processCR(ctx context.Context, request ctrl.
 		return "", nil, fmt.Errorf("failed to get the instance from hub: %w", err)
 	}
 
-	if isInstanceBeingDeleted(instance) {
+	if isInstanceBeingDeleted(instance) || (r.isRelevant != nil && !r.isRelevant(instance)) {
 		return "", nil, r.removeFinalizerAndDelete(ctx, instance, log)
 	}
 
@@ -93,6 +113,11 @@ func isInstanceBeingDeleted(instance client.Object) bool {
 	return !instance.GetDeletionTimestamp().IsZero()
 }
 
+func isInstanceHeld(instance client.Object) bool {
+	_, found := instance.GetAnnotations()[constants.HoldDistributionAnnotation]
+	return found
+}
+
 func (r *genericSpecToDBReconciler) removeFinalizerAndDelete(ctx context.Context, instance client.Object,
 	log logr.Logger,
 ) error {
@@ -107,6 +132,10 @@ func (r *genericSpecToDBReconciler) removeFinalizerAndDelete(ctx context.Context
 		return fmt.Errorf("failed to delete an instance from the database: %w", err)
 	}
 
+	if err := r.specDB.ReleaseSpecObject(ctx, r.tableName, string(instance.GetUID())); err != nil {
+		return fmt.Errorf("failed to release a held instance in the database: %w", err)
+	}
+
 	log.Info("Removing finalizer")
 	controllerutil.RemoveFinalizer(instance, r.finalizerName)
 
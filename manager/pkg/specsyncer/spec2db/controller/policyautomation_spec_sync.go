@@ -0,0 +1,71 @@
+// Copyright (c) 2022 Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	policyv1beta1 "open-cluster-management.io/governance-policy-propagator/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+func AddPolicyAutomationController(mgr ctrl.Manager, specDB db.SpecDB) error {
+	policyAutomationPredicate, _ := predicate.LabelSelectorPredicate(metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      constants.GlobalHubLocalResource,
+				Operator: metav1.LabelSelectorOpDoesNotExist,
+			},
+		},
+	})
+	if err := ctrl.NewControllerManagedBy(mgr).
+		For(&policyv1beta1.PolicyAutomation{}).
+		WithEventFilter(policyAutomationPredicate).
+		Complete(&genericSpecToDBReconciler{
+			client:         mgr.GetClient(),
+			specDB:         specDB,
+			log:            ctrl.Log.WithName("policyautomations-spec-syncer"),
+			tableName:      "policyautomations",
+			finalizerName:  constants.GlobalHubCleanupFinalizer,
+			createInstance: func() client.Object { return &policyv1beta1.PolicyAutomation{} },
+			cleanStatus:    cleanPolicyAutomationStatus,
+			areEqual:       arePolicyAutomationsEqual,
+		}); err != nil {
+		return fmt.Errorf("failed to add policy automation controller to the manager: %w", err)
+	}
+
+	return nil
+}
+
+func cleanPolicyAutomationStatus(instance client.Object) {
+	policyAutomation, ok := instance.(*policyv1beta1.PolicyAutomation)
+
+	if !ok {
+		panic("wrong instance passed to cleanPolicyAutomationStatus: not a PolicyAutomation")
+	}
+
+	policyAutomation.Status = policyv1beta1.PolicyAutomationStatus{}
+}
+
+func arePolicyAutomationsEqual(instance1, instance2 client.Object) bool {
+	policyAutomation1, ok1 := instance1.(*policyv1beta1.PolicyAutomation)
+	policyAutomation2, ok2 := instance2.(*policyv1beta1.PolicyAutomation)
+
+	if !ok1 || !ok2 {
+		return false
+	}
+
+	specMatch := equality.Semantic.DeepEqual(policyAutomation1.Spec, policyAutomation2.Spec)
+	annotationsMatch := equality.Semantic.DeepEqual(instance1.GetAnnotations(), instance2.GetAnnotations())
+	labelsMatch := equality.Semantic.DeepEqual(instance1.GetLabels(), instance2.GetLabels())
+
+	return specMatch && annotationsMatch && labelsMatch
+}
@@ -25,6 +25,9 @@ func AddSpec2DBControllers(mgr ctrl.Manager, specDB db.SpecDB) error {
 		controller.AddManagedClusterSetController,
 		controller.AddManagedClusterSetBindingController,
 		controller.AddPlacementController,
+		controller.AddPolicySetController,
+		controller.AddPolicyAutomationController,
+		controller.AddGenericResourcesController,
 	}
 
 	for _, addControllerFunction := range addControllerFunctions {
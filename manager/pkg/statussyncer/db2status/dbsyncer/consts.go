@@ -18,8 +18,13 @@ const (
 
 	placementDecisionsStatusTableName = "placementdecisions"
 
+	policySetsSpecTableName   = "policysets"
+	policySetsStatusTableName = "policysets"
+
 	subscriptionsSpecTableName = "subscriptions"
 
 	subscriptionStatusesTableName      = "subscription_statuses"
 	subscriptionReportsStatusTableName = "subscription_reports"
+
+	specApplyResultsTableName = "spec_apply_results"
 )
@@ -10,19 +10,26 @@ import (
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	policyv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
 )
 
 const (
 	dbEnumCompliant    = "compliant"
 	dbEnumNonCompliant = "non_compliant"
 
-	policiesSpecTableName     = "policies"
-	complianceStatusTableName = "compliance"
+	policiesSpecTableName      = "policies"
+	complianceStatusTableName  = "compliance"
+	complianceDetailsTableName = "compliance_details"
+
+	// maxViolationsPerTemplate is the highest number of cluster violations of a template in the policy status, all
+	// the violations are served by the non-k8s api.
+	maxViolationsPerTemplate = 100
 )
 
 func AddPolicyDBSyncer(mgr ctrl.Manager, database db.DB, statusSyncInterval time.Duration) error {
@@ -88,8 +95,14 @@ func handlePolicy(ctx context.Context, log logr.Logger, database db.DB,
 		return
 	}
 
+	detailsPerTemplate, err := getComplianceDetails(ctx, database, policy)
+	if err != nil {
+		log.Error(err, "failed to get compliance details of a policy", "uid", policy.GetUID())
+		return
+	}
+
 	if err = updateComplianceStatus(ctx, k8sClient, policy, compliancePerClusterStatuses,
-		hasNonCompliantClusters); err != nil {
+		hasNonCompliantClusters, detailsPerTemplate); err != nil {
 		log.Error(err, "failed to update policy status")
 	}
 }
@@ -137,13 +150,71 @@ func getComplianceStatus(ctx context.Context, database db.DB,
 	return compliancePerClusterStatuses, hasNonCompliantClusters, nil
 }
 
+// returns the violations of the non compliant clusters per template, the history of a template holds the last
+// violation of each cluster.
+func getComplianceDetails(ctx context.Context, database db.DB,
+	policy *policyv1.Policy,
+) ([]*policyv1.DetailsPerTemplate, error) {
+	rows, err := database.GetConn().Query(ctx,
+		fmt.Sprintf(`SELECT cluster_name,leaf_hub_name,violations FROM status.%s
+			WHERE id=$1 ORDER BY leaf_hub_name, cluster_name`, complianceDetailsTableName), string(policy.GetUID()))
+	if err != nil {
+		return nil, fmt.Errorf("error in getting policy compliance details from DB - %w", err)
+	}
+
+	defer rows.Close()
+
+	var detailsPerTemplate []*policyv1.DetailsPerTemplate
+
+	templateDetails := make(map[string]*policyv1.DetailsPerTemplate)
+
+	for rows.Next() {
+		var clusterName, leafHubName string
+
+		var violations []*status.TemplateViolation
+
+		if err := rows.Scan(&clusterName, &leafHubName, &violations); err != nil {
+			return nil, fmt.Errorf("error in getting policy compliance details from DB - %w", err)
+		}
+
+		for _, violation := range violations {
+			details, found := templateDetails[violation.TemplateName]
+			if !found {
+				details = &policyv1.DetailsPerTemplate{
+					TemplateMeta:    metav1.ObjectMeta{Name: violation.TemplateName},
+					ComplianceState: policyv1.NonCompliant,
+				}
+				templateDetails[violation.TemplateName] = details
+				detailsPerTemplate = append(detailsPerTemplate, details)
+			}
+
+			if len(details.History) == maxViolationsPerTemplate {
+				continue
+			}
+
+			message := fmt.Sprintf("%s/%s: %s", leafHubName, clusterName, violation.Message)
+			if violation.Truncated {
+				message += "..."
+			}
+
+			details.History = append(details.History, policyv1.ComplianceHistory{
+				LastTimestamp: metav1.NewTime(violation.Timestamp),
+				Message:       message,
+			})
+		}
+	}
+
+	return detailsPerTemplate, nil
+}
+
 func updateComplianceStatus(ctx context.Context, k8sClient client.StatusClient, policy *policyv1.Policy,
 	compliancePerClusterStatuses []*policyv1.CompliancePerClusterStatus,
-	hasNonCompliantClusters bool,
+	hasNonCompliantClusters bool, detailsPerTemplate []*policyv1.DetailsPerTemplate,
 ) error {
 	originalPolicy := policy.DeepCopy()
 
 	policy.Status.Status = compliancePerClusterStatuses
+	policy.Status.Details = detailsPerTemplate
 	policy.Status.ComplianceState = ""
 
 	if hasNonCompliantClusters {
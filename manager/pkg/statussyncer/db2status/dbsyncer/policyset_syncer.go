@@ -0,0 +1,223 @@
+// Copyright (c) 2022 Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+
+package dbsyncer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	policyv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
+	policyv1beta1 "open-cluster-management.io/governance-policy-propagator/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
+)
+
+// pendingComplianceState is the compliance of a policy set whose policies are pending on the leaf hub.
+const pendingComplianceState = "Pending"
+
+func AddPolicySetDBSyncer(mgr ctrl.Manager, database db.DB, statusSyncInterval time.Duration) error {
+	err := mgr.Add(&genericDBSyncer{
+		statusSyncInterval: statusSyncInterval,
+		statusSyncFunc: func(ctx context.Context) {
+			syncPolicySets(ctx,
+				ctrl.Log.WithName("policysets-db-syncer"),
+				database,
+				mgr.GetClient())
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add policy sets syncer to the manager: %w", err)
+	}
+
+	return nil
+}
+
+func syncPolicySets(ctx context.Context, log logr.Logger, database db.DB, k8sClient client.Client) {
+	rows, err := database.GetConn().Query(ctx,
+		fmt.Sprintf(`SELECT id, payload->'metadata'->>'name', payload->'metadata'->>'namespace'
+		FROM spec.%s WHERE deleted = FALSE`, policySetsSpecTableName))
+	if err != nil {
+		log.Error(err, "error in getting policy sets spec")
+		return
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, name, namespace string
+
+		if err := rows.Scan(&id, &name, &namespace); err != nil {
+			log.Error(err, "error in select", "table", policySetsSpecTableName)
+			continue
+		}
+
+		go handlePolicySetStatus(ctx, log, database, k8sClient, id, name, namespace)
+	}
+}
+
+func handlePolicySetStatus(ctx context.Context, log logr.Logger, database db.DB, k8sClient client.Client,
+	id string, policySetName string, policySetNamespace string,
+) {
+	policySetStatus, statusEntriesFound, err := getPolicySetStatus(ctx, database, id)
+	if err != nil {
+		log.Error(err, "failed to get aggregated policy set", "name", policySetName,
+			"namespace", policySetNamespace)
+		return
+	}
+
+	if !statusEntriesFound { // no status resources found in DB - policy set is never created on the leaf hubs
+		return
+	}
+
+	if err := updatePolicySetStatus(ctx, k8sClient, policySetName, policySetNamespace,
+		policySetStatus); err != nil {
+		log.Error(err, "failed to update policy set status")
+	}
+}
+
+// getPolicySetStatus returns the status of the policy set aggregated from the statuses on the leaf hubs.
+func getPolicySetStatus(ctx context.Context, database db.DB,
+	id string,
+) (*policyv1beta1.PolicySetStatus, bool, error) {
+	rows, err := database.GetConn().Query(ctx,
+		fmt.Sprintf(`SELECT leaf_hub_name, payload FROM status.%s WHERE id=$1`, policySetsStatusTableName), id)
+	if err != nil {
+		return nil, false, fmt.Errorf("error in getting policy sets from DB - %w", err)
+	}
+
+	defer rows.Close()
+
+	statusPerLeafHub := map[string]*policyv1beta1.PolicySetStatus{}
+
+	for rows.Next() {
+		var leafHubName string
+
+		var leafHubPolicySet policyv1beta1.PolicySet
+
+		if err := rows.Scan(&leafHubName, &leafHubPolicySet); err != nil {
+			return nil, false, fmt.Errorf("error getting policy set from DB - %w", err)
+		}
+
+		statusPerLeafHub[leafHubName] = &leafHubPolicySet.Status
+	}
+
+	if len(statusPerLeafHub) == 0 {
+		return nil, false, nil
+	}
+
+	return aggregatePolicySetStatus(statusPerLeafHub), true, nil
+}
+
+// aggregatePolicySetStatus aggregates the statuses of the policy set on the leaf hubs. the policy set is non-compliant
+// if it's non-compliant on any of the leaf hubs, and the leaf hubs it isn't compliant on are listed in the message.
+func aggregatePolicySetStatus(
+	statusPerLeafHub map[string]*policyv1beta1.PolicySetStatus,
+) *policyv1beta1.PolicySetStatus {
+	leafHubsPerCompliance := map[string][]string{}
+	messages := make([]string, 0)
+	placements := make([]policyv1beta1.PolicySetStatusPlacement, 0)
+
+	for leafHubName, leafHubStatus := range statusPerLeafHub {
+		leafHubsPerCompliance[leafHubStatus.Compliant] = append(leafHubsPerCompliance[leafHubStatus.Compliant],
+			leafHubName)
+
+		if leafHubStatus.StatusMessage != "" {
+			messages = append(messages, fmt.Sprintf("%s: %s", leafHubName, leafHubStatus.StatusMessage))
+		}
+
+		for _, placement := range leafHubStatus.Placement {
+			if !containsPolicySetPlacement(placements, placement) {
+				placements = append(placements, placement)
+			}
+		}
+	}
+
+	policySetStatus := &policyv1beta1.PolicySetStatus{}
+
+	switch {
+	case len(leafHubsPerCompliance[string(policyv1.NonCompliant)]) > 0:
+		policySetStatus.Compliant = string(policyv1.NonCompliant)
+	case len(leafHubsPerCompliance[pendingComplianceState]) > 0:
+		policySetStatus.Compliant = pendingComplianceState
+	case len(leafHubsPerCompliance[string(policyv1.Compliant)]) == len(statusPerLeafHub):
+		policySetStatus.Compliant = string(policyv1.Compliant)
+	}
+
+	for _, compliance := range []string{string(policyv1.NonCompliant), pendingComplianceState} {
+		if leafHubNames := leafHubsPerCompliance[compliance]; len(leafHubNames) > 0 {
+			sort.Strings(leafHubNames)
+			messages = append(messages, fmt.Sprintf("%s on regional hubs %s", compliance,
+				strings.Join(leafHubNames, ", ")))
+		}
+	}
+
+	sort.Strings(messages)
+	policySetStatus.StatusMessage = strings.Join(messages, "; ")
+
+	sort.Slice(placements, func(i, j int) bool {
+		return fmt.Sprint(placements[i]) < fmt.Sprint(placements[j])
+	})
+
+	if len(placements) > 0 {
+		policySetStatus.Placement = placements
+	}
+
+	return policySetStatus
+}
+
+func containsPolicySetPlacement(placements []policyv1beta1.PolicySetStatusPlacement,
+	placement policyv1beta1.PolicySetStatusPlacement,
+) bool {
+	for _, existingPlacement := range placements {
+		if existingPlacement == placement {
+			return true
+		}
+	}
+
+	return false
+}
+
+func updatePolicySetStatus(ctx context.Context, k8sClient client.Client,
+	policySetName string, policySetNamespace string, policySetStatus *policyv1beta1.PolicySetStatus,
+) error {
+	deployedPolicySet := &policyv1beta1.PolicySet{}
+
+	err := k8sClient.Get(ctx, client.ObjectKey{
+		Name:      policySetName,
+		Namespace: policySetNamespace,
+	}, deployedPolicySet)
+	if err != nil {
+		if errors.IsNotFound(err) { // CR getting deleted
+			return nil
+		}
+
+		return fmt.Errorf("failed to get policy set {name=%s, namespace=%s} - %w",
+			policySetName, policySetNamespace, err)
+	}
+
+	if equality.Semantic.DeepEqual(deployedPolicySet.Status, *policySetStatus) {
+		return nil
+	}
+
+	// if object exists, clone and update
+	originalPolicySet := deployedPolicySet.DeepCopy()
+
+	deployedPolicySet.Status = *policySetStatus
+
+	err = k8sClient.Status().Patch(ctx, deployedPolicySet, client.MergeFrom(originalPolicySet))
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to update policy set CR (name=%s, namespace=%s): %w",
+			deployedPolicySet.Name, deployedPolicySet.Namespace, err)
+	}
+
+	return nil
+}
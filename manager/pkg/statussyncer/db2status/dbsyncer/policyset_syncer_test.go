@@ -0,0 +1,39 @@
+// Copyright (c) 2022 Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+
+package dbsyncer
+
+import (
+	"reflect"
+	"testing"
+
+	policyv1beta1 "open-cluster-management.io/governance-policy-propagator/api/v1beta1"
+)
+
+func TestAggregatePolicySetStatus(t *testing.T) {
+	placement := policyv1beta1.PolicySetStatusPlacement{PlacementBinding: "binding", Placement: "placement"}
+
+	status := aggregatePolicySetStatus(map[string]*policyv1beta1.PolicySetStatus{
+		"hub1": {Compliant: "Compliant", Placement: []policyv1beta1.PolicySetStatusPlacement{placement}},
+		"hub2": {Compliant: "NonCompliant", Placement: []policyv1beta1.PolicySetStatusPlacement{placement}},
+		"hub3": {Compliant: "Pending", StatusMessage: "Disabled policies: policy1"},
+	})
+
+	expected := &policyv1beta1.PolicySetStatus{
+		Compliant:     "NonCompliant",
+		StatusMessage: "NonCompliant on regional hubs hub2; Pending on regional hubs hub3; hub3: Disabled policies: policy1",
+		Placement:     []policyv1beta1.PolicySetStatusPlacement{placement},
+	}
+	if !reflect.DeepEqual(status, expected) {
+		t.Fatalf("expected status %+v, got %+v", expected, status)
+	}
+
+	status = aggregatePolicySetStatus(map[string]*policyv1beta1.PolicySetStatus{
+		"hub1": {Compliant: "Compliant"},
+		"hub2": {Compliant: "Compliant"},
+	})
+
+	if expected := (&policyv1beta1.PolicySetStatus{Compliant: "Compliant"}); !reflect.DeepEqual(status, expected) {
+		t.Fatalf("expected status %+v, got %+v", expected, status)
+	}
+}
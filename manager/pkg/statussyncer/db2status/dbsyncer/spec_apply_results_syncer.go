@@ -0,0 +1,441 @@
+// Copyright (c) 2022 Red Hat, Inc.
+// Copyright Contributors to the Open Cluster Management project
+
+package dbsyncer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/specsyncer/db2transport/db"
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+)
+
+const (
+	// AppliedOnRegionalHubsCondition is the condition type of the global hub objects reporting whether the object is
+	// applied on all the regional hubs it's sent to.
+	AppliedOnRegionalHubsCondition = "AppliedOnRegionalHubs"
+
+	appliedReason        = "Applied"
+	applyFailedReason    = "ApplyFailed"
+	driftCorrectedReason = "DriftCorrected"
+)
+
+// specApplyResultsSyncer surfaces the spec apply results as a condition on the global hub objects whose status schema
+// has the conditions field, and as events of the other global hub objects, e.g. policies and subscriptions. the drifts
+// corrected on the regional hubs are recorded as events of the global hub objects.
+type specApplyResultsSyncer struct {
+	log       logr.Logger
+	database  db.DB
+	k8sClient client.Client
+	recorder  record.EventRecorder
+	startTime time.Time
+	// reportedDrifts is a map of object uid and leaf hub name -> the time of the last drift recorded as an event.
+	reportedDrifts map[string]time.Time
+	// reportedResults is a map of object uid -> the last result recorded as an event, of the objects without the
+	// conditions.
+	reportedResults map[string]string
+	// conditionKinds is a map of kind -> whether its status schema has the conditions field.
+	conditionKinds map[schema.GroupVersionKind]bool
+}
+
+// AddSpecApplyResultsDBSyncer adds the syncer of the spec apply results to the manager.
+func AddSpecApplyResultsDBSyncer(mgr ctrl.Manager, database db.DB, statusSyncInterval time.Duration) error {
+	syncer := newSpecApplyResultsSyncer(mgr.GetClient(), mgr.GetEventRecorderFor("spec-apply-results-db-syncer"))
+	syncer.database = database
+
+	err := mgr.Add(&genericDBSyncer{
+		statusSyncInterval: statusSyncInterval,
+		statusSyncFunc:     syncer.sync,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add spec apply results syncer to the manager: %w", err)
+	}
+
+	return nil
+}
+
+func newSpecApplyResultsSyncer(k8sClient client.Client, recorder record.EventRecorder) *specApplyResultsSyncer {
+	return &specApplyResultsSyncer{
+		log:             ctrl.Log.WithName("spec-apply-results-db-syncer"),
+		k8sClient:       k8sClient,
+		recorder:        recorder,
+		startTime:       time.Now(),
+		reportedDrifts:  make(map[string]time.Time),
+		reportedResults: make(map[string]string),
+		conditionKinds:  make(map[schema.GroupVersionKind]bool),
+	}
+}
+
+func (syncer *specApplyResultsSyncer) sync(ctx context.Context) {
+	log := syncer.log
+
+	rows, err := syncer.database.GetConn().Query(ctx,
+		fmt.Sprintf(`SELECT id, leaf_hub_name, payload FROM status.%s`, specApplyResultsTableName))
+	if err != nil {
+		log.Error(err, "error in getting spec apply results")
+		return
+	}
+	defer rows.Close()
+
+	// the results of each global hub object, keyed by the object uid and then by the leaf hub name
+	resultsPerObject := map[string]map[string]*status.SpecApplyResult{}
+
+	for rows.Next() {
+		var id, leafHubName string
+		result := &status.SpecApplyResult{}
+
+		if err := rows.Scan(&id, &leafHubName, result); err != nil {
+			log.Error(err, "error in select", "table", specApplyResultsTableName)
+			continue
+		}
+
+		if _, found := resultsPerObject[id]; !found {
+			resultsPerObject[id] = map[string]*status.SpecApplyResult{}
+		}
+
+		resultsPerObject[id][leafHubName] = result
+	}
+
+	syncer.syncResults(ctx, resultsPerObject)
+}
+
+// syncResults surfaces the results of the objects, and clears the results of the objects that have no results left,
+// e.g. when the regional hubs are detached or the objects aren't sent to them anymore.
+func (syncer *specApplyResultsSyncer) syncResults(ctx context.Context,
+	resultsPerObject map[string]map[string]*status.SpecApplyResult,
+) {
+	for uid, results := range resultsPerObject {
+		if err := syncer.updateObject(ctx, uid, results); err != nil {
+			syncer.log.Error(err, "failed to update the applied condition", "uid", uid)
+		}
+	}
+
+	for uid := range syncer.reportedResults {
+		if _, found := resultsPerObject[uid]; !found {
+			delete(syncer.reportedResults, uid)
+		}
+	}
+
+	for gvk, hasConditions := range syncer.conditionKinds {
+		if !hasConditions {
+			continue
+		}
+
+		if err := syncer.clearConditions(ctx, gvk, resultsPerObject); err != nil {
+			syncer.log.Error(err, "failed to clear the applied conditions", "kind", gvk.Kind)
+		}
+	}
+}
+
+func (syncer *specApplyResultsSyncer) updateObject(ctx context.Context, uid string,
+	results map[string]*status.SpecApplyResult,
+) error {
+	k8sClient := syncer.k8sClient
+
+	var result *status.SpecApplyResult
+	for _, leafHubResult := range results {
+		result = leafHubResult
+		break
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(result.APIVersion)
+	obj.SetKind(result.Kind)
+
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: result.Name, Namespace: result.Namespace}, obj); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) { // CR getting deleted
+			return nil
+		}
+
+		return fmt.Errorf("failed to get %s {name=%s, namespace=%s} - %w", result.Kind, result.Name,
+			result.Namespace, err)
+	}
+
+	if string(obj.GetUID()) != uid { // the object is recreated, the results are of the previous one
+		return nil
+	}
+
+	syncer.recordDrifts(obj, uid, results)
+
+	appliedCondition := buildAppliedCondition(results, obj.GetGeneration())
+
+	hasConditions, err := syncer.hasConditions(ctx, obj.GroupVersionKind())
+	if err != nil {
+		return err
+	}
+
+	if !hasConditions { // the api server would prune the condition
+		syncer.recordResult(obj, uid, appliedCondition)
+		return nil
+	}
+
+	return syncer.patchConditions(ctx, obj, func(conditions *[]metav1.Condition) {
+		meta.SetStatusCondition(conditions, appliedCondition)
+	})
+}
+
+// recordResult records the result as an event of the object when it changes.
+func (syncer *specApplyResultsSyncer) recordResult(obj *unstructured.Unstructured, uid string,
+	appliedCondition metav1.Condition,
+) {
+	reportedResult := fmt.Sprintf("%s: %s", appliedCondition.Reason, appliedCondition.Message)
+	if syncer.reportedResults[uid] == reportedResult {
+		return
+	}
+
+	eventType := corev1.EventTypeNormal
+	if appliedCondition.Status != metav1.ConditionTrue {
+		eventType = corev1.EventTypeWarning
+	}
+
+	syncer.recorder.Event(obj, eventType, appliedCondition.Reason, appliedCondition.Message)
+	syncer.reportedResults[uid] = reportedResult
+}
+
+// clearConditions removes the applied condition of the objects of the kind that have no results.
+func (syncer *specApplyResultsSyncer) clearConditions(ctx context.Context, gvk schema.GroupVersionKind,
+	resultsPerObject map[string]map[string]*status.SpecApplyResult,
+) error {
+	objList := &unstructured.UnstructuredList{}
+	objList.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+	if err := syncer.k8sClient.List(ctx, objList); err != nil {
+		return fmt.Errorf("failed to list %s - %w", gvk.Kind, err)
+	}
+
+	for i := range objList.Items {
+		obj := &objList.Items[i]
+		if _, found := resultsPerObject[string(obj.GetUID())]; found {
+			continue
+		}
+
+		if err := syncer.patchConditions(ctx, obj, func(conditions *[]metav1.Condition) {
+			meta.RemoveStatusCondition(conditions, AppliedOnRegionalHubsCondition)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// patchConditions patches the status conditions of the object if they're changed by the given function.
+func (syncer *specApplyResultsSyncer) patchConditions(ctx context.Context, obj *unstructured.Unstructured,
+	updateConditions func(conditions *[]metav1.Condition),
+) error {
+	conditions, err := getConditions(obj)
+	if err != nil {
+		return err
+	}
+
+	originalConditions := make([]metav1.Condition, len(conditions))
+	copy(originalConditions, conditions)
+
+	updateConditions(&conditions)
+
+	if equality.Semantic.DeepEqual(originalConditions, conditions) {
+		return nil
+	}
+
+	originalObj := obj.DeepCopy()
+
+	if err := setConditions(obj, conditions); err != nil {
+		return err
+	}
+
+	if err := syncer.k8sClient.Status().Patch(ctx, obj, client.MergeFrom(originalObj)); err != nil &&
+		!errors.IsNotFound(err) {
+		return fmt.Errorf("failed to update %s CR (name=%s, namespace=%s): %w", obj.GetKind(), obj.GetName(),
+			obj.GetNamespace(), err)
+	}
+
+	return nil
+}
+
+// hasConditions returns whether the status schema of the kind has the conditions field, by its custom resource
+// definition. the kinds that aren't custom resources don't have the applied condition.
+func (syncer *specApplyResultsSyncer) hasConditions(ctx context.Context, gvk schema.GroupVersionKind) (bool, error) {
+	if hasConditions, found := syncer.conditionKinds[gvk]; found {
+		return hasConditions, nil
+	}
+
+	mapping, err := syncer.k8sClient.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the resource of %s - %w", gvk.Kind, err)
+	}
+
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(customResourceDefinitionGVK)
+
+	if err := syncer.k8sClient.Get(ctx, client.ObjectKey{
+		Name: fmt.Sprintf("%s.%s", mapping.Resource.Resource, mapping.Resource.Group),
+	}, crd); err != nil && !errors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get the custom resource definition of %s - %w", gvk.Kind, err)
+	} else if err != nil {
+		syncer.conditionKinds[gvk] = false
+		return false, nil
+	}
+
+	syncer.conditionKinds[gvk] = statusSchemaHasConditions(crd, gvk.Version)
+
+	return syncer.conditionKinds[gvk], nil
+}
+
+var customResourceDefinitionGVK = schema.GroupVersionKind{
+	Group:   "apiextensions.k8s.io",
+	Version: "v1",
+	Kind:    "CustomResourceDefinition",
+}
+
+// statusSchemaHasConditions returns whether the version of the custom resource definition has the status subresource
+// and a status schema that keeps the conditions field.
+func statusSchemaHasConditions(crd *unstructured.Unstructured, version string) bool {
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+
+	for _, rawVersion := range versions {
+		crdVersion, ok := rawVersion.(map[string]interface{})
+		if !ok || crdVersion["name"] != version {
+			continue
+		}
+
+		if _, found, _ := unstructured.NestedMap(crdVersion, "subresources", "status"); !found {
+			return false
+		}
+
+		statusSchema, _, _ := unstructured.NestedMap(crdVersion, "schema", "openAPIV3Schema", "properties",
+			"status")
+		if preserveUnknownFields, _, _ := unstructured.NestedBool(statusSchema,
+			"x-kubernetes-preserve-unknown-fields"); preserveUnknownFields {
+			return true
+		}
+
+		_, found, _ := unstructured.NestedMap(statusSchema, "properties", "conditions")
+
+		return found
+	}
+
+	return false
+}
+
+// recordDrifts records the drifts corrected on the leaf hubs since the last sync as events of the object, the drifts
+// corrected before the syncer started are skipped.
+func (syncer *specApplyResultsSyncer) recordDrifts(obj *unstructured.Unstructured, uid string,
+	results map[string]*status.SpecApplyResult,
+) {
+	for leafHubName, result := range results {
+		if result.Drift == nil {
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s", uid, leafHubName)
+
+		lastReported, found := syncer.reportedDrifts[key]
+		if !found {
+			lastReported = syncer.startTime
+		}
+
+		if !result.Drift.Timestamp.After(lastReported) {
+			continue
+		}
+
+		syncer.recorder.Eventf(obj, corev1.EventTypeWarning, driftCorrectedReason,
+			"%s %s was %s on regional hub %s and restored to the state of the global hub (%d drifts corrected)",
+			result.Kind, result.Name, strings.ToLower(result.Drift.Reason), leafHubName, result.Drift.Count)
+		syncer.reportedDrifts[key] = result.Drift.Timestamp
+	}
+}
+
+// buildAppliedCondition builds the condition from the results of the leaf hubs, the failed leaf hubs are listed in
+// the message in a deterministic order.
+func buildAppliedCondition(results map[string]*status.SpecApplyResult, generation int64) metav1.Condition {
+	failures := make([]string, 0)
+
+	for leafHubName, result := range results {
+		if !result.Applied {
+			failures = append(failures, fmt.Sprintf("%s: %s", leafHubName, result.Error))
+		}
+	}
+
+	if len(failures) == 0 {
+		return metav1.Condition{
+			Type:               AppliedOnRegionalHubsCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             appliedReason,
+			Message:            fmt.Sprintf("applied on %d regional hubs", len(results)),
+		}
+	}
+
+	sort.Strings(failures)
+
+	return metav1.Condition{
+		Type:               AppliedOnRegionalHubsCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             applyFailedReason,
+		Message: fmt.Sprintf("failed to apply on %d of %d regional hubs - %s", len(failures), len(results),
+			strings.Join(failures, "; ")),
+	}
+}
+
+func getConditions(obj *unstructured.Unstructured) ([]metav1.Condition, error) {
+	rawConditions, found, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil || !found {
+		return []metav1.Condition{}, nil // the object doesn't have the conditions yet
+	}
+
+	conditions := make([]metav1.Condition, 0, len(rawConditions))
+
+	for _, rawCondition := range rawConditions {
+		conditionMap, ok := rawCondition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		condition := metav1.Condition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(conditionMap, &condition); err != nil {
+			return nil, fmt.Errorf("failed to convert the conditions of %s %s - %w", obj.GetKind(),
+				obj.GetName(), err)
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, nil
+}
+
+func setConditions(obj *unstructured.Unstructured, conditions []metav1.Condition) error {
+	rawConditions := make([]interface{}, 0, len(conditions))
+
+	for i := range conditions {
+		conditionMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&conditions[i])
+		if err != nil {
+			return fmt.Errorf("failed to convert the conditions of %s %s - %w", obj.GetKind(), obj.GetName(), err)
+		}
+
+		rawConditions = append(rawConditions, conditionMap)
+	}
+
+	if err := unstructured.SetNestedSlice(obj.Object, rawConditions, "status", "conditions"); err != nil {
+		return fmt.Errorf("failed to set the conditions of %s %s - %w", obj.GetKind(), obj.GetName(), err)
+	}
+
+	return nil
+}
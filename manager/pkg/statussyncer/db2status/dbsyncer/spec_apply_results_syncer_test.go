@@ -0,0 +1,225 @@
+package dbsyncer
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+)
+
+const policyCRDPath = "../../../../../agent/pkg/applier/manifests/2.6/" +
+	"policy.open-cluster-management.io_policies.yaml"
+
+var (
+	policyGVK = schema.GroupVersionKind{Group: "policy.open-cluster-management.io", Version: "v1", Kind: "Policy"}
+	// placementGVK is a kind whose status schema has the conditions field
+	placementGVK = schema.GroupVersionKind{Group: "cluster.open-cluster-management.io", Version: "v1beta1",
+		Kind: "Placement"}
+)
+
+func TestBuildAppliedCondition(t *testing.T) {
+	condition := buildAppliedCondition(map[string]*status.SpecApplyResult{
+		"hub1": {Applied: true},
+		"hub2": {Applied: true},
+	}, 3)
+
+	if condition.Status != metav1.ConditionTrue || condition.Reason != appliedReason ||
+		condition.Message != "applied on 2 regional hubs" || condition.ObservedGeneration != 3 {
+		t.Errorf("unexpected condition %+v", condition)
+	}
+
+	condition = buildAppliedCondition(map[string]*status.SpecApplyResult{
+		"hub1": {Applied: true},
+		"hub3": {Applied: false, Error: "forbidden"},
+		"hub2": {Applied: false, Error: "no matches for kind"},
+	}, 3)
+
+	expectedMessage := "failed to apply on 2 of 3 regional hubs - hub2: no matches for kind; hub3: forbidden"
+	if condition.Status != metav1.ConditionFalse || condition.Reason != applyFailedReason ||
+		condition.Message != expectedMessage {
+		t.Errorf("unexpected condition %+v", condition)
+	}
+}
+
+func TestSpecApplyResultsOfPolicy(t *testing.T) {
+	ctx := context.Background()
+	policy := newObject(policyGVK, "policy1", "uid1")
+	k8sClient := newFakeClient(t, loadCRD(t, policyCRDPath), policy)
+	recorder := record.NewFakeRecorder(10)
+	syncer := newSpecApplyResultsSyncer(k8sClient, recorder)
+
+	results := map[string]map[string]*status.SpecApplyResult{
+		"uid1": {"hub1": newResult(policyGVK, "policy1", false, "forbidden")},
+	}
+
+	// the policy status has no conditions, the result is recorded as an event only when it changes
+	syncer.syncResults(ctx, results)
+	syncer.syncResults(ctx, results)
+
+	if events := readEvents(recorder); len(events) != 1 || !strings.Contains(events[0], applyFailedReason) {
+		t.Fatalf("expected an event of the failed apply, got %v", events)
+	}
+
+	if conditions := getObjectConditions(t, k8sClient, policy); len(conditions) != 0 {
+		t.Errorf("expected no conditions on the policy, got %v", conditions)
+	}
+
+	results["uid1"]["hub1"] = newResult(policyGVK, "policy1", true, "")
+	syncer.syncResults(ctx, results)
+
+	if events := readEvents(recorder); len(events) != 1 || !strings.Contains(events[0], appliedReason) {
+		t.Fatalf("expected an event of the applied policy, got %v", events)
+	}
+}
+
+func TestSpecApplyResultsCondition(t *testing.T) {
+	ctx := context.Background()
+	placement := newObject(placementGVK, "placement1", "uid1")
+	k8sClient := newFakeClient(t, newConditionsCRD(), placement)
+	recorder := record.NewFakeRecorder(10)
+	syncer := newSpecApplyResultsSyncer(k8sClient, recorder)
+
+	syncer.syncResults(ctx, map[string]map[string]*status.SpecApplyResult{
+		"uid1": {"hub1": newResult(placementGVK, "placement1", true, "")},
+	})
+
+	conditions := getObjectConditions(t, k8sClient, placement)
+	if condition := meta.FindStatusCondition(conditions, AppliedOnRegionalHubsCondition); condition == nil ||
+		condition.Status != metav1.ConditionTrue {
+		t.Fatalf("expected the applied condition, got %v", conditions)
+	}
+
+	if events := readEvents(recorder); len(events) != 0 {
+		t.Errorf("expected no events of the object with the condition, got %v", events)
+	}
+
+	// the results are gone, e.g. the regional hub is detached
+	syncer.syncResults(ctx, map[string]map[string]*status.SpecApplyResult{})
+
+	if conditions := getObjectConditions(t, k8sClient, placement); meta.FindStatusCondition(conditions,
+		AppliedOnRegionalHubsCondition) != nil {
+		t.Errorf("expected the applied condition to be cleared, got %v", conditions)
+	}
+}
+
+func newFakeClient(t *testing.T, crd *unstructured.Unstructured, objects ...client.Object) client.Client {
+	t.Helper()
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.AddSpecific(policyGVK, policyGVK.GroupVersion().WithResource("policies"),
+		policyGVK.GroupVersion().WithResource("policy"), meta.RESTScopeNamespace)
+	mapper.AddSpecific(placementGVK, placementGVK.GroupVersion().WithResource("placements"),
+		placementGVK.GroupVersion().WithResource("placement"), meta.RESTScopeNamespace)
+
+	return fake.NewClientBuilder().WithRESTMapper(mapper).WithObjects(append(objects, crd)...).Build()
+}
+
+func loadCRD(t *testing.T, path string) *unstructured.Unstructured {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crd := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(strings.TrimPrefix(string(data), "---\n")), &crd.Object); err != nil {
+		t.Fatal(err)
+	}
+
+	return crd
+}
+
+func newConditionsCRD() *unstructured.Unstructured {
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"versions": []interface{}{
+				map[string]interface{}{
+					"name":         placementGVK.Version,
+					"subresources": map[string]interface{}{"status": map[string]interface{}{}},
+					"schema": map[string]interface{}{
+						"openAPIV3Schema": map[string]interface{}{
+							"properties": map[string]interface{}{
+								"status": map[string]interface{}{
+									"properties": map[string]interface{}{
+										"conditions": map[string]interface{}{"type": "array"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+	crd.SetGroupVersionKind(customResourceDefinitionGVK)
+	crd.SetName("placements." + placementGVK.Group)
+
+	return crd
+}
+
+func newObject(gvk schema.GroupVersionKind, name, uid string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	obj.SetUID(types.UID(uid))
+
+	return obj
+}
+
+func newResult(gvk schema.GroupVersionKind, name string, applied bool, applyErr string,
+) *status.SpecApplyResult {
+	return &status.SpecApplyResult{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       name,
+		Namespace:  "default",
+		Applied:    applied,
+		Error:      applyErr,
+	}
+}
+
+func getObjectConditions(t *testing.T, k8sClient client.Client, obj *unstructured.Unstructured,
+) []metav1.Condition {
+	t.Helper()
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(obj.GroupVersionKind())
+
+	if err := k8sClient.Get(context.Background(), client.ObjectKeyFromObject(obj), current); err != nil {
+		t.Fatal(err)
+	}
+
+	conditions, err := getConditions(current)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return conditions
+}
+
+func readEvents(recorder *record.FakeRecorder) []string {
+	events := []string{}
+
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
@@ -20,8 +20,10 @@ func AddDBSyncers(mgr ctrl.Manager, database db.DB, statusSyncInterval time.Dura
 		dbsyncer.AddPlacementRuleStatusDBSyncer,
 		dbsyncer.AddPlacementStatusDBSyncer,
 		dbsyncer.AddPlacementDecisionDBSyncer,
+		dbsyncer.AddPolicySetDBSyncer,
 		dbsyncer.AddSubscriptionStatusStatusDBSyncer,
 		dbsyncer.AddSubscriptionReportDBSyncer,
+		dbsyncer.AddSpecApplyResultsDBSyncer,
 	}
 
 	for _, addDBSyncerFunction := range addDBSyncerFunctions {
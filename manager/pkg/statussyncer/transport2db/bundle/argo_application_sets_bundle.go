@@ -0,0 +1,24 @@
+package bundle
+
+import "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+
+// NewArgoApplicationSetsBundle creates a new instance of ArgoApplicationSetsBundle.
+func NewArgoApplicationSetsBundle() Bundle {
+	return &ArgoApplicationSetsBundle{}
+}
+
+// ArgoApplicationSetsBundle abstracts management of argo cd application sets bundle.
+type ArgoApplicationSetsBundle struct {
+	baseBundle
+	Objects []*status.ArgoApplicationSetStatus `json:"objects"`
+}
+
+// GetObjects return all the objects that the bundle holds.
+func (bundle *ArgoApplicationSetsBundle) GetObjects() []interface{} {
+	result := make([]interface{}, len(bundle.Objects))
+	for i, obj := range bundle.Objects {
+		result[i] = obj
+	}
+
+	return result
+}
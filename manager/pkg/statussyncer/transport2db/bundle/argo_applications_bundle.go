@@ -0,0 +1,24 @@
+package bundle
+
+import "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+
+// NewArgoApplicationsBundle creates a new instance of ArgoApplicationsBundle.
+func NewArgoApplicationsBundle() Bundle {
+	return &ArgoApplicationsBundle{}
+}
+
+// ArgoApplicationsBundle abstracts management of argo cd applications bundle.
+type ArgoApplicationsBundle struct {
+	baseBundle
+	Objects []*status.ArgoApplicationStatus `json:"objects"`
+}
+
+// GetObjects return all the objects that the bundle holds.
+func (bundle *ArgoApplicationsBundle) GetObjects() []interface{} {
+	result := make([]interface{}, len(bundle.Objects))
+	for i, obj := range bundle.Objects {
+		result[i] = obj
+	}
+
+	return result
+}
@@ -0,0 +1,24 @@
+package bundle
+
+import "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+
+// NewClusterDeploymentsBundle creates a new instance of ClusterDeploymentsBundle.
+func NewClusterDeploymentsBundle() Bundle {
+	return &ClusterDeploymentsBundle{}
+}
+
+// ClusterDeploymentsBundle abstracts management of cluster deployments bundle.
+type ClusterDeploymentsBundle struct {
+	baseBundle
+	Objects []*status.ClusterDeploymentStatus `json:"objects"`
+}
+
+// GetObjects return all the objects that the bundle holds.
+func (bundle *ClusterDeploymentsBundle) GetObjects() []interface{} {
+	result := make([]interface{}, len(bundle.Objects))
+	for i, obj := range bundle.Objects {
+		result[i] = obj
+	}
+
+	return result
+}
@@ -0,0 +1,24 @@
+package bundle
+
+import "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+
+// NewClusterPoolClaimsBundle creates a new instance of ClusterPoolClaimsBundle.
+func NewClusterPoolClaimsBundle() Bundle {
+	return &ClusterPoolClaimsBundle{}
+}
+
+// ClusterPoolClaimsBundle abstracts management of cluster pool claims bundle.
+type ClusterPoolClaimsBundle struct {
+	baseBundle
+	Objects []*status.ClusterPoolClaimStatus `json:"objects"`
+}
+
+// GetObjects return all the objects that the bundle holds.
+func (bundle *ClusterPoolClaimsBundle) GetObjects() []interface{} {
+	result := make([]interface{}, len(bundle.Objects))
+	for i, obj := range bundle.Objects {
+		result[i] = obj
+	}
+
+	return result
+}
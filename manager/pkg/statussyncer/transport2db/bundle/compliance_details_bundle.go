@@ -0,0 +1,24 @@
+package bundle
+
+import "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+
+// NewComplianceDetailsBundle creates a new instance of ComplianceDetailsBundle.
+func NewComplianceDetailsBundle() Bundle {
+	return &ComplianceDetailsBundle{}
+}
+
+// ComplianceDetailsBundle abstracts management of compliance details bundle.
+type ComplianceDetailsBundle struct {
+	baseBundle
+	Objects []*status.PolicyComplianceDetails `json:"objects"`
+}
+
+// GetObjects return all the objects that the bundle holds.
+func (bundle *ComplianceDetailsBundle) GetObjects() []interface{} {
+	result := make([]interface{}, len(bundle.Objects))
+	for i, obj := range bundle.Objects {
+		result[i] = obj
+	}
+
+	return result
+}
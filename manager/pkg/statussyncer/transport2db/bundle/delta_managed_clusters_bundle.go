@@ -0,0 +1,98 @@
+package bundle
+
+import (
+	"fmt"
+
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+)
+
+// NewDeltaManagedClustersBundle creates a new instance of DeltaManagedClustersBundle.
+func NewDeltaManagedClustersBundle() Bundle {
+	return &DeltaManagedClustersBundle{}
+}
+
+// DeltaManagedClustersBundle abstracts management of delta managed clusters bundle.
+type DeltaManagedClustersBundle struct {
+	status.BaseDeltaManagedClustersBundle
+}
+
+// GetLeafHubName returns the leaf hub name that sent the bundle.
+func (bundle *DeltaManagedClustersBundle) GetLeafHubName() string {
+	return bundle.LeafHubName
+}
+
+// GetObjects returns the objects in the bundle.
+func (bundle *DeltaManagedClustersBundle) GetObjects() []interface{} {
+	result := make([]interface{}, len(bundle.Objects))
+
+	for i, obj := range bundle.Objects {
+		result[i] = obj
+	}
+
+	return result
+}
+
+// GetVersion returns the bundle version.
+func (bundle *DeltaManagedClustersBundle) GetVersion() *status.BundleVersion {
+	return bundle.BundleVersion
+}
+
+// GetDependencyVersion returns the bundle dependency required version.
+func (bundle *DeltaManagedClustersBundle) GetDependencyVersion() *status.BundleVersion {
+	return bundle.BaseBundleVersion
+}
+
+// InheritEvents updates the content of this bundle with that of another older one (this bundle is the source of truth).
+func (bundle *DeltaManagedClustersBundle) InheritEvents(olderBundle Bundle) error {
+	if olderBundle == nil {
+		return nil
+	}
+
+	oldDeltaManagedClustersBundle, ok := olderBundle.(*DeltaManagedClustersBundle)
+	if !ok {
+		return fmt.Errorf("%w - expecting %s", errWrongType, "DeltaManagedClustersBundle")
+	}
+
+	if !oldDeltaManagedClustersBundle.GetDependencyVersion().Equals(bundle.GetDependencyVersion()) {
+		// if old bundle's dependency version is not equal then its content is covered by a complete-state baseline.
+		return nil
+	}
+
+	bundle.inheritObjects(oldDeltaManagedClustersBundle.Objects, oldDeltaManagedClustersBundle.DeletedClusters)
+
+	return nil
+}
+
+// inheritObjects adds the clusters of the older bundle that are neither updated nor deleted in this bundle.
+func (bundle *DeltaManagedClustersBundle) inheritObjects(oldObjects []*clusterv1.ManagedCluster,
+	oldDeletedClusters []string,
+) {
+	handledClusters := make(map[string]struct{}, len(bundle.Objects)+len(bundle.DeletedClusters))
+
+	for _, cluster := range bundle.Objects {
+		handledClusters[cluster.GetName()] = struct{}{}
+	}
+
+	for _, clusterName := range bundle.DeletedClusters {
+		handledClusters[clusterName] = struct{}{}
+	}
+
+	survivingOldClusters := make([]*clusterv1.ManagedCluster, 0, len(oldObjects))
+
+	for _, cluster := range oldObjects {
+		if _, found := handledClusters[cluster.GetName()]; !found {
+			survivingOldClusters = append(survivingOldClusters, cluster)
+		}
+	}
+
+	for _, clusterName := range oldDeletedClusters {
+		if _, found := handledClusters[clusterName]; !found {
+			bundle.DeletedClusters = append(bundle.DeletedClusters, clusterName)
+		}
+	}
+
+	// update bundle's objects with the surviving clusters as-is
+	bundle.Objects = append(survivingOldClusters, bundle.Objects...)
+}
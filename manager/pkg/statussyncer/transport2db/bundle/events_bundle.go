@@ -0,0 +1,24 @@
+package bundle
+
+import "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+
+// NewEventsBundle creates a new instance of EventsBundle.
+func NewEventsBundle() Bundle {
+	return &EventsBundle{}
+}
+
+// EventsBundle abstracts management of events bundle.
+type EventsBundle struct {
+	baseBundle
+	Objects []*status.Event `json:"objects"`
+}
+
+// GetObjects return all the objects that the bundle holds.
+func (bundle *EventsBundle) GetObjects() []interface{} {
+	result := make([]interface{}, len(bundle.Objects))
+	for i, obj := range bundle.Objects {
+		result[i] = obj
+	}
+
+	return result
+}
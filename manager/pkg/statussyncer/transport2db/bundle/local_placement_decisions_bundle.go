@@ -0,0 +1,24 @@
+package bundle
+
+import clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+
+// NewLocalPlacementDecisionsBundle creates a new instance of LocalPlacementDecisionsBundle.
+func NewLocalPlacementDecisionsBundle() Bundle {
+	return &LocalPlacementDecisionsBundle{}
+}
+
+// LocalPlacementDecisionsBundle abstracts management of local placement decisions bundle.
+type LocalPlacementDecisionsBundle struct {
+	baseBundle
+	Objects []*clusterv1beta1.PlacementDecision `json:"objects"`
+}
+
+// GetObjects returns the objects in the bundle.
+func (bundle *LocalPlacementDecisionsBundle) GetObjects() []interface{} {
+	result := make([]interface{}, len(bundle.Objects))
+	for i, obj := range bundle.Objects {
+		result[i] = obj
+	}
+
+	return result
+}
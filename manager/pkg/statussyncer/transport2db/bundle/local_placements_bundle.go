@@ -0,0 +1,24 @@
+package bundle
+
+import clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+
+// NewLocalPlacementsBundle creates a new instance of LocalPlacementsBundle.
+func NewLocalPlacementsBundle() Bundle {
+	return &LocalPlacementsBundle{}
+}
+
+// LocalPlacementsBundle abstracts management of local placements bundle.
+type LocalPlacementsBundle struct {
+	baseBundle
+	Objects []*clusterv1beta1.Placement `json:"objects"`
+}
+
+// GetObjects returns the objects in the bundle.
+func (bundle *LocalPlacementsBundle) GetObjects() []interface{} {
+	result := make([]interface{}, len(bundle.Objects))
+	for i, obj := range bundle.Objects {
+		result[i] = obj
+	}
+
+	return result
+}
@@ -0,0 +1,24 @@
+package bundle
+
+import addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+
+// NewManagedClusterAddOnsBundle creates a new instance of ManagedClusterAddOnsBundle.
+func NewManagedClusterAddOnsBundle() Bundle {
+	return &ManagedClusterAddOnsBundle{}
+}
+
+// ManagedClusterAddOnsBundle abstracts management of managed cluster add-ons bundle.
+type ManagedClusterAddOnsBundle struct {
+	baseBundle
+	Objects []*addonv1alpha1.ManagedClusterAddOn `json:"objects"`
+}
+
+// GetObjects return all the objects that the bundle holds.
+func (bundle *ManagedClusterAddOnsBundle) GetObjects() []interface{} {
+	result := make([]interface{}, len(bundle.Objects))
+	for i, obj := range bundle.Objects {
+		result[i] = obj
+	}
+
+	return result
+}
@@ -0,0 +1,24 @@
+package bundle
+
+import policyv1beta1 "open-cluster-management.io/governance-policy-propagator/api/v1beta1"
+
+// NewPolicySetsBundle creates a new instance of PolicySetsBundle.
+func NewPolicySetsBundle() Bundle {
+	return &PolicySetsBundle{}
+}
+
+// PolicySetsBundle abstracts management of policy set bundle.
+type PolicySetsBundle struct {
+	baseBundle
+	Objects []*policyv1beta1.PolicySet `json:"objects"`
+}
+
+// GetObjects return all the objects that the bundle holds.
+func (bundle *PolicySetsBundle) GetObjects() []interface{} {
+	result := make([]interface{}, len(bundle.Objects))
+	for i, obj := range bundle.Objects {
+		result[i] = obj
+	}
+
+	return result
+}
@@ -0,0 +1,26 @@
+package bundle
+
+import "github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+
+// NewSpecApplyResultsBundle creates a new instance of SpecApplyResultsBundle.
+func NewSpecApplyResultsBundle() Bundle {
+	return &SpecApplyResultsBundle{}
+}
+
+// SpecApplyResultsBundle abstracts management of spec apply results bundle, it also holds the ids of the spec
+// bundles whose full state is requested by the leaf hub.
+type SpecApplyResultsBundle struct {
+	baseBundle
+	Objects           []*status.SpecApplyResult `json:"objects"`
+	FullStateRequests []string                  `json:"fullStateRequests"`
+}
+
+// GetObjects return all the objects that the bundle holds.
+func (bundle *SpecApplyResultsBundle) GetObjects() []interface{} {
+	result := make([]interface{}, len(bundle.Objects))
+	for i, obj := range bundle.Objects {
+		result[i] = obj
+	}
+
+	return result
+}
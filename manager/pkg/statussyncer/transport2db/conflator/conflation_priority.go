@@ -6,18 +6,30 @@ type ConflationPriority uint8
 // priority list of conflation unit.
 const (
 	ManagedClustersPriority               ConflationPriority = iota
+	DeltaManagedClustersPriority          ConflationPriority = iota
+	ManagedClusterAddOnsPriority          ConflationPriority = iota
+	ClusterDeploymentsPriority            ConflationPriority = iota
+	ClusterPoolClaimsPriority             ConflationPriority = iota
 	ClustersPerPolicyPriority             ConflationPriority = iota
 	CompleteComplianceStatusPriority      ConflationPriority = iota
 	DeltaComplianceStatusPriority         ConflationPriority = iota
 	MinimalComplianceStatusPriority       ConflationPriority = iota
+	ComplianceDetailsPriority             ConflationPriority = iota
 	PlacementRulePriority                 ConflationPriority = iota
 	PlacementPriority                     ConflationPriority = iota
 	PlacementDecisionPriority             ConflationPriority = iota
 	SubscriptionStatusPriority            ConflationPriority = iota
 	SubscriptionReportPriority            ConflationPriority = iota
+	ArgoApplicationsPriority              ConflationPriority = iota
+	ArgoApplicationSetsPriority           ConflationPriority = iota
 	ControlInfoPriority                   ConflationPriority = iota
 	LocalPolicySpecPriority               ConflationPriority = iota
 	LocalClustersPerPolicyPriority        ConflationPriority = iota
 	LocalCompleteComplianceStatusPriority ConflationPriority = iota
 	LocalPlacementRulesSpecPriority       ConflationPriority = iota
+	LocalPlacementsSpecPriority           ConflationPriority = iota
+	LocalPlacementDecisionsPriority       ConflationPriority = iota
+	SpecApplyResultsPriority              ConflationPriority = iota
+	PolicySetPriority                     ConflationPriority = iota
+	EventsPriority                        ConflationPriority = iota
 )
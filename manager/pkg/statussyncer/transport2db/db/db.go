@@ -2,8 +2,11 @@ package db
 
 import (
 	"context"
+	"time"
 
 	set "github.com/deckarep/golang-set"
+
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
 )
 
 // StatusTransportBridgeDB is the db interface required by status transport bridge.
@@ -17,6 +20,11 @@ type StatusTransportBridgeDB interface {
 	GenericStatusResourceDB
 	LocalPoliciesStatusDB
 	ControlInfoDB
+	SpecApplyResultsDB
+	ComplianceDetailsDB
+	ClusterLifecycleDB
+	GitOpsDB
+	EventsDB
 }
 
 // BatchSenderDB is the db interface required for sending batch updates.
@@ -33,6 +41,13 @@ type ManagedClustersStatusDB interface {
 		leafHubName string) (map[string]string, error)
 	// NewManagedClustersBatchBuilder returns managed clusters batch builder.
 	NewManagedClustersBatchBuilder(schema string, tableName string, leafHubName string) ManagedClustersBatchBuilder
+	// GetManagedClusterInventoryNames returns the set of the clusters of the leaf hub that have inventory rows.
+	GetManagedClusterInventoryNames(ctx context.Context, schema string, tableName string,
+		leafHubName string) (map[string]struct{}, error)
+	// UpdateManagedClusterInventory upserts the inventory of the given clusters of the leaf hub and deletes the
+	// inventory of the deleted clusters.
+	UpdateManagedClusterInventory(ctx context.Context, schema string, tableName string, leafHubName string,
+		inventories []*ManagedClusterInventory, deletedClusterNames []string) error
 }
 
 // PoliciesStatusDB is the db interface required to manage policies status.
@@ -81,3 +96,48 @@ type ControlInfoDB interface {
 	// UpdateHeartbeat inserts or updates heartbeat for a leaf hub.
 	UpdateHeartbeat(ctx context.Context, schema string, tableName string, leafHubName string) error
 }
+
+// ComplianceDetailsDB is the db interface required to manage the policy violations of the non compliant clusters.
+type ComplianceDetailsDB interface {
+	// UpdateComplianceDetails replaces the compliance details of a leaf hub.
+	UpdateComplianceDetails(ctx context.Context, schema string, tableName string, leafHubName string,
+		details []*status.PolicyComplianceDetails) error
+}
+
+// ClusterLifecycleDB is the db interface required to manage the lifecycle status of the hive clusters.
+type ClusterLifecycleDB interface {
+	// UpdateClusterLifecycleStatuses replaces the statuses of a leaf hub in the table, statuses is a map of the uid
+	// of the hive object -> its status.
+	UpdateClusterLifecycleStatuses(ctx context.Context, schema string, tableName string, leafHubName string,
+		statuses map[string]interface{}) error
+}
+
+// GitOpsDB is the db interface required to manage the status of the argo cd applications and application sets.
+type GitOpsDB interface {
+	// UpdateGitOpsStatuses replaces the statuses of a leaf hub in the table, statuses is a map of the uid of the argo
+	// cd object -> its status.
+	UpdateGitOpsStatuses(ctx context.Context, schema string, tableName string, leafHubName string,
+		statuses map[string]interface{}) error
+}
+
+// EventsDB is the db interface required to manage the events of the leaf hubs.
+type EventsDB interface {
+	// UpsertEvents inserts the events of a leaf hub, or updates them if they exist already.
+	UpsertEvents(ctx context.Context, schema string, tableName string, leafHubName string,
+		events []*status.Event) error
+	// EnsureEventsPartitions creates the daily partitions of the events table from the day of from to the day of to.
+	EnsureEventsPartitions(ctx context.Context, schema string, tableName string, from time.Time, to time.Time) error
+	// DropEventsPartitions drops the daily partitions of the events table that end before the given time.
+	DropEventsPartitions(ctx context.Context, schema string, tableName string, before time.Time) error
+}
+
+// SpecApplyResultsDB is the db interface required to manage the results of applying the spec objects.
+type SpecApplyResultsDB interface {
+	// UpdateSpecApplyResults replaces the spec apply results of a leaf hub.
+	UpdateSpecApplyResults(ctx context.Context, schema string, tableName string, leafHubName string,
+		results []*status.SpecApplyResult) error
+	// UpdateSpecFullStateRequests replaces the full state requests of a leaf hub, the time of the pending requests
+	// is kept.
+	UpdateSpecFullStateRequests(ctx context.Context, schema string, tableName string, leafHubName string,
+		bundleIDs []string) error
+}
@@ -14,11 +14,23 @@ const (
 const (
 	// ManagedClustersTableName table name of managed clusters.
 	ManagedClustersTableName = "managed_clusters"
+	// ManagedClusterInventoryTableName table name of the inventory of the managed clusters.
+	ManagedClusterInventoryTableName = "managed_cluster_inventory"
+	// ManagedClusterAddOnsTableName table name of managed cluster add-ons.
+	ManagedClusterAddOnsTableName = "managed_cluster_addons"
+	// ClusterDeploymentsTableName table name of the lifecycle status of the hive cluster deployments.
+	ClusterDeploymentsTableName = "cluster_deployments"
+	// ClusterPoolClaimsTableName table name of the status of the hive cluster pool claims.
+	ClusterPoolClaimsTableName = "cluster_pool_claims"
+	// EventsTableName table name of the events, partitioned by the day the events occurred first.
+	EventsTableName = "events"
 
 	// ComplianceTableName table name of policy compliance status.
 	ComplianceTableName = "compliance"
 	// MinimalComplianceTable table name of minimal policy compliance status.
 	MinimalComplianceTable = "aggregated_compliance"
+	// ComplianceDetailsTableName table name of the policy violations of the non compliant clusters.
+	ComplianceDetailsTableName = "compliance_details"
 	// LocalPolicySpecTableName table name of local policy spec.
 	LocalPolicySpecTableName = "policies"
 
@@ -26,6 +38,10 @@ const (
 	SubscriptionStatusesTableName = "subscription_statuses"
 	// SubscriptionReportsTableName table name of subscription-reports.
 	SubscriptionReportsTableName = "subscription_reports"
+	// ArgoApplicationsTableName table name of the sync and health status of the argo cd applications.
+	ArgoApplicationsTableName = "argo_applications"
+	// ArgoApplicationSetsTableName table name of the generation status of the argo cd application sets.
+	ArgoApplicationSetsTableName = "argo_application_sets"
 
 	// PlacementRulesTableName table name of placement-rules.
 	PlacementRulesTableName = "placementrules"
@@ -34,8 +50,15 @@ const (
 	// PlacementDecisionsTableName table name of placement-decisions.
 	PlacementDecisionsTableName = "placementdecisions"
 
+	// PolicySetsTableName table name of policy-sets.
+	PolicySetsTableName = "policysets"
+
 	// LeafHubHeartbeatsTableName table name for LH heartbeats.
 	LeafHubHeartbeatsTableName = "leaf_hub_heartbeats"
+	// SpecApplyResultsTableName table name of the results of applying the spec objects on the leaf hubs.
+	SpecApplyResultsTableName = "spec_apply_results"
+	// SpecFullStateRequestsTableName table name of the spec bundles whose full state is requested by the leaf hubs.
+	SpecFullStateRequestsTableName = "spec_full_state_requests"
 )
 
 // default values.
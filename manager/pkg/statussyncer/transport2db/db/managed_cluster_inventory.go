@@ -0,0 +1,74 @@
+package db
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+)
+
+// well-known cluster claims of the managed clusters.
+const (
+	PlatformClusterClaim    = "platform.open-cluster-management.io"
+	ProductClusterClaim     = "product.open-cluster-management.io"
+	RegionClusterClaim      = "region.open-cluster-management.io"
+	KubeVersionClusterClaim = "kubeversion.open-cluster-management.io"
+	OpenShiftVersionClaim   = "version.openshift.io"
+)
+
+// ManagedClusterInventory is the inventory of a managed cluster, derived from its cluster claims and status.
+type ManagedClusterInventory struct {
+	ClusterName string
+	Platform    string
+	Product     string
+	// Version is the OpenShift version of the cluster, or the kubernetes version for other products.
+	Version     string
+	Region      string
+	KubeVersion string
+	CPUCores    *int64
+	MemoryBytes *int64
+	// Available is the status of the available condition of the cluster, True, False or Unknown.
+	Available string
+}
+
+// NewManagedClusterInventory returns the inventory of the managed cluster.
+func NewManagedClusterInventory(cluster *clusterv1.ManagedCluster) *ManagedClusterInventory {
+	claims := make(map[string]string, len(cluster.Status.ClusterClaims))
+	for _, claim := range cluster.Status.ClusterClaims {
+		claims[claim.Name] = claim.Value
+	}
+
+	inventory := &ManagedClusterInventory{
+		ClusterName: cluster.GetName(),
+		Platform:    claims[PlatformClusterClaim],
+		Product:     claims[ProductClusterClaim],
+		Version:     claims[OpenShiftVersionClaim],
+		Region:      claims[RegionClusterClaim],
+		KubeVersion: claims[KubeVersionClusterClaim],
+		Available:   string(metav1.ConditionUnknown),
+	}
+
+	if inventory.KubeVersion == "" {
+		inventory.KubeVersion = cluster.Status.Version.Kubernetes
+	}
+
+	if inventory.Version == "" {
+		inventory.Version = inventory.KubeVersion
+	}
+
+	if cpu, found := cluster.Status.Capacity[clusterv1.ResourceCPU]; found {
+		cpuCores := cpu.Value()
+		inventory.CPUCores = &cpuCores
+	}
+
+	if memory, found := cluster.Status.Capacity[clusterv1.ResourceMemory]; found {
+		memoryBytes := memory.Value()
+		inventory.MemoryBytes = &memoryBytes
+	}
+
+	if available := meta.FindStatusCondition(cluster.Status.Conditions,
+		clusterv1.ManagedClusterConditionAvailable); available != nil {
+		inventory.Available = string(available.Status)
+	}
+
+	return inventory
+}
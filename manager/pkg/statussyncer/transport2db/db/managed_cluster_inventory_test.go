@@ -0,0 +1,55 @@
+package db
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+)
+
+func TestNewManagedClusterInventory(t *testing.T) {
+	cluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1"},
+		Status: clusterv1.ManagedClusterStatus{
+			ClusterClaims: []clusterv1.ManagedClusterClaim{
+				{Name: PlatformClusterClaim, Value: "AWS"},
+				{Name: ProductClusterClaim, Value: "OpenShift"},
+				{Name: OpenShiftVersionClaim, Value: "4.10.3"},
+				{Name: RegionClusterClaim, Value: "us-east-1"},
+			},
+			Capacity: clusterv1.ResourceList{
+				clusterv1.ResourceCPU:    resource.MustParse("128"),
+				clusterv1.ResourceMemory: resource.MustParse("512Gi"),
+			},
+			Version: clusterv1.ManagedClusterVersion{Kubernetes: "v1.23.3"},
+			Conditions: []metav1.Condition{
+				{Type: clusterv1.ManagedClusterConditionAvailable, Status: metav1.ConditionTrue},
+			},
+		},
+	}
+
+	inventory := NewManagedClusterInventory(cluster)
+
+	if inventory.Platform != "AWS" || inventory.Product != "OpenShift" || inventory.Version != "4.10.3" ||
+		inventory.Region != "us-east-1" || inventory.KubeVersion != "v1.23.3" || inventory.Available != "True" {
+		t.Errorf("unexpected inventory %+v", inventory)
+	}
+
+	if inventory.CPUCores == nil || *inventory.CPUCores != 128 {
+		t.Errorf("expected 128 cpu cores, got %v", inventory.CPUCores)
+	}
+
+	if inventory.MemoryBytes == nil || *inventory.MemoryBytes != 512<<30 {
+		t.Errorf("expected 512Gi memory, got %v", inventory.MemoryBytes)
+	}
+
+	// the version of a cluster that isn't OpenShift is its kubernetes version
+	inventory = NewManagedClusterInventory(&clusterv1.ManagedCluster{
+		Status: clusterv1.ManagedClusterStatus{Version: clusterv1.ManagedClusterVersion{Kubernetes: "v1.24.0"}},
+	})
+
+	if inventory.Version != "v1.24.0" || inventory.Available != "Unknown" || inventory.CPUCores != nil {
+		t.Errorf("unexpected inventory %+v", inventory)
+	}
+}
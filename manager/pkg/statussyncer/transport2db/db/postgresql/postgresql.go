@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	set "github.com/deckarep/golang-set"
 	"github.com/jackc/pgx/v4"
@@ -12,6 +13,14 @@ import (
 
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/db"
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/db/postgresql/batch"
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+)
+
+const (
+	// eventsPartitionNameLayout is the layout of the day in the names of the daily partitions of the events table.
+	eventsPartitionNameLayout = "20060102"
+	// eventsPartitionBoundLayout is the layout of the bounds of the daily partitions of the events table.
+	eventsPartitionBoundLayout = "2006-01-02"
 )
 
 var (
@@ -98,6 +107,77 @@ func (p *PostgreSQL) GetManagedClustersByLeafHub(ctx context.Context, schema str
 	return result, nil
 }
 
+// GetManagedClusterInventoryNames returns the set of the clusters of the leaf hub that have inventory rows.
+func (p *PostgreSQL) GetManagedClusterInventoryNames(ctx context.Context, schema string, tableName string,
+	leafHubName string,
+) (map[string]struct{}, error) {
+	rows, err := p.conn.Query(ctx, fmt.Sprintf(`SELECT cluster_name FROM %s.%s WHERE leaf_hub_name=$1`,
+		schema, tableName), leafHubName)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading from table %s.%s - %w", schema, tableName, err)
+	}
+
+	defer rows.Close()
+
+	clusterNames := make(map[string]struct{})
+
+	for rows.Next() {
+		var clusterName string
+		if err := rows.Scan(&clusterName); err != nil {
+			return nil, fmt.Errorf("failed reading from table %s.%s - %w", schema, tableName, err)
+		}
+
+		clusterNames[clusterName] = struct{}{}
+	}
+
+	return clusterNames, nil
+}
+
+// UpdateManagedClusterInventory upserts the inventory of the given clusters of the leaf hub and deletes the
+// inventory of the deleted clusters.
+func (p *PostgreSQL) UpdateManagedClusterInventory(ctx context.Context, schema string, tableName string,
+	leafHubName string, inventories []*db.ManagedClusterInventory, deletedClusterNames []string,
+) error {
+	if len(inventories) == 0 && len(deletedClusterNames) == 0 {
+		return nil
+	}
+
+	tx, err := p.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if len(deletedClusterNames) > 0 {
+		if _, err := tx.Exec(ctx, fmt.Sprintf(`DELETE FROM %s.%s WHERE leaf_hub_name=$1 AND
+			cluster_name=ANY($2)`, schema, tableName), leafHubName, deletedClusterNames); err != nil {
+			return fmt.Errorf("failed to delete managed cluster inventory: %w", err)
+		}
+	}
+
+	upsertStatement := fmt.Sprintf(`INSERT INTO %s.%s (leaf_hub_name, cluster_name, platform, product, version,
+		region, kube_version, cpu_cores, memory_bytes, available, updated_at)
+		values($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, (now() at time zone 'utc'))
+		ON CONFLICT (leaf_hub_name, cluster_name) DO UPDATE SET platform=EXCLUDED.platform,
+		product=EXCLUDED.product, version=EXCLUDED.version, region=EXCLUDED.region,
+		kube_version=EXCLUDED.kube_version, cpu_cores=EXCLUDED.cpu_cores, memory_bytes=EXCLUDED.memory_bytes,
+		available=EXCLUDED.available, updated_at=EXCLUDED.updated_at`, schema, tableName)
+
+	for _, inventory := range inventories {
+		if _, err := tx.Exec(ctx, upsertStatement, leafHubName, inventory.ClusterName, inventory.Platform,
+			inventory.Product, inventory.Version, inventory.Region, inventory.KubeVersion, inventory.CPUCores,
+			inventory.MemoryBytes, inventory.Available); err != nil {
+			return fmt.Errorf("failed to upsert managed cluster inventory: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 // NewPoliciesBatchBuilder creates a new instance of PoliciesBatchBuilder.
 func (p *PostgreSQL) NewPoliciesBatchBuilder(schema string, tableName string,
 	leafHubName string,
@@ -276,6 +356,149 @@ func (p *PostgreSQL) UpdateHeartbeat(ctx context.Context, schema string, tableNa
 	return nil
 }
 
+// UpdateSpecApplyResults replaces the spec apply results of a leaf hub.
+func (p *PostgreSQL) UpdateSpecApplyResults(ctx context.Context, schema string, tableName string,
+	leafHubName string, results []*status.SpecApplyResult,
+) error {
+	tx, err := p.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf(`DELETE FROM %s.%s WHERE leaf_hub_name = $1`, schema, tableName),
+		leafHubName); err != nil {
+		return fmt.Errorf("failed to delete spec apply results: %w", err)
+	}
+
+	insertStatement := fmt.Sprintf(`INSERT INTO %s.%s (id, leaf_hub_name, payload, updated_at) 
+		values($1, $2, $3, (now() at time zone 'utc'))`, schema, tableName)
+	for _, result := range results {
+		if _, err := tx.Exec(ctx, insertStatement, result.OriginOwnerReferenceUID, leafHubName,
+			result); err != nil {
+			return fmt.Errorf("failed to insert spec apply result: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateComplianceDetails replaces the compliance details of a leaf hub.
+func (p *PostgreSQL) UpdateComplianceDetails(ctx context.Context, schema string, tableName string,
+	leafHubName string, details []*status.PolicyComplianceDetails,
+) error {
+	tx, err := p.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf(`DELETE FROM %s.%s WHERE leaf_hub_name = $1`, schema, tableName),
+		leafHubName); err != nil {
+		return fmt.Errorf("failed to delete compliance details: %w", err)
+	}
+
+	insertStatement := fmt.Sprintf(`INSERT INTO %s.%s (id, cluster_name, leaf_hub_name, violations,
+		omitted_violations, updated_at) values($1, $2, $3, $4, $5, (now() at time zone 'utc'))`, schema, tableName)
+	for _, policyDetails := range details {
+		if _, err := tx.Exec(ctx, insertStatement, policyDetails.PolicyID, policyDetails.ClusterName, leafHubName,
+			policyDetails.Violations, policyDetails.OmittedViolations); err != nil {
+			return fmt.Errorf("failed to insert compliance details: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateClusterLifecycleStatuses replaces the statuses of a leaf hub in the table.
+func (p *PostgreSQL) UpdateClusterLifecycleStatuses(ctx context.Context, schema string, tableName string,
+	leafHubName string, statuses map[string]interface{},
+) error {
+	return p.replaceStatuses(ctx, schema, tableName, leafHubName, statuses)
+}
+
+// UpdateGitOpsStatuses replaces the statuses of a leaf hub in the table.
+func (p *PostgreSQL) UpdateGitOpsStatuses(ctx context.Context, schema string, tableName string,
+	leafHubName string, statuses map[string]interface{},
+) error {
+	return p.replaceStatuses(ctx, schema, tableName, leafHubName, statuses)
+}
+
+// replaceStatuses replaces the rows of a leaf hub in a table of (id, leaf_hub_name, payload, updated_at) rows,
+// statuses is a map of id -> payload.
+func (p *PostgreSQL) replaceStatuses(ctx context.Context, schema string, tableName string,
+	leafHubName string, statuses map[string]interface{},
+) error {
+	tx, err := p.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf(`DELETE FROM %s.%s WHERE leaf_hub_name = $1`, schema, tableName),
+		leafHubName); err != nil {
+		return fmt.Errorf("failed to delete statuses from %s: %w", tableName, err)
+	}
+
+	insertStatement := fmt.Sprintf(`INSERT INTO %s.%s (id, leaf_hub_name, payload, updated_at) 
+		values($1, $2, $3, (now() at time zone 'utc'))`, schema, tableName)
+	for uid, status := range statuses {
+		if _, err := tx.Exec(ctx, insertStatement, uid, leafHubName, status); err != nil {
+			return fmt.Errorf("failed to insert status into %s: %w", tableName, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSpecFullStateRequests replaces the full state requests of a leaf hub, the time of the pending requests
+// is kept.
+func (p *PostgreSQL) UpdateSpecFullStateRequests(ctx context.Context, schema string, tableName string,
+	leafHubName string, bundleIDs []string,
+) error {
+	if bundleIDs == nil {
+		bundleIDs = []string{} // a null array doesn't match any row in the delete statement
+	}
+
+	tx, err := p.conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf(`DELETE FROM %s.%s WHERE leaf_hub_name = $1 AND 
+		NOT (bundle_id = ANY($2))`, schema, tableName), leafHubName, bundleIDs); err != nil {
+		return fmt.Errorf("failed to delete full state requests: %w", err)
+	}
+
+	insertStatement := fmt.Sprintf(`INSERT INTO %s.%s (leaf_hub_name, bundle_id, requested_at) 
+		values($1, $2, (now() at time zone 'utc')) ON CONFLICT (leaf_hub_name, bundle_id) DO NOTHING`,
+		schema, tableName)
+	for _, bundleID := range bundleIDs {
+		if _, err := tx.Exec(ctx, insertStatement, leafHubName, bundleID); err != nil {
+			return fmt.Errorf("failed to insert full state request: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 func buildKeyValueMapFromRows(rows pgx.Rows) (map[string]string, error) {
 	result := make(map[string]string)
 
@@ -291,3 +514,113 @@ func buildKeyValueMapFromRows(rows pgx.Rows) (map[string]string, error) {
 
 	return result, nil
 }
+
+// UpsertEvents inserts the events of a leaf hub, or updates them if they exist already. the updated events get the
+// next value of the sequence of the table, so they're streamed by the watches again.
+func (p *PostgreSQL) UpsertEvents(ctx context.Context, schema string, tableName string, leafHubName string,
+	events []*status.Event,
+) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	upsertStatement := fmt.Sprintf(`INSERT INTO %s.%s (leaf_hub_name, event_namespace, event_name,
+		involved_api_version, involved_kind, involved_namespace, involved_name, involved_uid, reason, message, type,
+		source, count, first_timestamp, last_timestamp)
+		values($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (leaf_hub_name, involved_kind, involved_namespace, involved_name, reason, first_timestamp)
+		DO UPDATE SET event_namespace=EXCLUDED.event_namespace, event_name=EXCLUDED.event_name,
+		involved_api_version=EXCLUDED.involved_api_version, involved_uid=EXCLUDED.involved_uid,
+		message=EXCLUDED.message, type=EXCLUDED.type, source=EXCLUDED.source, count=EXCLUDED.count,
+		last_timestamp=EXCLUDED.last_timestamp, sequence=nextval('%s.%s_sequence')`, schema, tableName, schema,
+		tableName)
+
+	batch := &pgx.Batch{}
+
+	for _, event := range events {
+		batch.Queue(upsertStatement, leafHubName, event.EventNamespace, event.EventName,
+			event.InvolvedObject.APIVersion, event.InvolvedObject.Kind, event.InvolvedObject.Namespace,
+			event.InvolvedObject.Name, event.InvolvedObject.UID, event.Reason, event.Message, event.Type,
+			event.Source, event.Count, event.FirstTimestamp.UTC(), event.LastTimestamp.UTC())
+	}
+
+	batchResult := p.conn.SendBatch(ctx, batch)
+	defer batchResult.Close()
+
+	for range events {
+		if _, err := batchResult.Exec(); err != nil {
+			return fmt.Errorf("failed to upsert event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// EnsureEventsPartitions creates the daily partitions of the events table from the day of from to the day of to.
+func (p *PostgreSQL) EnsureEventsPartitions(ctx context.Context, schema string, tableName string, from time.Time,
+	to time.Time,
+) error {
+	for day := truncateToDay(from); !day.After(to); day = day.AddDate(0, 0, 1) {
+		if _, err := p.conn.Exec(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s.%s PARTITION OF %s.%s
+			FOR VALUES FROM ('%s') TO ('%s')`, schema, eventsPartitionName(tableName, day), schema, tableName,
+			day.Format(eventsPartitionBoundLayout), day.AddDate(0, 0, 1).Format(eventsPartitionBoundLayout)),
+		); err != nil {
+			return fmt.Errorf("failed to create partition of %s.%s - %w", schema, tableName, err)
+		}
+	}
+
+	return nil
+}
+
+// DropEventsPartitions drops the daily partitions of the events table that end before the given time.
+func (p *PostgreSQL) DropEventsPartitions(ctx context.Context, schema string, tableName string,
+	before time.Time,
+) error {
+	rows, err := p.conn.Query(ctx, `SELECT partition.relname FROM pg_inherits
+		JOIN pg_class partition ON partition.oid = pg_inherits.inhrelid
+		JOIN pg_class parent ON parent.oid = pg_inherits.inhparent
+		JOIN pg_namespace ON pg_namespace.oid = parent.relnamespace
+		WHERE pg_namespace.nspname = $1 AND parent.relname = $2`, schema, tableName)
+	if err != nil {
+		return fmt.Errorf("failed to get partitions of %s.%s - %w", schema, tableName, err)
+	}
+
+	partitionsToDrop := make([]string, 0)
+
+	for rows.Next() {
+		var partitionName string
+		if err := rows.Scan(&partitionName); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to get partitions of %s.%s - %w", schema, tableName, err)
+		}
+
+		day, err := time.Parse(eventsPartitionNameLayout, strings.TrimPrefix(partitionName, tableName+"_"))
+		if err != nil {
+			continue // not a daily partition
+		}
+
+		if !day.AddDate(0, 0, 1).After(before) {
+			partitionsToDrop = append(partitionsToDrop, partitionName)
+		}
+	}
+
+	rows.Close()
+
+	for _, partitionName := range partitionsToDrop {
+		if _, err := p.conn.Exec(ctx, fmt.Sprintf(`DROP TABLE IF EXISTS %s.%s`, schema,
+			partitionName)); err != nil {
+			return fmt.Errorf("failed to drop partition %s.%s - %w", schema, partitionName, err)
+		}
+	}
+
+	return nil
+}
+
+func truncateToDay(timestamp time.Time) time.Time {
+	year, month, day := timestamp.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func eventsPartitionName(tableName string, day time.Time) string {
+	return fmt.Sprintf("%s_%s", tableName, day.Format(eventsPartitionNameLayout))
+}
@@ -66,3 +66,8 @@ func (pool *DBWorkerPool) Acquire() *DBWorker {
 	pool.statistics.SetNumberOfAvailableDBWorkers(len(pool.dbWorkers))
 	return <-pool.dbWorkers
 }
+
+// GetDB returns the db of the workers, for the jobs that aren't bundle handlers, e.g. the retention of the events.
+func (pool *DBWorkerPool) GetDB() db.StatusTransportBridgeDB {
+	return pool.dbConnPool
+}
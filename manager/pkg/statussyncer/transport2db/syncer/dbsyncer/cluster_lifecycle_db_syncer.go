@@ -0,0 +1,103 @@
+package dbsyncer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/bundle"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/conflator"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/db"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/helpers"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/transport"
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+// NewClusterLifecycleDBSyncer creates a new instance of ClusterLifecycleDBSyncer.
+func NewClusterLifecycleDBSyncer(log logr.Logger) DBSyncer {
+	dbSyncer := &ClusterLifecycleDBSyncer{
+		log:                                log,
+		createClusterDeploymentsBundleFunc: bundle.NewClusterDeploymentsBundle,
+		createClusterPoolClaimsBundleFunc:  bundle.NewClusterPoolClaimsBundle,
+	}
+
+	log.Info("initialized cluster lifecycle db syncer")
+
+	return dbSyncer
+}
+
+// ClusterLifecycleDBSyncer implements hive cluster deployments and cluster pool claims transport to db sync.
+type ClusterLifecycleDBSyncer struct {
+	log                                logr.Logger
+	createClusterDeploymentsBundleFunc bundle.CreateBundleFunction
+	createClusterPoolClaimsBundleFunc  bundle.CreateBundleFunction
+}
+
+// RegisterCreateBundleFunctions registers create bundle functions within the transport instance.
+func (syncer *ClusterLifecycleDBSyncer) RegisterCreateBundleFunctions(transportInstance transport.Transport) {
+	transportInstance.Register(&transport.BundleRegistration{
+		MsgID:            constants.ClusterDeploymentsMsgKey,
+		CreateBundleFunc: syncer.createClusterDeploymentsBundleFunc,
+		Predicate:        func() bool { return true }, // always get cluster deployments bundles
+	})
+
+	transportInstance.Register(&transport.BundleRegistration{
+		MsgID:            constants.ClusterPoolClaimsMsgKey,
+		CreateBundleFunc: syncer.createClusterPoolClaimsBundleFunc,
+		Predicate:        func() bool { return true }, // always get cluster pool claims bundles
+	})
+}
+
+// RegisterBundleHandlerFunctions registers bundle handler functions within the conflation manager.
+func (syncer *ClusterLifecycleDBSyncer) RegisterBundleHandlerFunctions(
+	conflationManager *conflator.ConflationManager,
+) {
+	conflationManager.Register(conflator.NewConflationRegistration(
+		conflator.ClusterDeploymentsPriority,
+		status.CompleteStateMode,
+		helpers.GetBundleType(syncer.createClusterDeploymentsBundleFunc()),
+		func(ctx context.Context, bundle bundle.Bundle, dbClient db.StatusTransportBridgeDB) error {
+			return syncer.handleClusterLifecycleBundle(ctx, bundle, dbClient, db.ClusterDeploymentsTableName)
+		},
+	))
+
+	conflationManager.Register(conflator.NewConflationRegistration(
+		conflator.ClusterPoolClaimsPriority,
+		status.CompleteStateMode,
+		helpers.GetBundleType(syncer.createClusterPoolClaimsBundleFunc()),
+		func(ctx context.Context, bundle bundle.Bundle, dbClient db.StatusTransportBridgeDB) error {
+			return syncer.handleClusterLifecycleBundle(ctx, bundle, dbClient, db.ClusterPoolClaimsTableName)
+		},
+	))
+}
+
+func (syncer *ClusterLifecycleDBSyncer) handleClusterLifecycleBundle(ctx context.Context,
+	receivedBundle bundle.Bundle, dbClient db.ClusterLifecycleDB, tableName string,
+) error {
+	logBundleHandlingMessage(syncer.log, receivedBundle, startBundleHandlingMessage)
+	leafHubName := receivedBundle.GetLeafHubName()
+
+	statuses := make(map[string]interface{}, len(receivedBundle.GetObjects()))
+
+	for _, object := range receivedBundle.GetObjects() {
+		switch lifecycleStatus := object.(type) {
+		case *status.ClusterDeploymentStatus:
+			statuses[lifecycleStatus.UID] = lifecycleStatus
+		case *status.ClusterPoolClaimStatus:
+			statuses[lifecycleStatus.UID] = lifecycleStatus
+		}
+	}
+
+	delete(statuses, "") // do not handle objects without uid
+
+	if err := dbClient.UpdateClusterLifecycleStatuses(ctx, db.StatusSchema, tableName, leafHubName,
+		statuses); err != nil {
+		return fmt.Errorf("failed handling %s bundle of leaf hub '%s' - %w", tableName, leafHubName, err)
+	}
+
+	logBundleHandlingMessage(syncer.log, receivedBundle, finishBundleHandlingMessage)
+
+	return nil
+}
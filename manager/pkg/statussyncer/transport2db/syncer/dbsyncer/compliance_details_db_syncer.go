@@ -0,0 +1,87 @@
+package dbsyncer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/bundle"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/conflator"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/db"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/helpers"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/transport"
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+// NewComplianceDetailsDBSyncer creates a new instance of ComplianceDetailsDBSyncer.
+func NewComplianceDetailsDBSyncer(log logr.Logger, config *corev1.ConfigMap) DBSyncer {
+	dbSyncer := &ComplianceDetailsDBSyncer{
+		log:              log,
+		config:           config,
+		createBundleFunc: bundle.NewComplianceDetailsBundle,
+	}
+
+	log.Info("initialized compliance details db syncer")
+
+	return dbSyncer
+}
+
+// ComplianceDetailsDBSyncer implements policy compliance details transport to db sync.
+type ComplianceDetailsDBSyncer struct {
+	log              logr.Logger
+	config           *corev1.ConfigMap
+	createBundleFunc bundle.CreateBundleFunction
+}
+
+// RegisterCreateBundleFunctions registers create bundle functions within the transport instance.
+func (syncer *ComplianceDetailsDBSyncer) RegisterCreateBundleFunctions(transportInstance transport.Transport) {
+	// the bundle without objects that clears the details is sent once the details are disabled
+	transportInstance.Register(&transport.BundleRegistration{
+		MsgID:            constants.PolicyComplianceDetailsMsgKey,
+		CreateBundleFunc: syncer.createBundleFunc,
+		Predicate:        func() bool { return syncer.config.Data["aggregationLevel"] == "full" },
+	})
+}
+
+// RegisterBundleHandlerFunctions registers bundle handler functions within the conflation manager.
+func (syncer *ComplianceDetailsDBSyncer) RegisterBundleHandlerFunctions(
+	conflationManager *conflator.ConflationManager,
+) {
+	conflationManager.Register(conflator.NewConflationRegistration(
+		conflator.ComplianceDetailsPriority,
+		status.CompleteStateMode,
+		helpers.GetBundleType(syncer.createBundleFunc()),
+		func(ctx context.Context, bundle bundle.Bundle, dbClient db.StatusTransportBridgeDB) error {
+			return syncer.handleComplianceDetailsBundle(ctx, bundle, dbClient)
+		},
+	))
+}
+
+func (syncer *ComplianceDetailsDBSyncer) handleComplianceDetailsBundle(ctx context.Context,
+	receivedBundle bundle.Bundle, dbClient db.ComplianceDetailsDB,
+) error {
+	logBundleHandlingMessage(syncer.log, receivedBundle, startBundleHandlingMessage)
+	leafHubName := receivedBundle.GetLeafHubName()
+
+	details := make([]*status.PolicyComplianceDetails, 0, len(receivedBundle.GetObjects()))
+	for _, object := range receivedBundle.GetObjects() {
+		policyDetails, ok := object.(*status.PolicyComplianceDetails)
+		if !ok || policyDetails.PolicyID == "" {
+			continue // do not handle objects other than PolicyComplianceDetails with the policy id
+		}
+
+		details = append(details, policyDetails)
+	}
+
+	if err := dbClient.UpdateComplianceDetails(ctx, db.StatusSchema, db.ComplianceDetailsTableName, leafHubName,
+		details); err != nil {
+		return fmt.Errorf("failed handling compliance details bundle of leaf hub '%s' - %w", leafHubName, err)
+	}
+
+	logBundleHandlingMessage(syncer.log, receivedBundle, finishBundleHandlingMessage)
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package dbsyncer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/bundle"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/conflator"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/db"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/helpers"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/transport"
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+// MaxEventAge is the max age of the events stored, the partitions of the events table are kept for at least this
+// age. the agents relay the events for an hour since they occurred last.
+const MaxEventAge = 24 * time.Hour
+
+// NewEventsDBSyncer creates a new instance of EventsDBSyncer.
+func NewEventsDBSyncer(log logr.Logger) DBSyncer {
+	dbSyncer := &EventsDBSyncer{
+		log:              log,
+		createBundleFunc: bundle.NewEventsBundle,
+	}
+
+	log.Info("initialized events db syncer")
+
+	return dbSyncer
+}
+
+// EventsDBSyncer implements events transport to db sync.
+type EventsDBSyncer struct {
+	log              logr.Logger
+	createBundleFunc bundle.CreateBundleFunction
+}
+
+// RegisterCreateBundleFunctions registers create bundle functions within the transport instance.
+func (syncer *EventsDBSyncer) RegisterCreateBundleFunctions(transportInstance transport.Transport) {
+	transportInstance.Register(&transport.BundleRegistration{
+		MsgID:            constants.EventsMsgKey,
+		CreateBundleFunc: syncer.createBundleFunc,
+		Predicate:        func() bool { return true }, // always get events bundles
+	})
+}
+
+// RegisterBundleHandlerFunctions registers bundle handler functions within the conflation manager.
+// the events bundle holds the recent events of the leaf hub, the events are upserted and never deleted by the
+// handler, they're deleted by the retention of the events table.
+func (syncer *EventsDBSyncer) RegisterBundleHandlerFunctions(conflationManager *conflator.ConflationManager) {
+	conflationManager.Register(conflator.NewConflationRegistration(
+		conflator.EventsPriority,
+		status.CompleteStateMode,
+		helpers.GetBundleType(syncer.createBundleFunc()),
+		func(ctx context.Context, bundle bundle.Bundle, dbClient db.StatusTransportBridgeDB) error {
+			return syncer.handleEventsBundle(ctx, bundle, dbClient)
+		},
+	))
+}
+
+func (syncer *EventsDBSyncer) handleEventsBundle(ctx context.Context, bundle bundle.Bundle,
+	dbClient db.EventsDB,
+) error {
+	logBundleHandlingMessage(syncer.log, bundle, startBundleHandlingMessage)
+	leafHubName := bundle.GetLeafHubName()
+
+	minFirstTimestamp := time.Now().Add(-MaxEventAge)
+	events := make([]*status.Event, 0, len(bundle.GetObjects()))
+
+	for _, object := range bundle.GetObjects() {
+		event, ok := object.(*status.Event)
+		if !ok {
+			continue // do not handle objects other than Event
+		}
+
+		if event.FirstTimestamp.Before(minFirstTimestamp) {
+			continue // the partition of the event may be dropped already
+		}
+
+		events = append(events, event)
+	}
+
+	if err := dbClient.UpsertEvents(ctx, db.StatusSchema, db.EventsTableName, leafHubName, events); err != nil {
+		return fmt.Errorf("failed handling events bundle of leaf hub '%s' - %w", leafHubName, err)
+	}
+
+	logBundleHandlingMessage(syncer.log, bundle, finishBundleHandlingMessage)
+
+	return nil
+}
@@ -27,14 +27,21 @@ type genericDBSyncer struct {
 	createBundleFunc func() bundle.Bundle
 	bundlePriority   conflator.ConflationPriority
 	bundleSyncMode   status.BundleSyncMode
+	// bundlePredicate tells whether to get the bundles, the bundles are always got if it's nil.
+	bundlePredicate func() bool
 }
 
 // RegisterCreateBundleFunctions registers create bundle functions within the transport instance.
 func (syncer *genericDBSyncer) RegisterCreateBundleFunctions(transportInstance transport.Transport) {
+	predicate := syncer.bundlePredicate
+	if predicate == nil {
+		predicate = func() bool { return true } // always get generic status resources
+	}
+
 	transportInstance.Register(&transport.BundleRegistration{
 		MsgID:            syncer.transportMsgKey,
 		CreateBundleFunc: syncer.createBundleFunc,
-		Predicate:        func() bool { return true }, // always get generic status resources
+		Predicate:        predicate,
 	})
 }
 
@@ -0,0 +1,100 @@
+package dbsyncer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/bundle"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/conflator"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/db"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/helpers"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/transport"
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+// NewGitOpsDBSyncer creates a new instance of GitOpsDBSyncer.
+func NewGitOpsDBSyncer(log logr.Logger) DBSyncer {
+	dbSyncer := &GitOpsDBSyncer{
+		log:                                 log,
+		createArgoApplicationsBundleFunc:    bundle.NewArgoApplicationsBundle,
+		createArgoApplicationSetsBundleFunc: bundle.NewArgoApplicationSetsBundle,
+	}
+
+	log.Info("initialized gitops db syncer")
+
+	return dbSyncer
+}
+
+// GitOpsDBSyncer implements argo cd applications and application sets transport to db sync.
+type GitOpsDBSyncer struct {
+	log                                 logr.Logger
+	createArgoApplicationsBundleFunc    bundle.CreateBundleFunction
+	createArgoApplicationSetsBundleFunc bundle.CreateBundleFunction
+}
+
+// RegisterCreateBundleFunctions registers create bundle functions within the transport instance.
+func (syncer *GitOpsDBSyncer) RegisterCreateBundleFunctions(transportInstance transport.Transport) {
+	transportInstance.Register(&transport.BundleRegistration{
+		MsgID:            constants.ArgoApplicationsMsgKey,
+		CreateBundleFunc: syncer.createArgoApplicationsBundleFunc,
+		Predicate:        func() bool { return true }, // always get argo applications bundles
+	})
+
+	transportInstance.Register(&transport.BundleRegistration{
+		MsgID:            constants.ArgoApplicationSetsMsgKey,
+		CreateBundleFunc: syncer.createArgoApplicationSetsBundleFunc,
+		Predicate:        func() bool { return true }, // always get argo application sets bundles
+	})
+}
+
+// RegisterBundleHandlerFunctions registers bundle handler functions within the conflation manager.
+func (syncer *GitOpsDBSyncer) RegisterBundleHandlerFunctions(conflationManager *conflator.ConflationManager) {
+	conflationManager.Register(conflator.NewConflationRegistration(
+		conflator.ArgoApplicationsPriority,
+		status.CompleteStateMode,
+		helpers.GetBundleType(syncer.createArgoApplicationsBundleFunc()),
+		func(ctx context.Context, bundle bundle.Bundle, dbClient db.StatusTransportBridgeDB) error {
+			return syncer.handleGitOpsBundle(ctx, bundle, dbClient, db.ArgoApplicationsTableName)
+		},
+	))
+
+	conflationManager.Register(conflator.NewConflationRegistration(
+		conflator.ArgoApplicationSetsPriority,
+		status.CompleteStateMode,
+		helpers.GetBundleType(syncer.createArgoApplicationSetsBundleFunc()),
+		func(ctx context.Context, bundle bundle.Bundle, dbClient db.StatusTransportBridgeDB) error {
+			return syncer.handleGitOpsBundle(ctx, bundle, dbClient, db.ArgoApplicationSetsTableName)
+		},
+	))
+}
+
+func (syncer *GitOpsDBSyncer) handleGitOpsBundle(ctx context.Context, receivedBundle bundle.Bundle,
+	dbClient db.GitOpsDB, tableName string,
+) error {
+	logBundleHandlingMessage(syncer.log, receivedBundle, startBundleHandlingMessage)
+	leafHubName := receivedBundle.GetLeafHubName()
+
+	statuses := make(map[string]interface{}, len(receivedBundle.GetObjects()))
+
+	for _, object := range receivedBundle.GetObjects() {
+		switch gitOpsStatus := object.(type) {
+		case *status.ArgoApplicationStatus:
+			statuses[gitOpsStatus.UID] = gitOpsStatus
+		case *status.ArgoApplicationSetStatus:
+			statuses[gitOpsStatus.UID] = gitOpsStatus
+		}
+	}
+
+	delete(statuses, "") // do not handle objects without uid
+
+	if err := dbClient.UpdateGitOpsStatuses(ctx, db.StatusSchema, tableName, leafHubName, statuses); err != nil {
+		return fmt.Errorf("failed handling %s bundle of leaf hub '%s' - %w", tableName, leafHubName, err)
+	}
+
+	logBundleHandlingMessage(syncer.log, receivedBundle, finishBundleHandlingMessage)
+
+	return nil
+}
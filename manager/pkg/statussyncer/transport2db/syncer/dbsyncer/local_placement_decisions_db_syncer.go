@@ -0,0 +1,33 @@
+package dbsyncer
+
+import (
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/bundle"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/conflator"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/db"
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+// NewLocalPlacementDecisionsDBSyncer creates a new instance of genericDBSyncer to sync the placement-decisions of
+// the local placements.
+func NewLocalPlacementDecisionsDBSyncer(log logr.Logger, config *corev1.ConfigMap) DBSyncer {
+	dbSyncer := &genericDBSyncer{
+		log:              log,
+		transportMsgKey:  constants.LocalPlacementDecisionsMsgKey,
+		dbSchema:         db.LocalStatusSchema,
+		dbTableName:      db.PlacementDecisionsTableName,
+		createBundleFunc: bundle.NewLocalPlacementDecisionsBundle,
+		bundlePriority:   conflator.LocalPlacementDecisionsPriority,
+		bundleSyncMode:   status.CompleteStateMode,
+		bundlePredicate: func() bool {
+			return config.Data["enableLocalPolicies"] == "true"
+		},
+	}
+
+	log.Info("initialized local placement-decisions db syncer")
+
+	return dbSyncer
+}
@@ -24,6 +24,7 @@ func NewLocalSpecDBSyncer(log logr.Logger, config *corev1.ConfigMap) DBSyncer {
 		config:                                  config,
 		createLocalPolicySpecBundleFunc:         bundle.NewLocalPolicySpecBundle,
 		createLocalPlacementRulesSpecBundleFunc: bundle.NewLocalPlacementRulesBundle,
+		createLocalPlacementsSpecBundleFunc:     bundle.NewLocalPlacementsBundle,
 	}
 
 	log.Info("initialized local spec db syncer")
@@ -37,6 +38,7 @@ type LocalSpecDBSyncer struct {
 	config                                  *corev1.ConfigMap
 	createLocalPolicySpecBundleFunc         bundle.CreateBundleFunction
 	createLocalPlacementRulesSpecBundleFunc bundle.CreateBundleFunction
+	createLocalPlacementsSpecBundleFunc     bundle.CreateBundleFunction
 }
 
 // RegisterCreateBundleFunctions registers create bundle functions within the transport instance.
@@ -56,6 +58,12 @@ func (syncer *LocalSpecDBSyncer) RegisterCreateBundleFunctions(transportInstance
 		CreateBundleFunc: syncer.createLocalPlacementRulesSpecBundleFunc,
 		Predicate:        predicate,
 	})
+
+	transportInstance.Register(&transport.BundleRegistration{
+		MsgID:            constants.LocalPlacementsMsgKey,
+		CreateBundleFunc: syncer.createLocalPlacementsSpecBundleFunc,
+		Predicate:        predicate,
+	})
 }
 
 // RegisterBundleHandlerFunctions registers bundle handler functions within the conflation manager.
@@ -77,6 +85,12 @@ func (syncer *LocalSpecDBSyncer) RegisterBundleHandlerFunctions(conflationManage
 		status.CompleteStateMode,
 		helpers.GetBundleType(syncer.createLocalPlacementRulesSpecBundleFunc()),
 		syncer.handleLocalObjectsBundleWrapper(db.PlacementRulesTableName)))
+
+	conflationManager.Register(conflator.NewConflationRegistration(
+		conflator.LocalPlacementsSpecPriority,
+		status.CompleteStateMode,
+		helpers.GetBundleType(syncer.createLocalPlacementsSpecBundleFunc()),
+		syncer.handleLocalObjectsBundleWrapper(db.PlacementsTableName)))
 }
 
 func (syncer *LocalSpecDBSyncer) handleLocalObjectsBundleWrapper(tableName string) func(ctx context.Context,
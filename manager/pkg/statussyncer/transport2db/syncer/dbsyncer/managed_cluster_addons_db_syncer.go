@@ -0,0 +1,28 @@
+package dbsyncer
+
+import (
+	"github.com/go-logr/logr"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/bundle"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/conflator"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/db"
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+// NewManagedClusterAddOnsDBSyncer creates a new instance of genericDBSyncer to sync managed cluster add-ons.
+func NewManagedClusterAddOnsDBSyncer(log logr.Logger) DBSyncer {
+	dbSyncer := &genericDBSyncer{
+		log:              log,
+		transportMsgKey:  constants.ManagedClusterAddOnsMsgKey,
+		dbSchema:         db.StatusSchema,
+		dbTableName:      db.ManagedClusterAddOnsTableName,
+		createBundleFunc: bundle.NewManagedClusterAddOnsBundle,
+		bundlePriority:   conflator.ManagedClusterAddOnsPriority,
+		bundleSyncMode:   status.CompleteStateMode,
+	}
+
+	log.Info("initialized managed cluster add-ons db syncer")
+
+	return dbSyncer
+}
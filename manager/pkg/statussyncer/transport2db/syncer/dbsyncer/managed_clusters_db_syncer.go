@@ -9,6 +9,7 @@ import (
 
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/bundle"
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/conflator"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/conflator/dependency"
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/db"
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/helpers"
 	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/transport"
@@ -19,8 +20,9 @@ import (
 // NewManagedClustersDBSyncer creates a new instance of ManagedClustersDBSyncer.
 func NewManagedClustersDBSyncer(log logr.Logger) DBSyncer {
 	dbSyncer := &ManagedClustersDBSyncer{
-		log:              log,
-		createBundleFunc: bundle.NewManagedClustersStatusBundle,
+		log:                   log,
+		createBundleFunc:      bundle.NewManagedClustersStatusBundle,
+		createDeltaBundleFunc: bundle.NewDeltaManagedClustersBundle,
 	}
 
 	log.Info("initialized managed clusters db syncer")
@@ -30,8 +32,9 @@ func NewManagedClustersDBSyncer(log logr.Logger) DBSyncer {
 
 // ManagedClustersDBSyncer implements managed clusters db sync business logic.
 type ManagedClustersDBSyncer struct {
-	log              logr.Logger
-	createBundleFunc bundle.CreateBundleFunction
+	log                   logr.Logger
+	createBundleFunc      bundle.CreateBundleFunction
+	createDeltaBundleFunc bundle.CreateBundleFunction
 }
 
 // RegisterCreateBundleFunctions registers create bundle functions within the transport instance.
@@ -41,6 +44,12 @@ func (syncer *ManagedClustersDBSyncer) RegisterCreateBundleFunctions(transportIn
 		CreateBundleFunc: syncer.createBundleFunc,
 		Predicate:        func() bool { return true }, // always get managed clusters bundles
 	})
+
+	transportInstance.Register(&transport.BundleRegistration{
+		MsgID:            constants.DeltaManagedClustersMsgKey,
+		CreateBundleFunc: syncer.createDeltaBundleFunc,
+		Predicate:        func() bool { return true }, // always get delta managed clusters bundles
+	})
 }
 
 // RegisterBundleHandlerFunctions registers bundle handler functions within the conflation manager.
@@ -51,14 +60,25 @@ func (syncer *ManagedClustersDBSyncer) RegisterCreateBundleFunctions(transportIn
 // for the objects that appear in both, need to check if something has changed using resourceVersion field comparison
 // and if the object was changed, update the db with the current object.
 func (syncer *ManagedClustersDBSyncer) RegisterBundleHandlerFunctions(conflationManager *conflator.ConflationManager) {
+	managedClustersBundleType := helpers.GetBundleType(syncer.createBundleFunc())
+
 	conflationManager.Register(conflator.NewConflationRegistration(
 		conflator.ManagedClustersPriority,
 		status.CompleteStateMode,
-		helpers.GetBundleType(syncer.createBundleFunc()),
+		managedClustersBundleType,
 		func(ctx context.Context, bundle bundle.Bundle, dbClient db.StatusTransportBridgeDB) error {
 			return syncer.handleManagedClustersBundle(ctx, bundle, dbClient)
 		},
 	))
+
+	conflationManager.Register(conflator.NewConflationRegistration(
+		conflator.DeltaManagedClustersPriority,
+		status.DeltaStateMode,
+		helpers.GetBundleType(syncer.createDeltaBundleFunc()),
+		func(ctx context.Context, bundle bundle.Bundle, dbClient db.StatusTransportBridgeDB) error {
+			return syncer.handleDeltaManagedClustersBundle(ctx, bundle, dbClient)
+		}).WithDependency(dependency.NewDependency(managedClustersBundleType, dependency.ExactMatch)))
+	// delta managed clusters depend on managed clusters. should be processed only when there is an exact match
 }
 
 func (syncer *ManagedClustersDBSyncer) handleManagedClustersBundle(ctx context.Context, bundle bundle.Bundle,
@@ -72,6 +92,16 @@ func (syncer *ManagedClustersDBSyncer) handleManagedClustersBundle(ctx context.C
 	if err != nil {
 		return fmt.Errorf("failed fetching leaf hub managed clusters from db - %w", err)
 	}
+
+	clustersWithInventory, err := dbClient.GetManagedClusterInventoryNames(ctx, db.StatusSchema,
+		db.ManagedClusterInventoryTableName, leafHubName)
+	if err != nil {
+		return fmt.Errorf("failed fetching leaf hub managed cluster inventory from db - %w", err)
+	}
+
+	// the inventory is updated for the changed clusters, and for the clusters without inventory yet
+	inventories := make([]*db.ManagedClusterInventory, 0)
+
 	// batch is per leaf hub, therefore no need to specify leafHubName in Insert/Update/Delete
 	batchBuilder := dbClient.NewManagedClustersBatchBuilder(db.StatusSchema,
 		db.ManagedClustersTableName, leafHubName)
@@ -82,19 +112,29 @@ func (syncer *ManagedClustersDBSyncer) handleManagedClustersBundle(ctx context.C
 			continue // do not handle objects other than ManagedCluster
 		}
 
+		_, clusterHasInventory := clustersWithInventory[cluster.GetName()]
+		delete(clustersWithInventory, cluster.GetName())
+
 		resourceVersionFromDB, clusterExistsInDB := clustersFromDB[cluster.GetName()]
 		if !clusterExistsInDB { // cluster not found in the db table
 			batchBuilder.Insert(cluster, db.ErrorNone)
+			inventories = append(inventories, db.NewManagedClusterInventory(cluster))
+
 			continue
 		}
 
 		delete(clustersFromDB, cluster.GetName()) // if we got here, cluster exists both in db and in received bundle.
 
 		if cluster.GetResourceVersion() == resourceVersionFromDB {
+			if !clusterHasInventory {
+				inventories = append(inventories, db.NewManagedClusterInventory(cluster))
+			}
+
 			continue // update cluster in db only if what we got is a different (newer) version of the resource
 		}
 
 		batchBuilder.Update(cluster.GetName(), cluster)
+		inventories = append(inventories, db.NewManagedClusterInventory(cluster))
 	}
 	// delete clusters that in the db but were not sent in the bundle (leaf hub sends only living resources).
 	for clusterName := range clustersFromDB {
@@ -105,7 +145,86 @@ func (syncer *ManagedClustersDBSyncer) handleManagedClustersBundle(ctx context.C
 		return fmt.Errorf("failed to perform batch - %w", err)
 	}
 
+	// the remaining clusters with inventory were not sent in the bundle
+	deletedClusterNames := make([]string, 0, len(clustersWithInventory))
+	for clusterName := range clustersWithInventory {
+		deletedClusterNames = append(deletedClusterNames, clusterName)
+	}
+
+	if err := dbClient.UpdateManagedClusterInventory(ctx, db.StatusSchema, db.ManagedClusterInventoryTableName,
+		leafHubName, inventories, deletedClusterNames); err != nil {
+		return fmt.Errorf("failed to update managed cluster inventory - %w", err)
+	}
+
 	logBundleHandlingMessage(syncer.log, bundle, finishBundleHandlingMessage)
 
 	return nil
 }
+
+// if we got to the handler function, then the bundle pre-conditions were satisfied (the version is newer than what
+// was already handled and base bundle was already handled successfully).
+// the delta bundle holds only the clusters that changed and the clusters that were deleted since the base bundle,
+// therefore only their rows are updated, the other clusters of the leaf hub are left as is.
+func (syncer *ManagedClustersDBSyncer) handleDeltaManagedClustersBundle(ctx context.Context, receivedBundle bundle.Bundle,
+	dbClient db.ManagedClustersStatusDB,
+) error {
+	logBundleHandlingMessage(syncer.log, receivedBundle, startBundleHandlingMessage)
+	leafHubName := receivedBundle.GetLeafHubName()
+
+	deltaBundle, ok := receivedBundle.(*bundle.DeltaManagedClustersBundle)
+	if !ok {
+		return fmt.Errorf("received invalid bundle type %s, expecting DeltaManagedClustersBundle",
+			helpers.GetBundleType(receivedBundle))
+	}
+
+	clustersFromDB, err := dbClient.GetManagedClustersByLeafHub(ctx, db.StatusSchema, db.ManagedClustersTableName,
+		leafHubName)
+	if err != nil {
+		return fmt.Errorf("failed fetching leaf hub managed clusters from db - %w", err)
+	}
+
+	inventories := make([]*db.ManagedClusterInventory, 0, len(deltaBundle.Objects))
+	deletedClusterNames := make([]string, 0, len(deltaBundle.DeletedClusters))
+
+	// batch is per leaf hub, therefore no need to specify leafHubName in Insert/Update/Delete
+	batchBuilder := dbClient.NewManagedClustersBatchBuilder(db.StatusSchema,
+		db.ManagedClustersTableName, leafHubName)
+
+	for _, cluster := range deltaBundle.Objects {
+		resourceVersionFromDB, clusterExistsInDB := clustersFromDB[cluster.GetName()]
+		if !clusterExistsInDB { // cluster not found in the db table
+			batchBuilder.Insert(cluster, db.ErrorNone)
+			inventories = append(inventories, db.NewManagedClusterInventory(cluster))
+
+			continue
+		}
+
+		if cluster.GetResourceVersion() == resourceVersionFromDB {
+			continue // update cluster in db only if what we got is a different (newer) version of the resource
+		}
+
+		batchBuilder.Update(cluster.GetName(), cluster)
+		inventories = append(inventories, db.NewManagedClusterInventory(cluster))
+	}
+
+	for _, clusterName := range deltaBundle.DeletedClusters {
+		if _, clusterExistsInDB := clustersFromDB[clusterName]; clusterExistsInDB {
+			batchBuilder.Delete(clusterName)
+		}
+
+		deletedClusterNames = append(deletedClusterNames, clusterName)
+	}
+	// batch contains at most number of statements as the number of clusters in the delta bundle
+	if err := dbClient.SendBatch(ctx, batchBuilder.Build()); err != nil {
+		return fmt.Errorf("failed to perform batch - %w", err)
+	}
+
+	if err := dbClient.UpdateManagedClusterInventory(ctx, db.StatusSchema, db.ManagedClusterInventoryTableName,
+		leafHubName, inventories, deletedClusterNames); err != nil {
+		return fmt.Errorf("failed to update managed cluster inventory - %w", err)
+	}
+
+	logBundleHandlingMessage(syncer.log, receivedBundle, finishBundleHandlingMessage)
+
+	return nil
+}
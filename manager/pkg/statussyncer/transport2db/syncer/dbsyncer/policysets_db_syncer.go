@@ -0,0 +1,28 @@
+package dbsyncer
+
+import (
+	"github.com/go-logr/logr"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/bundle"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/conflator"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/db"
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+// NewPolicySetsDBSyncer creates a new instance of genericDBSyncer to sync policy sets.
+func NewPolicySetsDBSyncer(log logr.Logger) DBSyncer {
+	dbSyncer := &genericDBSyncer{
+		log:              log,
+		transportMsgKey:  constants.PolicySetMsgKey,
+		dbSchema:         db.StatusSchema,
+		dbTableName:      db.PolicySetsTableName,
+		createBundleFunc: bundle.NewPolicySetsBundle,
+		bundlePriority:   conflator.PolicySetPriority,
+		bundleSyncMode:   status.CompleteStateMode,
+	}
+
+	log.Info("initialized policy sets db syncer")
+
+	return dbSyncer
+}
@@ -0,0 +1,90 @@
+package dbsyncer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/bundle"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/conflator"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/db"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/helpers"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/transport"
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/status"
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+// NewSpecApplyResultsDBSyncer creates a new instance of SpecApplyResultsDBSyncer.
+func NewSpecApplyResultsDBSyncer(log logr.Logger) DBSyncer {
+	dbSyncer := &SpecApplyResultsDBSyncer{
+		log:              log,
+		createBundleFunc: bundle.NewSpecApplyResultsBundle,
+	}
+
+	log.Info("initialized spec apply results db syncer")
+
+	return dbSyncer
+}
+
+// SpecApplyResultsDBSyncer implements spec apply results transport to db sync.
+type SpecApplyResultsDBSyncer struct {
+	log              logr.Logger
+	createBundleFunc bundle.CreateBundleFunction
+}
+
+// RegisterCreateBundleFunctions registers create bundle functions within the transport instance.
+func (syncer *SpecApplyResultsDBSyncer) RegisterCreateBundleFunctions(transportInstance transport.Transport) {
+	transportInstance.Register(&transport.BundleRegistration{
+		MsgID:            constants.SpecApplyResultsMsgKey,
+		CreateBundleFunc: syncer.createBundleFunc,
+		Predicate:        func() bool { return true }, // always get spec apply results bundles
+	})
+}
+
+// RegisterBundleHandlerFunctions registers bundle handler functions within the conflation manager.
+func (syncer *SpecApplyResultsDBSyncer) RegisterBundleHandlerFunctions(
+	conflationManager *conflator.ConflationManager,
+) {
+	conflationManager.Register(conflator.NewConflationRegistration(
+		conflator.SpecApplyResultsPriority,
+		status.CompleteStateMode,
+		helpers.GetBundleType(syncer.createBundleFunc()),
+		func(ctx context.Context, bundle bundle.Bundle, dbClient db.StatusTransportBridgeDB) error {
+			return syncer.handleSpecApplyResultsBundle(ctx, bundle, dbClient)
+		},
+	))
+}
+
+func (syncer *SpecApplyResultsDBSyncer) handleSpecApplyResultsBundle(ctx context.Context, receivedBundle bundle.Bundle,
+	dbClient db.SpecApplyResultsDB,
+) error {
+	logBundleHandlingMessage(syncer.log, receivedBundle, startBundleHandlingMessage)
+	leafHubName := receivedBundle.GetLeafHubName()
+
+	results := make([]*status.SpecApplyResult, 0, len(receivedBundle.GetObjects()))
+	for _, object := range receivedBundle.GetObjects() {
+		result, ok := object.(*status.SpecApplyResult)
+		if !ok || result.OriginOwnerReferenceUID == "" {
+			continue // do not handle objects other than SpecApplyResult with the origin owner reference
+		}
+
+		results = append(results, result)
+	}
+
+	if err := dbClient.UpdateSpecApplyResults(ctx, db.StatusSchema, db.SpecApplyResultsTableName, leafHubName,
+		results); err != nil {
+		return fmt.Errorf("failed handling spec apply results bundle of leaf hub '%s' - %w", leafHubName, err)
+	}
+
+	if specApplyResultsBundle, ok := receivedBundle.(*bundle.SpecApplyResultsBundle); ok {
+		if err := dbClient.UpdateSpecFullStateRequests(ctx, db.StatusSchema, db.SpecFullStateRequestsTableName,
+			leafHubName, specApplyResultsBundle.FullStateRequests); err != nil {
+			return fmt.Errorf("failed handling full state requests of leaf hub '%s' - %w", leafHubName, err)
+		}
+	}
+
+	logBundleHandlingMessage(syncer.log, receivedBundle, finishBundleHandlingMessage)
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package syncer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/db"
+	"github.com/stolostron/multicluster-global-hub/manager/pkg/statussyncer/transport2db/syncer/dbsyncer"
+)
+
+// eventsRetentionInterval is the interval of creating and dropping the daily partitions of the events table.
+const eventsRetentionInterval = time.Hour
+
+// AddEventsRetention adds the runnable that keeps the daily partitions of the events table, the partitions are
+// created ahead of the events and dropped once they're older than the retention.
+func AddEventsRetention(mgr ctrl.Manager, eventsDB db.EventsDB, retention time.Duration) error {
+	if retention < dbsyncer.MaxEventAge {
+		return fmt.Errorf("events retention %s is shorter than the max event age %s", retention,
+			dbsyncer.MaxEventAge)
+	}
+
+	if err := mgr.Add(&eventsRetention{
+		log:       ctrl.Log.WithName("events-retention"),
+		eventsDB:  eventsDB,
+		retention: retention,
+	}); err != nil {
+		return fmt.Errorf("failed to add events retention - %w", err)
+	}
+
+	return nil
+}
+
+type eventsRetention struct {
+	log       logr.Logger
+	eventsDB  db.EventsDB
+	retention time.Duration
+}
+
+// Start creates and drops the partitions of the events table periodically.
+func (retention *eventsRetention) Start(ctx context.Context) error {
+	retention.enforce(ctx)
+
+	ticker := time.NewTicker(eventsRetentionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			retention.enforce(ctx)
+		}
+	}
+}
+
+func (retention *eventsRetention) enforce(ctx context.Context) {
+	now := time.Now()
+
+	// the partitions of the stored events and of the next day
+	if err := retention.eventsDB.EnsureEventsPartitions(ctx, db.StatusSchema, db.EventsTableName,
+		now.Add(-dbsyncer.MaxEventAge), now.AddDate(0, 0, 1)); err != nil {
+		retention.log.Error(err, "failed to create the partitions of the events")
+	}
+
+	if err := retention.eventsDB.DropEventsPartitions(ctx, db.StatusSchema, db.EventsTableName,
+		now.Add(-retention.retention)); err != nil {
+		retention.log.Error(err, "failed to drop the expired partitions of the events")
+	}
+}
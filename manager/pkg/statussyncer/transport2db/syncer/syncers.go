@@ -38,14 +38,22 @@ func AddTransport2DBSyncers(mgr ctrl.Manager, dbWorkerPool *workerpool.DBWorkerP
 	// register db syncers create bundle functions within transport and handler functions within dispatcher
 	dbSyncers := []dbsyncer.DBSyncer{
 		dbsyncer.NewManagedClustersDBSyncer(ctrl.Log.WithName("managed-clusters-db-syncer")),
+		dbsyncer.NewManagedClusterAddOnsDBSyncer(ctrl.Log.WithName("managed-cluster-addons-db-syncer")),
+		dbsyncer.NewClusterLifecycleDBSyncer(ctrl.Log.WithName("cluster-lifecycle-db-syncer")),
 		dbsyncer.NewPoliciesDBSyncer(ctrl.Log.WithName("policies-db-syncer"), config),
+		dbsyncer.NewComplianceDetailsDBSyncer(ctrl.Log.WithName("compliance-details-db-syncer"), config),
 		dbsyncer.NewPlacementRulesDBSyncer(ctrl.Log.WithName("placement-rules-db-syncer")),
 		dbsyncer.NewPlacementsDBSyncer(ctrl.Log.WithName("placements-db-syncer")),
 		dbsyncer.NewPlacementDecisionsDBSyncer(ctrl.Log.WithName("placement-decisions-db-syncer")),
+		dbsyncer.NewPolicySetsDBSyncer(ctrl.Log.WithName("policy-sets-db-syncer")),
 		dbsyncer.NewSubscriptionStatusesDBSyncer(ctrl.Log.WithName("subscription-statuses-db-syncer")),
 		dbsyncer.NewSubscriptionReportsDBSyncer(ctrl.Log.WithName("subscription-reports-db-syncer")),
+		dbsyncer.NewGitOpsDBSyncer(ctrl.Log.WithName("gitops-db-syncer")),
 		dbsyncer.NewLocalSpecDBSyncer(ctrl.Log.WithName("local-spec-db-syncer"), config),
+		dbsyncer.NewLocalPlacementDecisionsDBSyncer(ctrl.Log.WithName("local-placement-decisions-db-syncer"), config),
 		dbsyncer.NewControlInfoDBSyncer(ctrl.Log.WithName("control-info-db-syncer")),
+		dbsyncer.NewSpecApplyResultsDBSyncer(ctrl.Log.WithName("spec-apply-results-db-syncer")),
+		dbsyncer.NewEventsDBSyncer(ctrl.Log.WithName("events-db-syncer")),
 	}
 
 	for _, dbsyncerObj := range dbSyncers {
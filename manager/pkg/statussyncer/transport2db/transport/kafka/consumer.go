@@ -31,6 +31,8 @@ var errMessageIDWrongFormat = errors.New("message ID format is bad")
 type KafkaConsumerConfig struct {
 	ConsumerID    string
 	ConsumerTopic string
+	SaslUser      string
+	SaslPassword  string
 }
 
 // NewConsumer creates a new instance of Consumer.
@@ -51,6 +53,11 @@ func NewConsumer(committerInterval time.Duration, bootstrapServer, SslCa string,
 		return nil, err
 	}
 
+	if err := kafkaclient.SetSASLCredential(kafkaConfigMap, consumerConfig.SaslUser,
+		consumerConfig.SaslPassword); err != nil {
+		return nil, err
+	}
+
 	msgChan := make(chan *kafka.Message)
 	kafkaConsumer, err := kafkaconsumer.NewKafkaConsumer(kafkaConfigMap, msgChan, log)
 	if err != nil {
@@ -32,6 +32,19 @@ const (
 	Minimal AggregationLevel = "minimal"
 )
 
+// ComplianceDetailsLevel specifies the details of the policy compliance the leaf hubs send besides the compliance
+// state of the clusters
+// +kubebuilder:validation:Enum=none;violations
+type ComplianceDetailsLevel string
+
+const (
+	// ComplianceDetailsNone is a ComplianceDetailsLevel, only the compliance state of the clusters is sent
+	ComplianceDetailsNone ComplianceDetailsLevel = "none"
+	// ComplianceDetailsViolations is a ComplianceDetailsLevel, the violation messages of the non compliant
+	// clusters are sent too
+	ComplianceDetailsViolations ComplianceDetailsLevel = "violations"
+)
+
 // DataLayerType specifies the type of data layer that global hub stores and transports the data.
 // +kubebuilder:validation:Enum:="native";"largeScale"
 type DataLayerType string
@@ -46,6 +59,8 @@ const (
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=mgh
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The overall phase of the MulticlusterGlobalHub"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // MulticlusterGlobalHub is the Schema for the multiclusterglobalhubs API
 type MulticlusterGlobalHub struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -61,6 +76,15 @@ type MulticlusterGlobalHubSpec struct {
 	AggregationLevel AggregationLevel `json:"aggregationLevel,omitempty"` // full or minimal
 	// +kubebuilder:default:=true
 	EnableLocalPolicies bool `json:"enableLocalPolicies,omitempty"`
+	// ComplianceDetailsLevel specifies whether the leaf hubs send the violation messages of the non compliant
+	// clusters, the messages are truncated to keep the status bundles small. It requires the full aggregation level.
+	// +kubebuilder:default:=none
+	// +optional
+	ComplianceDetailsLevel ComplianceDetailsLevel `json:"complianceDetailsLevel,omitempty"` // none or violations
+	// StatusProjections select the fields of the objects the leaf hubs send in the status bundles, to keep the fields
+	// the global hub doesn't read off the transport. The fields the global hub reads can't be denied.
+	// +optional
+	StatusProjections []StatusProjection `json:"statusProjections,omitempty"`
 	// Pull policy of the multicluster global hub images
 	// +optional
 	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
@@ -73,6 +97,12 @@ type MulticlusterGlobalHubSpec struct {
 	// Tolerations causes all components to tolerate any taints.
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Manager is the deployment config of the multicluster global hub manager
+	// +optional
+	Manager *ComponentConfig `json:"manager,omitempty"`
+	// Agent is the deployment config of the multicluster global hub agent on the regional hubs
+	// +optional
+	Agent *ComponentConfig `json:"agent,omitempty"`
 	// DataLayer can be configured to use a different data layer.
 	// native: use the native data layer (default).
 	// largeScale: large scale data layer served by kafka and postgres.
@@ -80,6 +110,21 @@ type MulticlusterGlobalHubSpec struct {
 	DataLayer *DataLayerConfig `json:"dataLayer"`
 }
 
+// StatusProjection selects the fields of the objects of a status bundle type, the fields are selected by dot
+// separated paths, a segment ending with [] selects the field in each item of a list, e.g.
+// status.conditions[].message
+type StatusProjection struct {
+	// BundleType is the type of the status bundle, e.g. ManagedClusters, PlacementDecision or SubscriptionStatus
+	// +kubebuilder:validation:Required
+	BundleType string `json:"bundleType"`
+	// Allow are the paths of the fields that are sent, all the fields are sent if it's empty
+	// +optional
+	Allow []string `json:"allow,omitempty"`
+	// Deny are the paths of the fields that are not sent
+	// +optional
+	Deny []string `json:"deny,omitempty"`
+}
+
 // DataLayerConfig is a discriminated union of data layer specific configuration.
 // +union
 type DataLayerConfig struct {
@@ -103,14 +148,87 @@ type NativeConfig struct{}
 
 // LargeScaleConfig is the config of large scale data layer
 type LargeScaleConfig struct {
+	// Managed asks the operator to provision the kafka and postgres clusters through the strimzi and
+	// crunchy postgres operators, and to generate the transport and storage secrets for them.
+	// The kafka and postgres references are optional in managed mode, they are used as the names
+	// of the generated secrets if they are specified.
+	// +optional
+	Managed *ManagedConfig `json:"managed,omitempty"`
 	// +optional
 	Kafka corev1.LocalObjectReference `json:"kafka,omitempty"`
 	// +optional
 	Postgres corev1.LocalObjectReference `json:"postgres,omitempty"`
 }
 
+// ManagedConfig is the config of the kafka and postgres clusters provisioned by the operator
+type ManagedConfig struct {
+	// Kafka is the deployment config of the kafka brokers, the replicas (3 by default) is also used for the
+	// zookeeper nodes, the affinity, priorityClassName and podAnnotations are applied to the zookeeper pods too,
+	// the args are not supported and only the name and value of the env are used.
+	// +optional
+	Kafka *ComponentConfig `json:"kafka,omitempty"`
+	// Postgres is the deployment config of the postgres instances, the args and env are not supported.
+	// +optional
+	Postgres *ComponentConfig `json:"postgres,omitempty"`
+	// KafkaStorageSize is the size of the persistent volume for each kafka broker,
+	// the kafka cluster uses ephemeral storage if it is empty
+	// +optional
+	KafkaStorageSize string `json:"kafkaStorageSize,omitempty"`
+	// PostgresStorageSize is the size of the persistent volume for the postgres instance
+	// +kubebuilder:default:="20Gi"
+	// +optional
+	PostgresStorageSize string `json:"postgresStorageSize,omitempty"`
+	// StorageClassName is the storage class of the persistent volumes, the default storage class is used if empty
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+}
+
+// ComponentConfig is the deployment config of a multicluster global hub component
+type ComponentConfig struct {
+	// Replicas is the number of the component pods
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Resources is the compute resources (requests/limits) of the component container
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Affinity is the scheduling constraints of the component pods
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// PriorityClassName is the priority class of the component pods
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// PodAnnotations are the extra annotations of the component pods
+	// +optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+	// Args are the extra arguments appended to the component container
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// Env are the extra environment variables of the component container
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// GlobalHubPhase is the overall phase of the MulticlusterGlobalHub
+// +kubebuilder:validation:Enum:="Installing";"Running";"Degraded";"Uninstalling"
+type GlobalHubPhase string
+
+const (
+	// GlobalHubInstalling means the global hub components are being installed and aren't ready yet
+	GlobalHubInstalling GlobalHubPhase = "Installing"
+	// GlobalHubRunning means all the global hub components are ready and the regional hubs are healthy
+	GlobalHubRunning GlobalHubPhase = "Running"
+	// GlobalHubDegraded means some of the global hub components or regional hubs are unhealthy
+	GlobalHubDegraded GlobalHubPhase = "Degraded"
+	// GlobalHubUninstalling means the global hub is being deleted and the resources are being cleaned up
+	GlobalHubUninstalling GlobalHubPhase = "Uninstalling"
+)
+
 // MulticlusterGlobalHubStatus defines the observed state of MulticlusterGlobalHub
 type MulticlusterGlobalHubStatus struct {
+	// Phase is the overall phase of the MulticlusterGlobalHub, it's derived from the conditions
+	// +optional
+	Phase GlobalHubPhase `json:"phase,omitempty"`
 	// MulticlusterGlobalHubStatus defines the observed state of MulticlusterGlobalHub
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
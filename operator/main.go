@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
 
@@ -51,7 +52,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	operatorv1alpha2 "github.com/stolostron/multicluster-global-hub/operator/apis/v1alpha2"
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/config"
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/constants"
 	hubofhubscontrollers "github.com/stolostron/multicluster-global-hub/operator/pkg/controllers/hubofhubs"
+	mghwebhook "github.com/stolostron/multicluster-global-hub/operator/pkg/webhook"
 	commonconstants "github.com/stolostron/multicluster-global-hub/pkg/constants"
 )
 
@@ -87,6 +91,7 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var webhookCertDir string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080",
 		"The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081",
@@ -94,6 +99,8 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", constants.DefaultWebhookCertDir,
+		"The directory to write the generated serving certificate of the webhook server.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -156,7 +163,8 @@ func main() {
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
-		Port:                   9443,
+		Port:                   constants.DefaultWebhookPort,
+		CertDir:                webhookCertDir,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "549a8919.open-cluster-management.io",
@@ -184,6 +192,29 @@ func main() {
 	}
 	//+kubebuilder:scaffold:builder
 
+	// the webhook is disabled when running the operator out of the cluster, e.g. make run
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		certManager := &mghwebhook.CertManager{
+			KubeClient: kubeClient,
+			Namespace:  config.GetDefaultNamespace(),
+			CertDir:    webhookCertDir,
+			Log:        ctrl.Log.WithName("webhook-cert-manager"),
+		}
+		// the serving certificate must be ready before the webhook server is started
+		if err := certManager.EnsureCertificate(context.Background()); err != nil {
+			setupLog.Error(err, "unable to ensure webhook certificate")
+			os.Exit(1)
+		}
+		if err := mgr.Add(certManager); err != nil {
+			setupLog.Error(err, "unable to add webhook certificate manager")
+			os.Exit(1)
+		}
+		if err := mghwebhook.SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "MulticlusterGlobalHub")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -19,8 +19,12 @@ package condition
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -52,13 +56,57 @@ const (
 	CONDITION_MESSAGE_TRANSPORT_INIT = "Transport has been initialized"
 )
 
-// NOTE: the status of ManagerDeployed can only be True; otherwise there is no condition
+// NOTE: the status of KafkaProvisioned can be True or False, it only exists when the data layer is managed
+const (
+	CONDITION_TYPE_KAFKA_PROVISION       = "KafkaProvisioned"
+	CONDITION_REASON_KAFKA_PROVISION     = "KafkaProvisioned"
+	CONDITION_MESSAGE_KAFKA_PROVISION    = "Kafka cluster has been provisioned and the transport secret is generated"
+	CONDITION_REASON_KAFKA_PROVISIONING  = "KafkaProvisioning"
+	CONDITION_MESSAGE_KAFKA_PROVISIONING = "Waiting for the kafka cluster to be ready"
+)
+
+// NOTE: the status of PostgresProvisioned can be True or False, it only exists when the data layer is managed
+const (
+	CONDITION_TYPE_POSTGRES_PROVISION       = "PostgresProvisioned"
+	CONDITION_REASON_POSTGRES_PROVISION     = "PostgresProvisioned"
+	CONDITION_MESSAGE_POSTGRES_PROVISION    = "Postgres cluster has been provisioned and the storage secret is generated"
+	CONDITION_REASON_POSTGRES_PROVISIONING  = "PostgresProvisioning"
+	CONDITION_MESSAGE_POSTGRES_PROVISIONING = "Waiting for the postgres cluster to be ready"
+)
+
+// NOTE: the status of DatabaseReachable can be True or False, the message contains the schema version
+const (
+	CONDITION_TYPE_DATABASE_REACHABLE      = "DatabaseReachable"
+	CONDITION_REASON_DATABASE_REACHABLE    = "DatabaseReachable"
+	CONDITION_MESSAGE_DATABASE_REACHABLE   = "Database is reachable, schema version: %s"
+	CONDITION_REASON_DATABASE_UNREACHABLE  = "DatabaseUnreachable"
+	CONDITION_MESSAGE_DATABASE_UNREACHABLE = "Database is unreachable: %s"
+)
+
+// NOTE: the status of KafkaReachable can be True or False
+const (
+	CONDITION_TYPE_KAFKA_REACHABLE      = "KafkaReachable"
+	CONDITION_REASON_KAFKA_REACHABLE    = "KafkaReachable"
+	CONDITION_MESSAGE_KAFKA_REACHABLE   = "Kafka bootstrap server is reachable"
+	CONDITION_REASON_KAFKA_UNREACHABLE  = "KafkaUnreachable"
+	CONDITION_MESSAGE_KAFKA_UNREACHABLE = "Kafka bootstrap server is unreachable: %s"
+)
+
+// NOTE: the status of ManagerDeployed can be True or False, it means the manager resources are applied
 const (
 	CONDITION_TYPE_MANAGER_DEPLOY    = "ManagerDeployed"
 	CONDITION_REASON_MANAGER_DEPLOY  = "ManagerDeployed"
 	CONDITION_MESSAGE_MANAGER_DEPLOY = "Multicluster Global Hub Manager Deployed"
 )
 
+// NOTE: the status of ManagerReady can be True or False, it means all the replicas of the manager are available
+const (
+	CONDITION_TYPE_MANAGER_READY      = "ManagerReady"
+	CONDITION_REASON_MANAGER_READY    = "ManagerReady"
+	CONDITION_REASON_MANAGER_NOTREADY = "ManagerNotReady"
+	CONDITION_MESSAGE_MANAGER_READY   = "Multicluster Global Hub Manager has %d/%d available replicas"
+)
+
 // NOTE: the status of LeafHubDeployed can only be True; otherwise there is no condition
 const (
 	CONDITION_TYPE_LEAFHUB_DEPLOY           = "LeafHubDeployed"
@@ -68,40 +116,117 @@ const (
 	CONDITION_MESSAGE_LEAFHUB_DEPLOY_FAILED = "Leaf Hub Deployed FAILED"
 )
 
-// SetConditionFunc is function type that receives the concrete condition method
-type SetConditionFunc func(ctx context.Context, c client.Client,
-	mgh *operatorv1alpha2.MulticlusterGlobalHub,
-	status metav1.ConditionStatus) error
+// NOTE: the status of RegionalHubsHealthy can be True or False, the message contains the healthy and total count
+const (
+	CONDITION_TYPE_REGIONAL_HUBS_HEALTHY      = "RegionalHubsHealthy"
+	CONDITION_REASON_REGIONAL_HUBS_HEALTHY    = "RegionalHubsHealthy"
+	CONDITION_REASON_REGIONAL_HUBS_UNHEALTHY  = "RegionalHubsUnhealthy"
+	CONDITION_MESSAGE_REGIONAL_HUBS_HEALTHY   = "%d/%d regional hubs are healthy"
+	CONDITION_MESSAGE_REGIONAL_HUBS_UNHEALTHY = "%d/%d regional hubs are healthy, unhealthy: %s"
+	CONDITION_MESSAGE_REGIONAL_HUBS_MORE      = "%s and %d more"
+)
+
+// maxListedUnhealthyHubs is the maximum number of the unhealthy regional hubs listed in the condition message
+const maxListedUnhealthyHubs = 10
+
+// NOTE: the status of CleanupCompleted can be True or False, it only exists when the MGH is deleting
+const (
+	CONDITION_TYPE_CLEANUP             = "CleanupCompleted"
+	CONDITION_REASON_CLEANUP           = "CleanupCompleted"
+	CONDITION_MESSAGE_CLEANUP          = "All the resources are cleaned up"
+	CONDITION_REASON_CLEANUP_PROGRESS  = "CleanupInProgress"
+	CONDITION_MESSAGE_CLEANUP_PROGRESS = "Cleaning up %s (%d/%d)"
+	CONDITION_REASON_CLEANUP_FAILED    = "CleanupFailed"
+	CONDITION_MESSAGE_CLEANUP_FAILED   = "Failed to clean up %s: %s"
+)
 
-func FailToSetConditionError(condition string, err error) error {
-	return fmt.Errorf("failed to set condition(%s): %w",
-		condition, err)
+// readyConditionTypes are the conditions that must be True for the global hub to be running
+var readyConditionTypes = []string{
+	CONDITION_TYPE_DATABASE_REACHABLE,
+	CONDITION_TYPE_TRANSPORT_INIT,
+	CONDITION_TYPE_KAFKA_REACHABLE,
+	CONDITION_TYPE_MANAGER_READY,
 }
 
-func SetConditionDatabaseInit(ctx context.Context, c client.Client, mgh *operatorv1alpha2.MulticlusterGlobalHub,
-	status metav1.ConditionStatus,
-) error {
-	return SetCondition(ctx, c, mgh, CONDITION_TYPE_DATABASE_INIT, status,
+// SetConditionFunc is function type that receives the concrete condition method
+type SetConditionFunc func(mgh *operatorv1alpha2.MulticlusterGlobalHub, status metav1.ConditionStatus)
+
+func SetConditionDatabaseInit(mgh *operatorv1alpha2.MulticlusterGlobalHub, status metav1.ConditionStatus) {
+	SetCondition(mgh, CONDITION_TYPE_DATABASE_INIT, status,
 		CONDITION_REASON_DATABASE_INIT, CONDITION_MESSAGE_DATABASE_INIT)
 }
 
-func SetConditionTransportInit(ctx context.Context, c client.Client, mgh *operatorv1alpha2.MulticlusterGlobalHub,
-	status metav1.ConditionStatus,
-) error {
-	return SetCondition(ctx, c, mgh, CONDITION_TYPE_TRANSPORT_INIT, status,
+func SetConditionTransportInit(mgh *operatorv1alpha2.MulticlusterGlobalHub, status metav1.ConditionStatus) {
+	SetCondition(mgh, CONDITION_TYPE_TRANSPORT_INIT, status,
 		CONDITION_REASON_TRANSPORT_INIT, CONDITION_MESSAGE_TRANSPORT_INIT)
 }
 
-func SetConditionManagerDeployed(ctx context.Context, c client.Client, mgh *operatorv1alpha2.MulticlusterGlobalHub,
-	status metav1.ConditionStatus,
-) error {
-	return SetCondition(ctx, c, mgh, CONDITION_TYPE_MANAGER_DEPLOY, status,
+func SetConditionKafkaProvisioned(mgh *operatorv1alpha2.MulticlusterGlobalHub, status metav1.ConditionStatus) {
+	reason := CONDITION_REASON_KAFKA_PROVISION
+	message := CONDITION_MESSAGE_KAFKA_PROVISION
+	if status == CONDITION_STATUS_FALSE {
+		reason = CONDITION_REASON_KAFKA_PROVISIONING
+		message = CONDITION_MESSAGE_KAFKA_PROVISIONING
+	}
+
+	SetCondition(mgh, CONDITION_TYPE_KAFKA_PROVISION, status, reason, message)
+}
+
+func SetConditionPostgresProvisioned(mgh *operatorv1alpha2.MulticlusterGlobalHub, status metav1.ConditionStatus) {
+	reason := CONDITION_REASON_POSTGRES_PROVISION
+	message := CONDITION_MESSAGE_POSTGRES_PROVISION
+	if status == CONDITION_STATUS_FALSE {
+		reason = CONDITION_REASON_POSTGRES_PROVISIONING
+		message = CONDITION_MESSAGE_POSTGRES_PROVISIONING
+	}
+
+	SetCondition(mgh, CONDITION_TYPE_POSTGRES_PROVISION, status, reason, message)
+}
+
+func SetConditionDatabaseReachable(mgh *operatorv1alpha2.MulticlusterGlobalHub, schemaVersion string,
+	reachableErr error,
+) {
+	if reachableErr != nil {
+		SetCondition(mgh, CONDITION_TYPE_DATABASE_REACHABLE, CONDITION_STATUS_FALSE,
+			CONDITION_REASON_DATABASE_UNREACHABLE, fmt.Sprintf(CONDITION_MESSAGE_DATABASE_UNREACHABLE, reachableErr))
+		return
+	}
+
+	SetCondition(mgh, CONDITION_TYPE_DATABASE_REACHABLE, CONDITION_STATUS_TRUE,
+		CONDITION_REASON_DATABASE_REACHABLE, fmt.Sprintf(CONDITION_MESSAGE_DATABASE_REACHABLE, schemaVersion))
+}
+
+func SetConditionKafkaReachable(mgh *operatorv1alpha2.MulticlusterGlobalHub, reachableErr error) {
+	if reachableErr != nil {
+		SetCondition(mgh, CONDITION_TYPE_KAFKA_REACHABLE, CONDITION_STATUS_FALSE,
+			CONDITION_REASON_KAFKA_UNREACHABLE, fmt.Sprintf(CONDITION_MESSAGE_KAFKA_UNREACHABLE, reachableErr))
+		return
+	}
+
+	SetCondition(mgh, CONDITION_TYPE_KAFKA_REACHABLE, CONDITION_STATUS_TRUE,
+		CONDITION_REASON_KAFKA_REACHABLE, CONDITION_MESSAGE_KAFKA_REACHABLE)
+}
+
+func SetConditionManagerDeployed(mgh *operatorv1alpha2.MulticlusterGlobalHub, status metav1.ConditionStatus) {
+	SetCondition(mgh, CONDITION_TYPE_MANAGER_DEPLOY, status,
 		CONDITION_REASON_MANAGER_DEPLOY, CONDITION_MESSAGE_MANAGER_DEPLOY)
 }
 
-func SetConditionLeafHubDeployed(ctx context.Context, c client.Client, mgh *operatorv1alpha2.MulticlusterGlobalHub,
-	clusterName string, status metav1.ConditionStatus,
-) error {
+func SetConditionManagerReady(mgh *operatorv1alpha2.MulticlusterGlobalHub, availableReplicas, desiredReplicas int32) {
+	status := metav1.ConditionStatus(CONDITION_STATUS_TRUE)
+	reason := CONDITION_REASON_MANAGER_READY
+	if availableReplicas < desiredReplicas {
+		status = CONDITION_STATUS_FALSE
+		reason = CONDITION_REASON_MANAGER_NOTREADY
+	}
+
+	SetCondition(mgh, CONDITION_TYPE_MANAGER_READY, status, reason,
+		fmt.Sprintf(CONDITION_MESSAGE_MANAGER_READY, availableReplicas, desiredReplicas))
+}
+
+func SetConditionLeafHubDeployed(mgh *operatorv1alpha2.MulticlusterGlobalHub, clusterName string,
+	status metav1.ConditionStatus,
+) {
 	reason := CONDITION_REASON_LEAFHUB_DEPLOY
 	message := CONDITION_MESSAGE_LEAFHUB_DEPLOY
 	if status == CONDITION_STATUS_FALSE {
@@ -109,29 +234,115 @@ func SetConditionLeafHubDeployed(ctx context.Context, c client.Client, mgh *oper
 		message = fmt.Sprintf("%s-%s", CONDITION_MESSAGE_LEAFHUB_DEPLOY_FAILED, clusterName)
 	}
 
-	return SetCondition(ctx, c, mgh, CONDITION_TYPE_LEAFHUB_DEPLOY, status, reason, message)
+	SetCondition(mgh, CONDITION_TYPE_LEAFHUB_DEPLOY, status, reason, message)
 }
 
-func SetCondition(ctx context.Context, c client.Client, mgh *operatorv1alpha2.MulticlusterGlobalHub, typeName string,
-	status metav1.ConditionStatus, reason string, message string,
-) error {
-	if !ContainsCondition(mgh, typeName) {
-		return AppendCondition(ctx, c, mgh, typeName,
-			status, reason, message)
-	} else {
-		currentStatus := GetConditionStatus(mgh, typeName)
-		if currentStatus != status {
-			err := DeleteCondition(ctx, c, mgh, typeName, reason)
-			if err != nil {
-				return err
-			}
-			return AppendCondition(ctx, c, mgh, typeName, status,
-				reason, message)
+// SetConditionRegionalHubsHealthy reports the number of the healthy regional hubs, the message lists the first
+// unhealthy hubs only to keep the status small with many regional hubs
+func SetConditionRegionalHubsHealthy(mgh *operatorv1alpha2.MulticlusterGlobalHub, unhealthyHubs []string,
+	totalHubs int,
+) {
+	healthyHubs := totalHubs - len(unhealthyHubs)
+	if len(unhealthyHubs) > 0 {
+		listedHubs := strings.Join(unhealthyHubs, ",")
+		if len(unhealthyHubs) > maxListedUnhealthyHubs {
+			listedHubs = fmt.Sprintf(CONDITION_MESSAGE_REGIONAL_HUBS_MORE,
+				strings.Join(unhealthyHubs[:maxListedUnhealthyHubs], ","),
+				len(unhealthyHubs)-maxListedUnhealthyHubs)
 		}
+		SetCondition(mgh, CONDITION_TYPE_REGIONAL_HUBS_HEALTHY, CONDITION_STATUS_FALSE,
+			CONDITION_REASON_REGIONAL_HUBS_UNHEALTHY, fmt.Sprintf(CONDITION_MESSAGE_REGIONAL_HUBS_UNHEALTHY,
+				healthyHubs, totalHubs, listedHubs))
+		return
+	}
+
+	SetCondition(mgh, CONDITION_TYPE_REGIONAL_HUBS_HEALTHY, CONDITION_STATUS_TRUE,
+		CONDITION_REASON_REGIONAL_HUBS_HEALTHY, fmt.Sprintf(CONDITION_MESSAGE_REGIONAL_HUBS_HEALTHY,
+			healthyHubs, totalHubs))
+}
+
+// SetConditionCleanupProgress reports the cleanup step of the deleting MGH, the step is 1-based
+func SetConditionCleanupProgress(mgh *operatorv1alpha2.MulticlusterGlobalHub, stepName string,
+	step, totalSteps int,
+) {
+	SetCondition(mgh, CONDITION_TYPE_CLEANUP, CONDITION_STATUS_FALSE,
+		CONDITION_REASON_CLEANUP_PROGRESS, fmt.Sprintf(CONDITION_MESSAGE_CLEANUP_PROGRESS, stepName, step, totalSteps))
+}
+
+func SetConditionCleanupFailed(mgh *operatorv1alpha2.MulticlusterGlobalHub, stepName string, cleanupErr error) {
+	SetCondition(mgh, CONDITION_TYPE_CLEANUP, CONDITION_STATUS_FALSE,
+		CONDITION_REASON_CLEANUP_FAILED, fmt.Sprintf(CONDITION_MESSAGE_CLEANUP_FAILED, stepName, cleanupErr))
+}
+
+func SetConditionCleanupCompleted(mgh *operatorv1alpha2.MulticlusterGlobalHub) {
+	SetCondition(mgh, CONDITION_TYPE_CLEANUP, CONDITION_STATUS_TRUE,
+		CONDITION_REASON_CLEANUP, CONDITION_MESSAGE_CLEANUP)
+}
+
+// SetCondition sets the condition with the observed generation of the MGH and updates the phase accordingly,
+// the condition is only set in memory, the status is updated once per reconcile by UpdateStatus
+func SetCondition(mgh *operatorv1alpha2.MulticlusterGlobalHub, typeName string, status metav1.ConditionStatus,
+	reason string, message string,
+) {
+	// the last transition time is only changed if the status is changed
+	meta.SetStatusCondition(&mgh.Status.Conditions, metav1.Condition{
+		Type:               typeName,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: mgh.GetGeneration(),
+	})
+	mgh.Status.Phase = GetPhase(mgh)
+}
+
+// UpdateStatus updates the status of the MGH with the conditions set during the reconcile, the status is only
+// updated if it is changed from the original status fetched at the beginning of the reconcile
+func UpdateStatus(ctx context.Context, c client.Client, mgh *operatorv1alpha2.MulticlusterGlobalHub,
+	originalStatus *operatorv1alpha2.MulticlusterGlobalHubStatus,
+) error {
+	if equality.Semantic.DeepEqual(originalStatus, &mgh.Status) {
+		return nil
+	}
+
+	// the MGH is gone once its finalizer is removed
+	if err := c.Status().Update(ctx, mgh); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to update hoh mgh status condition: %v", err)
 	}
 	return nil
 }
 
+// GetPhase derives the phase of the MGH from its conditions, the global hub is running once all the ready
+// conditions are True and no condition is False, a running global hub becomes degraded if any condition is False
+func GetPhase(mgh *operatorv1alpha2.MulticlusterGlobalHub) operatorv1alpha2.GlobalHubPhase {
+	if mgh.GetDeletionTimestamp() != nil {
+		return operatorv1alpha2.GlobalHubUninstalling
+	}
+
+	ready := true
+	for _, typeName := range readyConditionTypes {
+		if !meta.IsStatusConditionTrue(mgh.Status.Conditions, typeName) {
+			ready = false
+		}
+	}
+
+	healthy := true
+	for _, condition := range mgh.Status.Conditions {
+		if condition.Status == CONDITION_STATUS_FALSE {
+			healthy = false
+		}
+	}
+
+	switch {
+	case ready && healthy:
+		return operatorv1alpha2.GlobalHubRunning
+	case ready || mgh.Status.Phase == operatorv1alpha2.GlobalHubRunning ||
+		mgh.Status.Phase == operatorv1alpha2.GlobalHubDegraded:
+		return operatorv1alpha2.GlobalHubDegraded
+	default:
+		return operatorv1alpha2.GlobalHubInstalling
+	}
+}
+
 func ContainsCondition(mgh *operatorv1alpha2.MulticlusterGlobalHub, typeName string) bool {
 	output := false
 	for _, condition := range mgh.Status.Conditions {
@@ -0,0 +1,127 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+
+	operatorv1alpha2 "github.com/stolostron/multicluster-global-hub/operator/apis/v1alpha2"
+)
+
+// ComponentValues is the deployment config of a component rendered for the manifest templates,
+// the complex values are encoded as json which is valid inline yaml, and they are empty if not set
+type ComponentValues struct {
+	Replicas          int32
+	Resources         string
+	Affinity          string
+	PriorityClassName string
+	PodAnnotations    string
+	// Args and Env are appended to the arguments and environment variables in the templates
+	Args []string
+	Env  []string
+}
+
+// PodValues is the pod scheduling config shared by all the components rendered for the manifest templates
+type PodValues struct {
+	NodeSelector    string
+	Tolerations     string
+	ImagePullPolicy string
+	ImagePullSecret string
+}
+
+// GetComponentValues returns the template values of the given component config,
+// the default replicas is used if the replicas is not specified
+func GetComponentValues(component *operatorv1alpha2.ComponentConfig, defaultReplicas int32,
+) (*ComponentValues, error) {
+	values := &ComponentValues{Replicas: defaultReplicas}
+	if component == nil {
+		return values, nil
+	}
+
+	if component.Replicas != nil {
+		values.Replicas = *component.Replicas
+	}
+	values.PriorityClassName = component.PriorityClassName
+
+	var err error
+	if component.Resources != nil {
+		if values.Resources, err = toJSON(component.Resources); err != nil {
+			return nil, err
+		}
+	}
+	if component.Affinity != nil {
+		if values.Affinity, err = toJSON(component.Affinity); err != nil {
+			return nil, err
+		}
+	}
+	if len(component.PodAnnotations) > 0 {
+		if values.PodAnnotations, err = toJSON(component.PodAnnotations); err != nil {
+			return nil, err
+		}
+	}
+	for _, arg := range component.Args {
+		encodedArg, err := toJSON(arg)
+		if err != nil {
+			return nil, err
+		}
+		values.Args = append(values.Args, encodedArg)
+	}
+	for _, env := range component.Env {
+		encodedEnv, err := toJSON(env)
+		if err != nil {
+			return nil, err
+		}
+		values.Env = append(values.Env, encodedEnv)
+	}
+
+	return values, nil
+}
+
+// GetPodValues returns the template values of the pod scheduling config in the MulticlusterGlobalHub spec
+func GetPodValues(mgh *operatorv1alpha2.MulticlusterGlobalHub) (*PodValues, error) {
+	values := &PodValues{
+		ImagePullPolicy: string(corev1.PullAlways),
+		ImagePullSecret: mgh.Spec.ImagePullSecret,
+	}
+	if mgh.Spec.ImagePullPolicy != "" {
+		values.ImagePullPolicy = string(mgh.Spec.ImagePullPolicy)
+	}
+
+	var err error
+	if len(mgh.Spec.NodeSelector) > 0 {
+		if values.NodeSelector, err = toJSON(mgh.Spec.NodeSelector); err != nil {
+			return nil, err
+		}
+	}
+	if len(mgh.Spec.Tolerations) > 0 {
+		if values.Tolerations, err = toJSON(mgh.Spec.Tolerations); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
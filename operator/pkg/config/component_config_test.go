@@ -0,0 +1,86 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	operatorv1alpha2 "github.com/stolostron/multicluster-global-hub/operator/apis/v1alpha2"
+)
+
+func TestGetComponentValues(t *testing.T) {
+	replicas := int32(2)
+	tests := []struct {
+		desc            string
+		component       *operatorv1alpha2.ComponentConfig
+		defaultReplicas int32
+		want            *ComponentValues
+	}{
+		{
+			desc:            "nil component config",
+			component:       nil,
+			defaultReplicas: 3,
+			want:            &ComponentValues{Replicas: 3},
+		},
+		{
+			desc: "full component config",
+			component: &operatorv1alpha2.ComponentConfig{
+				Replicas: &replicas,
+				Resources: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+				Affinity: &corev1.Affinity{
+					PodAntiAffinity: &corev1.PodAntiAffinity{
+						PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+							{Weight: 100, PodAffinityTerm: corev1.PodAffinityTerm{TopologyKey: "zone"}},
+						},
+					},
+				},
+				PriorityClassName: "system-cluster-critical",
+				PodAnnotations:    map[string]string{"foo": "bar"},
+				Args:              []string{"--foo=bar"},
+				Env:               []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+			},
+			defaultReplicas: 1,
+			want: &ComponentValues{
+				Replicas:          2,
+				Resources:         `{"limits":{"memory":"1Gi"}}`,
+				Affinity:          `{"podAntiAffinity":{"preferredDuringSchedulingIgnoredDuringExecution":[{"weight":100,"podAffinityTerm":{"topologyKey":"zone"}}]}}`,
+				PriorityClassName: "system-cluster-critical",
+				PodAnnotations:    `{"foo":"bar"}`,
+				Args:              []string{`"--foo=bar"`},
+				Env:               []string{`{"name":"FOO","value":"bar"}`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := GetComponentValues(tt.component, tt.defaultReplicas)
+			if err != nil {
+				t.Errorf("GetComponentValues() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetComponentValues() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -48,6 +48,8 @@ var (
 		"multicluster_global_hub_agent":    "quay.io/stolostron/multicluster-global-hub-agent:latest",
 		"multicluster_global_hub_manager":  "quay.io/stolostron/multicluster-global-hub-manager:latest",
 		"multicluster_global_hub_operator": "quay.io/stolostron/multicluster-global-hub-operator:latest",
+		"postgresql":                       "registry.developers.crunchydata.com/crunchydata/crunchy-postgres:centos8-13.4-1",
+		"pgbackrest":                       "registry.developers.crunchydata.com/crunchydata/crunchy-pgbackrest:centos8-2.35-0",
 	}
 )
 
@@ -100,6 +102,37 @@ func SkipDBInit(mgh *operatorv1alpha2.MulticlusterGlobalHub) bool {
 	return false
 }
 
+// IsManagedDataLayer returns true if the kafka and postgres of the large scale data layer are provisioned
+// by the operator, and false otherwise
+func IsManagedDataLayer(mgh *operatorv1alpha2.MulticlusterGlobalHub) bool {
+	return mgh.Spec.DataLayer != nil && mgh.Spec.DataLayer.LargeScale != nil &&
+		mgh.Spec.DataLayer.LargeScale.Managed != nil
+}
+
+// GetTransportSecretName returns the name of the secret containing the kafka bootstrap server and CA,
+// the generated secret name is returned in managed mode if it is not specified
+func GetTransportSecretName(mgh *operatorv1alpha2.MulticlusterGlobalHub) string {
+	if mgh.Spec.DataLayer == nil || mgh.Spec.DataLayer.LargeScale == nil {
+		return ""
+	}
+	if name := mgh.Spec.DataLayer.LargeScale.Kafka.Name; name != "" || !IsManagedDataLayer(mgh) {
+		return name
+	}
+	return constants.ManagedTransportSecretName
+}
+
+// GetStorageSecretName returns the name of the secret containing the database uri,
+// the generated secret name is returned in managed mode if it is not specified
+func GetStorageSecretName(mgh *operatorv1alpha2.MulticlusterGlobalHub) string {
+	if mgh.Spec.DataLayer == nil || mgh.Spec.DataLayer.LargeScale == nil {
+		return ""
+	}
+	if name := mgh.Spec.DataLayer.LargeScale.Postgres.Name; name != "" || !IsManagedDataLayer(mgh) {
+		return name
+	}
+	return constants.ManagedStorageSecretName
+}
+
 // GetImageOverridesConfigmap returns the images override configmap annotation, or an empty string if not set
 func GetImageOverridesConfigmap(mgh *operatorv1alpha2.MulticlusterGlobalHub) string {
 	return getAnnotation(mgh, constants.AnnotationImageOverridesCM)
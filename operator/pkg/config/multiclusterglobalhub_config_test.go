@@ -230,3 +230,65 @@ func TestSetImageOverrides(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDataLayerSecretNames(t *testing.T) {
+	tests := []struct {
+		desc                string
+		largeScale          *operatorv1alpha2.LargeScaleConfig
+		wantTransportSecret string
+		wantStorageSecret   string
+	}{
+		{
+			desc: "unmanaged data layer",
+			largeScale: &operatorv1alpha2.LargeScaleConfig{
+				Kafka:    corev1.LocalObjectReference{Name: "transport-secret"},
+				Postgres: corev1.LocalObjectReference{Name: "storage-secret"},
+			},
+			wantTransportSecret: "transport-secret",
+			wantStorageSecret:   "storage-secret",
+		},
+		{
+			desc:                "unmanaged data layer without secrets",
+			largeScale:          &operatorv1alpha2.LargeScaleConfig{},
+			wantTransportSecret: "",
+			wantStorageSecret:   "",
+		},
+		{
+			desc: "managed data layer with generated secrets",
+			largeScale: &operatorv1alpha2.LargeScaleConfig{
+				Managed: &operatorv1alpha2.ManagedConfig{},
+			},
+			wantTransportSecret: constants.ManagedTransportSecretName,
+			wantStorageSecret:   constants.ManagedStorageSecretName,
+		},
+		{
+			desc: "managed data layer with specified secrets",
+			largeScale: &operatorv1alpha2.LargeScaleConfig{
+				Managed:  &operatorv1alpha2.ManagedConfig{},
+				Kafka:    corev1.LocalObjectReference{Name: "transport-secret"},
+				Postgres: corev1.LocalObjectReference{Name: "storage-secret"},
+			},
+			wantTransportSecret: "transport-secret",
+			wantStorageSecret:   "storage-secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mgh := &operatorv1alpha2.MulticlusterGlobalHub{
+				Spec: operatorv1alpha2.MulticlusterGlobalHubSpec{
+					DataLayer: &operatorv1alpha2.DataLayerConfig{
+						Type:       operatorv1alpha2.LargeScale,
+						LargeScale: tt.largeScale,
+					},
+				},
+			}
+			if got := GetTransportSecretName(mgh); got != tt.wantTransportSecret {
+				t.Errorf("GetTransportSecretName() = %s, want %s", got, tt.wantTransportSecret)
+			}
+			if got := GetStorageSecretName(mgh); got != tt.wantStorageSecret {
+				t.Errorf("GetStorageSecretName() = %s, want %s", got, tt.wantStorageSecret)
+			}
+		})
+	}
+}
@@ -22,6 +22,26 @@ const (
 	HOHConfigName              = "multicluster-global-hub-config"
 	LocalClusterName           = "local-cluster"
 	DefaultImagePullSecretName = "multiclusterhub-operator-pull-secret"
+	// TransportKeysSecretName is the secret of the keys that encrypt the spec messages to the regional hubs, it holds
+	// the keys of all the regional hubs on the global hub and the keys of the regional hub on a regional hub.
+	TransportKeysSecretName = "multicluster-global-hub-transport-keys"
+)
+
+// the kafka and postgres clusters provisioned by the operator in the managed data layer mode
+const (
+	ManagedKafkaClusterName       = "kafka-brokers-cluster"
+	ManagedKafkaExternalListener  = "external"
+	ManagedKafkaSpecTopic         = "spec"
+	ManagedKafkaStatusTopic       = "status"
+	ManagedKafkaManagerUser       = "global-hub-manager"
+	ManagedKafkaAgentUserPrefix   = "global-hub-agent-"
+	ManagedTransportSecretName    = "multicluster-global-hub-transport"
+	ManagedPostgresClusterName    = "hoh"
+	ManagedPostgresUserName       = "postgres"
+	ManagedPostgresDatabaseName   = "hoh"
+	ManagedStorageSecretName      = "multicluster-global-hub-storage"
+	DefaultManagedKafkaReplicas   = 3
+	DefaultManagedPostgresStorage = "20Gi"
 )
 
 const (
@@ -87,3 +107,15 @@ const (
 	// AnnotationHostingClusterName is the annotation for indicating the hosting cluster name
 	AnnotationHostingClusterName = "addon.open-cluster-management.io/hosting-cluster-name"
 )
+
+// the admission webhook of the MulticlusterGlobalHub served by the operator
+const (
+	WebhookServiceName                 = "multicluster-global-hub-operator-webhook"
+	WebhookCertSecretName              = "multicluster-global-hub-operator-webhook-cert"
+	MutatingWebhookConfigurationName   = "multicluster-global-hub-operator-mutating"
+	ValidatingWebhookConfigurationName = "multicluster-global-hub-operator-validating"
+	DefaultWebhookCertDir              = "/tmp/k8s-webhook-server/serving-certs"
+	DefaultWebhookPort                 = 9443
+	// SkipWebhookLabelKey is the label of the MulticlusterGlobalHub to skip the admission webhook
+	SkipWebhookLabelKey = "global-hub.open-cluster-management.io/skip-webhook"
+)
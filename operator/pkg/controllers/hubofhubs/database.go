@@ -2,9 +2,12 @@ package hubofhubs
 
 import (
 	"context"
+	"crypto/sha256"
 	"embed"
+	"encoding/hex"
 	"fmt"
 	iofs "io/fs"
+	"time"
 
 	"github.com/jackc/pgx/v4"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,16 +21,18 @@ import (
 //go:embed database
 var databaseFS embed.FS
 
+const (
+	// databaseConnectTimeout is the timeout to connect to the database for the reachability check
+	databaseConnectTimeout = 10 * time.Second
+	// unknownSchemaVersion is reported if the schema version isn't recorded in the database
+	unknownSchemaVersion = "unknown"
+)
+
 func (reconciler *MulticlusterGlobalHubReconciler) reconcileDatabase(ctx context.Context, mgh *operatorv1alpha2.MulticlusterGlobalHub,
-	namespacedName types.NamespacedName,
+	namespacedName types.NamespacedName, skipInit bool,
 ) error {
 	log := ctrllog.FromContext(ctx)
-	if condition.ContainConditionStatus(mgh, condition.CONDITION_TYPE_DATABASE_INIT, condition.CONDITION_STATUS_TRUE) {
-		log.Info("Database has initialized")
-		return nil
-	}
 
-	log.Info("Database initializing")
 	postgreSecret, err := reconciler.KubeClient.CoreV1().Secrets(namespacedName.Namespace).Get(
 		ctx, namespacedName.Name, metav1.GetOptions{})
 	if err != nil {
@@ -38,16 +43,44 @@ func (reconciler *MulticlusterGlobalHubReconciler) reconcileDatabase(ctx context
 	}
 
 	databaseURI := string(postgreSecret.Data["database_uri"])
-	conn, err := pgx.Connect(ctx, databaseURI)
+	connectCtx, cancel := context.WithTimeout(ctx, databaseConnectTimeout)
+	defer cancel()
+	conn, err := pgx.Connect(connectCtx, databaseURI)
 	if err != nil {
-		conditionError := condition.SetConditionDatabaseInit(ctx, reconciler.Client, mgh, condition.CONDITION_STATUS_FALSE)
-		if conditionError != nil {
-			return condition.FailToSetConditionError(condition.CONDITION_STATUS_FALSE, conditionError)
+		condition.SetConditionDatabaseReachable(mgh, "", err)
+		if skipInit {
+			log.Error(err, "failed to connect to postgres")
+			return nil
 		}
+		condition.SetConditionDatabaseInit(mgh, condition.CONDITION_STATUS_FALSE)
 		return fmt.Errorf("failed to connect to postgres: %w", err)
 	}
 	defer conn.Close(ctx)
 
+	currentSchemaVersion, err := getDatabaseSchemaVersion(ctx, conn)
+	if err != nil {
+		condition.SetConditionDatabaseReachable(mgh, "", err)
+		return err
+	}
+
+	expectedSchemaVersion, err := getSchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	// the schema is applied again if the SQL files are changed, e.g. the operator is upgraded
+	if skipInit || (currentSchemaVersion == expectedSchemaVersion &&
+		condition.ContainConditionStatus(mgh, condition.CONDITION_TYPE_DATABASE_INIT, condition.CONDITION_STATUS_TRUE)) {
+		log.Info("Database has initialized", "schemaVersion", currentSchemaVersion)
+		if currentSchemaVersion == "" {
+			currentSchemaVersion = unknownSchemaVersion
+		}
+		condition.SetConditionDatabaseReachable(mgh, currentSchemaVersion, nil)
+		return nil
+	}
+
+	log.Info("Database initializing", "currentSchemaVersion", currentSchemaVersion,
+		"schemaVersion", expectedSchemaVersion)
 	err = iofs.WalkDir(databaseFS, "database", func(file string, d iofs.DirEntry, beforeError error) error {
 		if beforeError != nil {
 			return beforeError
@@ -66,18 +99,81 @@ func (reconciler *MulticlusterGlobalHubReconciler) reconcileDatabase(ctx context
 		}
 		return nil
 	})
+	if err == nil {
+		err = setDatabaseSchemaVersion(ctx, conn, expectedSchemaVersion)
+	}
 	if err != nil {
-		conditionError := condition.SetConditionDatabaseInit(ctx, reconciler.Client, mgh, condition.CONDITION_STATUS_FALSE)
-		if conditionError != nil {
-			return condition.FailToSetConditionError(condition.CONDITION_STATUS_FALSE, conditionError)
-		}
+		condition.SetConditionDatabaseInit(mgh, condition.CONDITION_STATUS_FALSE)
 		return fmt.Errorf("failed to walk database directory: %w", err)
 	}
 
-	log.Info("Database initialized")
-	err = condition.SetConditionDatabaseInit(ctx, reconciler.Client, mgh, condition.CONDITION_STATUS_TRUE)
+	log.Info("Database initialized", "schemaVersion", expectedSchemaVersion)
+	condition.SetConditionDatabaseReachable(mgh, expectedSchemaVersion, nil)
+	condition.SetConditionDatabaseInit(mgh, condition.CONDITION_STATUS_TRUE)
+	return nil
+}
+
+// getSchemaVersion returns the version of the embedded SQL files, which is the prefix of their digest
+func getSchemaVersion() (string, error) {
+	hash := sha256.New()
+	err := iofs.WalkDir(databaseFS, "database", func(file string, d iofs.DirEntry, beforeError error) error {
+		if beforeError != nil {
+			return beforeError
+		}
+		if d.IsDir() {
+			return nil
+		}
+		sqlBytes, err := databaseFS.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		hash.Write([]byte(file))
+		hash.Write(sqlBytes)
+		return nil
+	})
 	if err != nil {
-		return condition.FailToSetConditionError(condition.CONDITION_STATUS_TRUE, err)
+		return "", err
 	}
-	return nil
+	return hex.EncodeToString(hash.Sum(nil))[:12], nil
+}
+
+// getDatabaseSchemaVersion returns the schema version recorded in the database, it's empty if not recorded yet
+func getDatabaseSchemaVersion(ctx context.Context, conn *pgx.Conn) (string, error) {
+	var exists bool
+	if err := conn.QueryRow(ctx, `SELECT to_regclass('public.schema_version') IS NOT NULL`).Scan(
+		&exists); err != nil {
+		return "", fmt.Errorf("failed to check schema version table: %w", err)
+	}
+	if !exists {
+		return "", nil
+	}
+
+	version := ""
+	rows, err := conn.Query(ctx, `SELECT version FROM public.schema_version ORDER BY updated_at DESC LIMIT 1`)
+	if err != nil {
+		return "", fmt.Errorf("failed to get schema version: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := rows.Scan(&version); err != nil {
+			return "", fmt.Errorf("failed to get schema version: %w", err)
+		}
+	}
+	return version, rows.Err()
+}
+
+func setDatabaseSchemaVersion(ctx context.Context, conn *pgx.Conn, version string) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // no-op if the transaction is committed
+
+	if _, err := tx.Exec(ctx, `DELETE FROM public.schema_version`); err != nil {
+		return fmt.Errorf("failed to delete schema version: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `INSERT INTO public.schema_version (version) VALUES ($1)`, version); err != nil {
+		return fmt.Errorf("failed to insert schema version: %w", err)
+	}
+	return tx.Commit(ctx)
 }
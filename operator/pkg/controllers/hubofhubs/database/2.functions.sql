@@ -83,6 +83,36 @@ BEGIN
 END;
 $$;
 
+CREATE OR REPLACE FUNCTION public.move_generic_resources_to_history() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+  INSERT INTO history.generic_resources SELECT * FROM spec.generic_resources
+  WHERE payload ->> 'apiVersion' = NEW.payload ->> 'apiVersion' AND payload ->> 'kind' = NEW.payload ->> 'kind' AND
+  payload -> 'metadata' ->> 'name' = NEW.payload -> 'metadata' ->> 'name' AND
+  (
+    (
+      (payload -> 'metadata' ->> 'namespace' IS NOT NULL AND NEW.payload -> 'metadata' ->> 'namespace' IS NOT NULL)
+    AND payload -> 'metadata' ->> 'namespace' = NEW.payload -> 'metadata' ->> 'namespace'
+    ) OR (
+      payload -> 'metadata' -> 'namespace' IS NULL AND NEW.payload -> 'metadata' -> 'namespace' IS NULL
+    )
+  );
+  DELETE FROM spec.generic_resources
+  WHERE payload ->> 'apiVersion' = NEW.payload ->> 'apiVersion' AND payload ->> 'kind' = NEW.payload ->> 'kind' AND
+  payload -> 'metadata' ->> 'name' = NEW.payload -> 'metadata' ->> 'name' AND
+  (
+    (
+      (payload -> 'metadata' ->> 'namespace' IS NOT NULL AND NEW.payload -> 'metadata' ->> 'namespace' IS NOT NULL)
+    AND payload -> 'metadata' ->> 'namespace' = NEW.payload -> 'metadata' ->> 'namespace'
+    ) OR (
+      payload -> 'metadata' -> 'namespace' IS NULL AND NEW.payload -> 'metadata' -> 'namespace' IS NULL
+    )
+  );
+  RETURN NEW;
+END;
+$$;
+
 CREATE OR REPLACE FUNCTION public.move_managedclustersetbindings_to_history() RETURNS trigger
     LANGUAGE plpgsql
     AS $$
@@ -251,6 +281,62 @@ BEGIN
 END;
 $$;
 
+CREATE OR REPLACE FUNCTION public.move_policyautomations_to_history() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+  INSERT INTO history.policyautomations SELECT * FROM spec.policyautomations
+  WHERE payload -> 'metadata' ->> 'name' = NEW.payload -> 'metadata' ->> 'name' AND
+  (
+    (
+      (payload -> 'metadata' ->> 'namespace' IS NOT NULL AND NEW.payload -> 'metadata' ->> 'namespace' IS NOT NULL)
+    AND payload -> 'metadata' ->> 'namespace' = NEW.payload -> 'metadata' ->> 'namespace'
+    ) OR (
+      payload -> 'metadata' -> 'namespace' IS NULL AND NEW.payload -> 'metadata' -> 'namespace' IS NULL
+    )
+  );
+  DELETE FROM spec.policyautomations
+  WHERE payload -> 'metadata' ->> 'name' = NEW.payload -> 'metadata' ->> 'name' AND
+  (
+    (
+      (payload -> 'metadata' ->> 'namespace' IS NOT NULL AND NEW.payload -> 'metadata' ->> 'namespace' IS NOT NULL)
+    AND payload -> 'metadata' ->> 'namespace' = NEW.payload -> 'metadata' ->> 'namespace'
+    ) OR (
+      payload -> 'metadata' -> 'namespace' IS NULL AND NEW.payload -> 'metadata' -> 'namespace' IS NULL
+    )
+  );
+  RETURN NEW;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION public.move_policysets_to_history() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+  INSERT INTO history.policysets SELECT * FROM spec.policysets
+  WHERE payload -> 'metadata' ->> 'name' = NEW.payload -> 'metadata' ->> 'name' AND
+  (
+    (
+      (payload -> 'metadata' ->> 'namespace' IS NOT NULL AND NEW.payload -> 'metadata' ->> 'namespace' IS NOT NULL)
+    AND payload -> 'metadata' ->> 'namespace' = NEW.payload -> 'metadata' ->> 'namespace'
+    ) OR (
+      payload -> 'metadata' -> 'namespace' IS NULL AND NEW.payload -> 'metadata' -> 'namespace' IS NULL
+    )
+  );
+  DELETE FROM spec.policysets
+  WHERE payload -> 'metadata' ->> 'name' = NEW.payload -> 'metadata' ->> 'name' AND
+  (
+    (
+      (payload -> 'metadata' ->> 'namespace' IS NOT NULL AND NEW.payload -> 'metadata' ->> 'namespace' IS NOT NULL)
+    AND payload -> 'metadata' ->> 'namespace' = NEW.payload -> 'metadata' ->> 'namespace'
+    ) OR (
+      payload -> 'metadata' -> 'namespace' IS NULL AND NEW.payload -> 'metadata' -> 'namespace' IS NULL
+    )
+  );
+  RETURN NEW;
+END;
+$$;
+
 CREATE OR REPLACE FUNCTION public.move_subscriptions_to_history() RETURNS trigger
     LANGUAGE plpgsql
     AS $$
@@ -22,6 +22,14 @@ CREATE TABLE IF NOT EXISTS  history.configs (
     deleted boolean DEFAULT false NOT NULL
 );
 
+CREATE TABLE IF NOT EXISTS  history.generic_resources (
+    id uuid NOT NULL,
+    payload jsonb NOT NULL,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    deleted boolean DEFAULT false NOT NULL
+);
+
 CREATE TABLE IF NOT EXISTS  history.managedclustersetbindings (
     id uuid NOT NULL,
     payload jsonb NOT NULL,
@@ -70,6 +78,22 @@ CREATE TABLE IF NOT EXISTS  history.policies (
     deleted boolean DEFAULT false NOT NULL
 );
 
+CREATE TABLE IF NOT EXISTS  history.policyautomations (
+    id uuid NOT NULL,
+    payload jsonb NOT NULL,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    deleted boolean DEFAULT false NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS  history.policysets (
+    id uuid NOT NULL,
+    payload jsonb NOT NULL,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    deleted boolean DEFAULT false NOT NULL
+);
+
 CREATE TABLE IF NOT EXISTS  history.subscriptions (
     id uuid NOT NULL,
     payload jsonb NOT NULL,
@@ -85,6 +109,13 @@ CREATE TABLE IF NOT EXISTS  local_spec.placementrules (
     updated_at timestamp without time zone DEFAULT now() NOT NULL
 );
 
+CREATE TABLE IF NOT EXISTS  local_spec.placements (
+    leaf_hub_name text,
+    payload jsonb NOT NULL,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
 CREATE TABLE IF NOT EXISTS  local_spec.policies (
     leaf_hub_name text,
     payload jsonb NOT NULL,
@@ -100,6 +131,17 @@ CREATE TABLE IF NOT EXISTS  local_status.compliance (
     compliance local_status.compliance_type NOT NULL
 );
 
+CREATE TABLE IF NOT EXISTS  local_status.placementdecisions (
+    id uuid NOT NULL,
+    leaf_hub_name character varying(63) NOT NULL,
+    payload jsonb NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS  public.schema_version (
+    version character varying(64) NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
 CREATE TABLE IF NOT EXISTS  spec.applications (
     id uuid NOT NULL,
     payload jsonb NOT NULL,
@@ -124,6 +166,22 @@ CREATE TABLE IF NOT EXISTS  spec.configs (
     deleted boolean DEFAULT false NOT NULL
 );
 
+CREATE TABLE IF NOT EXISTS  spec.generic_resources (
+    id uuid NOT NULL,
+    payload jsonb NOT NULL,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    deleted boolean DEFAULT false NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS  spec.held_resources (
+    table_name character varying(63) NOT NULL,
+    id uuid NOT NULL,
+    payload jsonb NOT NULL,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
 CREATE TABLE IF NOT EXISTS  spec.managed_cluster_sets_tracking (
     cluster_set_name character varying(63) NOT NULL,
     leaf_hub_name character varying(63) NOT NULL,
@@ -189,6 +247,27 @@ CREATE TABLE IF NOT EXISTS  spec.policies (
     deleted boolean DEFAULT false NOT NULL
 );
 
+CREATE TABLE IF NOT EXISTS  spec.policyautomations (
+    id uuid NOT NULL,
+    payload jsonb NOT NULL,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    deleted boolean DEFAULT false NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS  spec.policysets (
+    id uuid NOT NULL,
+    payload jsonb NOT NULL,
+    created_at timestamp without time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL,
+    deleted boolean DEFAULT false NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS  spec.resyncs (
+    id uuid NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
 CREATE TABLE IF NOT EXISTS  spec.subscriptions (
     id uuid NOT NULL,
     payload jsonb NOT NULL,
@@ -212,6 +291,15 @@ CREATE TABLE IF NOT EXISTS  status.compliance (
     compliance status.compliance_type NOT NULL
 );
 
+CREATE TABLE IF NOT EXISTS  status.compliance_details (
+    id uuid NOT NULL,
+    cluster_name character varying(63) NOT NULL,
+    leaf_hub_name character varying(63) NOT NULL,
+    violations jsonb NOT NULL,
+    omitted_violations integer DEFAULT 0 NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
 CREATE TABLE IF NOT EXISTS  status.leaf_hub_heartbeats (
     leaf_hub_name character varying(63) NOT NULL,
     last_timestamp timestamp without time zone DEFAULT now() NOT NULL
@@ -223,6 +311,77 @@ CREATE TABLE IF NOT EXISTS  status.managed_clusters (
     error status.error_type NOT NULL
 );
 
+CREATE TABLE IF NOT EXISTS  status.managed_cluster_inventory (
+    leaf_hub_name character varying(63) NOT NULL,
+    cluster_name character varying(63) NOT NULL,
+    platform text,
+    product text,
+    version text,
+    region text,
+    kube_version text,
+    cpu_cores bigint,
+    memory_bytes bigint,
+    available text,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS  status.managed_cluster_addons (
+    id uuid NOT NULL,
+    leaf_hub_name character varying(63) NOT NULL,
+    payload jsonb NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS  status.cluster_deployments (
+    id uuid NOT NULL,
+    leaf_hub_name character varying(63) NOT NULL,
+    payload jsonb NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS  status.cluster_pool_claims (
+    id uuid NOT NULL,
+    leaf_hub_name character varying(63) NOT NULL,
+    payload jsonb NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS  status.argo_applications (
+    id uuid NOT NULL,
+    leaf_hub_name character varying(63) NOT NULL,
+    payload jsonb NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS  status.argo_application_sets (
+    id uuid NOT NULL,
+    leaf_hub_name character varying(63) NOT NULL,
+    payload jsonb NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+-- the sequence orders the inserts and the updates of the events, the events are watched by it
+CREATE SEQUENCE IF NOT EXISTS status.events_sequence;
+
+-- the daily partitions of the events are created and dropped by the manager
+CREATE TABLE IF NOT EXISTS  status.events (
+    leaf_hub_name character varying(63) NOT NULL,
+    event_namespace text NOT NULL,
+    event_name text NOT NULL,
+    involved_api_version text NOT NULL,
+    involved_kind text NOT NULL,
+    involved_namespace text NOT NULL,
+    involved_name text NOT NULL,
+    involved_uid text NOT NULL,
+    reason text NOT NULL,
+    message text NOT NULL,
+    type text NOT NULL,
+    source text NOT NULL,
+    count integer NOT NULL,
+    first_timestamp timestamp without time zone NOT NULL,
+    last_timestamp timestamp without time zone NOT NULL,
+    sequence bigint DEFAULT nextval('status.events_sequence') NOT NULL
+) PARTITION BY RANGE (first_timestamp);
+
 CREATE TABLE IF NOT EXISTS  status.placementdecisions (
     id uuid NOT NULL,
     leaf_hub_name character varying(63) NOT NULL,
@@ -241,6 +400,25 @@ CREATE TABLE IF NOT EXISTS  status.placements (
     payload jsonb NOT NULL
 );
 
+CREATE TABLE IF NOT EXISTS  status.policysets (
+    id uuid NOT NULL,
+    leaf_hub_name character varying(63) NOT NULL,
+    payload jsonb NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS  status.spec_apply_results (
+    id uuid NOT NULL,
+    leaf_hub_name character varying(63) NOT NULL,
+    payload jsonb NOT NULL,
+    updated_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS  status.spec_full_state_requests (
+    leaf_hub_name character varying(63) NOT NULL,
+    bundle_id character varying(63) NOT NULL,
+    requested_at timestamp without time zone DEFAULT now() NOT NULL
+);
+
 CREATE TABLE IF NOT EXISTS  status.subscription_reports (
     id uuid NOT NULL,
     leaf_hub_name character varying(63) NOT NULL,
@@ -265,6 +443,10 @@ ALTER TABLE history.configs DROP CONSTRAINT IF EXISTS configs_pkey;
 ALTER TABLE ONLY history.configs
     ADD CONSTRAINT configs_pkey PRIMARY KEY (id);
 
+ALTER TABLE history.generic_resources DROP CONSTRAINT IF EXISTS generic_resources_pkey;
+ALTER TABLE ONLY history.generic_resources
+    ADD CONSTRAINT generic_resources_pkey PRIMARY KEY (id);
+
 ALTER TABLE history.managedclustersetbindings DROP CONSTRAINT IF EXISTS managedclustersetbindings_pkey;
 ALTER TABLE ONLY history.managedclustersetbindings
     ADD CONSTRAINT managedclustersetbindings_pkey PRIMARY KEY (id);
@@ -290,6 +472,14 @@ ALTER TABLE history.policies DROP CONSTRAINT IF EXISTS policies_pkey;
 ALTER TABLE ONLY history.policies
     ADD CONSTRAINT policies_pkey PRIMARY KEY (id);
 
+ALTER TABLE history.policyautomations DROP CONSTRAINT IF EXISTS policyautomations_pkey;
+ALTER TABLE ONLY history.policyautomations
+    ADD CONSTRAINT policyautomations_pkey PRIMARY KEY (id);
+
+ALTER TABLE history.policysets DROP CONSTRAINT IF EXISTS policysets_pkey;
+ALTER TABLE ONLY history.policysets
+    ADD CONSTRAINT policysets_pkey PRIMARY KEY (id);
+
 ALTER TABLE history.subscriptions DROP CONSTRAINT IF EXISTS subscriptions_pkey;
 ALTER TABLE ONLY history.subscriptions
     ADD CONSTRAINT subscriptions_pkey PRIMARY KEY (id);
@@ -308,6 +498,14 @@ ALTER TABLE ONLY spec.configs
     ADD CONSTRAINT configs_pkey PRIMARY KEY (id);
 
 
+ALTER TABLE spec.generic_resources DROP CONSTRAINT IF EXISTS generic_resources_pkey;
+ALTER TABLE ONLY spec.generic_resources
+    ADD CONSTRAINT generic_resources_pkey PRIMARY KEY (id);
+
+ALTER TABLE spec.held_resources DROP CONSTRAINT IF EXISTS held_resources_pkey;
+ALTER TABLE ONLY spec.held_resources
+    ADD CONSTRAINT held_resources_pkey PRIMARY KEY (table_name, id);
+
 ALTER TABLE spec.managedclustersetbindings DROP CONSTRAINT IF EXISTS managedclustersetbindings_pkey;
 ALTER TABLE ONLY spec.managedclustersetbindings
     ADD CONSTRAINT managedclustersetbindings_pkey PRIMARY KEY (id);
@@ -333,6 +531,18 @@ ALTER TABLE spec.policies DROP CONSTRAINT IF EXISTS policies_pkey;
 ALTER TABLE ONLY spec.policies
     ADD CONSTRAINT policies_pkey PRIMARY KEY (id);
 
+ALTER TABLE spec.policyautomations DROP CONSTRAINT IF EXISTS policyautomations_pkey;
+ALTER TABLE ONLY spec.policyautomations
+    ADD CONSTRAINT policyautomations_pkey PRIMARY KEY (id);
+
+ALTER TABLE spec.policysets DROP CONSTRAINT IF EXISTS policysets_pkey;
+ALTER TABLE ONLY spec.policysets
+    ADD CONSTRAINT policysets_pkey PRIMARY KEY (id);
+
+ALTER TABLE spec.resyncs DROP CONSTRAINT IF EXISTS resyncs_pkey;
+ALTER TABLE ONLY spec.resyncs
+    ADD CONSTRAINT resyncs_pkey PRIMARY KEY (id);
+
 ALTER TABLE spec.subscriptions DROP CONSTRAINT IF EXISTS subscriptions_pkey;
 ALTER TABLE ONLY spec.subscriptions
     ADD CONSTRAINT subscriptions_pkey PRIMARY KEY (id);
@@ -340,10 +550,18 @@ ALTER TABLE ONLY spec.subscriptions
 
 CREATE UNIQUE INDEX IF NOT EXISTS placementrules_leaf_hub_name_id_idx ON local_spec.placementrules USING btree (leaf_hub_name, (((payload -> 'metadata'::text) ->> 'uid'::text)));
 
+CREATE UNIQUE INDEX IF NOT EXISTS placements_leaf_hub_name_id_idx ON local_spec.placements USING btree (leaf_hub_name, (((payload -> 'metadata'::text) ->> 'uid'::text)));
+
+CREATE UNIQUE INDEX IF NOT EXISTS placementdecisions_leaf_hub_name_id_idx ON local_status.placementdecisions USING btree (leaf_hub_name, id);
+
+CREATE INDEX IF NOT EXISTS placementdecisions_payload_placement_idx ON local_status.placementdecisions USING btree ((((payload -> 'metadata'::text) ->> 'namespace'::text)), ((((payload -> 'metadata'::text) -> 'labels'::text) ->> 'cluster.open-cluster-management.io/placement'::text)));
+
 CREATE UNIQUE INDEX IF NOT EXISTS policies_leaf_hub_name_id_idx ON local_spec.policies USING btree (leaf_hub_name, (((payload -> 'metadata'::text) ->> 'uid'::text)));
 
 CREATE UNIQUE INDEX IF NOT EXISTS managed_cluster_sets_tracking_cluster_set_name_and_leaf_hub_nam ON spec.managed_cluster_sets_tracking USING btree (cluster_set_name, leaf_hub_name);
 
+CREATE INDEX IF NOT EXISTS generic_resources_api_version_kind_idx ON spec.generic_resources USING btree (((payload ->> 'apiVersion'::text)), ((payload ->> 'kind'::text)));
+
 CREATE UNIQUE INDEX IF NOT EXISTS managed_clusters_labels_leaf_hub_name_and_cluster_name_idx ON spec.managed_clusters_labels USING btree (leaf_hub_name, managed_cluster_name);
 
 CREATE INDEX IF NOT EXISTS compliance_leaf_hub_cluster_idx ON status.compliance USING btree (leaf_hub_name, cluster_name);
@@ -352,8 +570,38 @@ CREATE INDEX IF NOT EXISTS compliance_leaf_hub_non_compliant_idx ON status.compl
 
 CREATE UNIQUE INDEX IF NOT EXISTS compliance_leaf_hub_policy_cluster_idx ON status.compliance USING btree (leaf_hub_name, id, cluster_name);
 
+CREATE UNIQUE INDEX IF NOT EXISTS compliance_details_leaf_hub_policy_cluster_idx ON status.compliance_details USING btree (leaf_hub_name, id, cluster_name);
+
+CREATE INDEX IF NOT EXISTS compliance_details_id_idx ON status.compliance_details USING btree (id);
+
 CREATE UNIQUE INDEX IF NOT EXISTS leaf_hub_heartbeats_leaf_hub_idx ON status.leaf_hub_heartbeats USING btree (leaf_hub_name);
 
+CREATE UNIQUE INDEX IF NOT EXISTS cluster_deployments_leaf_hub_name_id_idx ON status.cluster_deployments USING btree (leaf_hub_name, id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS cluster_pool_claims_leaf_hub_name_id_idx ON status.cluster_pool_claims USING btree (leaf_hub_name, id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS argo_applications_leaf_hub_name_id_idx ON status.argo_applications USING btree (leaf_hub_name, id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS argo_application_sets_leaf_hub_name_id_idx ON status.argo_application_sets USING btree (leaf_hub_name, id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS events_leaf_hub_involved_object_reason_idx ON status.events USING btree (leaf_hub_name, involved_kind, involved_namespace, involved_name, reason, first_timestamp);
+
+CREATE INDEX IF NOT EXISTS events_last_timestamp_idx ON status.events USING btree (last_timestamp);
+
+CREATE INDEX IF NOT EXISTS events_sequence_idx ON status.events USING btree (sequence);
+
+CREATE UNIQUE INDEX IF NOT EXISTS managed_cluster_inventory_leaf_hub_cluster_idx ON status.managed_cluster_inventory USING btree (leaf_hub_name, cluster_name);
+
+CREATE INDEX IF NOT EXISTS managed_cluster_inventory_platform_product_version_idx ON status.managed_cluster_inventory USING btree (platform, product, version);
+
+CREATE INDEX IF NOT EXISTS managed_cluster_inventory_region_idx ON status.managed_cluster_inventory USING btree (region);
+
+CREATE INDEX IF NOT EXISTS managed_cluster_inventory_cpu_cores_idx ON status.managed_cluster_inventory USING btree (cpu_cores);
+
+CREATE UNIQUE INDEX IF NOT EXISTS managed_cluster_addons_leaf_hub_name_id_idx ON status.managed_cluster_addons USING btree (leaf_hub_name, id);
+
+CREATE INDEX IF NOT EXISTS managed_cluster_addons_cluster_name_idx ON status.managed_cluster_addons USING btree ((((payload -> 'metadata'::text) ->> 'namespace'::text)), (((payload -> 'metadata'::text) ->> 'name'::text)));
+
 CREATE UNIQUE INDEX IF NOT EXISTS managed_clusters_leaf_hub_name_metadata_name_idx ON status.managed_clusters USING btree (leaf_hub_name, (((payload -> 'metadata'::text) ->> 'name'::text)));
 
 CREATE INDEX IF NOT EXISTS managed_clusters_metadata_name_idx ON status.managed_clusters USING btree ((((payload -> 'metadata'::text) ->> 'name'::text)));
@@ -370,6 +618,16 @@ CREATE UNIQUE INDEX IF NOT EXISTS placements_leaf_hub_name_and_payload_name_name
 
 CREATE INDEX IF NOT EXISTS placements_payload_name_and_namespace_idx ON status.placements USING btree ((((payload -> 'metadata'::text) ->> 'name'::text)), (((payload -> 'metadata'::text) ->> 'namespace'::text)));
 
+CREATE UNIQUE INDEX IF NOT EXISTS policysets_leaf_hub_name_id_idx ON status.policysets USING btree (leaf_hub_name, id);
+
+CREATE INDEX IF NOT EXISTS policysets_id_idx ON status.policysets USING btree (id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS spec_apply_results_leaf_hub_name_id_idx ON status.spec_apply_results USING btree (leaf_hub_name, id);
+
+CREATE INDEX IF NOT EXISTS spec_apply_results_id_idx ON status.spec_apply_results USING btree (id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS spec_full_state_requests_leaf_hub_name_bundle_id_idx ON status.spec_full_state_requests USING btree (leaf_hub_name, bundle_id);
+
 CREATE UNIQUE INDEX IF NOT EXISTS subscription_reports_leaf_hub_name_and_payload_name_namespace_i ON status.subscription_reports USING btree (leaf_hub_name, (((payload -> 'metadata'::text) ->> 'name'::text)), (((payload -> 'metadata'::text) ->> 'namespace'::text)));
 
 CREATE INDEX IF NOT EXISTS subscription_reports_payload_name_and_namespace_idx ON status.subscription_reports USING btree ((((payload -> 'metadata'::text) ->> 'name'::text)), (((payload -> 'metadata'::text) ->> 'namespace'::text)));
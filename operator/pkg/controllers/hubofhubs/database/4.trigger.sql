@@ -7,6 +7,8 @@ DROP TRIGGER IF EXISTS set_timestamp ON history.channels;
 CREATE TRIGGER set_timestamp BEFORE UPDATE ON history.channels FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
 DROP TRIGGER IF EXISTS set_timestamp ON history.configs;
 CREATE TRIGGER set_timestamp BEFORE UPDATE ON history.configs FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
+DROP TRIGGER IF EXISTS set_timestamp ON history.generic_resources;
+CREATE TRIGGER set_timestamp BEFORE UPDATE ON history.generic_resources FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
 DROP TRIGGER IF EXISTS set_timestamp ON history.managedclustersetbindings;
 CREATE TRIGGER set_timestamp BEFORE UPDATE ON history.managedclustersetbindings FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
 DROP TRIGGER IF EXISTS set_timestamp ON history.managedclustersets;
@@ -19,10 +21,16 @@ DROP TRIGGER IF EXISTS set_timestamp ON history.placements;
 CREATE TRIGGER set_timestamp BEFORE UPDATE ON history.placements FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
 DROP TRIGGER IF EXISTS set_timestamp ON history.policies;
 CREATE TRIGGER set_timestamp BEFORE UPDATE ON history.policies FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
+DROP TRIGGER IF EXISTS set_timestamp ON history.policyautomations;
+CREATE TRIGGER set_timestamp BEFORE UPDATE ON history.policyautomations FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
+DROP TRIGGER IF EXISTS set_timestamp ON history.policysets;
+CREATE TRIGGER set_timestamp BEFORE UPDATE ON history.policysets FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
 DROP TRIGGER IF EXISTS set_timestamp ON history.subscriptions;
 CREATE TRIGGER set_timestamp BEFORE UPDATE ON history.subscriptions FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
 DROP TRIGGER IF EXISTS set_timestamp ON local_spec.placementrules;
 CREATE TRIGGER set_timestamp BEFORE UPDATE ON local_spec.placementrules FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
+DROP TRIGGER IF EXISTS set_timestamp ON local_spec.placements;
+CREATE TRIGGER set_timestamp BEFORE UPDATE ON local_spec.placements FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
 DROP TRIGGER IF EXISTS set_timestamp ON local_spec.policies;
 CREATE TRIGGER set_timestamp BEFORE UPDATE ON local_spec.policies FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
 
@@ -32,6 +40,8 @@ DROP TRIGGER IF EXISTS move_to_history ON spec.channels;
 CREATE TRIGGER move_to_history BEFORE INSERT ON spec.channels FOR EACH ROW EXECUTE FUNCTION public.move_channels_to_history();
 DROP TRIGGER IF EXISTS move_to_history ON spec.configs;
 CREATE TRIGGER move_to_history BEFORE INSERT ON spec.configs FOR EACH ROW EXECUTE FUNCTION public.move_configs_to_history();
+DROP TRIGGER IF EXISTS move_to_history ON spec.generic_resources;
+CREATE TRIGGER move_to_history BEFORE INSERT ON spec.generic_resources FOR EACH ROW EXECUTE FUNCTION public.move_generic_resources_to_history();
 DROP TRIGGER IF EXISTS move_to_history ON spec.managedclustersetbindings;
 CREATE TRIGGER move_to_history BEFORE INSERT ON spec.managedclustersetbindings FOR EACH ROW EXECUTE FUNCTION public.move_managedclustersetbindings_to_history();
 DROP TRIGGER IF EXISTS move_to_history ON spec.managedclustersets;
@@ -44,6 +54,10 @@ DROP TRIGGER IF EXISTS move_to_history ON spec.placements;
 CREATE TRIGGER move_to_history BEFORE INSERT ON spec.placements FOR EACH ROW EXECUTE FUNCTION public.move_placements_to_history();
 DROP TRIGGER IF EXISTS move_to_history ON spec.policies;
 CREATE TRIGGER move_to_history BEFORE INSERT ON spec.policies FOR EACH ROW EXECUTE FUNCTION public.move_policies_to_history();
+DROP TRIGGER IF EXISTS move_to_history ON spec.policyautomations;
+CREATE TRIGGER move_to_history BEFORE INSERT ON spec.policyautomations FOR EACH ROW EXECUTE FUNCTION public.move_policyautomations_to_history();
+DROP TRIGGER IF EXISTS move_to_history ON spec.policysets;
+CREATE TRIGGER move_to_history BEFORE INSERT ON spec.policysets FOR EACH ROW EXECUTE FUNCTION public.move_policysets_to_history();
 DROP TRIGGER IF EXISTS move_to_history ON spec.subscriptions;
 CREATE TRIGGER move_to_history BEFORE INSERT ON spec.subscriptions FOR EACH ROW EXECUTE FUNCTION public.move_subscriptions_to_history();
 
@@ -53,6 +67,10 @@ DROP TRIGGER IF EXISTS set_timestamp ON spec.channels;
 CREATE TRIGGER set_timestamp BEFORE UPDATE ON spec.channels FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
 DROP TRIGGER IF EXISTS set_timestamp ON spec.configs;
 CREATE TRIGGER set_timestamp BEFORE UPDATE ON spec.configs FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
+DROP TRIGGER IF EXISTS set_timestamp ON spec.generic_resources;
+CREATE TRIGGER set_timestamp BEFORE UPDATE ON spec.generic_resources FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
+DROP TRIGGER IF EXISTS set_timestamp ON spec.held_resources;
+CREATE TRIGGER set_timestamp BEFORE UPDATE ON spec.held_resources FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
 DROP TRIGGER IF EXISTS set_timestamp ON spec.managedclustersetbindings;
 CREATE TRIGGER set_timestamp BEFORE UPDATE ON spec.managedclustersetbindings FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
 DROP TRIGGER IF EXISTS set_timestamp ON spec.managedclustersets;
@@ -65,5 +83,9 @@ DROP TRIGGER IF EXISTS set_timestamp ON spec.placements;
 CREATE TRIGGER set_timestamp BEFORE UPDATE ON spec.placements FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
 DROP TRIGGER IF EXISTS set_timestamp ON spec.policies;
 CREATE TRIGGER set_timestamp BEFORE UPDATE ON spec.policies FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
+DROP TRIGGER IF EXISTS set_timestamp ON spec.policyautomations;
+CREATE TRIGGER set_timestamp BEFORE UPDATE ON spec.policyautomations FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
+DROP TRIGGER IF EXISTS set_timestamp ON spec.policysets;
+CREATE TRIGGER set_timestamp BEFORE UPDATE ON spec.policysets FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
 DROP TRIGGER IF EXISTS set_timestamp ON spec.subscriptions;
 CREATE TRIGGER set_timestamp BEFORE UPDATE ON spec.subscriptions FOR EACH ROW EXECUTE FUNCTION public.trigger_set_timestamp();
@@ -0,0 +1,377 @@
+package hubofhubs
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorv1alpha2 "github.com/stolostron/multicluster-global-hub/operator/apis/v1alpha2"
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/condition"
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/config"
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/constants"
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/deployer"
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/renderer"
+	commonconstants "github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+var (
+	kafkaGVK = schema.GroupVersionKind{
+		Group:   "kafka.strimzi.io",
+		Version: "v1beta2",
+		Kind:    "Kafka",
+	}
+	postgresClusterGVK = schema.GroupVersionKind{
+		Group:   "postgres-operator.crunchydata.com",
+		Version: "v1beta1",
+		Kind:    "PostgresCluster",
+	}
+)
+
+// reconcileManagedDataLayer provisions the kafka and postgres clusters through the strimzi and crunchy operators,
+// and generates the transport and storage secrets once the clusters are ready.
+// it returns false if any of the clusters is not ready yet.
+func (r *MulticlusterGlobalHubReconciler) reconcileManagedDataLayer(ctx context.Context,
+	mgh *operatorv1alpha2.MulticlusterGlobalHub, hohRenderer renderer.Renderer, hohDeployer deployer.Deployer,
+	mapper *restmapper.DeferredDiscoveryRESTMapper, log logr.Logger,
+) (bool, error) {
+	kafkaReady, err := r.reconcileManagedKafka(ctx, mgh, hohRenderer, hohDeployer, mapper, log)
+	if err != nil {
+		return false, err
+	}
+
+	postgresReady, err := r.reconcileManagedPostgres(ctx, mgh, hohRenderer, hohDeployer, mapper, log)
+	if err != nil {
+		return false, err
+	}
+
+	return kafkaReady && postgresReady, nil
+}
+
+func (r *MulticlusterGlobalHubReconciler) reconcileManagedKafka(ctx context.Context,
+	mgh *operatorv1alpha2.MulticlusterGlobalHub, hohRenderer renderer.Renderer, hohDeployer deployer.Deployer,
+	mapper *restmapper.DeferredDiscoveryRESTMapper, log logr.Logger,
+) (bool, error) {
+	managed := mgh.Spec.DataLayer.LargeScale.Managed
+	kafkaComponent := managed.Kafka
+	if kafkaComponent != nil && len(kafkaComponent.Env) > 0 {
+		// the strimzi container template only supports the name and value of the environment variables
+		kafkaComponent = kafkaComponent.DeepCopy()
+		for i := range kafkaComponent.Env {
+			kafkaComponent.Env[i].ValueFrom = nil
+		}
+	}
+	kafkaValues, err := config.GetComponentValues(kafkaComponent, constants.DefaultManagedKafkaReplicas)
+	if err != nil {
+		return false, err
+	}
+	replicas := kafkaValues.Replicas
+	// the internal topics can't be replicated more than the number of brokers
+	replicationFactor := replicas
+	if replicationFactor > constants.DefaultManagedKafkaReplicas {
+		replicationFactor = constants.DefaultManagedKafkaReplicas
+	}
+	minInsyncReplicas := replicationFactor - 1
+	if minInsyncReplicas < 1 {
+		minInsyncReplicas = 1
+	}
+
+	kafkaObjects, err := hohRenderer.Render("manifests/kafka", "", func(profile string) (interface{}, error) {
+		return struct {
+			KafkaCluster      string
+			Namespace         string
+			ExternalListener  string
+			SpecTopic         string
+			StatusTopic       string
+			ManagerUser       string
+			Replicas          int32
+			ReplicationFactor int32
+			MinInsyncReplicas int32
+			StorageSize       string
+			StorageClass      string
+			Kafka             *config.ComponentValues
+		}{
+			KafkaCluster:      constants.ManagedKafkaClusterName,
+			Namespace:         config.GetDefaultNamespace(),
+			ExternalListener:  constants.ManagedKafkaExternalListener,
+			SpecTopic:         constants.ManagedKafkaSpecTopic,
+			StatusTopic:       constants.ManagedKafkaStatusTopic,
+			ManagerUser:       constants.ManagedKafkaManagerUser,
+			Replicas:          replicas,
+			ReplicationFactor: replicationFactor,
+			MinInsyncReplicas: minInsyncReplicas,
+			StorageSize:       managed.KafkaStorageSize,
+			StorageClass:      managed.StorageClassName,
+			Kafka:             kafkaValues,
+		}, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if err = r.manipulateObj(ctx, hohDeployer, mapper, kafkaObjects, mgh, nil, log); err != nil {
+		condition.SetConditionKafkaProvisioned(mgh, condition.CONDITION_STATUS_FALSE)
+		return false, fmt.Errorf("failed to provision kafka, make sure the strimzi operator is installed: %w", err)
+	}
+
+	kafka := &unstructured.Unstructured{}
+	kafka.SetGroupVersionKind(kafkaGVK)
+	if err := r.Client.Get(ctx, types.NamespacedName{
+		Namespace: config.GetDefaultNamespace(),
+		Name:      constants.ManagedKafkaClusterName,
+	}, kafka); err != nil {
+		return false, err
+	}
+
+	bootstrapServer, kafkaCA, ready := getKafkaListenerStatus(kafka, constants.ManagedKafkaExternalListener)
+	if !ready {
+		log.Info("waiting for the kafka cluster to be ready", "name", constants.ManagedKafkaClusterName)
+		condition.SetConditionKafkaProvisioned(mgh, condition.CONDITION_STATUS_FALSE)
+		return false, nil
+	}
+
+	transportData := map[string][]byte{
+		"bootstrap_server": []byte(bootstrapServer),
+		"CA":               []byte(kafkaCA),
+	}
+	// the external listener requires the SCRAM authentication. the manager connects as its own kafka user, each
+	// regional hub agent connects as a kafka user of the regional hub that is created by the leaf hub controller.
+	password, err := r.getKafkaUserPassword(ctx, constants.ManagedKafkaManagerUser)
+	if err != nil {
+		return false, err
+	}
+	if password == "" {
+		log.Info("waiting for the kafka user to be ready", "name", constants.ManagedKafkaManagerUser)
+		condition.SetConditionKafkaProvisioned(mgh, condition.CONDITION_STATUS_FALSE)
+		return false, nil
+	}
+	transportData["manager_user"] = []byte(constants.ManagedKafkaManagerUser)
+	transportData["manager_password"] = []byte(password)
+
+	if err := r.reconcileGeneratedSecret(ctx, mgh, config.GetTransportSecretName(mgh), transportData); err != nil {
+		return false, err
+	}
+
+	condition.SetConditionKafkaProvisioned(mgh, condition.CONDITION_STATUS_TRUE)
+	return true, nil
+}
+
+func (r *MulticlusterGlobalHubReconciler) reconcileManagedPostgres(ctx context.Context,
+	mgh *operatorv1alpha2.MulticlusterGlobalHub, hohRenderer renderer.Renderer, hohDeployer deployer.Deployer,
+	mapper *restmapper.DeferredDiscoveryRESTMapper, log logr.Logger,
+) (bool, error) {
+	managed := mgh.Spec.DataLayer.LargeScale.Managed
+	storageSize := managed.PostgresStorageSize
+	if storageSize == "" {
+		storageSize = constants.DefaultManagedPostgresStorage
+	}
+	postgresValues, err := config.GetComponentValues(managed.Postgres, 1)
+	if err != nil {
+		return false, err
+	}
+
+	postgresObjects, err := hohRenderer.Render("manifests/postgres", "", func(profile string) (interface{}, error) {
+		return struct {
+			PostgresCluster  string
+			Namespace        string
+			PostgresImage    string
+			PGBackRestImage  string
+			PostgresUser     string
+			PostgresDatabase string
+			StorageSize      string
+			StorageClass     string
+			Postgres         *config.ComponentValues
+		}{
+			PostgresCluster:  constants.ManagedPostgresClusterName,
+			Namespace:        config.GetDefaultNamespace(),
+			PostgresImage:    config.GetImage("postgresql"),
+			PGBackRestImage:  config.GetImage("pgbackrest"),
+			PostgresUser:     constants.ManagedPostgresUserName,
+			PostgresDatabase: constants.ManagedPostgresDatabaseName,
+			StorageSize:      storageSize,
+			StorageClass:     managed.StorageClassName,
+			Postgres:         postgresValues,
+		}, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if err = r.manipulateObj(ctx, hohDeployer, mapper, postgresObjects, mgh, nil, log); err != nil {
+		condition.SetConditionPostgresProvisioned(mgh, condition.CONDITION_STATUS_FALSE)
+		return false, fmt.Errorf("failed to provision postgres, make sure the crunchy postgres operator is installed: %w",
+			err)
+	}
+
+	postgres := &unstructured.Unstructured{}
+	postgres.SetGroupVersionKind(postgresClusterGVK)
+	if err := r.Client.Get(ctx, types.NamespacedName{
+		Namespace: config.GetDefaultNamespace(),
+		Name:      constants.ManagedPostgresClusterName,
+	}, postgres); err != nil {
+		return false, err
+	}
+
+	databaseURI := ""
+	if isPostgresClusterReady(postgres) {
+		// the user secret is generated by the crunchy operator with the name <cluster>-pguser-<user>
+		userSecret, err := r.KubeClient.CoreV1().Secrets(config.GetDefaultNamespace()).Get(ctx,
+			fmt.Sprintf("%s-pguser-%s", constants.ManagedPostgresClusterName, constants.ManagedPostgresUserName),
+			metav1.GetOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+		if err == nil {
+			databaseURI = getDatabaseURI(userSecret)
+		}
+	}
+
+	if databaseURI == "" {
+		log.Info("waiting for the postgres cluster to be ready", "name", constants.ManagedPostgresClusterName)
+		condition.SetConditionPostgresProvisioned(mgh, condition.CONDITION_STATUS_FALSE)
+		return false, nil
+	}
+
+	if err := r.reconcileGeneratedSecret(ctx, mgh, config.GetStorageSecretName(mgh), map[string][]byte{
+		"database_uri": []byte(databaseURI),
+	}); err != nil {
+		return false, err
+	}
+
+	condition.SetConditionPostgresProvisioned(mgh, condition.CONDITION_STATUS_TRUE)
+	return true, nil
+}
+
+// getKafkaUserPassword returns the password of the kafka user from the secret generated by the strimzi user
+// operator with the name of the user, it returns empty if the secret isn't generated yet.
+func (r *MulticlusterGlobalHubReconciler) getKafkaUserPassword(ctx context.Context, user string) (string, error) {
+	userSecret, err := r.KubeClient.CoreV1().Secrets(config.GetDefaultNamespace()).Get(ctx, user,
+		metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return string(userSecret.Data["password"]), nil
+}
+
+// reconcileGeneratedSecret creates or updates the secret generated for the managed data layer,
+// the secret is owned by the multiclusterglobalhub instance so it is garbage collected along with it.
+func (r *MulticlusterGlobalHubReconciler) reconcileGeneratedSecret(ctx context.Context,
+	mgh *operatorv1alpha2.MulticlusterGlobalHub, name string, data map[string][]byte,
+) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: config.GetDefaultNamespace(),
+			Name:      name,
+			Labels: map[string]string{
+				commonconstants.GlobalHubOwnerLabelKey: commonconstants.HoHOperatorOwnerLabelVal,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+	if err := controllerutil.SetControllerReference(mgh, secret, r.Scheme); err != nil {
+		return err
+	}
+
+	existingSecret := &corev1.Secret{}
+	if err := r.Client.Get(ctx, types.NamespacedName{
+		Namespace: secret.GetNamespace(),
+		Name:      secret.GetName(),
+	}, existingSecret); err != nil {
+		if errors.IsNotFound(err) {
+			return r.Client.Create(ctx, secret)
+		}
+		return err
+	}
+
+	if !equality.Semantic.DeepDerivative(secret.Data, existingSecret.Data) ||
+		!equality.Semantic.DeepDerivative(secret.GetLabels(), existingSecret.GetLabels()) {
+		secret.ObjectMeta.ResourceVersion = existingSecret.ObjectMeta.ResourceVersion
+		return r.Client.Update(ctx, secret)
+	}
+
+	return nil
+}
+
+// getKafkaListenerStatus returns the bootstrap server and CA of the given listener once the kafka cluster is ready
+func getKafkaListenerStatus(kafka *unstructured.Unstructured, listenerName string) (string, string, bool) {
+	conditions, _, _ := unstructured.NestedSlice(kafka.Object, "status", "conditions")
+	ready := false
+	for _, c := range conditions {
+		kafkaCondition, ok := c.(map[string]interface{})
+		if ok && kafkaCondition["type"] == "Ready" && kafkaCondition["status"] == "True" {
+			ready = true
+		}
+	}
+	if !ready {
+		return "", "", false
+	}
+
+	listeners, _, _ := unstructured.NestedSlice(kafka.Object, "status", "listeners")
+	for _, l := range listeners {
+		listener, ok := l.(map[string]interface{})
+		// the listener name is reported in the "type" field by the earlier strimzi versions
+		if !ok || (listener["name"] != listenerName && listener["type"] != listenerName) {
+			continue
+		}
+		bootstrapServer, _, _ := unstructured.NestedString(listener, "bootstrapServers")
+		certificates, _, _ := unstructured.NestedStringSlice(listener, "certificates")
+		if bootstrapServer == "" || len(certificates) == 0 {
+			return "", "", false
+		}
+		return bootstrapServer, certificates[0], true
+	}
+
+	return "", "", false
+}
+
+// isPostgresClusterReady returns true if all the instance sets of the postgres cluster are ready
+func isPostgresClusterReady(postgres *unstructured.Unstructured) bool {
+	instances, _, _ := unstructured.NestedSlice(postgres.Object, "status", "instances")
+	if len(instances) == 0 {
+		return false
+	}
+	for _, i := range instances {
+		instance, ok := i.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		replicas, _, _ := unstructured.NestedInt64(instance, "replicas")
+		readyReplicas, _, _ := unstructured.NestedInt64(instance, "readyReplicas")
+		if replicas == 0 || readyReplicas < replicas {
+			return false
+		}
+	}
+	return true
+}
+
+// getDatabaseURI builds the database uri from the user secret generated by the crunchy operator
+func getDatabaseURI(userSecret *corev1.Secret) string {
+	host, port := string(userSecret.Data["host"]), string(userSecret.Data["port"])
+	user, password := string(userSecret.Data["user"]), string(userSecret.Data["password"])
+	if host == "" || port == "" || user == "" || password == "" {
+		return ""
+	}
+
+	databaseURI := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(user, password),
+		Host:   net.JoinHostPort(host, port),
+		Path:   constants.ManagedPostgresDatabaseName,
+	}
+	return databaseURI.String()
+}
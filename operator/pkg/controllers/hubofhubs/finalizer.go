@@ -21,6 +21,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	operatorv1alpha2 "github.com/stolostron/multicluster-global-hub/operator/apis/v1alpha2"
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/condition"
 	"github.com/stolostron/multicluster-global-hub/operator/pkg/constants"
 	"github.com/stolostron/multicluster-global-hub/operator/pkg/deployer"
 	"github.com/stolostron/multicluster-global-hub/operator/pkg/renderer"
@@ -36,36 +37,39 @@ func (r *MulticlusterGlobalHubReconciler) recocileFinalizer(ctx context.Context,
 	if mgh.GetDeletionTimestamp() != nil && utils.Contains(mgh.GetFinalizers(),
 		commonconstants.GlobalHubCleanupFinalizer) {
 
-		mgh.SetFinalizers(utils.Remove(mgh.GetFinalizers(), commonconstants.GlobalHubCleanupFinalizer))
-		if err := r.Client.Update(context.TODO(), mgh); err != nil {
-			log.Error(err, "failed to remove finalizer from multiclusterglobalhub resource")
-			return true, err
-		}
-		log.Info("finalizer is removed from multiclusterglobalhub resource")
-
-		// clean up namesapced resources, eg. mgh system namespace, etc
-		if err := r.pruneNamespacedResources(ctx, log); err != nil {
-			log.Error(err, "failed to remove namespaced resources")
-			return true, err
+		// the finalizer is removed once all the cleanup steps are completed, so a failed step is retried and
+		// reported in the Cleanup condition
+		cleanupSteps := []struct {
+			name  string
+			prune func(ctx context.Context, log logr.Logger) error
+		}{
+			// clean up namesapced resources, eg. mgh system namespace, etc
+			{name: "namespaced resources", prune: r.pruneNamespacedResources},
+			// clean up the cluster resources, eg. clusterrole, clusterrolebinding, etc
+			{name: "global resources", prune: r.pruneGlobalResources},
+			// clean up the application finalizer
+			{name: "application finalizers", prune: r.pruneApplicationFinalizer},
+			// clean up the policy finalizer
+			{name: "policy finalizers", prune: r.prunePolicyFinalizer},
 		}
 
-		// clean up the cluster resources, eg. clusterrole, clusterrolebinding, etc
-		if err := r.pruneGlobalResources(ctx, log); err != nil {
-			log.Error(err, "failed to remove global resources")
-			return true, err
+		for idx, step := range cleanupSteps {
+			condition.SetConditionCleanupProgress(mgh, step.name, idx+1, len(cleanupSteps))
+			if err := step.prune(ctx, log); err != nil {
+				log.Error(err, "failed to remove "+step.name)
+				condition.SetConditionCleanupFailed(mgh, step.name, err)
+				return true, err
+			}
 		}
 
-		// clean up the application finalizer
-		if err := r.pruneApplicationFinalizer(ctx, log); err != nil {
-			log.Error(err, "failed to remove application finalizer")
-			return true, err
-		}
+		condition.SetConditionCleanupCompleted(mgh)
 
-		// clean up the policy finalizer
-		if err := r.prunePolicyFinalizer(ctx, log); err != nil {
-			log.Error(err, "failed to remove policy finalizer")
+		mgh.SetFinalizers(utils.Remove(mgh.GetFinalizers(), commonconstants.GlobalHubCleanupFinalizer))
+		if err := r.Client.Update(ctx, mgh); err != nil {
+			log.Error(err, "failed to remove finalizer from multiclusterglobalhub resource")
 			return true, err
 		}
+		log.Info("finalizer is removed from multiclusterglobalhub resource")
 		return true, nil
 	}
 
@@ -18,9 +18,11 @@ package hubofhubs
 
 import (
 	"context"
+	"encoding/json"
 	"embed"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -68,6 +70,18 @@ var fs embed.FS
 
 var isLeafHubControllerRunnning = false
 
+// managedDataLayerRequeuePeriod is the period to check the readiness of the managed kafka and postgres
+const managedDataLayerRequeuePeriod = 30 * time.Second
+
+// healthCheckPeriod is the period to recheck the reachability of the unreachable database or kafka
+const healthCheckPeriod = 1 * time.Minute
+
+// kafkaDialTimeout is the timeout to connect to the kafka bootstrap servers
+const kafkaDialTimeout = 5 * time.Second
+
+// managerDeploymentName is the name of the manager deployment rendered from manifests/manager
+const managerDeploymentName = "multicluster-global-hub-manager"
+
 // var isPackageManifestControllerRunnning = false
 
 // MulticlusterGlobalHubReconciler reconciles a MulticlusterGlobalHub object
@@ -91,6 +105,8 @@ type MulticlusterGlobalHubReconciler struct {
 //+kubebuilder:rbac:groups=app.k8s.io,resources=applications,verbs=get;list;patch;update
 //+kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=placements,verbs=get;list;patch;update
 //+kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=managedclustersets,verbs=get;list;patch;update
+//+kubebuilder:rbac:groups=kafka.strimzi.io,resources=kafkas;kafkatopics;kafkausers,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups=postgres-operator.crunchydata.com,resources=postgresclusters,verbs=get;list;watch;create;update;delete
 
 //+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;delete
@@ -114,7 +130,9 @@ type MulticlusterGlobalHubReconciler struct {
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
-func (r *MulticlusterGlobalHubReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *MulticlusterGlobalHubReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
+	result ctrl.Result, reconcileErr error,
+) {
 	log := ctrllog.FromContext(ctx)
 	log.Info("Reconciling", "namespacedname", req.NamespacedName)
 
@@ -138,6 +156,17 @@ func (r *MulticlusterGlobalHubReconciler) Reconcile(ctx context.Context, req ctr
 		return ctrl.Result{}, nil
 	}
 
+	// the conditions set during the reconcile are updated in a single status update
+	originalStatus := mgh.Status.DeepCopy()
+	defer func() {
+		if err := condition.UpdateStatus(ctx, r.Client, mgh, originalStatus); err != nil {
+			log.Error(err, "failed to update the status of multiclusterglobalhub")
+			if reconcileErr == nil {
+				reconcileErr = err
+			}
+		}
+	}()
+
 	if mgh.Spec.DataLayer == nil {
 		return ctrl.Result{}, fmt.Errorf("empty data layer type.")
 	}
@@ -148,8 +177,9 @@ func (r *MulticlusterGlobalHubReconciler) Reconcile(ctx context.Context, req ctr
 			return ctrl.Result{}, err
 		}
 	case operatorv1alpha2.LargeScale:
-		if err := r.reconcileLargeScaleGlobalHub(ctx, mgh, log); err != nil {
-			return ctrl.Result{}, err
+		result, err := r.reconcileLargeScaleGlobalHub(ctx, mgh, log)
+		if err != nil || !result.IsZero() {
+			return result, err
 		}
 	default:
 		return ctrl.Result{}, fmt.Errorf("unsupported data layer type: %s", mgh.Spec.DataLayer.Type)
@@ -193,6 +223,15 @@ func (r *MulticlusterGlobalHubReconciler) Reconcile(ctx context.Context, req ctr
 	// 	isPackageManifestControllerRunnning = true
 	// }
 
+	if !mgh.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+	// requeue to refresh the reachability conditions only while the database or kafka is unreachable
+	if condition.ContainConditionStatus(mgh, condition.CONDITION_TYPE_DATABASE_REACHABLE,
+		condition.CONDITION_STATUS_FALSE) || condition.ContainConditionStatus(mgh,
+		condition.CONDITION_TYPE_KAFKA_REACHABLE, condition.CONDITION_STATUS_FALSE) {
+		return ctrl.Result{RequeueAfter: healthCheckPeriod}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -204,12 +243,12 @@ func (r *MulticlusterGlobalHubReconciler) reconcileNativeGlobalHub(ctx context.C
 
 func (r *MulticlusterGlobalHubReconciler) reconcileLargeScaleGlobalHub(ctx context.Context,
 	mgh *operatorv1alpha2.MulticlusterGlobalHub, log logr.Logger,
-) error {
-	// make sure largae scale data type settings are not empty
+) (ctrl.Result, error) {
+	// make sure largae scale data type settings are not empty, the secrets are generated in managed mode
 	if mgh.Spec.DataLayer.LargeScale == nil ||
-		mgh.Spec.DataLayer.LargeScale.Postgres.Name == "" ||
-		mgh.Spec.DataLayer.LargeScale.Kafka.Name == "" {
-		return fmt.Errorf("invalid settings for large scale data layer, " +
+		config.GetStorageSecretName(mgh) == "" ||
+		config.GetTransportSecretName(mgh) == "" {
+		return ctrl.Result{}, fmt.Errorf("invalid settings for large scale data layer, " +
 			"storage and transport secrets are required.")
 	}
 
@@ -219,7 +258,7 @@ func (r *MulticlusterGlobalHubReconciler) reconcileLargeScaleGlobalHub(ctx conte
 	// create discovery client
 	dc, err := discovery.NewDiscoveryClientForConfig(r.Manager.GetConfig())
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	// create restmapper for deployer to find GVR
@@ -228,12 +267,12 @@ func (r *MulticlusterGlobalHubReconciler) reconcileLargeScaleGlobalHub(ctx conte
 	// handle gc
 	isTerminating, err := r.recocileFinalizer(ctx, mgh, hohRenderer, hohDeployer, mapper, log)
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	if isTerminating {
 		log.Info("multiclusterglobalhub is terminating, skip the reconcile")
-		return nil
+		return ctrl.Result{}, nil
 	}
 
 	// check for image overrides configmap
@@ -246,43 +285,63 @@ func (r *MulticlusterGlobalHubReconciler) reconcileLargeScaleGlobalHub(ctx conte
 			log.Error(err, "failed to get image overrides configmap",
 				"namespace", mgh.GetNamespace(),
 				"name", imageOverridesConfigmapName)
-			return err
+			return ctrl.Result{}, err
 		}
 	}
 
 	// set imgae overrides
 	if err := config.SetImageOverrides(mgh, imageOverridesConfigmap); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
-	if !config.SkipDBInit(mgh) {
-		// init DB and transport here
-		if err = r.reconcileDatabase(ctx, mgh, types.NamespacedName{
-			Name:      mgh.Spec.DataLayer.LargeScale.Postgres.Name,
-			Namespace: config.GetDefaultNamespace(),
-		}); err != nil {
-			return err
+	// provision kafka and postgres and generate the transport and storage secrets in managed mode
+	if config.IsManagedDataLayer(mgh) {
+		ready, err := r.reconcileManagedDataLayer(ctx, mgh, hohRenderer, hohDeployer, mapper, log)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !ready {
+			return ctrl.Result{RequeueAfter: managedDataLayerRequeuePeriod}, nil
 		}
 	}
 
+	// init DB and check its reachability, the initialization is skipped if SkipDBInit is set
+	if err = r.reconcileDatabase(ctx, mgh, types.NamespacedName{
+		Name:      config.GetStorageSecretName(mgh),
+		Namespace: config.GetDefaultNamespace(),
+	}, config.SkipDBInit(mgh)); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// reconcile open-cluster-management-global-hub-system namespace and multicluster-global-hub configuration
 	if err = r.reconcileHoHResources(ctx, mgh); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	// retrieve bootstrapserver and CA of kafka from secret
 	kafkaBootstrapServer, kafkaCA, err := utils.GetKafkaConfig(ctx, r.KubeClient, mgh)
 	if err != nil {
-		if conditionError := condition.SetConditionTransportInit(ctx, r.Client, mgh,
-			condition.CONDITION_STATUS_FALSE); conditionError != nil {
-			return condition.FailToSetConditionError(condition.CONDITION_STATUS_FALSE, conditionError)
-		}
-		return err
+		condition.SetConditionTransportInit(mgh, condition.CONDITION_STATUS_FALSE)
+		return ctrl.Result{}, err
 	}
 
-	if conditionError := condition.SetConditionTransportInit(ctx, r.Client, mgh,
-		condition.CONDITION_STATUS_TRUE); conditionError != nil {
-		return condition.FailToSetConditionError(condition.CONDITION_STATUS_TRUE, conditionError)
+	condition.SetConditionTransportInit(mgh, condition.CONDITION_STATUS_TRUE)
+
+	// the reachability of kafka is reported only, the manager retries to connect to kafka by itself
+	condition.SetConditionKafkaReachable(mgh, utils.CheckBootstrapServers(kafkaBootstrapServer, kafkaDialTimeout))
+
+	kafkaUser, _, err := utils.GetKafkaUserCredential(ctx, r.KubeClient, mgh, "manager")
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	managerValues, err := config.GetComponentValues(mgh.Spec.Manager, 1)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	podValues, err := config.GetPodValues(mgh)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
 	managerObjects, err := hohRenderer.Render("manifests/manager", "", func(profile string) (interface{}, error) {
@@ -291,24 +350,66 @@ func (r *MulticlusterGlobalHubReconciler) reconcileLargeScaleGlobalHub(ctx conte
 			DBSecret             string
 			KafkaCA              string
 			KafkaBootstrapServer string
+			KafkaUser            string
+			TransportSecret      string
 			Namespace            string
+			Manager              *config.ComponentValues
+			Pod                  *config.PodValues
 		}{
 			Image:                config.GetImage("multicluster_global_hub_manager"),
-			DBSecret:             mgh.Spec.DataLayer.LargeScale.Postgres.Name,
+			DBSecret:             config.GetStorageSecretName(mgh),
 			KafkaCA:              kafkaCA,
 			KafkaBootstrapServer: kafkaBootstrapServer,
+			KafkaUser:            kafkaUser,
+			TransportSecret:      config.GetTransportSecretName(mgh),
 			Namespace:            config.GetDefaultNamespace(),
+			Manager:              managerValues,
+			Pod:                  podValues,
 		}, nil
 	})
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	if err = r.manipulateObj(ctx, hohDeployer, mapper, managerObjects, mgh,
 		condition.SetConditionManagerDeployed, log); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err = r.reconcileManagerReady(ctx, mgh); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileManagerReady reports the available replicas of the manager deployment
+func (r *MulticlusterGlobalHubReconciler) reconcileManagerReady(ctx context.Context,
+	mgh *operatorv1alpha2.MulticlusterGlobalHub,
+) error {
+	managerDeployment := &appsv1.Deployment{}
+	if err := r.Client.Get(ctx, types.NamespacedName{
+		Namespace: config.GetDefaultNamespace(),
+		Name:      managerDeploymentName,
+	}, managerDeployment); err != nil {
+		if errors.IsNotFound(err) {
+			condition.SetConditionManagerReady(mgh, 0, 1)
+			return nil
+		}
 		return err
 	}
 
+	desiredReplicas := int32(1)
+	if managerDeployment.Spec.Replicas != nil {
+		desiredReplicas = *managerDeployment.Spec.Replicas
+	}
+	availableReplicas := managerDeployment.Status.AvailableReplicas
+	// the status is stale until the deployment controller observes the latest spec
+	if managerDeployment.Status.ObservedGeneration < managerDeployment.GetGeneration() {
+		availableReplicas = 0
+	}
+
+	condition.SetConditionManagerReady(mgh, availableReplicas, desiredReplicas)
 	return nil
 }
 
@@ -347,21 +448,14 @@ func (r *MulticlusterGlobalHubReconciler) manipulateObj(ctx context.Context, hoh
 		log.Info("Creating or updating object", "object", obj)
 		if err := hohDeployer.Deploy(obj); err != nil {
 			if setConditionFunc != nil {
-				conditionError := setConditionFunc(ctx, r.Client, mgh, condition.CONDITION_STATUS_FALSE)
-				if conditionError != nil {
-					return condition.FailToSetConditionError(
-						condition.CONDITION_STATUS_FALSE, conditionError)
-				}
+				setConditionFunc(mgh, condition.CONDITION_STATUS_FALSE)
 			}
 			return err
 		}
 	}
 
 	if setConditionFunc != nil {
-		if conditionError := setConditionFunc(ctx, r.Client, mgh,
-			condition.CONDITION_STATUS_TRUE); conditionError != nil {
-			return condition.FailToSetConditionError(condition.CONDITION_STATUS_TRUE, conditionError)
-		}
+		setConditionFunc(mgh, condition.CONDITION_STATUS_TRUE)
 	}
 
 	return nil
@@ -391,6 +485,15 @@ func (r *MulticlusterGlobalHubReconciler) reconcileHoHResources(ctx context.Cont
 		}
 	}
 
+	// the projections are always set, an empty value would be ignored when comparing to the existing configmap
+	statusProjections, err := json.Marshal(mgh.Spec.StatusProjections)
+	if err != nil {
+		return err
+	}
+	if mgh.Spec.StatusProjections == nil {
+		statusProjections = []byte("[]")
+	}
+
 	// hoh configmap
 	hohConfigMap := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -401,8 +504,10 @@ func (r *MulticlusterGlobalHubReconciler) reconcileHoHResources(ctx context.Cont
 			},
 		},
 		Data: map[string]string{
-			"aggregationLevel":    string(mgh.Spec.AggregationLevel),
-			"enableLocalPolicies": strconv.FormatBool(mgh.Spec.EnableLocalPolicies),
+			"aggregationLevel":       string(mgh.Spec.AggregationLevel),
+			"enableLocalPolicies":    strconv.FormatBool(mgh.Spec.EnableLocalPolicies),
+			"complianceDetailsLevel": string(mgh.Spec.ComplianceDetailsLevel),
+			"statusProjections":      string(statusProjections),
 		},
 	}
 
@@ -464,6 +569,25 @@ func (r *MulticlusterGlobalHubReconciler) SetupWithManager(mgr ctrl.Manager) err
 		},
 	}
 
+	// the deployments are also requeued once their available replicas are changed to refresh the ManagerReady condition
+	deploymentPred := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() {
+				return true
+			}
+			oldDeployment, oldOK := e.ObjectOld.(*appsv1.Deployment)
+			newDeployment, newOK := e.ObjectNew.(*appsv1.Deployment)
+			return oldOK && newOK &&
+				oldDeployment.Status.AvailableReplicas != newDeployment.Status.AvailableReplicas
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+	}
+
 	resPred := predicate.Funcs{
 		CreateFunc: func(e event.CreateEvent) bool {
 			return false
@@ -484,7 +608,7 @@ func (r *MulticlusterGlobalHubReconciler) SetupWithManager(mgr ctrl.Manager) err
 
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&operatorv1alpha2.MulticlusterGlobalHub{}, builder.WithPredicates(mghPred)).
-		Owns(&appsv1.Deployment{}, builder.WithPredicates(ownPred)).
+		Owns(&appsv1.Deployment{}, builder.WithPredicates(deploymentPred)).
 		Owns(&corev1.Service{}, builder.WithPredicates(ownPred)).
 		Owns(&corev1.ServiceAccount{}, builder.WithPredicates(ownPred)).
 		Owns(&corev1.Secret{}, builder.WithPredicates(ownPred)).
@@ -318,63 +318,61 @@ var _ = Describe("MulticlusterGlobalHub controller", Ordered, func() {
 			}, timeout, interval).Should(BeTrue())
 
 			By("By checking the MGH CR database init conditions are created as expected")
-			condition.SetConditionDatabaseInit(ctx, k8sClient, createdMGH, condition.CONDITION_STATUS_TRUE)
+			condition.SetConditionDatabaseInit(createdMGH, condition.CONDITION_STATUS_TRUE)
 			Expect(condition.GetConditionStatus(createdMGH,
 				condition.CONDITION_TYPE_DATABASE_INIT)).Should(Equal(metav1.ConditionTrue))
 			Eventually(func() bool {
-				condition.SetConditionDatabaseInit(ctx, k8sClient, createdMGH, condition.CONDITION_STATUS_FALSE)
+				condition.SetConditionDatabaseInit(createdMGH, condition.CONDITION_STATUS_FALSE)
 				return condition.GetConditionStatus(createdMGH,
 					condition.CONDITION_TYPE_DATABASE_INIT) == metav1.ConditionFalse
 			}, timeout, interval).Should(BeTrue())
 			Eventually(func() bool {
-				condition.SetConditionDatabaseInit(ctx, k8sClient, createdMGH, condition.CONDITION_STATUS_UNKNOWN)
+				condition.SetConditionDatabaseInit(createdMGH, condition.CONDITION_STATUS_UNKNOWN)
 				return condition.GetConditionStatus(createdMGH,
 					condition.CONDITION_TYPE_DATABASE_INIT) == metav1.ConditionUnknown
 			}, timeout, interval).Should(BeTrue())
 
 			By("By checking the MGH CR transport init conditions are created as expected")
-			condition.SetConditionTransportInit(ctx, k8sClient, createdMGH, condition.CONDITION_STATUS_UNKNOWN)
+			condition.SetConditionTransportInit(createdMGH, condition.CONDITION_STATUS_UNKNOWN)
 			Expect(condition.GetConditionStatus(createdMGH,
 				condition.CONDITION_TYPE_TRANSPORT_INIT)).Should(Equal(metav1.ConditionUnknown))
 			Eventually(func() bool {
-				condition.SetConditionTransportInit(ctx, k8sClient, createdMGH, condition.CONDITION_STATUS_FALSE)
+				condition.SetConditionTransportInit(createdMGH, condition.CONDITION_STATUS_FALSE)
 				return condition.GetConditionStatus(createdMGH,
 					condition.CONDITION_TYPE_TRANSPORT_INIT) == metav1.ConditionFalse
 			}, timeout, interval).Should(BeTrue())
 			Eventually(func() bool {
-				condition.SetConditionTransportInit(ctx, k8sClient, createdMGH, condition.CONDITION_STATUS_TRUE)
+				condition.SetConditionTransportInit(createdMGH, condition.CONDITION_STATUS_TRUE)
 				return condition.GetConditionStatus(createdMGH,
 					condition.CONDITION_TYPE_TRANSPORT_INIT) == metav1.ConditionTrue
 			}, timeout, interval).Should(BeTrue())
 
 			By("By checking the MGH CR manager deployed conditions are created as expected")
-			condition.SetConditionManagerDeployed(ctx, k8sClient, createdMGH, condition.CONDITION_STATUS_FALSE)
+			condition.SetConditionManagerDeployed(createdMGH, condition.CONDITION_STATUS_FALSE)
 			Expect(condition.GetConditionStatus(createdMGH,
 				condition.CONDITION_TYPE_MANAGER_DEPLOY)).Should(Equal(metav1.ConditionFalse))
 			Eventually(func() bool {
-				condition.SetConditionManagerDeployed(ctx, k8sClient, createdMGH, condition.CONDITION_STATUS_TRUE)
+				condition.SetConditionManagerDeployed(createdMGH, condition.CONDITION_STATUS_TRUE)
 				return condition.GetConditionStatus(createdMGH,
 					condition.CONDITION_TYPE_MANAGER_DEPLOY) == metav1.ConditionTrue
 			}, timeout, interval).Should(BeTrue())
 			Eventually(func() bool {
-				condition.SetConditionManagerDeployed(ctx, k8sClient, createdMGH, condition.CONDITION_STATUS_UNKNOWN)
+				condition.SetConditionManagerDeployed(createdMGH, condition.CONDITION_STATUS_UNKNOWN)
 				return condition.GetConditionStatus(createdMGH,
 					condition.CONDITION_TYPE_MANAGER_DEPLOY) == metav1.ConditionUnknown
 			}, timeout, interval).Should(BeTrue())
 
 			By("By checking the MGH CR regional hub deployed conditions are created as expected")
-			condition.SetConditionLeafHubDeployed(ctx, k8sClient, createdMGH, "test", condition.CONDITION_STATUS_TRUE)
+			condition.SetConditionLeafHubDeployed(createdMGH, "test", condition.CONDITION_STATUS_TRUE)
 			Expect(condition.GetConditionStatus(createdMGH,
 				condition.CONDITION_TYPE_LEAFHUB_DEPLOY)).Should(Equal(metav1.ConditionTrue))
 			Eventually(func() bool {
-				condition.SetConditionLeafHubDeployed(ctx, k8sClient, createdMGH,
-					"test", condition.CONDITION_STATUS_FALSE)
+				condition.SetConditionLeafHubDeployed(createdMGH, "test", condition.CONDITION_STATUS_FALSE)
 				return condition.GetConditionStatus(createdMGH,
 					condition.CONDITION_TYPE_LEAFHUB_DEPLOY) == metav1.ConditionFalse
 			}, timeout, interval).Should(BeTrue())
 			Eventually(func() bool {
-				condition.SetConditionLeafHubDeployed(ctx, k8sClient, createdMGH,
-					"test", condition.CONDITION_STATUS_UNKNOWN)
+				condition.SetConditionLeafHubDeployed(createdMGH, "test", condition.CONDITION_STATUS_UNKNOWN)
 				return condition.GetConditionStatus(createdMGH,
 					condition.CONDITION_TYPE_LEAFHUB_DEPLOY) == metav1.ConditionUnknown
 			}, timeout, interval).Should(BeTrue())
@@ -411,6 +409,10 @@ var _ = Describe("MulticlusterGlobalHub controller", Ordered, func() {
 			}
 
 			By("By checking the multicluster-global-hub-manager resources are created as expected")
+			managerValues, err := config.GetComponentValues(mgh.Spec.Manager, 1)
+			Expect(err).NotTo(HaveOccurred())
+			podValues, err := config.GetPodValues(mgh)
+			Expect(err).NotTo(HaveOccurred())
 			managerObjects, err := hohRenderer.Render("manifests/manager", "", func(
 				profile string,
 			) (interface{}, error) {
@@ -420,12 +422,16 @@ var _ = Describe("MulticlusterGlobalHub controller", Ordered, func() {
 					KafkaCA              string
 					KafkaBootstrapServer string
 					Namespace            string
+					Manager              *config.ComponentValues
+					Pod                  *config.PodValues
 				}{
 					Image:                config.GetImage("multicluster_global_hub_manager"),
 					DBSecret:             mgh.Spec.DataLayer.LargeScale.Postgres.Name,
 					KafkaCA:              base64.RawStdEncoding.EncodeToString([]byte(kafkaCA)),
 					KafkaBootstrapServer: kafkaBootstrapServer,
 					Namespace:            config.GetDefaultNamespace(),
+					Manager:              managerValues,
+					Pod:                  podValues,
 				}, nil
 			})
 			Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,138 @@
+package leafhub
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha2 "github.com/stolostron/multicluster-global-hub/operator/apis/v1alpha2"
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/config"
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/constants"
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/utils"
+)
+
+var kafkaUserGVK = schema.GroupVersionKind{
+	Group:   "kafka.strimzi.io",
+	Version: "v1beta2",
+	Kind:    "KafkaUser",
+}
+
+// getKafkaUserName returns the name of the kafka user of the regional hub, the strimzi user operator generates the
+// secret of the user's password with the same name.
+func getKafkaUserName(managedClusterName string) string {
+	return constants.ManagedKafkaAgentUserPrefix + managedClusterName
+}
+
+// applyKafkaUser creates or updates the kafka user of the regional hub if the kafka provisioned by the operator
+// requires the authentication, and returns its user and password. the user is empty if the kafka doesn't require the
+// authentication. the user can only consume the spec topic in the consumer group of the regional hub and produce to
+// the status topic, so a leaked credential of a regional hub can't read the status of the other regional hubs.
+func applyKafkaUser(ctx context.Context, c client.Client, kubeClient kubernetes.Interface,
+	mgh *operatorv1alpha2.MulticlusterGlobalHub, managedClusterName string,
+) (string, string, error) {
+	// the manager user is set only in the transport secret of the kafka provisioned by the operator
+	managerUser, _, err := utils.GetKafkaUserCredential(ctx, kubeClient, mgh, "manager")
+	if err != nil || managerUser == "" {
+		return "", "", err
+	}
+
+	userName := getKafkaUserName(managedClusterName)
+	desiredUser := buildKafkaUser(userName, managedClusterName)
+
+	existingUser := &unstructured.Unstructured{}
+	existingUser.SetGroupVersionKind(kafkaUserGVK)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(desiredUser), existingUser); err != nil {
+		if !errors.IsNotFound(err) {
+			return "", "", err
+		}
+		if err := c.Create(ctx, desiredUser); err != nil {
+			return "", "", fmt.Errorf("failed to create kafka user %s - %w", userName, err)
+		}
+	} else if !equality.Semantic.DeepEqual(existingUser.Object["spec"], desiredUser.Object["spec"]) {
+		existingUser.Object["spec"] = desiredUser.Object["spec"]
+		if err := c.Update(ctx, existingUser); err != nil {
+			return "", "", fmt.Errorf("failed to update kafka user %s - %w", userName, err)
+		}
+	}
+
+	userSecret, err := kubeClient.CoreV1().Secrets(config.GetDefaultNamespace()).Get(ctx, userName,
+		metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return "", "", err
+	}
+	if errors.IsNotFound(err) || len(userSecret.Data["password"]) == 0 { // requeued until the user is ready
+		return "", "", fmt.Errorf("waiting for the kafka user %s to be ready", userName)
+	}
+
+	return userName, string(userSecret.Data["password"]), nil
+}
+
+// removeKafkaUser removes the kafka user of the regional hub, the secret of its password is removed with it.
+func removeKafkaUser(ctx context.Context, c client.Client, managedClusterName string) error {
+	kafkaUser := &unstructured.Unstructured{}
+	kafkaUser.SetGroupVersionKind(kafkaUserGVK)
+	kafkaUser.SetNamespace(config.GetDefaultNamespace())
+	kafkaUser.SetName(getKafkaUserName(managedClusterName))
+
+	if err := c.Delete(ctx, kafkaUser); err != nil && !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return fmt.Errorf("failed to delete kafka user %s - %w", kafkaUser.GetName(), err)
+	}
+
+	return nil
+}
+
+// buildKafkaUser builds the kafka user of the regional hub with the ACLs of its topics and consumer group, the
+// consumer group of the agent is the name of the regional hub.
+func buildKafkaUser(userName, managedClusterName string) *unstructured.Unstructured {
+	acl := func(resourceType, name string, operations ...string) interface{} {
+		return map[string]interface{}{
+			"resource": map[string]interface{}{
+				"type":        resourceType,
+				"name":        name,
+				"patternType": "literal",
+			},
+			"operations": toInterfaces(operations),
+		}
+	}
+
+	kafkaUser := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"authentication": map[string]interface{}{
+				"type": "scram-sha-512",
+			},
+			"authorization": map[string]interface{}{
+				"type": "simple",
+				"acls": []interface{}{
+					acl("topic", constants.ManagedKafkaSpecTopic, "Read", "Describe"),
+					acl("topic", constants.ManagedKafkaStatusTopic, "Write", "Describe"),
+					acl("group", managedClusterName, "Read"),
+				},
+			},
+		},
+	}}
+	kafkaUser.SetGroupVersionKind(kafkaUserGVK)
+	kafkaUser.SetNamespace(config.GetDefaultNamespace())
+	kafkaUser.SetName(userName)
+	kafkaUser.SetLabels(map[string]string{
+		"strimzi.io/cluster": constants.ManagedKafkaClusterName,
+	})
+
+	return kafkaUser
+}
+
+func toInterfaces(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		result = append(result, value)
+	}
+
+	return result
+}
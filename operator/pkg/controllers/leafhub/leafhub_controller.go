@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 
@@ -98,7 +99,9 @@ type LeafHubReconciler struct {
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
-func (r *LeafHubReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *LeafHubReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
+	result ctrl.Result, reconcileErr error,
+) {
 	log := ctrllog.FromContext(ctx)
 	log.Info("Reconciling", "namespacedname", req.NamespacedName)
 
@@ -133,51 +136,88 @@ func (r *LeafHubReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, nil
 	}
 
+	// the conditions set during the reconcile are updated in a single status update
+	originalStatus := mgh.Status.DeepCopy()
+	defer func() {
+		if err := condition.UpdateStatus(ctx, r.Client, mgh, originalStatus); err != nil {
+			log.Error(err, "failed to update the status of multiclusterglobalhub")
+			if reconcileErr == nil {
+				reconcileErr = err
+			}
+		}
+	}()
+
 	// if namespace of the reconcile request is empty, then the reconcile request is
 	// from either managed cluster changes or manifestwork changes for that managedcluster
 	// in either case, the controller doesn't need to go through all managed clusters
 	if req.NamespacedName.Namespace == "" && req.NamespacedName.Name != "" {
 		if err := r.reconcileLeafHub(ctx, req, mgh, shouldPruneAll, log); err != nil {
 			if !shouldPruneAll {
-				if conditionError := condition.SetConditionLeafHubDeployed(ctx, r.Client, mgh,
-					req.NamespacedName.Name, condition.CONDITION_STATUS_FALSE); conditionError != nil {
-					return ctrl.Result{}, condition.FailToSetConditionError(
-						condition.CONDITION_STATUS_FALSE, conditionError)
-				}
+				condition.SetConditionLeafHubDeployed(mgh, req.NamespacedName.Name, condition.CONDITION_STATUS_FALSE)
 			}
 			return ctrl.Result{}, err
 		}
 		if !shouldPruneAll && !condition.ContainsCondition(mgh,
 			condition.CONDITION_TYPE_LEAFHUB_DEPLOY) {
-			if conditionError := condition.SetConditionLeafHubDeployed(ctx, r.Client, mgh, req.NamespacedName.Name,
-				condition.CONDITION_STATUS_TRUE); conditionError != nil {
-				return ctrl.Result{}, condition.FailToSetConditionError(
-					condition.CONDITION_STATUS_TRUE, conditionError)
-			}
+			condition.SetConditionLeafHubDeployed(mgh, req.NamespacedName.Name, condition.CONDITION_STATUS_TRUE)
+		}
+		if !shouldPruneAll {
+			// requeue the leaf hub to rotate its transport key without any other change
+			return ctrl.Result{RequeueAfter: transportKeyRotationCheckInterval},
+				r.reconcileRegionalHubsHealthy(ctx, mgh)
 		}
 		return ctrl.Result{}, nil
 	}
 
 	if err := r.reconcileMulticlusterGlobalHub(ctx, req, mgh, shouldPruneAll, log); err != nil {
-		if conditionError := condition.SetConditionLeafHubDeployed(ctx, r.Client, mgh, "",
-			condition.CONDITION_STATUS_FALSE); conditionError != nil {
-			return ctrl.Result{}, condition.FailToSetConditionError(
-				condition.CONDITION_STATUS_FALSE, conditionError)
-		}
+		condition.SetConditionLeafHubDeployed(mgh, "", condition.CONDITION_STATUS_FALSE)
 		return ctrl.Result{}, err
 	}
 
 	if !shouldPruneAll && !condition.ContainsCondition(mgh,
 		condition.CONDITION_TYPE_LEAFHUB_DEPLOY) {
-		if conditionError := condition.SetConditionLeafHubDeployed(ctx, r.Client, mgh, req.NamespacedName.Name,
-			condition.CONDITION_STATUS_TRUE); conditionError != nil {
-			return ctrl.Result{}, condition.FailToSetConditionError(
-				condition.CONDITION_STATUS_TRUE, conditionError)
-		}
+		condition.SetConditionLeafHubDeployed(mgh, req.NamespacedName.Name, condition.CONDITION_STATUS_TRUE)
+	}
+	if !shouldPruneAll {
+		return ctrl.Result{}, r.reconcileRegionalHubsHealthy(ctx, mgh)
 	}
 	return ctrl.Result{}, nil
 }
 
+// reconcileRegionalHubsHealthy reports the regional hubs whose global hub addon isn't available
+func (r *LeafHubReconciler) reconcileRegionalHubsHealthy(ctx context.Context,
+	mgh *operatorv1alpha2.MulticlusterGlobalHub,
+) error {
+	leafhubs.RLock()
+	hubNames := make([]string, 0, len(leafhubs.clusters))
+	for hubName := range leafhubs.clusters {
+		hubNames = append(hubNames, hubName)
+	}
+	leafhubs.RUnlock()
+	sort.Strings(hubNames)
+
+	unhealthyHubs := []string{}
+	for _, hubName := range hubNames {
+		addon := &addonv1alpha1.ManagedClusterAddOn{}
+		if err := r.Client.Get(ctx, types.NamespacedName{
+			Namespace: hubName,
+			Name:      constants.HoHManagedClusterAddonName,
+		}, addon); err != nil {
+			if errors.IsNotFound(err) {
+				unhealthyHubs = append(unhealthyHubs, hubName)
+				continue
+			}
+			return err
+		}
+		if !meta.IsStatusConditionTrue(addon.Status.Conditions, addonv1alpha1.ManagedClusterAddOnConditionAvailable) {
+			unhealthyHubs = append(unhealthyHubs, hubName)
+		}
+	}
+
+	condition.SetConditionRegionalHubsHealthy(mgh, unhealthyHubs, len(hubNames))
+	return nil
+}
+
 // reconcileLeafHub reconciles a single leafhub
 func (r *LeafHubReconciler) reconcileLeafHub(ctx context.Context, req ctrl.Request,
 	mgh *operatorv1alpha2.MulticlusterGlobalHub, toDelete bool, log logr.Logger,
@@ -260,6 +300,14 @@ func (r *LeafHubReconciler) reconcileLeafHub(ctx context.Context, req ctrl.Reque
 			}
 		}
 
+		if err := removeTransportKeys(ctx, r.KubeClient, managedClusterName); err != nil {
+			return err
+		}
+
+		if err := removeKafkaUser(ctx, r.Client, managedClusterName); err != nil {
+			return err
+		}
+
 		// delete managedclusteraddon for the managedcluster
 		return deleteManagedClusterAddon(ctx, r.Client, log, managedClusterName)
 	}
@@ -556,12 +604,19 @@ func (r *LeafHubReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			return false
 		},
 		UpdateFunc: func(e event.UpdateEvent) bool {
-			if e.ObjectNew.GetLabels()[commonconstants.GlobalHubOwnerLabelKey] ==
-				commonconstants.HoHOperatorOwnerLabelVal &&
-				e.ObjectNew.GetGeneration() != e.ObjectOld.GetGeneration() {
+			if e.ObjectNew.GetLabels()[commonconstants.GlobalHubOwnerLabelKey] !=
+				commonconstants.HoHOperatorOwnerLabelVal {
+				return false
+			}
+			if e.ObjectNew.GetGeneration() != e.ObjectOld.GetGeneration() {
 				return true
 			}
-			return false
+			// also requeue once the availability is changed to refresh the RegionalHubsHealthy condition
+			oldAddon, oldOK := e.ObjectOld.(*addonv1alpha1.ManagedClusterAddOn)
+			newAddon, newOK := e.ObjectNew.(*addonv1alpha1.ManagedClusterAddOn)
+			return oldOK && newOK &&
+				meta.IsStatusConditionTrue(oldAddon.Status.Conditions, addonv1alpha1.ManagedClusterAddOnConditionAvailable) !=
+					meta.IsStatusConditionTrue(newAddon.Status.Conditions, addonv1alpha1.ManagedClusterAddOnConditionAvailable)
 		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
 			return e.Object.GetLabels()[commonconstants.GlobalHubOwnerLabelKey] ==
@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"context"
 	"embed"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -92,7 +93,12 @@ type HoHAgentConfigValues struct {
 	LeadHubID              string
 	KafkaBootstrapServer   string
 	KafkaCA                string
+	KafkaUser              string
+	KafkaPassword          string // base64 encoded
 	HostedClusterNamespace string // for hypershift case
+	ImagePullPolicy        string
+	Agent                  *config.ComponentValues
+	TransportKeys          map[string]string // the base64 encoded keys that decrypt the spec messages
 }
 
 // applyHubSubWork creates or updates the subscription manifestwork for leafhub cluster
@@ -627,12 +633,34 @@ func applyHoHAgentWork(ctx context.Context, c client.Client, kubeClient kubernet
 	if err != nil {
 		return err
 	}
+	kafkaUser, kafkaPassword, err := applyKafkaUser(ctx, c, kubeClient, mgh, managedClusterName)
+	if err != nil {
+		return err
+	}
+
+	agentValues, err := config.GetComponentValues(mgh.Spec.Agent, 1)
+	if err != nil {
+		return err
+	}
+	podValues, err := config.GetPodValues(mgh)
+	if err != nil {
+		return err
+	}
+	transportKeys, err := applyTransportKeys(ctx, kubeClient, managedClusterName)
+	if err != nil {
+		return err
+	}
 
 	agentConfigValues := &HoHAgentConfigValues{
 		HoHAgentImage:        config.GetImage("multicluster_global_hub_agent"),
 		LeadHubID:            managedClusterName,
 		KafkaBootstrapServer: kafkaBootstrapServer,
 		KafkaCA:              kafkaCA,
+		KafkaUser:            kafkaUser,
+		KafkaPassword:        base64.StdEncoding.EncodeToString([]byte(kafkaPassword)),
+		ImagePullPolicy:      podValues.ImagePullPolicy,
+		Agent:                agentValues,
+		TransportKeys:        transportKeys,
 	}
 
 	tpl, err := parseNonHypershiftTemplates(nonHypershiftManifestFS)
@@ -698,13 +726,35 @@ func applyHoHAgentHypershiftWork(ctx context.Context, c client.Client, kubeClien
 	if err != nil {
 		return err
 	}
+	kafkaUser, kafkaPassword, err := applyKafkaUser(ctx, c, kubeClient, mgh, hcConfig.ManagedClusterName)
+	if err != nil {
+		return err
+	}
+
+	agentValues, err := config.GetComponentValues(mgh.Spec.Agent, 1)
+	if err != nil {
+		return err
+	}
+	podValues, err := config.GetPodValues(mgh)
+	if err != nil {
+		return err
+	}
+	transportKeys, err := applyTransportKeys(ctx, kubeClient, hcConfig.ManagedClusterName)
+	if err != nil {
+		return err
+	}
 
 	agentConfigValues := &HoHAgentConfigValues{
 		HoHAgentImage:          config.GetImage("multicluster_global_hub_agent"),
 		LeadHubID:              hcConfig.ManagedClusterName,
 		KafkaBootstrapServer:   kafkaBootstrapServer,
 		KafkaCA:                kafkaCA,
+		KafkaUser:              kafkaUser,
+		KafkaPassword:          base64.StdEncoding.EncodeToString([]byte(kafkaPassword)),
 		HostedClusterNamespace: fmt.Sprintf("%s-%s", hcConfig.HostingNamespace, hcConfig.HostedClusterName),
+		ImagePullPolicy:        podValues.ImagePullPolicy,
+		Agent:                  agentValues,
+		TransportKeys:          transportKeys,
 	}
 
 	tpl, err := parseAgentHypershiftTemplates(hypershiftAgentManifestFS)
@@ -1011,6 +1061,15 @@ func ensureManifestWork(desired, existing *workv1.ManifestWork, log logr.Logger)
 		metadata := existingObj.(map[string]interface{})["metadata"].(map[string]interface{})
 		// to avoid noise of compare due to https://github.com/kubernetes/kubernetes/issues/67610
 		metadata["creationTimestamp"] = nil
+		// the fields removed from the desired deployment, e.g. resources or affinity, are ignored by
+		// DeepDerivative, so the deployment spec is compared strictly
+		if desiredObj.(map[string]interface{})["kind"] == "Deployment" &&
+			!equality.Semantic.DeepEqual(desiredObj.(map[string]interface{})["spec"],
+				existingObj.(map[string]interface{})["spec"]) {
+			log.Info("existing manifest deployment is not equal to the desired manifest deployment",
+				"namespace", existing.GetNamespace(), "name", existing.GetName(), "index", i)
+			return true, nil
+		}
 		if !equality.Semantic.DeepDerivative(desiredObj, existingObj) {
 			log.Info("existing manifest object is not equal to the desired manifest object",
 				"namespace", existing.GetNamespace(), "name", existing.GetName(), "index", i)
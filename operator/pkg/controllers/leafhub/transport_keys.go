@@ -0,0 +1,135 @@
+package leafhub
+
+import (
+	"context"
+	"encoding/base64"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/config"
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/constants"
+	"github.com/stolostron/multicluster-global-hub/pkg/encryptor"
+)
+
+const (
+	// transportKeyRotationPeriod is the period of rotating the key that encrypts the spec messages to a regional hub.
+	transportKeyRotationPeriod = 30 * 24 * time.Hour
+	// transportKeyRotationCheckInterval is the interval of checking whether the key of a regional hub is to rotate.
+	transportKeyRotationCheckInterval = time.Hour
+	// transportKeysRetained is the number of the keys of a regional hub kept in the keys secrets, the previous key
+	// decrypts the messages sent before the rotation.
+	transportKeysRetained = 2
+)
+
+// applyTransportKeys creates the key of the regional hub in the keys secret of the global hub, or rotates it if it's
+// older than the rotation period. returns the base64 encoded keys of the regional hub by their names in the secret.
+func applyTransportKeys(ctx context.Context, kubeClient kubernetes.Interface,
+	managedClusterName string,
+) (map[string]string, error) {
+	secrets := kubeClient.CoreV1().Secrets(config.GetDefaultNamespace())
+
+	secret, err := secrets.Get(ctx, constants.TransportKeysSecretName, metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if errors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      constants.TransportKeysSecretName,
+				Namespace: config.GetDefaultNamespace(),
+			},
+			Type: corev1.SecretTypeOpaque,
+		}
+	}
+
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+
+	keyIDs := getTransportKeyIDs(secret, managedClusterName)
+	changed := false
+
+	if len(keyIDs) == 0 || keyIDs[len(keyIDs)-1] < encryptor.NewKeyID(time.Now().Add(-transportKeyRotationPeriod)) {
+		key, err := encryptor.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		keyID := encryptor.NewKeyID(time.Now())
+		secret.Data[encryptor.KeyName(managedClusterName, keyID)] = key
+		keyIDs = append(keyIDs, keyID)
+		changed = true
+	}
+
+	for len(keyIDs) > transportKeysRetained {
+		delete(secret.Data, encryptor.KeyName(managedClusterName, keyIDs[0]))
+		keyIDs = keyIDs[1:]
+		changed = true
+	}
+
+	if changed && secret.ResourceVersion == "" {
+		if _, err := secrets.Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			return nil, err
+		}
+	} else if changed {
+		if _, err := secrets.Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+			return nil, err
+		}
+	}
+
+	keys := make(map[string]string, len(keyIDs))
+	for _, keyID := range keyIDs {
+		keyName := encryptor.KeyName(managedClusterName, keyID)
+		keys[keyName] = base64.StdEncoding.EncodeToString(secret.Data[keyName])
+	}
+
+	return keys, nil
+}
+
+// removeTransportKeys removes the keys of the regional hub from the keys secret of the global hub.
+func removeTransportKeys(ctx context.Context, kubeClient kubernetes.Interface, managedClusterName string) error {
+	secrets := kubeClient.CoreV1().Secrets(config.GetDefaultNamespace())
+
+	secret, err := secrets.Get(ctx, constants.TransportKeysSecretName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	keyIDs := getTransportKeyIDs(secret, managedClusterName)
+	if len(keyIDs) == 0 {
+		return nil
+	}
+
+	for _, keyID := range keyIDs {
+		delete(secret.Data, encryptor.KeyName(managedClusterName, keyID))
+	}
+
+	_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+
+	return err
+}
+
+// getTransportKeyIDs returns the sorted ids of the keys of the regional hub in the keys secret.
+func getTransportKeyIDs(secret *corev1.Secret, managedClusterName string) []string {
+	keyIDs := []string{}
+
+	for keyName := range secret.Data {
+		if hubName, keyID, ok := encryptor.ParseKeyName(keyName); ok && hubName == managedClusterName {
+			keyIDs = append(keyIDs, keyID)
+		}
+	}
+
+	sort.Strings(keyIDs)
+
+	return keyIDs
+}
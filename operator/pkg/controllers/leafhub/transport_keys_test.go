@@ -0,0 +1,104 @@
+package leafhub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/config"
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/constants"
+	"github.com/stolostron/multicluster-global-hub/pkg/encryptor"
+)
+
+func TestApplyTransportKeys(t *testing.T) {
+	ctx := context.Background()
+	hubName := "hub1.example.com"
+	expiredKeyID := encryptor.NewKeyID(time.Now().Add(-2 * transportKeyRotationPeriod))
+	oldKeyID := encryptor.NewKeyID(time.Now().Add(-transportKeyRotationPeriod - time.Hour))
+	recentKeyID := encryptor.NewKeyID(time.Now().Add(-time.Hour))
+	otherHubKey := encryptor.KeyName("hub2", expiredKeyID)
+
+	cases := []struct {
+		name           string
+		keyIDs         []string
+		rotated        bool
+		expectedKeyIDs []string
+	}{
+		{"first key", nil, true, nil},
+		{"recent key", []string{recentKeyID}, false, []string{recentKeyID}},
+		{"rotated key", []string{oldKeyID}, true, []string{oldKeyID}},
+		{"rotated and pruned keys", []string{expiredKeyID, oldKeyID}, true, []string{oldKeyID}},
+		{"pruned keys", []string{expiredKeyID, oldKeyID, recentKeyID}, false, []string{oldKeyID, recentKeyID}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			kubeClient := fake.NewSimpleClientset()
+
+			if c.keyIDs != nil {
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            constants.TransportKeysSecretName,
+						Namespace:       config.GetDefaultNamespace(),
+						ResourceVersion: "1",
+					},
+					Data: map[string][]byte{otherHubKey: []byte("key")},
+				}
+				for _, keyID := range c.keyIDs {
+					secret.Data[encryptor.KeyName(hubName, keyID)] = []byte(keyID)
+				}
+
+				if _, err := kubeClient.CoreV1().Secrets(secret.Namespace).Create(ctx, secret,
+					metav1.CreateOptions{}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			keys, err := applyTransportKeys(ctx, kubeClient, hubName)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			secret, err := kubeClient.CoreV1().Secrets(config.GetDefaultNamespace()).Get(ctx,
+				constants.TransportKeysSecretName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			keyIDs := getTransportKeyIDs(secret, hubName)
+			expectedKeys := len(c.expectedKeyIDs)
+			if c.rotated {
+				expectedKeys++
+			}
+
+			if len(keyIDs) != expectedKeys || len(keys) != expectedKeys || expectedKeys > transportKeysRetained {
+				t.Fatalf("expected %d keys, got %v in the secret and %v returned", expectedKeys, keyIDs, keys)
+			}
+
+			for i, keyID := range c.expectedKeyIDs {
+				if keyIDs[i] != keyID {
+					t.Errorf("expected the key %s to be kept, got %v", keyID, keyIDs)
+				}
+			}
+
+			if c.rotated && len(secret.Data[encryptor.KeyName(hubName, keyIDs[len(keyIDs)-1])]) !=
+				encryptor.KeySize {
+				t.Errorf("expected a new key, got %v", keyIDs)
+			}
+
+			if c.keyIDs != nil && secret.Data[otherHubKey] == nil {
+				t.Error("expected the keys of the other hubs to be kept")
+			}
+
+			for keyName := range keys {
+				if _, found := secret.Data[keyName]; !found {
+					t.Errorf("expected the returned key %s to be in the secret", keyName)
+				}
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package deployer
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
+	"fmt"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -15,6 +17,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// podTemplateHashAnnotation is the hash of the pod template of the deployments rendered by the operator
+const podTemplateHashAnnotation = "global-hub.open-cluster-management.io/pod-template-hash"
+
 type deployFunc func(*unstructured.Unstructured, *unstructured.Unstructured) error
 
 // HoHDeployer is an implementation of Deployer interface
@@ -95,15 +100,70 @@ func (d *HoHDeployer) deployDeployment(desiredObj, existingObj *unstructured.Uns
 		return err
 	}
 
+	if err = setPodTemplateHash(desiredDepoly); err != nil {
+		return err
+	}
+
 	if !apiequality.Semantic.DeepDerivative(desiredDepoly.Spec, existingDepoly.Spec) ||
+		deploymentDrifted(desiredDepoly, existingDepoly) ||
 		!apiequality.Semantic.DeepDerivative(desiredDepoly.GetLabels(), existingDepoly.GetLabels()) ||
 		!apiequality.Semantic.DeepDerivative(desiredDepoly.GetAnnotations(), existingDepoly.GetAnnotations()) {
+		desiredDepoly.ObjectMeta.ResourceVersion = existingDepoly.ObjectMeta.ResourceVersion
 		return d.client.Update(context.TODO(), desiredDepoly)
 	}
 
 	return nil
 }
 
+// setPodTemplateHash annotates the deployment with the hash of its pod template, so that any change of the
+// desired pod template, including the removed fields and list items that DeepDerivative ignores, is detected
+// by comparing the annotations of the desired and the existing deployments.
+func setPodTemplateHash(deploy *appsv1.Deployment) error {
+	templateJSON, err := json.Marshal(deploy.Spec.Template)
+	if err != nil {
+		return err
+	}
+
+	annotations := deploy.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[podTemplateHashAnnotation] = fmt.Sprintf("%x", sha256.Sum256(templateJSON))
+	deploy.SetAnnotations(annotations)
+
+	return nil
+}
+
+// deploymentDrifted returns true if the replicas of the existing deployment differ from the desired one, or if
+// the scheduling and container settings were added to it manually. the changes of the desired pod template are
+// detected by its hash annotation, the manual changes of the existing values by DeepDerivative.
+func deploymentDrifted(desired, existing *appsv1.Deployment) bool {
+	if desired.Spec.Replicas != nil && (existing.Spec.Replicas == nil ||
+		*desired.Spec.Replicas != *existing.Spec.Replicas) {
+		return true
+	}
+
+	desiredPodSpec, existingPodSpec := desired.Spec.Template.Spec, existing.Spec.Template.Spec
+	if !apiequality.Semantic.DeepEqual(desiredPodSpec.Affinity, existingPodSpec.Affinity) ||
+		desiredPodSpec.PriorityClassName != existingPodSpec.PriorityClassName ||
+		!apiequality.Semantic.DeepEqual(desiredPodSpec.NodeSelector, existingPodSpec.NodeSelector) ||
+		!apiequality.Semantic.DeepEqual(desiredPodSpec.Tolerations, existingPodSpec.Tolerations) ||
+		len(desiredPodSpec.Containers) != len(existingPodSpec.Containers) {
+		return true
+	}
+
+	for i, desiredContainer := range desiredPodSpec.Containers {
+		existingContainer := existingPodSpec.Containers[i]
+		if !apiequality.Semantic.DeepEqual(desiredContainer.Resources, existingContainer.Resources) ||
+			!apiequality.Semantic.DeepEqual(desiredContainer.Args, existingContainer.Args) ||
+			len(desiredContainer.Env) != len(existingContainer.Env) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (d *HoHDeployer) deployService(desiredObj, existingObj *unstructured.Unstructured) error {
 	existingJSON, _ := existingObj.MarshalJSON()
 	existingService := &corev1.Service{}
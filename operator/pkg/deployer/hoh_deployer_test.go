@@ -270,6 +270,77 @@ var _ = Describe("Deployer", Ordered, func() {
 		})
 	})
 
+	Context("with typed objects", func() {
+		It("should be updated when the resources and tolerations are removed", func() {
+			By("load typed objects")
+			typedObjects, err := loadObjects(manifestsFS, "testdata/typedv1")
+			Expect(err).NotTo(HaveOccurred())
+
+			By("update the deployment by removing the resources and tolerations")
+			for _, obj := range typedObjects {
+				if obj.GetKind() != "Deployment" {
+					continue
+				}
+				containers, _, err := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+				Expect(err).NotTo(HaveOccurred())
+				for _, container := range containers {
+					delete(container.(map[string]interface{}), "resources")
+				}
+				Expect(unstructured.SetNestedSlice(obj.Object, containers,
+					"spec", "template", "spec", "containers")).NotTo(HaveOccurred())
+				unstructured.RemoveNestedField(obj.Object, "spec", "template", "spec", "tolerations")
+				Expect(hohdeployer.Deploy(obj)).NotTo(HaveOccurred())
+
+				By("check the resources and tolerations of the deployment are removed")
+				unsObj := &unstructured.Unstructured{}
+				unsObj.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+				err = k8sClient.Get(ctx, types.NamespacedName{
+					Namespace: obj.GetNamespace(),
+					Name:      obj.GetName(),
+				}, unsObj)
+				Expect(err).NotTo(HaveOccurred())
+				_, found, err := unstructured.NestedSlice(unsObj.Object, "spec", "template", "spec", "tolerations")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).Should(BeFalse())
+				containers, _, err = unstructured.NestedSlice(unsObj.Object, "spec", "template", "spec", "containers")
+				Expect(err).NotTo(HaveOccurred())
+				for _, container := range containers {
+					Expect(container.(map[string]interface{})["resources"]).Should(BeEmpty())
+				}
+			}
+		})
+	})
+
+	Context("with typed objects", func() {
+		It("should be updated when the pod annotations are removed", func() {
+			By("load typed objects")
+			typedObjects, err := loadObjects(manifestsFS, "testdata/typedv1")
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, obj := range typedObjects {
+				if obj.GetKind() != "Deployment" {
+					continue
+				}
+				By("update the deployment by removing the pod annotations")
+				unstructured.RemoveNestedField(obj.Object, "spec", "template", "metadata", "annotations")
+				Expect(hohdeployer.Deploy(obj)).NotTo(HaveOccurred())
+
+				By("check the pod annotations of the deployment are removed")
+				unsObj := &unstructured.Unstructured{}
+				unsObj.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+				err = k8sClient.Get(ctx, types.NamespacedName{
+					Namespace: obj.GetNamespace(),
+					Name:      obj.GetName(),
+				}, unsObj)
+				Expect(err).NotTo(HaveOccurred())
+				podAnnotations, _, err := unstructured.NestedStringMap(unsObj.Object,
+					"spec", "template", "metadata", "annotations")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(podAnnotations).ShouldNot(HaveKey("app.kubernetes.io/name"))
+			}
+		})
+	})
+
 	Context("with untyped objects", func() {
 		It("should be updated when new labels are added", func() {
 			By("load untyped objects")
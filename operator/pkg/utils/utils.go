@@ -20,6 +20,10 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"fmt"
+	"net"
+	"strings"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -89,7 +93,7 @@ func GetKafkaConfig(ctx context.Context, kubeClient kubernetes.Interface,
 	mgh *operatorv1alpha2.MulticlusterGlobalHub,
 ) (string, string, error) {
 	kafkaSecret, err := kubeClient.CoreV1().Secrets(config.GetDefaultNamespace()).Get(ctx,
-		mgh.Spec.DataLayer.LargeScale.Kafka.Name, metav1.GetOptions{})
+		config.GetTransportSecretName(mgh), metav1.GetOptions{})
 	if err != nil {
 		return "", "", err
 	}
@@ -97,3 +101,37 @@ func GetKafkaConfig(ctx context.Context, kubeClient kubernetes.Interface,
 	return string(kafkaSecret.Data["bootstrap_server"]),
 		base64.RawStdEncoding.EncodeToString(kafkaSecret.Data["CA"]), nil
 }
+
+// GetKafkaUserCredential retrieves the user and password of the given component, e.g. manager or agent, to
+// authenticate to kafka with from the kafka secret, the user is empty if kafka doesn't require authentication.
+func GetKafkaUserCredential(ctx context.Context, kubeClient kubernetes.Interface,
+	mgh *operatorv1alpha2.MulticlusterGlobalHub, component string,
+) (string, string, error) {
+	kafkaSecret, err := kubeClient.CoreV1().Secrets(config.GetDefaultNamespace()).Get(ctx,
+		config.GetTransportSecretName(mgh), metav1.GetOptions{})
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(kafkaSecret.Data[component+"_user"]), string(kafkaSecret.Data[component+"_password"]), nil
+}
+
+// CheckBootstrapServers tries to connect to each of the comma separated kafka bootstrap servers,
+// it returns the error of the first unreachable one
+func CheckBootstrapServers(bootstrapServers string, timeout time.Duration) error {
+	if strings.TrimSpace(bootstrapServers) == "" {
+		return fmt.Errorf("empty bootstrap server")
+	}
+	for _, server := range strings.Split(bootstrapServers, ",") {
+		server = strings.TrimSpace(server)
+		if server == "" {
+			continue
+		}
+		conn, err := net.DialTimeout("tcp", server, timeout)
+		if err != nil {
+			return fmt.Errorf("failed to connect to %s: %w", server, err)
+		}
+		conn.Close()
+	}
+	return nil
+}
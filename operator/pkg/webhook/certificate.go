@@ -0,0 +1,416 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	operatorv1alpha2 "github.com/stolostron/multicluster-global-hub/operator/apis/v1alpha2"
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/constants"
+	commonconstants "github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+//+kubebuilder:rbac:groups="admissionregistration.k8s.io",resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;create;update;delete
+
+const (
+	caCertKey = "ca.crt"
+	// certValidity is the validity of the generated CA and serving certificate
+	certValidity = 365 * 24 * time.Hour
+	// certRenewBefore is the time before the expiration to regenerate the certificates
+	certRenewBefore = 30 * 24 * time.Hour
+	// certCheckPeriod is the period to check the expiration of the certificates
+	certCheckPeriod = 24 * time.Hour
+	// webhookCheckPeriod is the period to recreate the webhook configurations removed by a stopped operator replica
+	webhookCheckPeriod = 10 * time.Second
+	// webhookTimeoutSeconds is the timeout of the admission requests
+	webhookTimeoutSeconds = 10
+)
+
+// CertManager generates the self-signed CA and serving certificate of the webhook server, it keeps them in a secret
+// so they're shared by the operator replicas, writes them to the cert dir of the webhook server and injects the CA
+// into the webhook configurations. The certificates are regenerated before they expire. The webhook configurations
+// are deleted when the operator stops, otherwise the failing webhook would reject every change of the
+// MulticlusterGlobalHub after the operator is uninstalled.
+type CertManager struct {
+	KubeClient kubernetes.Interface
+	Namespace  string
+	CertDir    string
+	Log        logr.Logger
+}
+
+// NeedLeaderElection makes every operator replica keep its serving certificate up to date
+func (m *CertManager) NeedLeaderElection() bool {
+	return false
+}
+
+// Start checks the certificates and the webhook configurations periodically until the context is done, then
+// deletes the webhook configurations
+func (m *CertManager) Start(ctx context.Context) error {
+	certTicker := time.NewTicker(certCheckPeriod)
+	defer certTicker.Stop()
+	webhookTicker := time.NewTicker(webhookCheckPeriod)
+	defer webhookTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// the context is done, delete with a new one. the other running replicas recreate them in a while
+			deleteCtx, cancel := context.WithTimeout(context.Background(), webhookTimeoutSeconds*time.Second)
+			defer cancel()
+			return m.deleteWebhookConfigurations(deleteCtx)
+		case <-certTicker.C:
+			if err := m.EnsureCertificate(ctx); err != nil {
+				m.Log.Error(err, "failed to ensure the webhook certificate")
+			}
+		case <-webhookTicker.C:
+			if err := m.ensureWebhookConfigurations(ctx); err != nil {
+				m.Log.Error(err, "failed to ensure the webhook configurations")
+			}
+		}
+	}
+}
+
+// EnsureCertificate makes sure the certificates are valid and the webhook server and configurations are using them
+func (m *CertManager) EnsureCertificate(ctx context.Context) error {
+	secret, err := m.ensureSecret(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := m.writeCertDir(secret); err != nil {
+		return err
+	}
+
+	if err := m.ensureMutatingWebhookConfiguration(ctx, secret.Data[caCertKey]); err != nil {
+		return err
+	}
+	return m.ensureValidatingWebhookConfiguration(ctx, secret.Data[caCertKey])
+}
+
+// ensureWebhookConfigurations makes sure the webhook configurations exist with the CA of the existing certificates
+func (m *CertManager) ensureWebhookConfigurations(ctx context.Context) error {
+	secret, err := m.KubeClient.CoreV1().Secrets(m.Namespace).Get(ctx, constants.WebhookCertSecretName,
+		metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if err := m.ensureMutatingWebhookConfiguration(ctx, secret.Data[caCertKey]); err != nil {
+		return err
+	}
+	return m.ensureValidatingWebhookConfiguration(ctx, secret.Data[caCertKey])
+}
+
+func (m *CertManager) deleteWebhookConfigurations(ctx context.Context) error {
+	m.Log.Info("deleting the webhook configurations")
+	err := m.KubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Delete(ctx,
+		constants.MutatingWebhookConfigurationName, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the mutating webhook configuration - %w", err)
+	}
+	err = m.KubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Delete(ctx,
+		constants.ValidatingWebhookConfigurationName, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the validating webhook configuration - %w", err)
+	}
+	return nil
+}
+
+func (m *CertManager) ensureSecret(ctx context.Context) (*corev1.Secret, error) {
+	secret, err := m.KubeClient.CoreV1().Secrets(m.Namespace).Get(ctx, constants.WebhookCertSecretName,
+		metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	exists := err == nil
+	if exists && isCertValid(secret.Data[corev1.TLSCertKey], time.Now().Add(certRenewBefore)) {
+		return secret, nil
+	}
+
+	m.Log.Info("generating the webhook certificate", "namespace", m.Namespace,
+		"name", constants.WebhookCertSecretName)
+	certData, err := generateCertificate(m.Namespace, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the webhook certificate: %w", err)
+	}
+
+	if !exists {
+		return m.KubeClient.CoreV1().Secrets(m.Namespace).Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      constants.WebhookCertSecretName,
+				Namespace: m.Namespace,
+				Labels: map[string]string{
+					commonconstants.GlobalHubOwnerLabelKey: commonconstants.HoHOperatorOwnerLabelVal,
+				},
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: certData,
+		}, metav1.CreateOptions{})
+	}
+
+	secret.Data = certData
+	return m.KubeClient.CoreV1().Secrets(m.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+}
+
+// writeCertDir writes the serving certificate to the cert dir, the webhook server reloads the changed certificate
+func (m *CertManager) writeCertDir(secret *corev1.Secret) error {
+	if err := os.MkdirAll(m.CertDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create the webhook cert dir: %w", err)
+	}
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		file := filepath.Join(m.CertDir, key)
+		if existing, err := os.ReadFile(file); err == nil && bytes.Equal(existing, secret.Data[key]) {
+			continue
+		}
+		if err := os.WriteFile(file, secret.Data[key], 0o600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+func (m *CertManager) webhookClientConfig(path string, caBundle []byte) admissionregistrationv1.WebhookClientConfig {
+	port := int32(constants.DefaultWebhookPort)
+	return admissionregistrationv1.WebhookClientConfig{
+		Service: &admissionregistrationv1.ServiceReference{
+			Namespace: m.Namespace,
+			Name:      constants.WebhookServiceName,
+			Path:      &path,
+			Port:      &port,
+		},
+		CABundle: caBundle,
+	}
+}
+
+func webhookRules() []admissionregistrationv1.RuleWithOperations {
+	return []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{
+				admissionregistrationv1.Create,
+				admissionregistrationv1.Update,
+			},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{operatorv1alpha2.GroupVersion.Group},
+				APIVersions: []string{operatorv1alpha2.GroupVersion.Version},
+				Resources:   []string{"multiclusterglobalhubs"},
+			},
+		},
+	}
+}
+
+// webhookNamespaceSelector skips the kubernetes system namespaces
+func webhookNamespaceSelector() *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      corev1.LabelMetadataName,
+				Operator: metav1.LabelSelectorOpNotIn,
+				Values:   []string{"kube-system", "kube-public", "kube-node-lease"},
+			},
+		},
+	}
+}
+
+// webhookObjectSelector skips the instance labeled with the skip webhook label, e.g. to remove its finalizer when
+// the operator was killed before deleting the webhook configurations
+func webhookObjectSelector() *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      constants.SkipWebhookLabelKey,
+				Operator: metav1.LabelSelectorOpDoesNotExist,
+			},
+		},
+	}
+}
+
+func (m *CertManager) ensureMutatingWebhookConfiguration(ctx context.Context, caBundle []byte) error {
+	failurePolicy := admissionregistrationv1.Fail
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	timeoutSeconds := int32(webhookTimeoutSeconds)
+	webhookConfiguration := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: constants.MutatingWebhookConfigurationName,
+			Labels: map[string]string{
+				commonconstants.GlobalHubOwnerLabelKey: commonconstants.HoHOperatorOwnerLabelVal,
+			},
+		},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{
+				Name:                    "mmulticlusterglobalhub.open-cluster-management.io",
+				ClientConfig:            m.webhookClientConfig(mutatingWebhookPath, caBundle),
+				Rules:                   webhookRules(),
+				NamespaceSelector:       webhookNamespaceSelector(),
+				ObjectSelector:          webhookObjectSelector(),
+				FailurePolicy:           &failurePolicy,
+				SideEffects:             &sideEffects,
+				TimeoutSeconds:          &timeoutSeconds,
+				AdmissionReviewVersions: []string{"v1"},
+			},
+		},
+	}
+
+	client := m.KubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations()
+	existing, err := client.Get(ctx, webhookConfiguration.GetName(), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		_, err = client.Create(ctx, webhookConfiguration, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	if equality.Semantic.DeepDerivative(webhookConfiguration.Webhooks, existing.Webhooks) &&
+		equality.Semantic.DeepDerivative(webhookConfiguration.Labels, existing.Labels) {
+		return nil
+	}
+
+	webhookConfiguration.ResourceVersion = existing.ResourceVersion
+	_, err = client.Update(ctx, webhookConfiguration, metav1.UpdateOptions{})
+	return err
+}
+
+func (m *CertManager) ensureValidatingWebhookConfiguration(ctx context.Context, caBundle []byte) error {
+	failurePolicy := admissionregistrationv1.Fail
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	timeoutSeconds := int32(webhookTimeoutSeconds)
+	webhookConfiguration := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: constants.ValidatingWebhookConfigurationName,
+			Labels: map[string]string{
+				commonconstants.GlobalHubOwnerLabelKey: commonconstants.HoHOperatorOwnerLabelVal,
+			},
+		},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{
+				Name:                    "vmulticlusterglobalhub.open-cluster-management.io",
+				ClientConfig:            m.webhookClientConfig(validatingWebhookPath, caBundle),
+				Rules:                   webhookRules(),
+				NamespaceSelector:       webhookNamespaceSelector(),
+				ObjectSelector:          webhookObjectSelector(),
+				FailurePolicy:           &failurePolicy,
+				SideEffects:             &sideEffects,
+				TimeoutSeconds:          &timeoutSeconds,
+				AdmissionReviewVersions: []string{"v1"},
+			},
+		},
+	}
+
+	client := m.KubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations()
+	existing, err := client.Get(ctx, webhookConfiguration.GetName(), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		_, err = client.Create(ctx, webhookConfiguration, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	if equality.Semantic.DeepDerivative(webhookConfiguration.Webhooks, existing.Webhooks) &&
+		equality.Semantic.DeepDerivative(webhookConfiguration.Labels, existing.Labels) {
+		return nil
+	}
+
+	webhookConfiguration.ResourceVersion = existing.ResourceVersion
+	_, err = client.Update(ctx, webhookConfiguration, metav1.UpdateOptions{})
+	return err
+}
+
+// isCertValid returns true if the PEM encoded certificate is still valid at the given time
+func isCertValid(certPEM []byte, at time.Time) bool {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false
+	}
+	return at.Before(cert.NotAfter)
+}
+
+// generateCertificate generates a self-signed CA and the serving certificate of the webhook service signed by it
+func generateCertificate(namespace string, now time.Time) (map[string][]byte, error) {
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(now.UnixNano()),
+		Subject:               pkix.Name{CommonName: fmt.Sprintf("%s-ca", constants.WebhookServiceName)},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(certValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName := constants.WebhookServiceName
+	servingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	servingTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(now.UnixNano() + 1),
+		Subject:      pkix.Name{CommonName: fmt.Sprintf("%s.%s.svc", serviceName, namespace)},
+		DNSNames: []string{
+			serviceName,
+			fmt.Sprintf("%s.%s", serviceName, namespace),
+			fmt.Sprintf("%s.%s.svc", serviceName, namespace),
+			fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, namespace),
+		},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(certValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	servingDER, err := x509.CreateCertificate(rand.Reader, servingTemplate, caCert, &servingKey.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{
+		caCertKey:               pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: servingDER}),
+		corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(servingKey)}),
+	}, nil
+}
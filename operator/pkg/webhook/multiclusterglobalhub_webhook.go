@@ -0,0 +1,258 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha2 "github.com/stolostron/multicluster-global-hub/operator/apis/v1alpha2"
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/config"
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/constants"
+	"github.com/stolostron/multicluster-global-hub/pkg/bundle/projection"
+)
+
+//+kubebuilder:webhook:path=/mutate-operator-open-cluster-management-io-v1alpha2-multiclusterglobalhub,mutating=true,failurePolicy=fail,sideEffects=None,groups=operator.open-cluster-management.io,resources=multiclusterglobalhubs,verbs=create;update,versions=v1alpha2,name=mmulticlusterglobalhub.open-cluster-management.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-operator-open-cluster-management-io-v1alpha2-multiclusterglobalhub,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.open-cluster-management.io,resources=multiclusterglobalhubs,verbs=create;update,versions=v1alpha2,name=vmulticlusterglobalhub.open-cluster-management.io,admissionReviewVersions=v1
+
+const (
+	mutatingWebhookPath   = "/mutate-operator-open-cluster-management-io-v1alpha2-multiclusterglobalhub"
+	validatingWebhookPath = "/validate-operator-open-cluster-management-io-v1alpha2-multiclusterglobalhub"
+)
+
+// MulticlusterGlobalHubWebhook defaults and validates the MulticlusterGlobalHub at admission time, so the invalid
+// specs are rejected with clear messages instead of failing the reconcile
+type MulticlusterGlobalHubWebhook struct {
+	// Reader lists the existing MulticlusterGlobalHub instances, it isn't cached to see the latest instances
+	Reader client.Reader
+}
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks to the webhook server of the manager
+func SetupWebhookWithManager(mgr ctrl.Manager) error {
+	mghWebhook := &MulticlusterGlobalHubWebhook{Reader: mgr.GetAPIReader()}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&operatorv1alpha2.MulticlusterGlobalHub{}).
+		WithDefaulter(mghWebhook).
+		WithValidator(mghWebhook).
+		Complete()
+}
+
+// Default sets the default values which aren't covered by the CRD defaults
+func (w *MulticlusterGlobalHubWebhook) Default(ctx context.Context, obj runtime.Object) error {
+	mgh, ok := obj.(*operatorv1alpha2.MulticlusterGlobalHub)
+	if !ok {
+		return fmt.Errorf("expected a MulticlusterGlobalHub but got a %T", obj)
+	}
+
+	if mgh.Spec.AggregationLevel == "" {
+		mgh.Spec.AggregationLevel = operatorv1alpha2.Full
+	}
+	if mgh.Spec.ImagePullPolicy == "" {
+		mgh.Spec.ImagePullPolicy = corev1.PullAlways
+	}
+	if config.IsManagedDataLayer(mgh) && mgh.Spec.DataLayer.LargeScale.Managed.PostgresStorageSize == "" {
+		mgh.Spec.DataLayer.LargeScale.Managed.PostgresStorageSize = constants.DefaultManagedPostgresStorage
+	}
+	return nil
+}
+
+// ValidateCreate rejects the invalid spec and a second MulticlusterGlobalHub instance
+func (w *MulticlusterGlobalHubWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	mgh, ok := obj.(*operatorv1alpha2.MulticlusterGlobalHub)
+	if !ok {
+		return fmt.Errorf("expected a MulticlusterGlobalHub but got a %T", obj)
+	}
+
+	existingMGHs := &operatorv1alpha2.MulticlusterGlobalHubList{}
+	if err := w.Reader.List(ctx, existingMGHs); err != nil {
+		return apierrors.NewInternalError(fmt.Errorf("failed to list MulticlusterGlobalHub: %w", err))
+	}
+	for _, existingMGH := range existingMGHs.Items {
+		if existingMGH.GetNamespace() != mgh.GetNamespace() || existingMGH.GetName() != mgh.GetName() {
+			return apierrors.NewForbidden(operatorv1alpha2.GroupVersion.WithResource("multiclusterglobalhubs").GroupResource(),
+				mgh.GetName(), fmt.Errorf("only one MulticlusterGlobalHub instance is supported, %s/%s already exists",
+					existingMGH.GetNamespace(), existingMGH.GetName()))
+		}
+	}
+
+	return toInvalidError(mgh, validateSpec(mgh))
+}
+
+// ValidateUpdate rejects the invalid spec and the unsafe transitions
+func (w *MulticlusterGlobalHubWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	oldMGH, ok := oldObj.(*operatorv1alpha2.MulticlusterGlobalHub)
+	if !ok {
+		return fmt.Errorf("expected a MulticlusterGlobalHub but got a %T", oldObj)
+	}
+	mgh, ok := newObj.(*operatorv1alpha2.MulticlusterGlobalHub)
+	if !ok {
+		return fmt.Errorf("expected a MulticlusterGlobalHub but got a %T", newObj)
+	}
+
+	// never block the cleanup, e.g. removing the finalizer of the deleting instance
+	if mgh.GetDeletionTimestamp() != nil {
+		return nil
+	}
+
+	allErrs := validateSpec(mgh)
+	allErrs = append(allErrs, validateTransition(oldMGH, mgh)...)
+	return toInvalidError(mgh, allErrs)
+}
+
+// ValidateDelete allows the deletion, the resources are cleaned up by the finalizer
+func (w *MulticlusterGlobalHubWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func validateSpec(mgh *operatorv1alpha2.MulticlusterGlobalHub) field.ErrorList {
+	allErrs := field.ErrorList{}
+	dataLayerPath := field.NewPath("spec", "dataLayer")
+
+	if mgh.Spec.ComplianceDetailsLevel == operatorv1alpha2.ComplianceDetailsViolations &&
+		getAggregationLevel(mgh) != operatorv1alpha2.Full {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "complianceDetailsLevel"),
+			mgh.Spec.ComplianceDetailsLevel, "the violation details require the full aggregation level"))
+	}
+
+	allErrs = append(allErrs, validateStatusProjections(mgh.Spec.StatusProjections)...)
+
+	dataLayer := mgh.Spec.DataLayer
+	if dataLayer == nil {
+		return append(allErrs, field.Required(dataLayerPath, "the data layer is required"))
+	}
+
+	switch dataLayer.Type {
+	case operatorv1alpha2.LargeScale:
+		largeScalePath := dataLayerPath.Child("largeScale")
+		if dataLayer.LargeScale == nil {
+			allErrs = append(allErrs, field.Required(largeScalePath,
+				"the kafka and postgres settings are required for the largeScale data layer"))
+			break
+		}
+		if dataLayer.LargeScale.Managed == nil {
+			if dataLayer.LargeScale.Kafka.Name == "" {
+				allErrs = append(allErrs, field.Required(largeScalePath.Child("kafka", "name"),
+					"the transport secret is required unless the data layer is managed"))
+			}
+			if dataLayer.LargeScale.Postgres.Name == "" {
+				allErrs = append(allErrs, field.Required(largeScalePath.Child("postgres", "name"),
+					"the storage secret is required unless the data layer is managed"))
+			}
+			break
+		}
+		managedPath := largeScalePath.Child("managed")
+		allErrs = append(allErrs, validateQuantity(managedPath.Child("kafkaStorageSize"),
+			dataLayer.LargeScale.Managed.KafkaStorageSize)...)
+		allErrs = append(allErrs, validateQuantity(managedPath.Child("postgresStorageSize"),
+			dataLayer.LargeScale.Managed.PostgresStorageSize)...)
+	case operatorv1alpha2.Native:
+		allErrs = append(allErrs, field.Invalid(dataLayerPath.Child("type"), dataLayer.Type,
+			"the native data layer is not supported yet"))
+	default:
+		allErrs = append(allErrs, field.NotSupported(dataLayerPath.Child("type"), dataLayer.Type,
+			[]string{string(operatorv1alpha2.LargeScale)}))
+	}
+
+	return allErrs
+}
+
+func validateQuantity(fldPath *field.Path, quantity string) field.ErrorList {
+	if quantity == "" {
+		return nil
+	}
+	if _, err := resource.ParseQuantity(quantity); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, quantity, err.Error())}
+	}
+	return nil
+}
+
+// validateTransition rejects the changes which can't be applied to a running global hub
+func validateTransition(oldMGH, mgh *operatorv1alpha2.MulticlusterGlobalHub) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if oldMGH.Spec.DataLayer != nil && mgh.Spec.DataLayer != nil {
+		dataLayerPath := field.NewPath("spec", "dataLayer")
+		if oldMGH.Spec.DataLayer.Type != mgh.Spec.DataLayer.Type {
+			allErrs = append(allErrs, field.Forbidden(dataLayerPath.Child("type"),
+				fmt.Sprintf("the data layer type can't be changed from %s to %s, "+
+					"reinstall the MulticlusterGlobalHub to switch the data layer",
+					oldMGH.Spec.DataLayer.Type, mgh.Spec.DataLayer.Type)))
+		} else if config.IsManagedDataLayer(oldMGH) != config.IsManagedDataLayer(mgh) {
+			allErrs = append(allErrs, field.Forbidden(dataLayerPath.Child("largeScale", "managed"),
+				"the data layer can't be switched between the managed and the unmanaged kafka and postgres"))
+		}
+	}
+
+	if getAggregationLevel(oldMGH) != getAggregationLevel(mgh) &&
+		(oldMGH.Spec.EnableLocalPolicies || mgh.Spec.EnableLocalPolicies) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "aggregationLevel"),
+			fmt.Sprintf("the aggregation level can't be changed from %s to %s while the local policies are enabled, "+
+				"disable spec.enableLocalPolicies first", getAggregationLevel(oldMGH), getAggregationLevel(mgh))))
+	}
+
+	return allErrs
+}
+
+func getAggregationLevel(mgh *operatorv1alpha2.MulticlusterGlobalHub) operatorv1alpha2.AggregationLevel {
+	if mgh.Spec.AggregationLevel == "" {
+		return operatorv1alpha2.Full
+	}
+	return mgh.Spec.AggregationLevel
+}
+
+func toInvalidError(mgh *operatorv1alpha2.MulticlusterGlobalHub, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(operatorv1alpha2.GroupVersion.WithKind("MulticlusterGlobalHub").GroupKind(),
+		mgh.GetName(), allErrs)
+}
+
+// validateStatusProjections rejects the projections of the bundle types that can't be projected, and the projections
+// that deny the fields the global hub reads
+func validateStatusProjections(statusProjections []operatorv1alpha2.StatusProjection) field.ErrorList {
+	allErrs := field.ErrorList{}
+	bundleTypes := map[string]struct{}{}
+
+	for i, statusProjection := range statusProjections {
+		path := field.NewPath("spec", "statusProjections").Index(i)
+
+		if _, found := bundleTypes[statusProjection.BundleType]; found {
+			allErrs = append(allErrs, field.Duplicate(path.Child("bundleType"), statusProjection.BundleType))
+			continue
+		}
+		bundleTypes[statusProjection.BundleType] = struct{}{}
+
+		if err := projection.Validate(projection.Projection{
+			BundleType: statusProjection.BundleType,
+			Allow:      statusProjection.Allow,
+			Deny:       statusProjection.Deny,
+		}); err != nil {
+			allErrs = append(allErrs, field.Invalid(path, statusProjection, err.Error()))
+		}
+	}
+
+	return allErrs
+}
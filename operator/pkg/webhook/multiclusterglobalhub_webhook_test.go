@@ -0,0 +1,327 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1alpha2 "github.com/stolostron/multicluster-global-hub/operator/apis/v1alpha2"
+	"github.com/stolostron/multicluster-global-hub/operator/pkg/constants"
+)
+
+func newMGH(name string, dataLayer *operatorv1alpha2.DataLayerConfig) *operatorv1alpha2.MulticlusterGlobalHub {
+	return &operatorv1alpha2.MulticlusterGlobalHub{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "open-cluster-management", Name: name},
+		Spec: operatorv1alpha2.MulticlusterGlobalHubSpec{
+			AggregationLevel:    operatorv1alpha2.Full,
+			EnableLocalPolicies: true,
+			DataLayer:           dataLayer,
+		},
+	}
+}
+
+func largeScale() *operatorv1alpha2.DataLayerConfig {
+	return &operatorv1alpha2.DataLayerConfig{
+		Type: operatorv1alpha2.LargeScale,
+		LargeScale: &operatorv1alpha2.LargeScaleConfig{
+			Kafka:    corev1.LocalObjectReference{Name: "transport-secret"},
+			Postgres: corev1.LocalObjectReference{Name: "storage-secret"},
+		},
+	}
+}
+
+func newWebhook(t *testing.T, objs ...runtime.Object) *MulticlusterGlobalHubWebhook {
+	scheme := runtime.NewScheme()
+	if err := operatorv1alpha2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return &MulticlusterGlobalHubWebhook{
+		Reader: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build(),
+	}
+}
+
+func TestValidateCreate(t *testing.T) {
+	tests := []struct {
+		desc     string
+		existing []runtime.Object
+		mgh      *operatorv1alpha2.MulticlusterGlobalHub
+		wantErr  string
+	}{
+		{
+			desc: "valid large scale data layer",
+			mgh:  newMGH("mgh", largeScale()),
+		},
+		{
+			desc: "valid managed data layer without secrets",
+			mgh: newMGH("mgh", &operatorv1alpha2.DataLayerConfig{
+				Type: operatorv1alpha2.LargeScale,
+				LargeScale: &operatorv1alpha2.LargeScaleConfig{
+					Managed: &operatorv1alpha2.ManagedConfig{PostgresStorageSize: "20Gi"},
+				},
+			}),
+		},
+		{
+			desc:    "large scale data layer without secrets",
+			mgh:     newMGH("mgh", &operatorv1alpha2.DataLayerConfig{Type: operatorv1alpha2.LargeScale}),
+			wantErr: "spec.dataLayer.largeScale: Required value",
+		},
+		{
+			desc: "large scale data layer without storage secret",
+			mgh: newMGH("mgh", &operatorv1alpha2.DataLayerConfig{
+				Type: operatorv1alpha2.LargeScale,
+				LargeScale: &operatorv1alpha2.LargeScaleConfig{
+					Kafka: corev1.LocalObjectReference{Name: "transport-secret"},
+				},
+			}),
+			wantErr: "spec.dataLayer.largeScale.postgres.name: Required value",
+		},
+		{
+			desc: "invalid storage size",
+			mgh: newMGH("mgh", &operatorv1alpha2.DataLayerConfig{
+				Type: operatorv1alpha2.LargeScale,
+				LargeScale: &operatorv1alpha2.LargeScaleConfig{
+					Managed: &operatorv1alpha2.ManagedConfig{PostgresStorageSize: "20 gigabytes"},
+				},
+			}),
+			wantErr: "spec.dataLayer.largeScale.managed.postgresStorageSize: Invalid value",
+		},
+		{
+			desc:    "native data layer",
+			mgh:     newMGH("mgh", &operatorv1alpha2.DataLayerConfig{Type: operatorv1alpha2.Native}),
+			wantErr: "the native data layer is not supported yet",
+		},
+		{
+			desc:    "unsupported data layer type",
+			mgh:     newMGH("mgh", &operatorv1alpha2.DataLayerConfig{Type: "etcd"}),
+			wantErr: "spec.dataLayer.type: Unsupported value",
+		},
+		{
+			desc: "violation details with minimal aggregation",
+			mgh: func() *operatorv1alpha2.MulticlusterGlobalHub {
+				mgh := newMGH("mgh", largeScale())
+				mgh.Spec.AggregationLevel = operatorv1alpha2.Minimal
+				mgh.Spec.ComplianceDetailsLevel = operatorv1alpha2.ComplianceDetailsViolations
+				return mgh
+			}(),
+			wantErr: "spec.complianceDetailsLevel: Invalid value",
+		},
+		{
+			desc: "status projection denying the inventory of the managed clusters",
+			mgh: func() *operatorv1alpha2.MulticlusterGlobalHub {
+				mgh := newMGH("mgh", largeScale())
+				mgh.Spec.StatusProjections = []operatorv1alpha2.StatusProjection{{
+					BundleType: "ManagedClusters",
+					Deny:       []string{"status.conditions[].message", "status.clusterClaims"},
+				}}
+				return mgh
+			}(),
+			wantErr: "spec.statusProjections[0]: Invalid value",
+		},
+		{
+			desc:     "second instance",
+			existing: []runtime.Object{newMGH("mgh", largeScale())},
+			mgh:      newMGH("another-mgh", largeScale()),
+			wantErr:  "only one MulticlusterGlobalHub instance is supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := newWebhook(t, tt.existing...).ValidateCreate(context.TODO(), tt.mgh)
+			checkError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	tests := []struct {
+		desc    string
+		oldMGH  *operatorv1alpha2.MulticlusterGlobalHub
+		update  func(mgh *operatorv1alpha2.MulticlusterGlobalHub)
+		wantErr string
+	}{
+		{
+			desc:   "change the image pull policy",
+			oldMGH: newMGH("mgh", largeScale()),
+			update: func(mgh *operatorv1alpha2.MulticlusterGlobalHub) {
+				mgh.Spec.ImagePullPolicy = corev1.PullIfNotPresent
+			},
+		},
+		{
+			desc:   "switch the data layer type",
+			oldMGH: newMGH("mgh", largeScale()),
+			update: func(mgh *operatorv1alpha2.MulticlusterGlobalHub) {
+				mgh.Spec.DataLayer = &operatorv1alpha2.DataLayerConfig{Type: operatorv1alpha2.Native}
+			},
+			wantErr: "spec.dataLayer.type: Forbidden",
+		},
+		{
+			desc:   "switch to the managed data layer",
+			oldMGH: newMGH("mgh", largeScale()),
+			update: func(mgh *operatorv1alpha2.MulticlusterGlobalHub) {
+				mgh.Spec.DataLayer.LargeScale.Managed = &operatorv1alpha2.ManagedConfig{}
+			},
+			wantErr: "spec.dataLayer.largeScale.managed: Forbidden",
+		},
+		{
+			desc:   "change the aggregation level with local policies",
+			oldMGH: newMGH("mgh", largeScale()),
+			update: func(mgh *operatorv1alpha2.MulticlusterGlobalHub) {
+				mgh.Spec.AggregationLevel = operatorv1alpha2.Minimal
+			},
+			wantErr: "spec.aggregationLevel: Forbidden",
+		},
+		{
+			desc: "change the aggregation level without local policies",
+			oldMGH: func() *operatorv1alpha2.MulticlusterGlobalHub {
+				mgh := newMGH("mgh", largeScale())
+				mgh.Spec.EnableLocalPolicies = false
+				return mgh
+			}(),
+			update: func(mgh *operatorv1alpha2.MulticlusterGlobalHub) {
+				mgh.Spec.AggregationLevel = operatorv1alpha2.Minimal
+			},
+		},
+		{
+			desc:   "remove the finalizer of the deleting instance",
+			oldMGH: newMGH("mgh", &operatorv1alpha2.DataLayerConfig{Type: operatorv1alpha2.LargeScale}),
+			update: func(mgh *operatorv1alpha2.MulticlusterGlobalHub) {
+				now := metav1.NewTime(time.Now())
+				mgh.SetDeletionTimestamp(&now)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mgh := tt.oldMGH.DeepCopy()
+			tt.update(mgh)
+			err := newWebhook(t).ValidateUpdate(context.TODO(), tt.oldMGH, mgh)
+			checkError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDefault(t *testing.T) {
+	mgh := newMGH("mgh", &operatorv1alpha2.DataLayerConfig{
+		Type: operatorv1alpha2.LargeScale,
+		LargeScale: &operatorv1alpha2.LargeScaleConfig{
+			Managed: &operatorv1alpha2.ManagedConfig{},
+		},
+	})
+	mgh.Spec.AggregationLevel = ""
+
+	if err := newWebhook(t).Default(context.TODO(), mgh); err != nil {
+		t.Fatal(err)
+	}
+	if mgh.Spec.AggregationLevel != operatorv1alpha2.Full {
+		t.Errorf("expected aggregation level %s, got %s", operatorv1alpha2.Full, mgh.Spec.AggregationLevel)
+	}
+	if mgh.Spec.ImagePullPolicy != corev1.PullAlways {
+		t.Errorf("expected image pull policy %s, got %s", corev1.PullAlways, mgh.Spec.ImagePullPolicy)
+	}
+	if mgh.Spec.DataLayer.LargeScale.Managed.PostgresStorageSize != "20Gi" {
+		t.Errorf("expected postgres storage size 20Gi, got %s",
+			mgh.Spec.DataLayer.LargeScale.Managed.PostgresStorageSize)
+	}
+}
+
+func TestGenerateCertificate(t *testing.T) {
+	now := time.Now()
+	certData, err := generateCertificate("open-cluster-management", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isCertValid(certData[corev1.TLSCertKey], now.Add(certRenewBefore)) {
+		t.Errorf("expected the certificate to be valid before renewal")
+	}
+	if isCertValid(certData[corev1.TLSCertKey], now.Add(certValidity+time.Hour)) {
+		t.Errorf("expected the certificate to be expired after its validity")
+	}
+}
+
+func TestCertManagerWebhookConfigurations(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	certManager := &CertManager{
+		KubeClient: kubeClient,
+		Namespace:  "open-cluster-management",
+		CertDir:    t.TempDir(),
+		Log:        logr.Discard(),
+	}
+	if err := certManager.EnsureCertificate(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	validating, err := kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(context.TODO(),
+		constants.ValidatingWebhookConfigurationName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if validating.Webhooks[0].NamespaceSelector == nil || validating.Webhooks[0].ObjectSelector == nil {
+		t.Errorf("expected the validating webhook to be scoped by the namespace and object selectors")
+	}
+
+	// the webhook configurations are deleted once the operator stops
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	if err := certManager.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	_, err = kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(context.TODO(),
+		constants.ValidatingWebhookConfigurationName, metav1.GetOptions{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("expected the validating webhook configuration to be deleted, got %v", err)
+	}
+	_, err = kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(context.TODO(),
+		constants.MutatingWebhookConfigurationName, metav1.GetOptions{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("expected the mutating webhook configuration to be deleted, got %v", err)
+	}
+
+	// the removed webhook configurations are recreated by the running operator
+	if err := certManager.ensureWebhookConfigurations(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(context.TODO(),
+		constants.MutatingWebhookConfigurationName, metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the mutating webhook configuration to be recreated, got %v", err)
+	}
+}
+
+func checkError(t *testing.T, err error, wantErr string) {
+	t.Helper()
+	if wantErr == "" {
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		return
+	}
+	if err == nil || !strings.Contains(err.Error(), wantErr) {
+		t.Errorf("expected error containing %q, got %v", wantErr, err)
+	}
+}
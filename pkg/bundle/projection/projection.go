@@ -0,0 +1,343 @@
+package projection
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/stolostron/multicluster-global-hub/pkg/constants"
+)
+
+// listSuffix marks a path segment whose value is a list, the rest of the path applies to each item of the list.
+const listSuffix = "[]"
+
+var errInvalidPath = errors.New("invalid path")
+
+// commonProtectedPaths are the paths of the objects the status transport bridge stores the objects by, they are
+// kept by every projection.
+var commonProtectedPaths = []string{
+	"metadata.name",
+	"metadata.namespace",
+	"metadata.uid",
+	"metadata.resourceVersion",
+	"metadata.annotations",
+}
+
+// protectedPaths is a map of bundle type -> the paths the global hub reads of the objects of the bundle type, they are
+// kept by the projections of the bundle type. the bundle types that aren't listed can't be projected, their objects
+// aren't kubernetes objects.
+var protectedPaths = map[string][]string{
+	constants.ManagedClustersMsgKey:      managedClusterProtectedPaths,
+	constants.DeltaManagedClustersMsgKey: managedClusterProtectedPaths,
+	constants.ManagedClusterAddOnsMsgKey: {
+		"metadata.labels", "status.conditions[].type", "status.conditions[].status",
+	},
+	constants.PlacementRuleMsgKey:       {"status.decisions"},
+	constants.PlacementMsgKey:           {"status.numberOfSelectedClusters"},
+	constants.PlacementDecisionMsgKey:   {"status.decisions"},
+	constants.PolicySetMsgKey:           {"status"},
+	constants.SubscriptionStatusMsgKey:  {},
+	constants.SubscriptionReportMsgKey:  {},
+	constants.LocalPolicySpecMsgKey:     {"spec"},
+	constants.LocalPlacementRulesMsgKey: {"spec"},
+	constants.LocalPlacementsMsgKey:     {"spec"},
+	// the local placement decisions are resolved to their placement by its label, the owner references aren't sent
+	constants.LocalPlacementDecisionsMsgKey: {"metadata.labels", "status.decisions"},
+}
+
+// managedClusterProtectedPaths are the paths the managed clusters are selected by and the inventory is built from.
+var managedClusterProtectedPaths = []string{
+	"metadata.labels",
+	"status.clusterClaims",
+	"status.conditions[].type",
+	"status.conditions[].status",
+	"status.capacity",
+	"status.version",
+}
+
+// Projection selects the fields of the objects of a bundle type that the leaf hubs send.
+type Projection struct {
+	// BundleType is the message key of the bundle, e.g. ManagedClusters.
+	BundleType string `json:"bundleType"`
+	// Allow are the paths of the fields that are sent, all the fields are sent if it's empty.
+	Allow []string `json:"allow,omitempty"`
+	// Deny are the paths of the fields that are not sent.
+	Deny []string `json:"deny,omitempty"`
+}
+
+// Projections is a map of bundle type -> the validated projection of the bundle type.
+type Projections map[string]*projection
+
+type projection struct {
+	allow [][]string
+	deny  [][]string
+}
+
+// Parse parses and validates the projections of the global hub config.
+func Parse(data string) (Projections, error) {
+	if data == "" {
+		return Projections{}, nil
+	}
+
+	configuredProjections := make([]Projection, 0)
+	if err := json.Unmarshal([]byte(data), &configuredProjections); err != nil {
+		return nil, fmt.Errorf("failed to parse status projections - %w", err)
+	}
+
+	return New(configuredProjections)
+}
+
+// New validates the projections.
+func New(configuredProjections []Projection) (Projections, error) {
+	projections := make(Projections, len(configuredProjections))
+
+	for _, configuredProjection := range configuredProjections {
+		if err := Validate(configuredProjection); err != nil {
+			return nil, err
+		}
+
+		if _, found := projections[configuredProjection.BundleType]; found {
+			return nil, fmt.Errorf("duplicate projection of bundle type %s", configuredProjection.BundleType)
+		}
+
+		projection := &projection{
+			allow: splitPaths(configuredProjection.Allow),
+			deny:  splitPaths(configuredProjection.Deny),
+		}
+
+		if len(projection.allow) > 0 { // the protected paths are sent even if they're not allowed explicitly
+			projection.allow = append(projection.allow, splitPaths(commonProtectedPaths)...)
+			projection.allow = append(projection.allow,
+				splitPaths(protectedPaths[configuredProjection.BundleType])...)
+		}
+
+		projections[configuredProjection.BundleType] = projection
+	}
+
+	return projections, nil
+}
+
+// Validate returns an error if the projection is of a bundle type that can't be projected, or if it denies a field the
+// global hub reads or a part of it.
+func Validate(configuredProjection Projection) error {
+	bundleProtectedPaths, found := protectedPaths[configuredProjection.BundleType]
+	if !found {
+		return fmt.Errorf("bundle type %s can't be projected", configuredProjection.BundleType)
+	}
+
+	for _, path := range append(configuredProjection.Allow, configuredProjection.Deny...) {
+		if err := validatePath(path); err != nil {
+			return fmt.Errorf("%w %q of bundle type %s - %v", errInvalidPath, path, configuredProjection.BundleType,
+				err)
+		}
+	}
+
+	for _, deniedPath := range configuredProjection.Deny {
+		for _, protectedPath := range append(commonProtectedPaths, bundleProtectedPaths...) {
+			if coversPath(deniedPath, protectedPath) || coversPath(protectedPath, deniedPath) {
+				return fmt.Errorf("%w %q of bundle type %s - %s is required by the global hub", errInvalidPath,
+					deniedPath, configuredProjection.BundleType, protectedPath)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Apply projects the objects of the marshalled bundle, the bundle is returned as is if the bundle type isn't
+// projected.
+func (projections Projections) Apply(bundleType string, payload []byte) ([]byte, error) {
+	projection, found := projections[bundleType]
+	if !found {
+		return payload, nil
+	}
+
+	bundle := make(map[string]interface{})
+	if err := json.Unmarshal(payload, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle - %w", err)
+	}
+
+	objects, ok := bundle["objects"].([]interface{})
+	if !ok {
+		return payload, nil // the bundle has no objects
+	}
+
+	for i, object := range objects {
+		objectMap, ok := object.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		objects[i] = projection.apply(objectMap)
+	}
+
+	projectedPayload, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal projected bundle - %w", err)
+	}
+
+	return projectedPayload, nil
+}
+
+func (projection *projection) apply(object map[string]interface{}) map[string]interface{} {
+	if len(projection.allow) > 0 {
+		allowedObject := make(map[string]interface{})
+		for _, path := range projection.allow {
+			keepPath(object, allowedObject, path)
+		}
+
+		object = allowedObject
+	}
+
+	for _, path := range projection.deny {
+		removePath(object, path)
+	}
+
+	return object
+}
+
+// keepPath copies the field of the path from the source object to the destination object.
+func keepPath(source, destination map[string]interface{}, path []string) {
+	name, isList := parseSegment(path[0])
+
+	value, found := source[name]
+	if !found {
+		return
+	}
+
+	if len(path) == 1 {
+		destination[name] = value
+		return
+	}
+
+	if !isList {
+		sourceChild, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		destinationChild, ok := destination[name].(map[string]interface{})
+		if !ok {
+			destinationChild = make(map[string]interface{})
+			destination[name] = destinationChild
+		}
+
+		keepPath(sourceChild, destinationChild, path[1:])
+
+		return
+	}
+
+	sourceItems, ok := value.([]interface{})
+	if !ok {
+		return
+	}
+
+	destinationItems, ok := destination[name].([]interface{})
+	if !ok || len(destinationItems) != len(sourceItems) {
+		destinationItems = make([]interface{}, len(sourceItems))
+		destination[name] = destinationItems
+	}
+
+	for i, sourceItem := range sourceItems {
+		sourceChild, ok := sourceItem.(map[string]interface{})
+		if !ok {
+			destinationItems[i] = sourceItem
+			continue
+		}
+
+		destinationChild, ok := destinationItems[i].(map[string]interface{})
+		if !ok {
+			destinationChild = make(map[string]interface{})
+			destinationItems[i] = destinationChild
+		}
+
+		keepPath(sourceChild, destinationChild, path[1:])
+	}
+}
+
+// removePath removes the field of the path from the object.
+func removePath(object map[string]interface{}, path []string) {
+	name, isList := parseSegment(path[0])
+
+	if len(path) == 1 {
+		delete(object, name)
+		return
+	}
+
+	if !isList {
+		if child, ok := object[name].(map[string]interface{}); ok {
+			removePath(child, path[1:])
+		}
+
+		return
+	}
+
+	items, ok := object[name].([]interface{})
+	if !ok {
+		return
+	}
+
+	for _, item := range items {
+		if child, ok := item.(map[string]interface{}); ok {
+			removePath(child, path[1:])
+		}
+	}
+}
+
+// coversPath returns true if the paths are the same or the path is an ancestor of the other path.
+func coversPath(path, otherPath string) bool {
+	segments, otherSegments := strings.Split(path, "."), strings.Split(otherPath, ".")
+	if len(segments) > len(otherSegments) {
+		return false
+	}
+
+	for i, segment := range segments {
+		name, _ := parseSegment(segment)
+		otherName, _ := parseSegment(otherSegments[i])
+
+		if name != otherName {
+			return false
+		}
+	}
+
+	return true
+}
+
+func validatePath(path string) error {
+	if path == "" {
+		return errors.New("the path is empty")
+	}
+
+	segments := strings.Split(path, ".")
+
+	for i, segment := range segments {
+		name, isList := parseSegment(segment)
+		if name == "" || strings.Contains(name, listSuffix) {
+			return fmt.Errorf("invalid segment %q", segment)
+		}
+
+		if isList && i == len(segments)-1 {
+			return fmt.Errorf("the last segment %q selects the items of a list, use %s instead", segment, name)
+		}
+	}
+
+	return nil
+}
+
+func parseSegment(segment string) (string, bool) {
+	if strings.HasSuffix(segment, listSuffix) {
+		return strings.TrimSuffix(segment, listSuffix), true
+	}
+
+	return segment, false
+}
+
+func splitPaths(paths []string) [][]string {
+	splitPaths := make([][]string, 0, len(paths))
+	for _, path := range paths {
+		splitPaths = append(splitPaths, strings.Split(path, "."))
+	}
+
+	return splitPaths
+}
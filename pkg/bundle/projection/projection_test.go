@@ -0,0 +1,78 @@
+package projection
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	for _, test := range []struct {
+		projection Projection
+		valid      bool
+	}{
+		{Projection{BundleType: "ManagedClusters", Deny: []string{"status.conditions[].message"}}, true},
+		{Projection{BundleType: "ManagedClusters", Deny: []string{"status.conditions"}}, false},
+		{Projection{BundleType: "ManagedClusters", Deny: []string{"status.clusterClaims[].value"}}, false},
+		{Projection{BundleType: "ManagedClusters", Deny: []string{"metadata"}}, false},
+		{Projection{BundleType: "ManagedClusters", Allow: []string{"status.conditions[]"}}, false},
+		{Projection{BundleType: "PolicyCompleteCompliance", Deny: []string{"spec"}}, false},
+	} {
+		if err := Validate(test.projection); (err == nil) != test.valid {
+			t.Errorf("expected projection %v to be valid %t, got %v", test.projection, test.valid, err)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	projections, err := Parse(`[
+		{"bundleType": "ManagedClusters", "deny": ["spec.managedClusterClientConfigs[].caBundle"]},
+		{"bundleType": "PlacementDecision", "allow": ["metadata.labels"]}
+	]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := projections.Apply("ManagedClusters", []byte(`{"leafHubName": "hub1", "objects": [{
+		"metadata": {"name": "cluster1"},
+		"spec": {"managedClusterClientConfigs": [{"url": "https://cluster1", "caBundle": "Y2E="}]}
+	}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkPayload(t, payload, `{"leafHubName": "hub1", "objects": [{
+		"metadata": {"name": "cluster1"},
+		"spec": {"managedClusterClientConfigs": [{"url": "https://cluster1"}]}
+	}]}`)
+
+	payload, err = projections.Apply("PlacementDecision", []byte(`{"objects": [{
+		"metadata": {"name": "decision1", "namespace": "default", "labels": {"a": "b"}, "generateName": "decision"},
+		"status": {"decisions": [{"clusterName": "cluster1", "reason": ""}]}
+	}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkPayload(t, payload, `{"objects": [{
+		"metadata": {"name": "decision1", "namespace": "default", "labels": {"a": "b"}},
+		"status": {"decisions": [{"clusterName": "cluster1", "reason": ""}]}
+	}]}`)
+}
+
+func checkPayload(t *testing.T, payload []byte, expectedPayload string) {
+	t.Helper()
+
+	var actual, expected interface{}
+	if err := json.Unmarshal(payload, &actual); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := json.Unmarshal([]byte(expectedPayload), &expected); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %s, got %s", expectedPayload, payload)
+	}
+}
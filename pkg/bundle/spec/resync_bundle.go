@@ -0,0 +1,10 @@
+package spec
+
+import "time"
+
+// ResyncSpecBundle requests the regional hubs to resend the complete state of their status bundles with a new
+// incarnation, it's sent once the database of the global hub is restored.
+type ResyncSpecBundle struct {
+	ID               string    `json:"id"`
+	RequestTimestamp time.Time `json:"requestTimestamp"`
+}
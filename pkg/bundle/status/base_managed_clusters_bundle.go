@@ -0,0 +1,13 @@
+package status
+
+import clusterv1 "open-cluster-management.io/api/cluster/v1"
+
+// BaseDeltaManagedClustersBundle the base struct for delta state managed clusters bundle. it holds the clusters that
+// changed since the previous delta state bundle, and the names of the clusters that were deleted since then.
+type BaseDeltaManagedClustersBundle struct {
+	Objects           []*clusterv1.ManagedCluster `json:"objects"`
+	DeletedClusters   []string                    `json:"deletedClusters"`
+	LeafHubName       string                      `json:"leafHubName"`
+	BaseBundleVersion *BundleVersion              `json:"baseBundleVersion"`
+	BundleVersion     *BundleVersion              `json:"bundleVersion"`
+}
@@ -0,0 +1,54 @@
+package status
+
+import "time"
+
+// provision statuses of a cluster deployment.
+const (
+	// ClusterProvisioning is the provision status of a cluster being installed.
+	ClusterProvisioning = "Provisioning"
+	// ClusterProvisionFailed is the provision status of a cluster whose last install attempt failed, the install is
+	// retried.
+	ClusterProvisionFailed = "ProvisionFailed"
+	// ClusterProvisionStopped is the provision status of a cluster whose install failed and isn't retried anymore.
+	ClusterProvisionStopped = "ProvisionStopped"
+	// ClusterInstalled is the provision status of an installed cluster.
+	ClusterInstalled = "Installed"
+	// ClusterDestroying is the provision status of a cluster being destroyed.
+	ClusterDestroying = "Destroying"
+)
+
+// ClusterDeploymentStatus is the lifecycle status of a cluster provisioned by hive on a regional hub.
+type ClusterDeploymentStatus struct {
+	UID       string `json:"uid"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Platform  string `json:"platform,omitempty"`
+	Region    string `json:"region,omitempty"`
+	// ClusterPoolName and ClaimName are set if the cluster is provisioned by a cluster pool.
+	ClusterPoolName string `json:"clusterPoolName,omitempty"`
+	ClaimName       string `json:"claimName,omitempty"`
+	// ProvisionStatus is one of Provisioning, ProvisionFailed, ProvisionStopped, Installed or Destroying.
+	ProvisionStatus string `json:"provisionStatus"`
+	// InstallRestarts is the number of the failed install attempts.
+	InstallRestarts int64 `json:"installRestarts,omitempty"`
+	// PowerState is the power state of the cluster, e.g. Running or Hibernating.
+	PowerState string `json:"powerState,omitempty"`
+	// Reason and Message explain the provision failure or the power state of the cluster.
+	Reason             string     `json:"reason,omitempty"`
+	Message            string     `json:"message,omitempty"`
+	InstalledTimestamp *time.Time `json:"installedTimestamp,omitempty"`
+}
+
+// ClusterPoolClaimStatus is the status of a claim of a cluster from a hive cluster pool on a regional hub.
+type ClusterPoolClaimStatus struct {
+	UID             string `json:"uid"`
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	ClusterPoolName string `json:"clusterPoolName"`
+	// ClusterName is the name of the cluster deployment assigned to the claim, it's empty until one is assigned.
+	ClusterName string `json:"clusterName,omitempty"`
+	// Pending is true until the claimed cluster is assigned and running.
+	Pending bool   `json:"pending"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,31 @@
+package status
+
+import "time"
+
+// Event is a kubernetes event of a regional hub about a resource synced by the agent. the events of the same
+// involved object and reason are deduplicated into a single event.
+type Event struct {
+	// EventNamespace and EventName are of the latest deduplicated event.
+	EventNamespace string              `json:"eventNamespace"`
+	EventName      string              `json:"eventName"`
+	InvolvedObject EventInvolvedObject `json:"involvedObject"`
+	Reason         string              `json:"reason"`
+	Message        string              `json:"message"`
+	// Type is Normal or Warning.
+	Type string `json:"type"`
+	// Source is the component that reported the event.
+	Source string `json:"source,omitempty"`
+	// Count is the number of occurrences of the deduplicated events.
+	Count          int32     `json:"count"`
+	FirstTimestamp time.Time `json:"firstTimestamp"`
+	LastTimestamp  time.Time `json:"lastTimestamp"`
+}
+
+// EventInvolvedObject is the object an event is about.
+type EventInvolvedObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	UID        string `json:"uid,omitempty"`
+}
@@ -0,0 +1,46 @@
+package status
+
+import "time"
+
+// ArgoApplicationStatus is the sync and health status of an Argo CD application on a regional hub.
+type ArgoApplicationStatus struct {
+	UID       string `json:"uid"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Project   string `json:"project,omitempty"`
+	// ApplicationSetName is set if the application is generated by an application set.
+	ApplicationSetName string `json:"applicationSetName,omitempty"`
+	// RepoURL, Path, Chart and TargetRevision are of the first source of the application.
+	RepoURL              string `json:"repoURL,omitempty"`
+	Path                 string `json:"path,omitempty"`
+	Chart                string `json:"chart,omitempty"`
+	TargetRevision       string `json:"targetRevision,omitempty"`
+	DestinationServer    string `json:"destinationServer,omitempty"`
+	DestinationName      string `json:"destinationName,omitempty"`
+	DestinationNamespace string `json:"destinationNamespace,omitempty"`
+	// SyncStatus is one of Synced, OutOfSync or Unknown.
+	SyncStatus   string `json:"syncStatus"`
+	SyncRevision string `json:"syncRevision,omitempty"`
+	// HealthStatus is one of Healthy, Progressing, Degraded, Suspended, Missing or Unknown.
+	HealthStatus  string `json:"healthStatus"`
+	HealthMessage string `json:"healthMessage,omitempty"`
+	// OperationPhase and OperationMessage are of the last sync operation, e.g. Failed.
+	OperationPhase   string     `json:"operationPhase,omitempty"`
+	OperationMessage string     `json:"operationMessage,omitempty"`
+	ReconciledAt     *time.Time `json:"reconciledAt,omitempty"`
+}
+
+// ArgoApplicationSetStatus is the generation status of an Argo CD application set on a regional hub.
+type ArgoApplicationSetStatus struct {
+	UID       string `json:"uid"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// ErrorOccurred is true if the application set failed to generate or update its applications.
+	ErrorOccurred bool `json:"errorOccurred"`
+	// ResourcesUpToDate is true if the generated applications are up to date.
+	ResourcesUpToDate bool `json:"resourcesUpToDate"`
+	// Applications is the number of the generated applications, it's reported by Argo CD 2.5 and later.
+	Applications int    `json:"applications"`
+	Reason       string `json:"reason,omitempty"`
+	Message      string `json:"message,omitempty"`
+}
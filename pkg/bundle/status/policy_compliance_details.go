@@ -0,0 +1,29 @@
+package status
+
+import "time"
+
+// PolicyComplianceDetails holds the violations of a policy on a non compliant cluster, as reported in the status of
+// the replicated policy.
+type PolicyComplianceDetails struct {
+	PolicyID    string               `json:"policyId"`
+	ClusterName string               `json:"clusterName"`
+	Violations  []*TemplateViolation `json:"violations"`
+	// OmittedViolations is the number of violations that aren't sent to keep the bundle small.
+	OmittedViolations int `json:"omittedViolations,omitempty"`
+}
+
+// TemplateViolation is the last violation message of a non compliant policy template.
+type TemplateViolation struct {
+	TemplateName string    `json:"templateName"`
+	Message      string    `json:"message"`
+	Timestamp    time.Time `json:"timestamp"`
+	// Truncated is true if the message is truncated to keep the bundle small.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// BaseComplianceDetailsBundle the base struct for compliance details bundle and contains the full state.
+type BaseComplianceDetailsBundle struct {
+	Objects       []*PolicyComplianceDetails `json:"objects"`
+	LeafHubName   string                     `json:"leafHubName"`
+	BundleVersion *BundleVersion             `json:"bundleVersion"`
+}
@@ -0,0 +1,39 @@
+package status
+
+import "time"
+
+// SpecApplyResult is the result of applying an object received from the global hub on a regional hub.
+type SpecApplyResult struct {
+	// OriginOwnerReferenceUID is the uid of the object on the global hub.
+	OriginOwnerReferenceUID string `json:"originOwnerReferenceUID"`
+	// UID is the uid of the applied object on the regional hub, it's empty if the object wasn't created.
+	UID        string `json:"uid,omitempty"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+	// Generation is the generation of the applied object on the regional hub.
+	Generation int64 `json:"generation,omitempty"`
+	Applied    bool  `json:"applied"`
+	// Error is the reason the object failed to be applied, e.g. rbac denial, missing crd or webhook rejection.
+	Error string `json:"error,omitempty"`
+	// Timestamp is the time the result was changed.
+	Timestamp time.Time `json:"timestamp"`
+	// Drift is the last drift of the object corrected on the regional hub, it's nil if no drift was detected.
+	Drift *SpecDrift `json:"drift,omitempty"`
+}
+
+const (
+	// SpecDriftModified is the reason of a drift of an object modified locally on the regional hub.
+	SpecDriftModified = "Modified"
+	// SpecDriftDeleted is the reason of a drift of an object deleted locally on the regional hub.
+	SpecDriftDeleted = "Deleted"
+)
+
+// SpecDrift is a local change of an object received from the global hub, which was reverted by the regional hub.
+type SpecDrift struct {
+	Reason string `json:"reason"`
+	// Count is the number of drifts corrected since the agent started.
+	Count     int       `json:"count"`
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -4,6 +4,10 @@ const (
 	// HohSystemNamespace - Hub of Hubs dedicated namespace.
 	HohSystemNamespace = "open-cluster-management-global-hub-system"
 	HoHConfigName      = "multicluster-global-hub-config"
+	// GenericResourcesConfigName - the configmap listing the kinds of the resources propagated to the regional hubs.
+	GenericResourcesConfigName = "multicluster-global-hub-propagated-resources"
+	// RolloutConfigName - the configmap of the maintenance windows and the waves of the spec rollout.
+	RolloutConfigName = "multicluster-global-hub-rollout"
 )
 
 // message types
@@ -15,8 +19,20 @@ const (
 
 	// ManagedClustersMsgKey - managed clusters message key.
 	ManagedClustersMsgKey = "ManagedClusters"
+	// DeltaManagedClustersMsgKey - delta state managed clusters message key.
+	DeltaManagedClustersMsgKey = "DeltaManagedClusters"
 	// ManagedClustersLabelsMsgKey - managed clusters labels message key.
 	ManagedClustersLabelsMsgKey = "ManagedClustersLabels"
+	// ManagedClusterAddOnsMsgKey - managed cluster add-ons message key.
+	ManagedClusterAddOnsMsgKey = "ManagedClusterAddOns"
+	// ClusterDeploymentsMsgKey - hive cluster deployments message key.
+	ClusterDeploymentsMsgKey = "ClusterDeployments"
+	// ClusterPoolClaimsMsgKey - hive cluster pool claims message key.
+	ClusterPoolClaimsMsgKey = "ClusterPoolClaims"
+	// EventsMsgKey - events message key.
+	EventsMsgKey = "Events"
+	// ResyncMsgKey - resync message key.
+	ResyncMsgKey = "Resync"
 
 	// ClustersPerPolicyMsgKey - clusters per policy message key.
 	ClustersPerPolicyMsgKey = "ClustersPerPolicy"
@@ -26,6 +42,8 @@ const (
 	PolicyDeltaComplianceMsgKey = "PolicyDeltaCompliance"
 	// MinimalPolicyComplianceMsgKey - minimal policy compliance message key.
 	MinimalPolicyComplianceMsgKey = "MinimalPolicyCompliance"
+	// PolicyComplianceDetailsMsgKey - policy compliance details message key.
+	PolicyComplianceDetailsMsgKey = "PolicyComplianceDetails"
 
 	// LocalPolicySpecMsgKey - the local policy spec message key.
 	LocalPolicySpecMsgKey = "LocalPolicySpec"
@@ -35,11 +53,19 @@ const (
 	LocalPolicyCompleteComplianceMsgKey = "LocalPolicyCompleteCompliance"
 	// LocalPlacementRulesMsgKey - local placement rules message key.
 	LocalPlacementRulesMsgKey = "LocalPlacementRules"
+	// LocalPlacementsMsgKey - local placements message key.
+	LocalPlacementsMsgKey = "LocalPlacements"
+	// LocalPlacementDecisionsMsgKey - local placement decisions message key.
+	LocalPlacementDecisionsMsgKey = "LocalPlacementDecisions"
 
 	// SubscriptionStatusMsgKey - subscription-status message key.
 	SubscriptionStatusMsgKey = "SubscriptionStatus"
 	// SubscriptionReportMsgKey - subscription-report message key.
 	SubscriptionReportMsgKey = "SubscriptionReport"
+	// ArgoApplicationsMsgKey - argo cd applications message key.
+	ArgoApplicationsMsgKey = "ArgoApplications"
+	// ArgoApplicationSetsMsgKey - argo cd application sets message key.
+	ArgoApplicationSetsMsgKey = "ArgoApplicationSets"
 
 	// PlacementRuleMsgKey - placement-rule message key.
 	PlacementRuleMsgKey = "PlacementRule"
@@ -48,8 +74,14 @@ const (
 	// PlacementDecisionMsgKey - placement-decision message key.
 	PlacementDecisionMsgKey = "PlacementDecision"
 
+	// PolicySetMsgKey - policy-set message key.
+	PolicySetMsgKey = "PolicySet"
+
 	// ControlInfoMsgKey - control info message key.
 	ControlInfoMsgKey = "ControlInfo"
+
+	// SpecApplyResultsMsgKey - spec apply results message key.
+	SpecApplyResultsMsgKey = "SpecApplyResults"
 )
 
 // store all the labels
@@ -69,6 +101,9 @@ const (
 
 	// identify the resource is a local-resource
 	GlobalHubLocalResource = "global-hub.open-cluster-management.io/local-resource"
+
+	// identify the resource is applied on the regional hub from the global hub cluster
+	GlobalHubGlobalResource = "global-hub.open-cluster-management.io/global-resource"
 )
 
 // store all the annotations
@@ -78,6 +113,15 @@ const (
 
 	// identify the resource is from the global hub cluster
 	OriginOwnerReferenceAnnotation = "global-hub.open-cluster-management.io/origin-ownerreference-uid"
+
+	// opt the resource from the global hub out of the drift correction on the regional hub, the local changes of
+	// the resource are kept until the resource is changed on the global hub
+	DriftCorrectionAnnotation = "global-hub.open-cluster-management.io/drift-correction"
+	DriftCorrectionDisabled   = "disabled"
+
+	// hold the changes of the resource on the global hub, the changes are kept in the database without being
+	// distributed to the regional hubs until the annotation is removed
+	HoldDistributionAnnotation = "global-hub.open-cluster-management.io/hold-distribution"
 )
 
 // store all the finalizers
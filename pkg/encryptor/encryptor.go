@@ -0,0 +1,147 @@
+package encryptor
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// KeySize is the size of the key encryption keys of the regional hubs and of the data keys of the messages.
+const KeySize = 32
+
+var (
+	// ErrKeyNotFound is returned when the message isn't encrypted for the regional hub or its key isn't known.
+	ErrKeyNotFound  = errors.New("encryption key not found")
+	errNoRecipients = errors.New("no keys to encrypt the message with")
+)
+
+// Envelope holds the data key of an encrypted message, wrapped by the key of every regional hub the message is
+// encrypted for. a data key is generated per message, so the key of a regional hub doesn't encrypt data directly.
+type Envelope struct {
+	// Keys is a map of regional hub -> the data key wrapped by the key of the regional hub.
+	Keys map[string]*WrappedKey `json:"keys"`
+}
+
+// WrappedKey is a data key encrypted by a key of a regional hub.
+type WrappedKey struct {
+	KeyID string `json:"keyID"`
+	Key   []byte `json:"key"`
+}
+
+// Key is a key encryption key of a regional hub.
+type Key struct {
+	ID    string
+	Bytes []byte
+}
+
+// GenerateKey returns a new random key.
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("failed to generate key - %w", err)
+	}
+
+	return key, nil
+}
+
+// Seal encrypts the data with a new data key and wraps the data key with the given keys, keys is a map of regional
+// hub -> the key to wrap the data key with for the regional hub.
+func Seal(data []byte, keys map[string]*Key) ([]byte, *Envelope, error) {
+	if len(keys) == 0 {
+		return nil, nil, errNoRecipients
+	}
+
+	dataKey, err := GenerateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encryptedData, err := encrypt(dataKey, data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encrypt data - %w", err)
+	}
+
+	envelope := &Envelope{Keys: make(map[string]*WrappedKey, len(keys))}
+
+	for hubName, key := range keys {
+		wrappedKey, err := encrypt(key.Bytes, dataKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to wrap data key for %s - %w", hubName, err)
+		}
+
+		envelope.Keys[hubName] = &WrappedKey{KeyID: key.ID, Key: wrappedKey}
+	}
+
+	return encryptedData, envelope, nil
+}
+
+// Open unwraps the data key of the regional hub from the envelope with the key returned by getKey and decrypts the
+// data with it. ErrKeyNotFound is returned if the data isn't encrypted for the regional hub or getKey doesn't find
+// the key.
+func Open(encryptedData []byte, envelope *Envelope, hubName string,
+	getKey func(keyID string) ([]byte, bool),
+) ([]byte, error) {
+	wrappedKey, found := envelope.Keys[hubName]
+	if !found {
+		return nil, fmt.Errorf("%w: the message isn't encrypted for %s", ErrKeyNotFound, hubName)
+	}
+
+	key, found := getKey(wrappedKey.KeyID)
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrKeyNotFound, wrappedKey.KeyID)
+	}
+
+	dataKey, err := decrypt(key, wrappedKey.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key - %w", err)
+	}
+
+	data, err := decrypt(dataKey, encryptedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data - %w", err)
+	}
+
+	return data, nil
+}
+
+// encrypt encrypts the data with AES-GCM, the random nonce is prepended to the encrypted data.
+func encrypt(key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce - %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, data, nil), nil
+}
+
+func decrypt(key, encryptedData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(encryptedData) < gcm.NonceSize() {
+		return nil, errors.New("encrypted data is too short")
+	}
+
+	nonce, cipherText := encryptedData[:gcm.NonceSize()], encryptedData[gcm.NonceSize():]
+
+	return gcm.Open(nil, nonce, cipherText, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key - %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,75 @@
+package encryptor
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSealOpen(t *testing.T) {
+	hub1Key := newTestKey(t, "20230101000000")
+	hub2Key := newTestKey(t, "20230102000000")
+	data := []byte("spec bundle")
+
+	encryptedData, envelope, err := Seal(data, map[string]*Key{"hub1": hub1Key, "hub2": hub2Key})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(encryptedData, data) {
+		t.Fatal("expected the data to be encrypted")
+	}
+
+	keysOf := func(keys ...*Key) func(keyID string) ([]byte, bool) {
+		return func(keyID string) ([]byte, bool) {
+			for _, key := range keys {
+				if key.ID == keyID {
+					return key.Bytes, true
+				}
+			}
+
+			return nil, false
+		}
+	}
+
+	for hubName, key := range map[string]*Key{"hub1": hub1Key, "hub2": hub2Key} {
+		openedData, err := Open(encryptedData, envelope, hubName, keysOf(key))
+		if err != nil {
+			t.Fatalf("%s: %v", hubName, err)
+		}
+
+		if !bytes.Equal(openedData, data) {
+			t.Errorf("%s: expected %q, got %q", hubName, data, openedData)
+		}
+	}
+
+	if _, err := Open(encryptedData, envelope, "hub3", keysOf(hub1Key)); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("expected the message not to be opened by another hub, got %v", err)
+	}
+
+	if _, err := Open(encryptedData, envelope, "hub1", keysOf(hub2Key)); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("expected the message not to be opened without the key of the hub, got %v", err)
+	}
+
+	// a key with the id of the key of the hub but other bytes, e.g. the hub's key was regenerated
+	wrongKey := newTestKey(t, hub1Key.ID)
+	if _, err := Open(encryptedData, envelope, "hub1", keysOf(wrongKey)); err == nil ||
+		errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("expected the message not to be opened with a wrong key, got %v", err)
+	}
+
+	if _, _, err := Seal(data, map[string]*Key{}); err == nil {
+		t.Error("expected an error of sealing the data without keys")
+	}
+}
+
+func newTestKey(t *testing.T, keyID string) *Key {
+	t.Helper()
+
+	keyBytes, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &Key{ID: keyID, Bytes: keyBytes}
+}
@@ -0,0 +1,205 @@
+package encryptor
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// KeyActivationDelay is the time a new key of a regional hub isn't used to encrypt messages, so the key reaches the
+	// regional hub before the messages encrypted with it.
+	KeyActivationDelay = 10 * time.Minute
+	keyIDFormat        = "20060102150405"
+	// reloadInterval is the interval of reloading the keys to get the rotated keys.
+	reloadInterval = time.Minute
+	// minReloadInterval limits the reloads of the keys when a message is encrypted with an unknown key.
+	minReloadInterval = 10 * time.Second
+)
+
+// NewKeyID returns the id of a key created at the given time, the ids are ordered by the creation time of the keys.
+func NewKeyID(createdAt time.Time) string {
+	return createdAt.UTC().Format(keyIDFormat)
+}
+
+// KeyName returns the name of the key of a regional hub in the keys secrets.
+func KeyName(hubName, keyID string) string {
+	return fmt.Sprintf("%s.%s", hubName, keyID)
+}
+
+// ParseKeyName returns the regional hub and the id of the key from the name of the key in the keys secrets.
+func ParseKeyName(name string) (string, string, bool) {
+	separator := strings.LastIndex(name, ".")
+	if separator <= 0 {
+		return "", "", false
+	}
+
+	keyID := name[separator+1:]
+	if _, err := time.Parse(keyIDFormat, keyID); err != nil {
+		return "", "", false
+	}
+
+	return name[:separator], keyID, true
+}
+
+// PrimaryKey returns the key that encrypts the messages of a regional hub: the newest key that is older than the
+// activation delay, or the oldest key if all the keys are new. the keys must be sorted by id.
+func PrimaryKey(keys []*Key, now time.Time) *Key {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	primaryKey := keys[0]
+
+	for _, key := range keys[1:] {
+		createdAt, err := time.Parse(keyIDFormat, key.ID)
+		if err != nil || now.Sub(createdAt) < KeyActivationDelay {
+			break
+		}
+
+		primaryKey = key
+	}
+
+	return primaryKey
+}
+
+// KeyStore holds the keys of the regional hubs loaded from a mounted keys secret, the files of the directory are
+// named by KeyName. the keys are reloaded periodically to get the rotated keys.
+type KeyStore struct {
+	dir      string
+	lock     sync.Mutex
+	keys     map[string][]*Key // map of regional hub -> the keys of the regional hub sorted by id
+	loadedAt time.Time
+}
+
+// NewKeyStore returns a key store of the keys in the given directory.
+func NewKeyStore(dir string) (*KeyStore, error) {
+	store := &KeyStore{dir: dir}
+
+	if err := store.load(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// PrimaryKey returns the key that encrypts the messages of the regional hub.
+func (store *KeyStore) PrimaryKey(hubName string) (*Key, bool) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	store.reloadIfOlderThan(reloadInterval)
+
+	key := PrimaryKey(store.keys[hubName], time.Now())
+
+	return key, key != nil
+}
+
+// PrimaryKeys returns a map of regional hub -> the key that encrypts the messages of the regional hub.
+func (store *KeyStore) PrimaryKeys() map[string]*Key {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	store.reloadIfOlderThan(reloadInterval)
+
+	primaryKeys := make(map[string]*Key, len(store.keys))
+	now := time.Now()
+
+	for hubName, keys := range store.keys {
+		primaryKeys[hubName] = PrimaryKey(keys, now)
+	}
+
+	return primaryKeys
+}
+
+// GetKey returns the key of the regional hub with the given id, the keys are reloaded if the key isn't found.
+func (store *KeyStore) GetKey(hubName, keyID string) ([]byte, bool) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	if key := store.findKey(hubName, keyID); key != nil {
+		return key.Bytes, true
+	}
+
+	if !store.reloadIfOlderThan(minReloadInterval) {
+		return nil, false
+	}
+
+	if key := store.findKey(hubName, keyID); key != nil {
+		return key.Bytes, true
+	}
+
+	return nil, false
+}
+
+func (store *KeyStore) findKey(hubName, keyID string) *Key {
+	for _, key := range store.keys[hubName] {
+		if key.ID == keyID {
+			return key
+		}
+	}
+
+	return nil
+}
+
+// reloadIfOlderThan reloads the keys if they were loaded before the given interval, returns true if reloaded. the
+// loaded keys are kept if the reload fails.
+func (store *KeyStore) reloadIfOlderThan(interval time.Duration) bool {
+	if time.Since(store.loadedAt) < interval {
+		return false
+	}
+
+	if err := store.load(); err != nil {
+		store.loadedAt = time.Now() // retry on the next interval
+		return false
+	}
+
+	return true
+}
+
+func (store *KeyStore) load() error {
+	entries, err := os.ReadDir(store.dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read keys directory %s - %w", store.dir, err)
+	}
+
+	keys := make(map[string][]*Key)
+
+	for _, entry := range entries {
+		// the files of a mounted secret are symbolic links to the hidden directory of the current version
+		if strings.HasPrefix(entry.Name(), "..") {
+			continue
+		}
+
+		hubName, keyID, ok := ParseKeyName(entry.Name())
+		if !ok {
+			continue
+		}
+
+		keyBytes, err := os.ReadFile(filepath.Join(store.dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read key %s - %w", entry.Name(), err)
+		}
+
+		if len(keyBytes) != KeySize {
+			continue
+		}
+
+		keys[hubName] = append(keys[hubName], &Key{ID: keyID, Bytes: keyBytes})
+	}
+
+	for _, hubKeys := range keys {
+		sort.Slice(hubKeys, func(i, j int) bool { return hubKeys[i].ID < hubKeys[j].ID })
+	}
+
+	store.keys = keys
+	store.loadedAt = time.Now()
+
+	return nil
+}
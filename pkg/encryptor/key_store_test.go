@@ -0,0 +1,103 @@
+package encryptor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseKeyName(t *testing.T) {
+	cases := []struct {
+		name    string
+		hubName string
+		keyID   string
+		ok      bool
+	}{
+		{"hub1.20230101000000", "hub1", "20230101000000", true},
+		{"hub1.example.com.20230101000000", "hub1.example.com", "20230101000000", true},
+		{KeyName("hub.1", "20230101000000"), "hub.1", "20230101000000", true},
+		{"hub1.example.com", "", "", false},
+		{"hub1", "", "", false},
+		{".20230101000000", "", "", false},
+		{"..data", "", "", false},
+	}
+
+	for _, c := range cases {
+		hubName, keyID, ok := ParseKeyName(c.name)
+		if hubName != c.hubName || keyID != c.keyID || ok != c.ok {
+			t.Errorf("%s: expected (%q, %q, %v), got (%q, %q, %v)", c.name, c.hubName, c.keyID, c.ok, hubName,
+				keyID, ok)
+		}
+	}
+}
+
+func TestPrimaryKey(t *testing.T) {
+	now := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	oldKey := &Key{ID: NewKeyID(now.Add(-30 * 24 * time.Hour))}
+	activeKey := &Key{ID: NewKeyID(now.Add(-KeyActivationDelay))}
+	newKey := &Key{ID: NewKeyID(now.Add(-KeyActivationDelay + time.Minute))}
+
+	cases := []struct {
+		name     string
+		keys     []*Key
+		expected *Key
+	}{
+		{"no keys", nil, nil},
+		{"single new key", []*Key{newKey}, newKey},
+		{"new key within the activation delay", []*Key{oldKey, newKey}, oldKey},
+		{"new key after the activation delay", []*Key{oldKey, activeKey}, activeKey},
+		{"newest key after the activation delay", []*Key{oldKey, activeKey, newKey}, activeKey},
+	}
+
+	for _, c := range cases {
+		if key := PrimaryKey(c.keys, now); key != c.expected {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, key)
+		}
+	}
+}
+
+func TestKeyStore(t *testing.T) {
+	dir := t.TempDir()
+	oldKey := newTestKey(t, NewKeyID(time.Now().Add(-time.Hour)))
+	newKey := newTestKey(t, NewKeyID(time.Now()))
+
+	files := map[string][]byte{
+		KeyName("hub1.example.com", newKey.ID): newKey.Bytes,
+		KeyName("hub1.example.com", oldKey.ID): oldKey.Bytes,
+		KeyName("hub2", oldKey.ID):             []byte("short key"),
+		"..data":                               oldKey.Bytes,
+	}
+
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store, err := NewKeyStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if key, found := store.PrimaryKey("hub1.example.com"); !found || key.ID != oldKey.ID {
+		t.Errorf("expected the old key to be primary during the activation delay of the new key, got %v", key)
+	}
+
+	if _, found := store.PrimaryKey("hub2"); found {
+		t.Error("expected the invalid key to be skipped")
+	}
+
+	if keys := store.PrimaryKeys(); len(keys) != 1 {
+		t.Errorf("expected the primary key of a single hub, got %v", keys)
+	}
+
+	if keyBytes, found := store.GetKey("hub1.example.com", newKey.ID); !found || string(keyBytes) !=
+		string(newKey.Bytes) {
+		t.Error("expected the new key to be found")
+	}
+
+	if _, found := store.GetKey("hub1.example.com", "20230101000000"); found {
+		t.Error("expected an unknown key not to be found")
+	}
+}
@@ -5,6 +5,8 @@ const (
 	DestinationHub = "destination-hub"
 	// CompressionType is the key used for compression type header.
 	CompressionType = "content-encoding"
+	// Encryption is the key used for the envelope of the data key of an encrypted message.
+	Encryption = "encryption"
 	// Size is the key used for total bundle size header.
 	Size = "size"
 	// Offset is the key used for message fragment offset header.
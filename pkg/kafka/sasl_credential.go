@@ -0,0 +1,40 @@
+package kafkaclient
+
+import (
+	"fmt"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+)
+
+const (
+	saslMechanism = "SCRAM-SHA-512"
+	// SASLPasswordEnvVar is the environment variable of the password of the SCRAM user, the password isn't passed as
+	// a flag so it isn't exposed in the command line of the process.
+	SASLPasswordEnvVar = "KAFKA_SASL_PASSWORD"
+)
+
+// SetSASLCredential configures the kafka client to authenticate as the given SCRAM user, it must be called after the
+// CA is loaded since the security protocol is upgraded to SASL on top of it. nothing is set if the user is empty.
+func SetSASLCredential(kafkaConfigMap *kafka.ConfigMap, user, password string) error {
+	if user == "" {
+		return nil
+	}
+
+	securityProtocol := "sasl_plaintext"
+	if protocol, err := kafkaConfigMap.Get("security.protocol", ""); err == nil && protocol == "ssl" {
+		securityProtocol = "sasl_ssl"
+	}
+
+	for key, value := range map[string]string{
+		"security.protocol": securityProtocol,
+		"sasl.mechanisms":   saslMechanism,
+		"sasl.username":     user,
+		"sasl.password":     password,
+	} {
+		if err := kafkaConfigMap.SetKey(key, value); err != nil {
+			return fmt.Errorf("failed to SetKey %s - %w", key, err)
+		}
+	}
+
+	return nil
+}
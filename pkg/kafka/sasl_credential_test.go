@@ -0,0 +1,37 @@
+package kafkaclient
+
+import (
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+)
+
+func TestSetSASLCredential(t *testing.T) {
+	cases := []struct {
+		name             string
+		user             string
+		securityProtocol string
+		expectedProtocol string
+	}{
+		{"no user", "", "ssl", "ssl"},
+		{"user over ssl", "global-hub-agent", "ssl", "sasl_ssl"},
+		{"user without ssl", "global-hub-agent", "", "sasl_plaintext"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			kafkaConfigMap := &kafka.ConfigMap{}
+			if c.securityProtocol != "" {
+				_ = kafkaConfigMap.SetKey("security.protocol", c.securityProtocol)
+			}
+			if err := SetSASLCredential(kafkaConfigMap, c.user, "secret"); err != nil {
+				t.Fatalf("failed to set the SASL credential: %v", err)
+			}
+			if protocol, _ := kafkaConfigMap.Get("security.protocol", ""); protocol != c.expectedProtocol {
+				t.Errorf("expected security protocol %q, got %q", c.expectedProtocol, protocol)
+			}
+			if user, _ := kafkaConfigMap.Get("sasl.username", ""); user != c.user {
+				t.Errorf("expected sasl user %q, got %q", c.user, user)
+			}
+		})
+	}
+}